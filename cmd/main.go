@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -13,12 +14,58 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jsarabia/fn-posts/internal"
 	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
 	_ "github.com/lib/pq"
 )
 
 func main() {
 	cfg := config.Load()
 
+	if err := domain.SetServiceIdentity(cfg.Service.Name, cfg.Service.Version); err != nil {
+		log.Fatalf("Invalid service identity configuration: %v", err)
+	}
+
+	if err := domain.SetAllowedPhotoFormats(cfg.StorageConfig.AllowedPhotoFormats); err != nil {
+		log.Fatalf("Invalid photo format configuration: %v", err)
+	}
+
+	if err := domain.SetPostBumpIntervalHours(cfg.Posts.BumpIntervalHours); err != nil {
+		log.Fatalf("Invalid post configuration: %v", err)
+	}
+
+	if err := domain.SetMaxContactExchangeExpirationHours(cfg.ContactExchange.MaxExpirationHours); err != nil {
+		log.Fatalf("Invalid contact exchange configuration: %v", err)
+	}
+
+	if err := domain.SetMaxContactExchangeMessageLength(cfg.ContactExchange.MaxMessageLength); err != nil {
+		log.Fatalf("Invalid contact exchange configuration: %v", err)
+	}
+
+	if err := domain.SetLocationPlausibility(domain.LocationPlausibility{
+		RejectNullIsland: cfg.LocationValidation.RejectNullIsland,
+		MaxDecimals:      cfg.LocationValidation.MaxCoordinateDecimals,
+	}); err != nil {
+		log.Fatalf("Invalid location validation configuration: %v", err)
+	}
+
+	if err := cfg.Search.Validate(); err != nil {
+		log.Fatalf("Invalid search configuration: %v", err)
+	}
+
+	if err := cfg.LocationPrivacy.Validate(); err != nil {
+		log.Fatalf("Invalid location privacy configuration: %v", err)
+	}
+
+	eventVersions, err := domain.ParseEventVersions(cfg.KafkaConfig.EventVersions)
+	if err != nil {
+		log.Fatalf("Invalid event version configuration: %v", err)
+	}
+	if err := domain.SetEventVersions(eventVersions); err != nil {
+		log.Fatalf("Invalid event version configuration: %v", err)
+	}
+
 	// Initialize database connection with connection pooling
 	db, err := sql.Open("postgres", cfg.PostgresURL)
 	if err != nil {
@@ -31,57 +78,94 @@ func main() {
 	db.SetMaxIdleConns(5)                  // Maximum number of idle connections
 	db.SetConnMaxLifetime(5 * time.Minute) // Maximum connection lifetime
 
-	// Test database connection
+	// Test database connection. A database that is still starting is waited for while the
+	// encryption key is loaded, which retries with backoff and fails with a descriptive error.
 	if err := db.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+		log.Printf("Warning: database not reachable yet, waiting for it during startup: %v", err)
+	} else {
+		log.Println("Connected to database successfully with connection pooling")
+	}
+
+	// Optional read replica for read-heavy endpoints; reads fall back to primary without one
+	var replica *sql.DB
+	if cfg.PostgresReplicaURL != "" {
+		replica, err = openReadReplica(cfg.PostgresReplicaURL)
+		if err != nil {
+			log.Printf("Warning: read replica unavailable, serving reads from primary: %v", err)
+		} else {
+			defer replica.Close()
+			log.Println("Connected to read replica successfully")
+		}
 	}
-	log.Println("Connected to database successfully with connection pooling")
 
 	// Initialize application using Wire
-	app, err := internal.InitializeApplication(db, cfg)
+	app, err := internal.InitializeApplication(repository.NewDBRouter(db, replica), cfg)
 	if err != nil {
+		var encryptionErr *domain.EncryptionInitError
+		if errors.As(err, &encryptionErr) {
+			log.Fatalf("Failed to initialize contact encryption (%s): %v", encryptionErr.Failure, encryptionErr)
+		}
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 	log.Println("Application initialized successfully with Wire dependency injection")
 
+	// Writes are refused while read-only mode is on; it starts from config and is switched
+	// through the internal maintenance route
+	readOnly := handler.NewReadOnlyMode(cfg.Maintenance)
+
+	// API requests carry a bearer JWT; dev mode may run without a key and name the caller in
+	// X-User-ID instead
+	verifier, err := handler.NewTokenVerifier(cfg.Auth)
+	if err != nil {
+		if !cfg.Auth.DevMode {
+			log.Fatalf("Invalid authentication configuration: %v", err)
+		}
+		log.Printf("Warning: bearer tokens are refused, only X-User-ID is accepted in dev mode: %v", err)
+	}
+	if cfg.Auth.DevMode {
+		log.Println("Warning: AUTH_DEV_MODE is on, requests may authenticate with an unverified X-User-ID header")
+	}
+	auth := handler.AuthMiddleware(verifier, cfg.Auth.DevMode)
+
 	// Setup router
 	router := gin.Default()
 
+	// Client IPs are only taken from X-Forwarded-For when a trusted proxy sent the request
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid trusted proxy configuration: %v", err)
+	}
+
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
-			"status":  "healthy",
-			"service": "posts-domain",
+			"status":    "healthy",
+			"service":   "posts-domain",
+			"storage":   app.StorageService.CircuitBreakerStats(),
+			"events":    app.EventPublisher.Stats(),
+			"read_only": readOnly.Enabled(),
 		})
 	})
 
 	// API routes using Wire-injected handlers
 	api := router.Group("/api")
+	api.Use(handler.ReadConsistencyMiddleware(), handler.RequestOriginMiddleware())
 
-	// Posts routes
-	posts := api.Group("/posts")
-	{
-		posts.POST("", app.PostHandler.CreatePost)
-		posts.GET("", app.PostHandler.ListPosts)
-		posts.GET("/nearby", app.PostHandler.SearchNearbyPosts)
-		posts.GET("/:id", app.PostHandler.GetPost)
-		posts.PUT("/:id", app.PostHandler.UpdatePost)
-		posts.PATCH("/:id/status", app.PostHandler.UpdatePostStatus)
-		posts.DELETE("/:id", app.PostHandler.DeletePost)
-
-		// Photo routes (sub-resource of posts)
-		posts.POST("/:postId/photos", app.PhotoHandler.UploadPhoto)
-	}
-
-	// Users routes
-	users := api.Group("/users")
-	{
-		users.GET("/:userId/posts", app.PostHandler.GetUserPosts)
-	}
+	// Every API route is registered from the one route table in the handler package
+	handler.SetupRoutes(api, handler.Handlers{
+		Post:            app.PostHandler,
+		Photo:           app.PhotoHandler,
+		ContactExchange: app.ContactExchangeHandler,
+		Claim:           app.ClaimHandler,
+		Import:          app.ImportHandler,
+	}, auth, readOnly, cfg)
 
 	srv := &http.Server{
-		Addr:    ":" + cfg.Port,
-		Handler: router,
+		Addr:              ":" + cfg.Port,
+		Handler:           router,
+		ReadHeaderTimeout: time.Duration(cfg.Server.ReadHeaderTimeoutSeconds) * time.Second,
+		ReadTimeout:       time.Duration(cfg.Server.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout:      time.Duration(cfg.Server.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:       time.Duration(cfg.Server.IdleTimeoutSeconds) * time.Second,
 	}
 
 	// Start server in a goroutine
@@ -92,6 +176,24 @@ func main() {
 		}
 	}()
 
+	// Relay events recorded in the outbox to Kafka; events left over at shutdown are sent after
+	// the next start
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	if cfg.Outbox.RelayEnabled {
+		go app.RelayWorker.Run(relayCtx)
+		log.Println("Outbox relay started")
+	}
+
+	// Expire overdue contact exchange requests and purge their contact data on a schedule; a run
+	// cut short by shutdown is finished by the next start
+	expirationCtx, stopExpiration := context.WithCancel(context.Background())
+	defer stopExpiration()
+	if cfg.ContactExchange.ExpirationJobEnabled {
+		go app.ExpirationWorker.Run(expirationCtx)
+		log.Println("Contact exchange expiration job started")
+	}
+
 	// Wait for interrupt signal to gracefully shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -104,6 +206,26 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
 	}
+	stopRelay()
+	stopExpiration()
 
 	log.Println("Server exited")
 }
+
+func openReadReplica(url string) (*sql.DB, error) {
+	replica, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	replica.SetMaxOpenConns(25)
+	replica.SetMaxIdleConns(5)
+	replica.SetConnMaxLifetime(5 * time.Minute)
+
+	if err := replica.Ping(); err != nil {
+		replica.Close()
+		return nil, err
+	}
+
+	return replica, nil
+}
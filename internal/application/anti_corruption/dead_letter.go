@@ -0,0 +1,120 @@
+package anti_corruption
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// DeadLetterTopic receives domain events that could not be translated to the external schema
+const DeadLetterTopic = "posts.events.dlq"
+
+// Stages at which an outbound event can be dead-lettered
+const (
+	DeadLetterStageTranslation   = "translation"
+	DeadLetterStageSerialization = "serialization"
+)
+
+// ErrEventDeadLettered is wrapped into the error returned by PublishEvent when an event
+// could not be translated and was routed to the dead letter topic instead
+var ErrEventDeadLettered = errors.New("event routed to dead letter topic")
+
+// OutboundTranslator converts domain events into the external Kafka schema
+type OutboundTranslator interface {
+	TranslatePostEvent(domainEvent *domain.PostEvent) (*KafkaEvent, error)
+	TranslateThinEvent(domainEvent *domain.PostEvent) (*KafkaEvent, error)
+	ToJSON(event *KafkaEvent) ([]byte, error)
+}
+
+// DeadLetterEvent carries the raw domain event alongside the reason it could not be published,
+// so schema drift can be diagnosed and the event replayed once the translator is fixed
+type DeadLetterEvent struct {
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	PostID    string          `json:"post_id"`
+	Source    string          `json:"source"`
+	Stage     string          `json:"stage"`
+	Error     string          `json:"error"`
+	FailedAt  time.Time       `json:"failed_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// PublisherStats is a point-in-time snapshot of the publisher counters
+type PublisherStats struct {
+	Published             int64 `json:"published"`
+	TranslationFailures   int64 `json:"translation_failures"`
+	SerializationFailures int64 `json:"serialization_failures"`
+	DeadLettered          int64 `json:"dead_lettered"`
+	DeadLetterFailures    int64 `json:"dead_letter_failures"`
+}
+
+type publisherMetrics struct {
+	published             atomic.Int64
+	translationFailures   atomic.Int64
+	serializationFailures atomic.Int64
+	deadLettered          atomic.Int64
+	deadLetterFailures    atomic.Int64
+}
+
+func (m *publisherMetrics) snapshot() PublisherStats {
+	return PublisherStats{
+		Published:             m.published.Load(),
+		TranslationFailures:   m.translationFailures.Load(),
+		SerializationFailures: m.serializationFailures.Load(),
+		DeadLettered:          m.deadLettered.Load(),
+		DeadLetterFailures:    m.deadLetterFailures.Load(),
+	}
+}
+
+// Stats returns the publish and dead letter counters
+func (p *AntiCorruptionEventPublisher) Stats() PublisherStats {
+	return p.metrics.snapshot()
+}
+
+// deadLetter routes an untranslatable event to the DLQ and returns the error for the caller
+func (p *AntiCorruptionEventPublisher) deadLetter(domainEvent *domain.PostEvent, stage string, cause error) error {
+	if stage == DeadLetterStageSerialization {
+		p.metrics.serializationFailures.Add(1)
+	} else {
+		p.metrics.translationFailures.Add(1)
+	}
+
+	message, err := json.Marshal(newDeadLetterEvent(domainEvent, stage, cause))
+	if err == nil {
+		err = p.kafkaPublisher.PublishMessage(DeadLetterTopic, domainEvent.PostID.String(), message)
+	}
+	if err != nil {
+		p.metrics.deadLetterFailures.Add(1)
+		log.Printf("Failed to dead-letter %s event %s after %s failure (%v): %v",
+			domainEvent.EventType, domainEvent.ID, stage, cause, err)
+		return fmt.Errorf("%s failed and dead-lettering failed: %w", stage, errors.Join(cause, err))
+	}
+
+	p.metrics.deadLettered.Add(1)
+	log.Printf("Dead-lettered %s event %s after %s failure: %v", domainEvent.EventType, domainEvent.ID, stage, cause)
+	return fmt.Errorf("%w: %s failed: %w", ErrEventDeadLettered, stage, cause)
+}
+
+func newDeadLetterEvent(domainEvent *domain.PostEvent, stage string, cause error) DeadLetterEvent {
+	payload, err := json.Marshal(domainEvent)
+	if err != nil {
+		// Keep whatever we can of the event rather than dropping it
+		payload, _ = json.Marshal(fmt.Sprintf("%+v", *domainEvent))
+	}
+
+	return DeadLetterEvent{
+		EventID:   domainEvent.ID.String(),
+		EventType: string(domainEvent.EventType),
+		PostID:    domainEvent.PostID.String(),
+		Source:    domainEvent.SourceService,
+		Stage:     stage,
+		Error:     cause.Error(),
+		FailedAt:  time.Now().UTC(),
+		Payload:   payload,
+	}
+}
@@ -98,33 +98,54 @@ type TranslatedPostData struct {
 	OrganizationID *domain.OrganizationID
 }
 
+// TranslatePostCreatedEvent translates an external post event. Every invalid field is reported
+// at once as TranslationErrors, so a dead-lettered event can be fixed in one pass.
 func (t *EventTranslator) TranslatePostCreatedEvent(event ExternalPostCreatedEvent) (*TranslatedPostData, error) {
-	if err := t.validatePostEvent(event); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+	data, errs := t.translatePostData(event)
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
-	location, err := t.translateLocation(event.Data.Location)
-	if err != nil {
-		return nil, fmt.Errorf("invalid location: %w", err)
-	}
+	return data, nil
+}
 
-	postType, err := t.translatePostType(event.Data.Type)
-	if err != nil {
-		return nil, fmt.Errorf("invalid post type: %w", err)
+// translatePostData validates and translates the fields shared by live and imported events,
+// collecting field errors instead of stopping at the first
+func (t *EventTranslator) translatePostData(event ExternalPostCreatedEvent) (*TranslatedPostData, TranslationErrors) {
+	errs := t.validatePostEvent(event)
+
+	location, locationErrs := t.translateLocation(event.Data.Location)
+	errs = append(errs, locationErrs...)
+
+	var postType domain.PostType
+	if event.Data.Type != "" {
+		pt, err := t.translatePostType(event.Data.Type)
+		if err != nil {
+			errs = append(errs, NewTranslationError("type", event.Data.Type, err.Error()))
+		}
+		postType = pt
 	}
 
 	var organizationID *domain.OrganizationID
 	if event.Data.OrganizationID != nil && *event.Data.OrganizationID != "" {
 		orgID, err := domain.OrganizationIDFromString(*event.Data.OrganizationID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid organization ID: %w", err)
+			errs = append(errs, NewTranslationError("organization_id", *event.Data.OrganizationID, "must be a UUID"))
 		}
 		organizationID = &orgID
 	}
 
-	userID, err := domain.UserIDFromString(event.Data.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+	var userID domain.UserID
+	if event.Data.UserID != "" {
+		id, err := domain.UserIDFromString(event.Data.UserID)
+		if err != nil {
+			errs = append(errs, NewTranslationError("user_id", event.Data.UserID, "must be a UUID"))
+		}
+		userID = id
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
 	return &TranslatedPostData{
@@ -138,14 +159,109 @@ func (t *EventTranslator) TranslatePostCreatedEvent(event ExternalPostCreatedEve
 	}, nil
 }
 
+// BatchTranslationResult holds the outcome of translating one event of a batch
+type BatchTranslationResult struct {
+	Index   int
+	EventID string
+	Post    *domain.Post
+	Err     error
+}
+
+// TranslateBatch translates legacy post events into posts ready to be persisted.
+// Each event is translated independently so one bad record doesn't fail the batch;
+// results are returned in input order.
+func (t *EventTranslator) TranslateBatch(events []ExternalPostCreatedEvent) []BatchTranslationResult {
+	results := make([]BatchTranslationResult, len(events))
+
+	for i, event := range events {
+		post, err := t.translatePostForImport(event)
+		results[i] = BatchTranslationResult{
+			Index:   i,
+			EventID: event.EventID,
+			Post:    post,
+			Err:     err,
+		}
+	}
+
+	return results
+}
+
+// translatePostForImport builds a full post aggregate from a legacy event, keeping the
+// legacy post ID, status and timestamps so re-running an import is detectable
+func (t *EventTranslator) translatePostForImport(event ExternalPostCreatedEvent) (*domain.Post, error) {
+	data, errs := t.translatePostData(event)
+
+	var postID domain.PostID
+	if event.Data.PostID != "" {
+		id, err := domain.PostIDFromString(event.Data.PostID)
+		if err != nil {
+			errs = append(errs, NewTranslationError("post_id", event.Data.PostID, "must be a UUID"))
+		}
+		postID = id
+	}
+
+	status, err := t.translatePostStatus(event.Data.Status)
+	if err != nil {
+		errs = append(errs, NewTranslationError("status", event.Data.Status, err.Error()))
+	}
+
+	for i, photo := range event.Data.Photos {
+		errs = append(errs, t.validatePhotoData(photo, i)...)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	photos, err := t.TranslatePhotosFromExternal(event.Data.Photos, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(photos) < 1 || len(photos) > domain.MaxPhotosPerPost {
+		return nil, domain.ErrInvalidPhotoCount(len(photos))
+	}
+
+	createdAt := event.Data.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	updatedAt := event.Data.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = createdAt
+	}
+
+	return domain.ReconstructPost(
+		postID,
+		data.Title,
+		data.Description,
+		data.Location,
+		data.RadiusMeters,
+		status,
+		data.Type,
+		data.CreatedBy,
+		data.OrganizationID,
+		createdAt,
+		updatedAt,
+		photos,
+	), nil
+}
+
+// TranslatePhotosFromExternal translates external photos, reporting every invalid photo field
+// as TranslationErrors
 func (t *EventTranslator) TranslatePhotosFromExternal(photos []ExternalPhotoData, postID domain.PostID) ([]domain.Photo, error) {
+	var errs TranslationErrors
+	for i, extPhoto := range photos {
+		errs = append(errs, t.validatePhotoData(extPhoto, i)...)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
 	var domainPhotos []domain.Photo
 
 	for _, extPhoto := range photos {
-		if err := t.validatePhotoData(extPhoto); err != nil {
-			return nil, fmt.Errorf("invalid photo data: %w", err)
-		}
-
 		photoID, err := domain.PhotoIDFromString(extPhoto.PhotoID)
 		if err != nil {
 			return nil, fmt.Errorf("invalid photo ID: %w", err)
@@ -169,56 +285,71 @@ func (t *EventTranslator) TranslatePhotosFromExternal(photos []ExternalPhotoData
 	return domainPhotos, nil
 }
 
-func (t *EventTranslator) validatePostEvent(event ExternalPostCreatedEvent) error {
-	if event.EventType == "" {
-		return fmt.Errorf("%w: event_type", ErrMissingRequiredField)
+// validatePostEvent reports every missing required field
+func (t *EventTranslator) validatePostEvent(event ExternalPostCreatedEvent) TranslationErrors {
+	var errs TranslationErrors
+
+	required := []struct {
+		field string
+		value string
+	}{
+		{"event_type", event.EventType},
+		{"post_id", event.Data.PostID},
+		{"title", event.Data.Title},
+		{"user_id", event.Data.UserID},
+		{"type", event.Data.Type},
 	}
-
-	if event.Data.PostID == "" {
-		return fmt.Errorf("%w: post_id", ErrMissingRequiredField)
-	}
-
-	if event.Data.Title == "" {
-		return fmt.Errorf("%w: title", ErrMissingRequiredField)
+	for _, r := range required {
+		if r.value == "" {
+			errs = append(errs, newMissingFieldError(r.field))
+		}
 	}
 
-	if event.Data.UserID == "" {
-		return fmt.Errorf("%w: user_id", ErrMissingRequiredField)
-	}
+	return errs
+}
 
-	if event.Data.Type == "" {
-		return fmt.Errorf("%w: type", ErrMissingRequiredField)
+// validatePhotoData reports every invalid field of the photo at index, naming fields by their
+// path in the event, such as photos[2].format
+func (t *EventTranslator) validatePhotoData(photo ExternalPhotoData, index int) TranslationErrors {
+	var errs TranslationErrors
+	field := func(name string) string {
+		return fmt.Sprintf("photos[%d].%s", index, name)
 	}
 
-	return nil
-}
-
-func (t *EventTranslator) validatePhotoData(photo ExternalPhotoData) error {
 	if photo.PhotoID == "" {
-		return fmt.Errorf("%w: photo_id", ErrMissingRequiredField)
+		errs = append(errs, newMissingFieldError(field("photo_id")))
+	} else if _, err := domain.PhotoIDFromString(photo.PhotoID); err != nil {
+		errs = append(errs, NewTranslationError(field("photo_id"), photo.PhotoID, "must be a UUID"))
 	}
 
 	if photo.URL == "" {
-		return fmt.Errorf("%w: url", ErrMissingRequiredField)
+		errs = append(errs, newMissingFieldError(field("url")))
 	}
 
 	if photo.Format == "" {
-		return fmt.Errorf("%w: format", ErrMissingRequiredField)
+		errs = append(errs, newMissingFieldError(field("format")))
+	} else if !domain.IsAllowedPhotoFormat(photo.Format) {
+		errs = append(errs, NewTranslationError(field("format"), photo.Format, "unsupported photo format"))
 	}
 
-	allowedFormats := map[string]bool{
-		"jpg": true, "jpeg": true, "png": true, "webp": true,
-	}
+	return errs
+}
 
-	if !allowedFormats[strings.ToLower(photo.Format)] {
-		return fmt.Errorf("unsupported photo format: %s", photo.Format)
-	}
+// translateLocation checks each coordinate separately so both are reported when both are wrong
+func (t *EventTranslator) translateLocation(extLoc ExternalLocation) (domain.Location, TranslationErrors) {
+	var errs TranslationErrors
 
-	return nil
-}
+	if err := (domain.Location{Latitude: extLoc.Latitude}).Validate(); err != nil {
+		errs = append(errs, NewTranslationError("location.latitude", extLoc.Latitude, "must be between -90 and 90"))
+	}
+	if err := (domain.Location{Longitude: extLoc.Longitude}).Validate(); err != nil {
+		errs = append(errs, NewTranslationError("location.longitude", extLoc.Longitude, "must be between -180 and 180"))
+	}
+	if len(errs) > 0 {
+		return domain.Location{}, errs
+	}
 
-func (t *EventTranslator) translateLocation(extLoc ExternalLocation) (domain.Location, error) {
-	return domain.NewLocation(extLoc.Latitude, extLoc.Longitude)
+	return domain.Location{Latitude: extLoc.Latitude, Longitude: extLoc.Longitude}, nil
 }
 
 func (t *EventTranslator) translatePostType(typeStr string) (domain.PostType, error) {
@@ -232,15 +363,24 @@ func (t *EventTranslator) translatePostType(typeStr string) (domain.PostType, er
 	}
 }
 
+func (t *EventTranslator) translatePostStatus(statusStr string) (domain.PostStatus, error) {
+	if statusStr == "" {
+		return domain.PostStatusActive, nil
+	}
+
+	status := domain.PostStatus(strings.ToLower(statusStr))
+	if !status.IsValid() {
+		return "", fmt.Errorf("unknown post status: %s", statusStr)
+	}
+
+	return status, nil
+}
+
 func (t *EventTranslator) sanitizeText(text string) string {
 	text = strings.TrimSpace(text)
 	text = strings.ReplaceAll(text, "\x00", "")
 
-	if len(text) > 2000 {
-		text = text[:2000]
-	}
-
-	return text
+	return domain.TruncateText(text, domain.MaxDescriptionLength)
 }
 
 func (t *EventTranslator) normalizeRadius(radius int) int {
@@ -253,17 +393,26 @@ func (t *EventTranslator) normalizeRadius(radius int) int {
 	return radius
 }
 
-// Translation error types
+// TranslationError describes one invalid field of an external event
 type TranslationError struct {
 	Field   string
 	Value   interface{}
 	Message string
+	// Err is the sentinel the error matches with errors.Is, such as ErrMissingRequiredField
+	Err error
 }
 
 func (e TranslationError) Error() string {
 	return fmt.Sprintf("translation error for field '%s' with value '%v': %s", e.Field, e.Value, e.Message)
 }
 
+func (e TranslationError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+	return ErrInvalidEventData
+}
+
 func NewTranslationError(field string, value interface{}, message string) *TranslationError {
 	return &TranslationError{
 		Field:   field,
@@ -271,3 +420,41 @@ func NewTranslationError(field string, value interface{}, message string) *Trans
 		Message: message,
 	}
 }
+
+func newMissingFieldError(field string) *TranslationError {
+	return &TranslationError{
+		Field:   field,
+		Value:   "",
+		Message: ErrMissingRequiredField.Error(),
+		Err:     ErrMissingRequiredField,
+	}
+}
+
+// TranslationErrors collects every field error found in one event. errors.As finds the
+// individual *TranslationError values and errors.Is their sentinels.
+type TranslationErrors []*TranslationError
+
+func (e TranslationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("validation failed with %d field error(s): %s", len(e), strings.Join(messages, "; "))
+}
+
+func (e TranslationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Fields lists the offending fields in the order they were found
+func (e TranslationErrors) Fields() []string {
+	fields := make([]string, len(e))
+	for i, err := range e {
+		fields[i] = err.Field
+	}
+	return fields
+}
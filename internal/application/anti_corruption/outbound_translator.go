@@ -4,33 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"github.com/jsarabia/fn-posts/internal/domain"
 )
 
-type OutboundEventTranslator struct {
-	serviceName string
-	version     string
-}
+// OutboundEventTranslator maps domain events to the external Kafka schema. Events carry the
+// service name they were created under; the version is the running service's.
+type OutboundEventTranslator struct{}
 
-func NewOutboundEventTranslator(serviceName, version string) *OutboundEventTranslator {
-	return &OutboundEventTranslator{
-		serviceName: serviceName,
-		version:     version,
-	}
+func NewOutboundEventTranslator() *OutboundEventTranslator {
+	return &OutboundEventTranslator{}
 }
 
 // External event schema for Kafka publishing
 type KafkaEvent struct {
-	EventID   string                 `json:"event_id"`
-	EventType string                 `json:"event_type"`
-	Source    string                 `json:"source"`
-	Timestamp time.Time              `json:"timestamp"`
-	Version   string                 `json:"version"`
-	Data      interface{}            `json:"data"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	EventID       string                 `json:"event_id"`
+	EventType     string                 `json:"event_type"`
+	Source        string                 `json:"source"`
+	Timestamp     time.Time              `json:"timestamp"`
+	Version       string                 `json:"version"`        // Version of the publishing service
+	SchemaVersion int                    `json:"schema_version"` // Per-type payload version
+	Data          interface{}            `json:"data"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // External schemas that will be consumed by fn-matcher, fn-notifications, etc.
@@ -48,6 +46,7 @@ type ExternalPostSchema struct {
 	UpdatedAt      time.Time              `json:"updated_at"`
 	Photos         []ExternalPhotoSchema  `json:"photos,omitempty"`
 	Tags           []string               `json:"tags,omitempty"`
+	BumpedAt       *time.Time             `json:"bumped_at,omitempty"`
 }
 
 type ExternalLocationSchema struct {
@@ -83,6 +82,12 @@ type PostStatusChangedEventData struct {
 	Timestamp      time.Time `json:"timestamp"`
 }
 
+type PostBumpedEventData struct {
+	Post             ExternalPostSchema `json:"post"`
+	BumpedAt         time.Time          `json:"bumped_at"`
+	PreviousBumpedAt *time.Time         `json:"previous_bumped_at,omitempty"`
+}
+
 type PhotoEventData struct {
 	PostID string              `json:"post_id"`
 	Photo  ExternalPhotoSchema `json:"photo"`
@@ -90,11 +95,12 @@ type PhotoEventData struct {
 
 func (t *OutboundEventTranslator) TranslatePostEvent(domainEvent *domain.PostEvent) (*KafkaEvent, error) {
 	kafkaEvent := &KafkaEvent{
-		EventID:   domainEvent.ID.String(),
-		EventType: string(domainEvent.EventType),
-		Source:    t.serviceName,
-		Timestamp: domainEvent.Timestamp,
-		Version:   t.version,
+		EventID:       domainEvent.ID.String(),
+		EventType:     string(domainEvent.EventType),
+		Source:        domainEvent.SourceService,
+		Timestamp:     domainEvent.Timestamp,
+		Version:       domain.ServiceVersion(),
+		SchemaVersion: domainEvent.EventVersion,
 		Metadata: map[string]interface{}{
 			"tenant_id": domainEvent.TenantID,
 			"user_id":   domainEvent.UserID.String(),
@@ -108,32 +114,29 @@ func (t *OutboundEventTranslator) TranslatePostEvent(domainEvent *domain.PostEve
 			return nil, fmt.Errorf("invalid data type for PostCreated event")
 		}
 
-		externalPost, err := t.translatePostToExternal(data.Post)
-		if err != nil {
-			return nil, err
-		}
-
 		kafkaEvent.Data = PostCreatedEventData{
-			Post: externalPost,
+			Post: t.translatePostToExternal(data.Post),
 		}
 
 	case domain.EventTypePostUpdated:
-		data, ok := domainEvent.Payload.(*domain.PostUpdatedEventData)
-		if !ok {
+		switch data := domainEvent.Payload.(type) {
+		case *domain.PostUpdatedEventData:
+			kafkaEvent.Data = PostUpdatedEventData{
+				Post:     t.translatePostToExternal(data.Post),
+				Changes:  data.Changes,
+				Previous: data.Previous,
+			}
+		case *domain.PostStatusChangedEventData:
+			// Status changes other than resolve and delete are announced as updates
+			kafkaEvent.Data = PostUpdatedEventData{
+				Post:     t.translatePostToExternal(data.Post),
+				Changes:  map[string]interface{}{"status": string(data.NewStatus)},
+				Previous: map[string]interface{}{"status": string(data.PreviousStatus)},
+			}
+		default:
 			return nil, fmt.Errorf("invalid data type for PostUpdated event")
 		}
 
-		externalPost, err := t.translatePostToExternal(data.Post)
-		if err != nil {
-			return nil, err
-		}
-
-		kafkaEvent.Data = PostUpdatedEventData{
-			Post:     externalPost,
-			Changes:  data.Changes,
-			Previous: data.Previous,
-		}
-
 	case domain.EventTypePostResolved, domain.EventTypePostDeleted:
 		data, ok := domainEvent.Payload.(*domain.PostStatusChangedEventData)
 		if !ok {
@@ -141,23 +144,41 @@ func (t *OutboundEventTranslator) TranslatePostEvent(domainEvent *domain.PostEve
 		}
 
 		kafkaEvent.Data = PostStatusChangedEventData{
-			PostID:         string(data.PostID),
+			PostID:         data.Post.ID,
 			NewStatus:      string(data.NewStatus),
 			PreviousStatus: string(data.PreviousStatus),
 			Timestamp:      domainEvent.Timestamp,
 		}
 
-	case domain.EventTypePhotoAdded, domain.EventTypePhotoRemoved:
-		data, ok := domainEvent.Payload.(*domain.PhotoEventData)
+	case domain.EventTypePostBumped:
+		data, ok := domainEvent.Payload.(*domain.PostBumpedEventData)
 		if !ok {
-			return nil, fmt.Errorf("invalid data type for Photo event")
+			return nil, fmt.Errorf("invalid data type for PostBumped event")
 		}
 
-		externalPhoto := t.translatePhotoToExternal(data.Photo)
+		kafkaEvent.Data = PostBumpedEventData{
+			Post:             t.translatePostToExternal(data.Post),
+			BumpedAt:         data.BumpedAt,
+			PreviousBumpedAt: data.PreviousBumpedAt,
+		}
+
+	case domain.EventTypePhotoAdded, domain.EventTypePhotoRemoved, domain.EventTypePhotoProcessed:
+		var post domain.PostData
+		var photo domain.PhotoData
+		switch data := domainEvent.Payload.(type) {
+		case *domain.PhotoAddedEventData:
+			post, photo = data.Post, data.Photo
+		case *domain.PhotoRemovedEventData:
+			post, photo = data.Post, data.Photo
+		case *domain.PhotoProcessedEventData:
+			post, photo = data.Post, data.Photo
+		default:
+			return nil, fmt.Errorf("invalid data type for Photo event")
+		}
 
 		kafkaEvent.Data = PhotoEventData{
-			PostID: string(data.PostID),
-			Photo:  externalPhoto,
+			PostID: post.ID,
+			Photo:  t.translatePhotoToExternal(photo),
 		}
 
 	default:
@@ -167,75 +188,62 @@ func (t *OutboundEventTranslator) TranslatePostEvent(domainEvent *domain.PostEve
 	return kafkaEvent, nil
 }
 
-func (t *OutboundEventTranslator) translatePostToExternal(post *domain.Post) (ExternalPostSchema, error) {
-	var organizationID *string
-	if post.OrganizationID() != nil {
-		orgID := post.OrganizationID().String()
-		organizationID = &orgID
+func (t *OutboundEventTranslator) translatePostToExternal(post domain.PostData) ExternalPostSchema {
+	var description string
+	if post.Description != nil {
+		description = *post.Description
 	}
 
 	var photos []ExternalPhotoSchema
-	for _, photo := range post.Photos() {
-		photos = append(photos, t.translatePhotoToExternal(&photo))
+	for _, photo := range post.Photos {
+		photos = append(photos, t.translatePhotoToExternal(photo))
 	}
 
 	return ExternalPostSchema{
-		PostID:      post.ID().String(),
-		Title:       post.Title(),
-		Description: post.Description(),
+		PostID:      post.ID,
+		Title:       post.Title,
+		Description: description,
 		Location: ExternalLocationSchema{
-			Latitude:  post.Location().Latitude,
-			Longitude: post.Location().Longitude,
+			Latitude:  post.Location.Latitude,
+			Longitude: post.Location.Longitude,
 		},
-		RadiusMeters:   post.RadiusMeters(),
-		Type:           string(post.PostType()),
-		Status:         string(post.Status()),
-		UserID:         post.CreatedBy().String(),
-		OrganizationID: organizationID,
-		CreatedAt:      post.CreatedAt(),
-		UpdatedAt:      post.UpdatedAt(),
+		RadiusMeters:   post.RadiusMeters,
+		Type:           post.Type,
+		Status:         post.Status,
+		UserID:         post.UserID,
+		OrganizationID: post.OrganizationID,
+		CreatedAt:      post.CreatedAt,
+		UpdatedAt:      post.UpdatedAt,
 		Photos:         photos,
-		Tags:           t.extractTagsFromDescription(post.Description()),
-	}, nil
-}
-
-func (t *OutboundEventTranslator) translatePhotoToExternal(photo *domain.Photo) ExternalPhotoSchema {
-	return ExternalPhotoSchema{
-		PhotoID:      photo.ID().String(),
-		URL:          photo.URL(),
-		ThumbnailURL: photo.ThumbnailURL(),
-		Caption:      photo.Caption(),
-		DisplayOrder: photo.DisplayOrder(),
-		Format:       photo.Format(),
-		SizeBytes:    photo.SizeBytes(),
-		CreatedAt:    photo.CreatedAt(),
+		Tags:           post.Tags,
+		BumpedAt:       post.BumpedAt,
 	}
 }
 
-func (t *OutboundEventTranslator) extractTagsFromDescription(description string) []string {
-	// Simple tag extraction - in a real implementation, this might use NLP
-	// For now, just extract common lost/found keywords
-	tags := []string{}
-	lowerDesc := strings.ToLower(description)
-
-	commonTags := map[string]bool{
-		"phone":       strings.Contains(lowerDesc, "phone"),
-		"wallet":      strings.Contains(lowerDesc, "wallet"),
-		"keys":        strings.Contains(lowerDesc, "key"),
-		"jewelry":     strings.Contains(lowerDesc, "jewelry") || strings.Contains(lowerDesc, "ring") || strings.Contains(lowerDesc, "necklace"),
-		"bag":         strings.Contains(lowerDesc, "bag") || strings.Contains(lowerDesc, "purse"),
-		"electronics": strings.Contains(lowerDesc, "laptop") || strings.Contains(lowerDesc, "tablet") || strings.Contains(lowerDesc, "electronic"),
-		"clothing":    strings.Contains(lowerDesc, "shirt") || strings.Contains(lowerDesc, "jacket") || strings.Contains(lowerDesc, "clothes"),
-		"pet":         strings.Contains(lowerDesc, "dog") || strings.Contains(lowerDesc, "cat") || strings.Contains(lowerDesc, "pet"),
+func (t *OutboundEventTranslator) translatePhotoToExternal(photo domain.PhotoData) ExternalPhotoSchema {
+	var thumbnailURL string
+	if photo.ThumbnailURL != nil {
+		thumbnailURL = *photo.ThumbnailURL
 	}
 
-	for tag, found := range commonTags {
-		if found {
-			tags = append(tags, tag)
-		}
+	return ExternalPhotoSchema{
+		PhotoID:      photo.ID,
+		URL:          photo.OriginalURL,
+		ThumbnailURL: thumbnailURL,
+		DisplayOrder: photo.Order,
+		Format:       photoFormat(photo.MimeType),
+		SizeBytes:    photo.FileSize,
+		CreatedAt:    photo.CreatedAt,
 	}
+}
 
-	return tags
+// photoFormat maps a MIME type back to the short format name of the external schema
+func photoFormat(mimeType string) string {
+	format := strings.TrimPrefix(mimeType, "image/")
+	if format == "jpeg" {
+		return "jpg"
+	}
+	return format
 }
 
 func (t *OutboundEventTranslator) ToJSON(event *KafkaEvent) ([]byte, error) {
@@ -244,32 +252,46 @@ func (t *OutboundEventTranslator) ToJSON(event *KafkaEvent) ([]byte, error) {
 
 // Domain Event Publisher that uses the translator
 type AntiCorruptionEventPublisher struct {
-	translator     *OutboundEventTranslator
+	translator     OutboundTranslator
 	kafkaPublisher KafkaPublisher
+	metrics        publisherMetrics
+	thinEventTypes map[domain.EventType]bool
+	passthrough    domain.EventPublisher
 }
 
 type KafkaPublisher interface {
 	PublishMessage(topic string, key string, message []byte) error
 }
 
-func NewAntiCorruptionEventPublisher(translator *OutboundEventTranslator, kafkaPublisher KafkaPublisher) *AntiCorruptionEventPublisher {
+func NewAntiCorruptionEventPublisher(translator OutboundTranslator, kafkaPublisher KafkaPublisher) *AntiCorruptionEventPublisher {
 	return &AntiCorruptionEventPublisher{
 		translator:     translator,
 		kafkaPublisher: kafkaPublisher,
 	}
 }
 
+// SetPassthrough sends events of aggregates other than posts, which the external post schema
+// does not cover, through publisher unchanged instead of dead-lettering them
+func (p *AntiCorruptionEventPublisher) SetPassthrough(publisher domain.EventPublisher) {
+	p.passthrough = publisher
+}
+
 func (p *AntiCorruptionEventPublisher) PublishEvent(ctx context.Context, domainEvent *domain.PostEvent) error {
-	// Translate domain event to external schema
+	if p.passthrough != nil && domainEvent.AggregateType != "Post" {
+		return p.passthrough.PublishEvent(ctx, domainEvent)
+	}
+
+	// Translate domain event to external schema; untranslatable events go to the DLQ
+	// so schema drift shows up there instead of disappearing into a log line
 	kafkaEvent, err := p.translator.TranslatePostEvent(domainEvent)
 	if err != nil {
-		return fmt.Errorf("failed to translate event: %w", err)
+		return p.deadLetter(domainEvent, DeadLetterStageTranslation, err)
 	}
 
 	// Serialize to JSON
 	eventJSON, err := p.translator.ToJSON(kafkaEvent)
 	if err != nil {
-		return fmt.Errorf("failed to serialize event: %w", err)
+		return p.deadLetter(domainEvent, DeadLetterStageSerialization, err)
 	}
 
 	// Determine topic based on event type
@@ -283,6 +305,16 @@ func (p *AntiCorruptionEventPublisher) PublishEvent(ctx context.Context, domainE
 		return fmt.Errorf("failed to publish to Kafka: %w", err)
 	}
 
+	p.metrics.published.Add(1)
+
+	// The fat event is already out and retrying would send it again, so a failed thin variant
+	// is logged and dropped
+	if p.thinEventTypes[domainEvent.EventType] {
+		if err := p.publishThinEvent(domainEvent); err != nil {
+			log.Printf("Warning: dropped thin variant of %s event %s: %v", domainEvent.EventType, domainEvent.ID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,201 @@
+package anti_corruption
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+const defaultImportBatchSize = 100
+
+// PostImportService imports posts from a legacy system in bulk, translating external
+// events at the boundary and persisting them in transactional batches. The created events
+// are recorded through the outbox publisher in the same transaction as the posts.
+type PostImportService struct {
+	translator      *EventTranslator
+	postRepo        domain.PostRepository
+	userContextRepo domain.UserContextRepository
+	eventPublisher  domain.EventPublisher
+	transactions    domain.TransactionManager
+	batchSize       int
+}
+
+// ImportReport summarizes an import run
+type ImportReport struct {
+	Total    int                 `json:"total"`
+	Imported int                 `json:"imported"`
+	Failed   int                 `json:"failed"`
+	Errors   []ImportRecordError `json:"errors,omitempty"`
+}
+
+// ImportRecordError describes why a single record was not imported
+type ImportRecordError struct {
+	Index          int    `json:"index"`
+	EventID        string `json:"event_id,omitempty"`
+	ExternalPostID string `json:"external_post_id,omitempty"`
+	Error          string `json:"error"`
+}
+
+func NewPostImportService(translator *EventTranslator, postRepo domain.PostRepository, userContextRepo domain.UserContextRepository, eventPublisher domain.EventPublisher, transactions domain.TransactionManager, batchSize int) *PostImportService {
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	return &PostImportService{
+		translator:      translator,
+		postRepo:        postRepo,
+		userContextRepo: userContextRepo,
+		eventPublisher:  eventPublisher,
+		transactions:    transactions,
+		batchSize:       batchSize,
+	}
+}
+
+// ImportPosts validates, translates and stores legacy posts. Records that fail are
+// reported individually and never abort the rest of the import.
+func (s *PostImportService) ImportPosts(ctx context.Context, events []ExternalPostCreatedEvent) (*ImportReport, error) {
+	report := &ImportReport{Total: len(events)}
+
+	results := s.translator.TranslateBatch(events)
+
+	var pending []BatchTranslationResult
+	for _, result := range results {
+		if result.Err != nil {
+			report.addFailure(result, events[result.Index].Data.PostID, result.Err)
+			continue
+		}
+
+		pending = append(pending, result)
+		if len(pending) == s.batchSize {
+			if err := s.importBatch(ctx, pending, events, report); err != nil {
+				return report, err
+			}
+			pending = nil
+		}
+	}
+
+	if len(pending) > 0 {
+		if err := s.importBatch(ctx, pending, events, report); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+func (s *PostImportService) importBatch(ctx context.Context, batch []BatchTranslationResult, events []ExternalPostCreatedEvent, report *ImportReport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	posts := make([]*domain.Post, len(batch))
+	for i, result := range batch {
+		posts[i] = result.Post
+	}
+	users := s.loadOwners(ctx, posts)
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.postRepo.SaveBatch(ctx, posts); err != nil {
+			return err
+		}
+		for _, post := range posts {
+			if err := s.publishPostImported(ctx, post, users[post.CreatedBy()]); err != nil {
+				return fmt.Errorf("failed to record created event for post %s: %w", post.ID(), err)
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		report.Imported += len(posts)
+		return nil
+	}
+	log.Printf("Batch import of %d posts failed, retrying individually: %v", len(posts), err)
+
+	// The batch was rolled back; save records one by one to find the bad ones
+	for _, result := range batch {
+		err := s.withinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.postRepo.Save(ctx, result.Post); err != nil {
+				return err
+			}
+			return s.publishPostImported(ctx, result.Post, users[result.Post.CreatedBy()])
+		})
+		if err != nil {
+			report.addFailure(result, events[result.Index].Data.PostID, err)
+			continue
+		}
+		report.Imported++
+	}
+
+	return nil
+}
+
+// loadOwners fetches the owners of a batch in one lookup. Events for owners that could not
+// be loaded fall back to an anonymous user rather than failing the import.
+func (s *PostImportService) loadOwners(ctx context.Context, posts []*domain.Post) map[domain.UserID]*domain.PrivacySafeUser {
+	userIDs := make([]domain.UserID, len(posts))
+	for i, post := range posts {
+		userIDs[i] = post.CreatedBy()
+	}
+
+	users, err := s.userContextRepo.GetPrivacySafeUsers(ctx, userIDs)
+	if err != nil {
+		log.Printf("Failed to load owners for %d imported posts: %v", len(posts), err)
+		return nil
+	}
+	return users
+}
+
+// withinTransaction runs fn in a transaction when a transaction manager is configured
+func (s *PostImportService) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transactions == nil {
+		return fn(ctx)
+	}
+	return s.transactions.WithinTransaction(ctx, fn)
+}
+
+// publishPostImported announces the post so downstream services can index and match it.
+// Notifications are disabled because imported posts are not new to their owners.
+func (s *PostImportService) publishPostImported(ctx context.Context, post *domain.Post, owner *domain.PrivacySafeUser) error {
+	triggers := domain.CreateEventTriggersForPostCreated()
+	triggers.Notifications = false
+
+	user := domain.PrivacySafeUser{
+		UserID:      post.CreatedBy(),
+		DisplayName: "Unknown User",
+		Preferences: domain.UserPreferences{
+			Timezone:             "UTC",
+			Language:             "en",
+			NotificationChannels: []domain.NotificationChannel{},
+		},
+	}
+	if owner != nil {
+		user = *owner
+	}
+
+	event := domain.NewPostEvent(
+		domain.EventTypePostCreated,
+		post.ID(),
+		post.CreatedBy(),
+		post.OrganizationID(),
+		&domain.PostCreatedEventData{
+			Post:       post.ToPostData(),
+			User:       user,
+			AIAnalysis: domain.CreateAIMetadataPlaceholder(),
+			Triggers:   triggers,
+		},
+	)
+
+	return s.eventPublisher.PublishEvent(ctx, event)
+}
+
+func (r *ImportReport) addFailure(result BatchTranslationResult, externalPostID string, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportRecordError{
+		Index:          result.Index,
+		EventID:        result.EventID,
+		ExternalPostID: externalPostID,
+		Error:          err.Error(),
+	})
+}
@@ -0,0 +1,117 @@
+package anti_corruption
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// ThinEventTopic carries lightweight references to post events for consumers that
+// hydrate what they need instead of parsing the full fat payload
+const ThinEventTopic = "posts.events.thin"
+
+// ThinEventData identifies what changed without the surrounding post, user and AI context
+type ThinEventData struct {
+	AggregateID    string   `json:"aggregate_id"`
+	AggregateType  string   `json:"aggregate_type"`
+	PostID         string   `json:"post_id,omitempty"`
+	UserID         string   `json:"user_id"`
+	TenantID       string   `json:"tenant_id,omitempty"`
+	ChangedFields  []string `json:"changed_fields,omitempty"`
+	NewStatus      string   `json:"new_status,omitempty"`
+	PreviousStatus string   `json:"previous_status,omitempty"`
+	PhotoID        string   `json:"photo_id,omitempty"`
+}
+
+// TranslateThinEvent builds the thin variant of a domain event: IDs plus a change summary
+func (t *OutboundEventTranslator) TranslateThinEvent(domainEvent *domain.PostEvent) (*KafkaEvent, error) {
+	if domainEvent == nil {
+		return nil, fmt.Errorf("cannot translate nil event")
+	}
+
+	data := ThinEventData{
+		AggregateID:   domainEvent.AggregateID,
+		AggregateType: domainEvent.AggregateType,
+		UserID:        domainEvent.UserID.String(),
+	}
+	if domainEvent.AggregateType == "Post" {
+		data.PostID = domainEvent.PostID.String()
+	}
+	if domainEvent.TenantID != nil {
+		data.TenantID = domainEvent.TenantID.String()
+	}
+
+	switch payload := domainEvent.Payload.(type) {
+	case *domain.PostUpdatedEventData:
+		for field := range payload.Changes {
+			data.ChangedFields = append(data.ChangedFields, field)
+		}
+		sort.Strings(data.ChangedFields)
+	case *domain.PostBumpedEventData:
+		data.ChangedFields = []string{"bumped_at"}
+	case *domain.PostStatusChangedEventData:
+		data.NewStatus = string(payload.NewStatus)
+		data.PreviousStatus = string(payload.PreviousStatus)
+	case *domain.PhotoAddedEventData:
+		data.PhotoID = payload.Photo.ID
+	case *domain.PhotoRemovedEventData:
+		data.PhotoID = payload.Photo.ID
+	case *domain.PhotoProcessedEventData:
+		data.PhotoID = payload.Photo.ID
+	}
+
+	return &KafkaEvent{
+		EventID:       domainEvent.ID.String(),
+		EventType:     string(domainEvent.EventType),
+		Source:        domainEvent.SourceService,
+		Timestamp:     domainEvent.Timestamp,
+		Version:       domain.ServiceVersion(),
+		SchemaVersion: domainEvent.EventVersion,
+		Data:          data,
+		Metadata: map[string]interface{}{
+			"variant": "thin",
+		},
+	}, nil
+}
+
+// EnableThinEvents also publishes the thin variant of the given event types to ThinEventTopic,
+// alongside the fat event
+func (p *AntiCorruptionEventPublisher) EnableThinEvents(eventTypes ...domain.EventType) {
+	if p.thinEventTypes == nil {
+		p.thinEventTypes = make(map[domain.EventType]bool, len(eventTypes))
+	}
+	for _, eventType := range eventTypes {
+		p.thinEventTypes[eventType] = true
+	}
+}
+
+// NewRelayEventPublisher creates the publisher the outbox relay sends events through. Post
+// events are translated to the external schema, with thin variants for the configured
+// thinEventTypes; events of other aggregates are sent through passthrough as they are.
+func NewRelayEventPublisher(kafkaPublisher KafkaPublisher, passthrough domain.EventPublisher, thinEventTypes []string) *AntiCorruptionEventPublisher {
+	publisher := NewAntiCorruptionEventPublisher(NewOutboundEventTranslator(), kafkaPublisher)
+	publisher.SetPassthrough(passthrough)
+	for _, eventType := range thinEventTypes {
+		publisher.EnableThinEvents(domain.EventType(eventType))
+	}
+	return publisher
+}
+
+func (p *AntiCorruptionEventPublisher) publishThinEvent(domainEvent *domain.PostEvent) error {
+	thinEvent, err := p.translator.TranslateThinEvent(domainEvent)
+	if err != nil {
+		return fmt.Errorf("failed to translate thin event: %w", err)
+	}
+
+	eventJSON, err := p.translator.ToJSON(thinEvent)
+	if err != nil {
+		return fmt.Errorf("failed to serialize thin event: %w", err)
+	}
+
+	if err := p.kafkaPublisher.PublishMessage(ThinEventTopic, domainEvent.AggregateID, eventJSON); err != nil {
+		return fmt.Errorf("failed to publish thin event to Kafka: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxClaimProofLength caps the description a claimant gives of the item, in characters
+	MaxClaimProofLength = 2000
+	// MaxClaimProofPhotos caps how many photos a claimant may attach as proof
+	MaxClaimProofPhotos = 5
+	// DefaultClaimRetentionDays is how long claims are kept when no retention is configured
+	DefaultClaimRetentionDays = 90
+)
+
+// ClaimStatus represents where a claim is in the owner's review
+type ClaimStatus string
+
+const (
+	ClaimStatusPending  ClaimStatus = "pending"
+	ClaimStatusApproved ClaimStatus = "approved"
+	ClaimStatusRejected ClaimStatus = "rejected"
+)
+
+func (s ClaimStatus) IsValid() bool {
+	switch s {
+	case ClaimStatusPending, ClaimStatusApproved, ClaimStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Claim is a claimant's proof of ownership of an item reported on a found post. The post
+// owner reviews it before any contact is exchanged.
+type Claim struct {
+	id                       ClaimID
+	postID                   PostID
+	claimantUserID           UserID
+	ownerUserID              UserID
+	status                   ClaimStatus
+	proofText                string
+	proofPhotoURLs           []string
+	contactExchangeRequestID *ContactExchangeRequestID
+	reviewedAt               *time.Time
+	retainUntil              time.Time
+	createdAt                time.Time
+	updatedAt                time.Time
+}
+
+// NewClaim creates a pending claim that is kept for the given retention period
+func NewClaim(
+	postID PostID,
+	claimantUserID UserID,
+	ownerUserID UserID,
+	proofText string,
+	proofPhotoURLs []string,
+	retention time.Duration,
+) (*Claim, error) {
+	if postID.IsZero() {
+		return nil, ErrInvalidPostID()
+	}
+
+	if claimantUserID.IsZero() || ownerUserID.IsZero() {
+		return nil, ErrInvalidUserID()
+	}
+
+	if claimantUserID.Equals(ownerUserID) {
+		return nil, ErrCannotClaimOwnPost()
+	}
+
+	proofText = strings.TrimSpace(proofText)
+	if err := validateClaimProof(proofText, proofPhotoURLs); err != nil {
+		return nil, err
+	}
+
+	if retention <= 0 {
+		retention = DefaultClaimRetentionDays * 24 * time.Hour
+	}
+
+	now := utcNow()
+
+	return &Claim{
+		id:             NewClaimID(),
+		postID:         postID,
+		claimantUserID: claimantUserID,
+		ownerUserID:    ownerUserID,
+		status:         ClaimStatusPending,
+		proofText:      proofText,
+		proofPhotoURLs: proofPhotoURLs,
+		retainUntil:    now.Add(retention),
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+// ReconstructClaim reconstructs a claim from persistence
+func ReconstructClaim(
+	id ClaimID,
+	postID PostID,
+	claimantUserID UserID,
+	ownerUserID UserID,
+	status ClaimStatus,
+	proofText string,
+	proofPhotoURLs []string,
+	contactExchangeRequestID *ContactExchangeRequestID,
+	reviewedAt *time.Time,
+	retainUntil time.Time,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Claim {
+	return &Claim{
+		id:                       id,
+		postID:                   postID,
+		claimantUserID:           claimantUserID,
+		ownerUserID:              ownerUserID,
+		status:                   status,
+		proofText:                proofText,
+		proofPhotoURLs:           proofPhotoURLs,
+		contactExchangeRequestID: contactExchangeRequestID,
+		reviewedAt:               utcPtr(reviewedAt),
+		retainUntil:              retainUntil.UTC(),
+		createdAt:                createdAt.UTC(),
+		updatedAt:                updatedAt.UTC(),
+	}
+}
+
+func validateClaimProof(proofText string, proofPhotoURLs []string) error {
+	if proofText == "" && len(proofPhotoURLs) == 0 {
+		return ErrInvalidClaimProof("a description or at least one photo is required")
+	}
+
+	if TextLength(proofText) > MaxClaimProofLength {
+		return ErrInvalidClaimProof("description is too long").WithDetail("max_length", MaxClaimProofLength)
+	}
+
+	if len(proofPhotoURLs) > MaxClaimProofPhotos {
+		return ErrInvalidClaimProof("too many photos").WithDetail("max_photos", MaxClaimProofPhotos)
+	}
+
+	for _, photoURL := range proofPhotoURLs {
+		parsed, err := url.ParseRequestURI(photoURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return ErrInvalidPhotoURL(photoURL)
+		}
+	}
+
+	return nil
+}
+
+// Approve accepts the claimant's proof
+func (c *Claim) Approve() error {
+	return c.review(ClaimStatusApproved)
+}
+
+// Reject turns down the claimant's proof
+func (c *Claim) Reject() error {
+	return c.review(ClaimStatusRejected)
+}
+
+func (c *Claim) review(status ClaimStatus) error {
+	if c.status != ClaimStatusPending {
+		return ErrInvalidClaimStatus(c.status, status)
+	}
+
+	now := utcNow()
+	c.status = status
+	c.reviewedAt = &now
+	c.updatedAt = now
+
+	return nil
+}
+
+// LinkContactExchange records the contact exchange started from an approved claim
+func (c *Claim) LinkContactExchange(requestID ContactExchangeRequestID) {
+	c.contactExchangeRequestID = &requestID
+	c.updatedAt = utcNow()
+}
+
+// IsOwner reports whether the user owns the post the claim was made on
+func (c *Claim) IsOwner(userID UserID) bool {
+	return c.ownerUserID.Equals(userID)
+}
+
+// IsClaimant reports whether the user made the claim
+func (c *Claim) IsClaimant(userID UserID) bool {
+	return c.claimantUserID.Equals(userID)
+}
+
+// CanBeViewedBy reports whether the user takes part in the claim
+func (c *Claim) CanBeViewedBy(userID UserID) bool {
+	return c.IsClaimant(userID) || c.IsOwner(userID)
+}
+
+// Getters
+func (c *Claim) ID() ClaimID {
+	return c.id
+}
+
+func (c *Claim) PostID() PostID {
+	return c.postID
+}
+
+func (c *Claim) ClaimantUserID() UserID {
+	return c.claimantUserID
+}
+
+func (c *Claim) OwnerUserID() UserID {
+	return c.ownerUserID
+}
+
+func (c *Claim) Status() ClaimStatus {
+	return c.status
+}
+
+func (c *Claim) ProofText() string {
+	return c.proofText
+}
+
+func (c *Claim) ProofPhotoURLs() []string {
+	return c.proofPhotoURLs
+}
+
+func (c *Claim) ContactExchangeRequestID() *ContactExchangeRequestID {
+	return c.contactExchangeRequestID
+}
+
+func (c *Claim) ReviewedAt() *time.Time {
+	return c.reviewedAt
+}
+
+func (c *Claim) RetainUntil() time.Time {
+	return c.retainUntil
+}
+
+func (c *Claim) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *Claim) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// ToClaimData converts a Claim to ClaimData for events. The proof itself stays out of events
+// since it can describe the item in identifying detail.
+func (c *Claim) ToClaimData() ClaimData {
+	data := ClaimData{
+		ClaimID:         c.id.String(),
+		Status:          string(c.status),
+		HasProofText:    c.proofText != "",
+		ProofPhotoCount: len(c.proofPhotoURLs),
+		ReviewedAt:      c.reviewedAt,
+		RetainUntil:     c.retainUntil,
+		CreatedAt:       c.createdAt,
+	}
+	if c.contactExchangeRequestID != nil {
+		requestID := c.contactExchangeRequestID.String()
+		data.ContactExchangeRequestID = &requestID
+	}
+	return data
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// utcNow returns the current time in UTC. Domain timestamps are kept in UTC whether they are
+// created here or read back from the database, so they compare and serialize the same way
+// regardless of the server's or the database session's time zone.
+func utcNow() time.Time {
+	return time.Now().UTC()
+}
+
+// utcPtr returns a UTC copy of an optional timestamp
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
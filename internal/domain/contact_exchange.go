@@ -2,9 +2,96 @@ package domain
 
 import (
 	"fmt"
+	"sync"
 	"time"
 )
 
+const (
+	// DefaultContactExchangeExpirationHours applies when a request does not ask for an expiration
+	DefaultContactExchangeExpirationHours = 72
+	// DefaultMaxContactExchangeExpirationHours caps how long a request may stay pending (30 days)
+	DefaultMaxContactExchangeExpirationHours = 720
+	// DefaultMaxContactExchangeMessageLength caps request, approval and denial messages, in characters
+	DefaultMaxContactExchangeMessageLength = 1000
+)
+
+var (
+	contactExchangeExpirationMu       sync.RWMutex
+	maxContactExchangeExpirationHours = DefaultMaxContactExchangeExpirationHours
+
+	contactExchangeMessageMu        sync.RWMutex
+	maxContactExchangeMessageLength = DefaultMaxContactExchangeMessageLength
+)
+
+// SetMaxContactExchangeExpirationHours sets the longest expiration a contact exchange request
+// may be created with. Zero restores the default.
+func SetMaxContactExchangeExpirationHours(hours int) error {
+	if hours == 0 {
+		hours = DefaultMaxContactExchangeExpirationHours
+	}
+	if hours < DefaultContactExchangeExpirationHours {
+		return fmt.Errorf("max contact exchange expiration must be at least the %d hour default, got %d",
+			DefaultContactExchangeExpirationHours, hours)
+	}
+
+	contactExchangeExpirationMu.Lock()
+	defer contactExchangeExpirationMu.Unlock()
+	maxContactExchangeExpirationHours = hours
+
+	return nil
+}
+
+// MaxContactExchangeExpirationHours returns the longest expiration a request may be created with
+func MaxContactExchangeExpirationHours() int {
+	contactExchangeExpirationMu.RLock()
+	defer contactExchangeExpirationMu.RUnlock()
+	return maxContactExchangeExpirationHours
+}
+
+// SetMaxContactExchangeMessageLength sets the longest message, in characters, a request,
+// approval or denial may carry. Zero restores the default.
+func SetMaxContactExchangeMessageLength(length int) error {
+	if length == 0 {
+		length = DefaultMaxContactExchangeMessageLength
+	}
+	if length < 0 {
+		return fmt.Errorf("max contact exchange message length must be positive, got %d", length)
+	}
+
+	contactExchangeMessageMu.Lock()
+	defer contactExchangeMessageMu.Unlock()
+	maxContactExchangeMessageLength = length
+
+	return nil
+}
+
+// MaxContactExchangeMessageLength returns the longest message a contact exchange may carry
+func MaxContactExchangeMessageLength() int {
+	contactExchangeMessageMu.RLock()
+	defer contactExchangeMessageMu.RUnlock()
+	return maxContactExchangeMessageLength
+}
+
+// ValidateContactExchangeMessage checks an optional message against the configured max length
+func ValidateContactExchangeMessage(message *string) error {
+	if message == nil {
+		return nil
+	}
+	if length, maxLength := TextLength(*message), MaxContactExchangeMessageLength(); length > maxLength {
+		return ErrContactExchangeMessageTooLong(length, maxLength)
+	}
+	return nil
+}
+
+// truncateContactExchangeMessage bounds a message copied into event data
+func truncateContactExchangeMessage(message *string) *string {
+	if message == nil {
+		return nil
+	}
+	truncated := TruncateText(*message, MaxContactExchangeMessageLength())
+	return &truncated
+}
+
 // ContactExchangeStatus represents the status of a contact exchange request
 type ContactExchangeStatus string
 
@@ -15,6 +102,24 @@ const (
 	ContactExchangeStatusExpired  ContactExchangeStatus = "expired"
 )
 
+func (s ContactExchangeStatus) IsValid() bool {
+	switch s {
+	case ContactExchangeStatusPending, ContactExchangeStatusApproved, ContactExchangeStatusDenied, ContactExchangeStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContactExchangeStatusFromString parses a contact exchange status as found in query parameters
+func ContactExchangeStatusFromString(s string) (ContactExchangeStatus, error) {
+	status := ContactExchangeStatus(s)
+	if !status.IsValid() {
+		return "", ErrUnknownContactExchangeStatus(s)
+	}
+	return status, nil
+}
+
 // ContactExchangeApprovalType represents the type of contact sharing approved
 type ContactExchangeApprovalType string
 
@@ -55,6 +160,8 @@ type ContactExchangeRequest struct {
 	message               *string
 	verificationRequired  bool
 	verificationDetails   *VerificationDetails
+	verificationCompleted bool
+	verificationSubmission *VerificationSubmission
 	approvalType          *ContactExchangeApprovalType
 	denialReason          *DenialReason
 	denialMessage         *string
@@ -71,13 +178,37 @@ type VerificationDetails struct {
 	Requirements []string          `json:"requirements,omitempty"`
 }
 
-// EncryptedContactInfo contains encrypted contact information
+// EncryptedContactInfo contains encrypted contact information. Only the preferred method and
+// sharing restrictions are kept in the clear.
 type EncryptedContactInfo struct {
+	// Email, Phone and Message are only found on contact info stored before Ciphertext existed,
+	// when Email held the ciphertext next to a plaintext phone and message. See
+	// UpgradeLegacyLayout.
 	Email               *string                  `json:"email,omitempty"`
 	Phone               *string                  `json:"phone,omitempty"`
 	PreferredMethod     string                   `json:"preferred_method"`
 	Message             *string                  `json:"message,omitempty"`
 	SharingRestrictions *SharingRestrictions     `json:"sharing_restrictions,omitempty"`
+	// Ciphertext holds the AES-256-GCM encrypted contact info and EncryptedKey the RSA encrypted
+	// AES key. Contact info stored before envelope encryption has no EncryptedKey or Nonce and
+	// its ciphertext is RSA encrypted directly.
+	Ciphertext          *string                  `json:"ciphertext,omitempty"`
+	EncryptedKey        *string                  `json:"encrypted_key,omitempty"`
+	Nonce               *string                  `json:"nonce,omitempty"`
+	// KeyFingerprint names the RSA key the contact info was encrypted with so it can still be
+	// decrypted after a key rotation. Older contact info has none and uses the active key.
+	KeyFingerprint      string                   `json:"key_fingerprint,omitempty"`
+}
+
+// UpgradeLegacyLayout moves ciphertext that older versions stored in Email into Ciphertext and
+// drops the plaintext contact details that were kept next to it
+func (e *EncryptedContactInfo) UpgradeLegacyLayout() {
+	if e.Ciphertext == nil {
+		e.Ciphertext = e.Email
+	}
+	e.Email = nil
+	e.Phone = nil
+	e.Message = nil
 }
 
 // SharingRestrictions defines limitations on contact sharing
@@ -110,10 +241,21 @@ func NewContactExchangeRequest(
 	}
 
 	if expirationHours <= 0 {
-		expirationHours = 72 // Default 3 days
+		expirationHours = DefaultContactExchangeExpirationHours
+	}
+	if maxHours := MaxContactExchangeExpirationHours(); expirationHours > maxHours {
+		return nil, ErrInvalidContactExchangeExpiration(expirationHours, maxHours)
+	}
+
+	if err := ValidateContactExchangeMessage(message); err != nil {
+		return nil, err
+	}
+
+	if err := validateVerificationDetails(verificationRequired, verificationDetails); err != nil {
+		return nil, err
 	}
 
-	now := time.Now()
+	now := utcNow()
 	expiresAt := now.Add(time.Duration(expirationHours) * time.Hour)
 
 	return &ContactExchangeRequest{
@@ -162,9 +304,9 @@ func ReconstructContactExchangeRequest(
 		denialReason:         denialReason,
 		denialMessage:        denialMessage,
 		encryptedContactInfo: encryptedContactInfo,
-		expiresAt:            expiresAt,
-		createdAt:            createdAt,
-		updatedAt:            updatedAt,
+		expiresAt:            expiresAt.UTC(),
+		createdAt:            createdAt.UTC(),
+		updatedAt:            updatedAt.UTC(),
 	}
 }
 
@@ -181,10 +323,14 @@ func (c *ContactExchangeRequest) Approve(
 		return ErrContactExchangeExpired()
 	}
 
+	if c.verificationRequired && !c.verificationCompleted {
+		return ErrContactExchangeNotVerified(c.VerificationMethod())
+	}
+
 	c.status = ContactExchangeStatusApproved
 	c.approvalType = &approvalType
 	c.encryptedContactInfo = encryptedContactInfo
-	c.updatedAt = time.Now()
+	c.updatedAt = utcNow()
 
 	return nil
 }
@@ -195,10 +341,14 @@ func (c *ContactExchangeRequest) Deny(reason DenialReason, message *string) erro
 		return ErrInvalidContactExchangeStatus(c.status, ContactExchangeStatusDenied)
 	}
 
+	if err := ValidateContactExchangeMessage(message); err != nil {
+		return err
+	}
+
 	c.status = ContactExchangeStatusDenied
 	c.denialReason = &reason
 	c.denialMessage = message
-	c.updatedAt = time.Now()
+	c.updatedAt = utcNow()
 
 	return nil
 }
@@ -214,7 +364,7 @@ func (c *ContactExchangeRequest) Expire() error {
 	}
 
 	c.status = ContactExchangeStatusExpired
-	c.updatedAt = time.Now()
+	c.updatedAt = utcNow()
 
 	return nil
 }
@@ -227,14 +377,31 @@ func (c *ContactExchangeRequest) ClearContactInfo() error {
 
 	// Securely clear the encrypted contact information
 	c.encryptedContactInfo = nil
-	c.updatedAt = time.Now()
+	c.updatedAt = utcNow()
 
 	return nil
 }
 
+// ReplaceEncryptedContactInfo swaps the shared contact info for the same details encrypted
+// under another key. The contact info itself does not change, so neither does updatedAt.
+func (c *ContactExchangeRequest) ReplaceEncryptedContactInfo(encryptedContactInfo *EncryptedContactInfo) error {
+	if c.status != ContactExchangeStatusApproved || c.encryptedContactInfo == nil {
+		return fmt.Errorf("can only re-encrypt contact info of approved requests")
+	}
+
+	c.encryptedContactInfo = encryptedContactInfo
+	return nil
+}
+
 // IsExpired checks if the request has expired
 func (c *ContactExchangeRequest) IsExpired() bool {
-	return time.Now().After(c.expiresAt)
+	return c.IsExpiredAt(utcNow())
+}
+
+// IsExpiredAt checks if the request had expired at the given instant. A request expires at its
+// expiry time, matching the expires_at <= NOW() check FindExpired runs in the database.
+func (c *ContactExchangeRequest) IsExpiredAt(at time.Time) bool {
+	return !at.Before(c.expiresAt)
 }
 
 // CanBeApproved checks if the request can be approved
@@ -247,6 +414,36 @@ func (c *ContactExchangeRequest) CanBeDenied() bool {
 	return c.status == ContactExchangeStatusPending
 }
 
+// IsOwner reports whether the user owns the post the request was made for
+func (c *ContactExchangeRequest) IsOwner(userID UserID) bool {
+	return c.ownerUserID.Equals(userID)
+}
+
+// IsRequester reports whether the user made the request
+func (c *ContactExchangeRequest) IsRequester(userID UserID) bool {
+	return c.requesterUserID.Equals(userID)
+}
+
+// CanBeViewedBy reports whether the user takes part in the request
+func (c *ContactExchangeRequest) CanBeViewedBy(userID UserID) bool {
+	return c.IsRequester(userID) || c.IsOwner(userID)
+}
+
+// CanBeApprovedBy reports whether the user is the post owner and the request is still approvable
+func (c *ContactExchangeRequest) CanBeApprovedBy(userID UserID) bool {
+	return c.IsOwner(userID) && c.CanBeApproved()
+}
+
+// CanBeDeniedBy reports whether the user is the post owner and the request is still pending
+func (c *ContactExchangeRequest) CanBeDeniedBy(userID UserID) bool {
+	return c.IsOwner(userID) && c.CanBeDenied()
+}
+
+// CanBeCancelledBy reports whether the user made the request and it is still pending
+func (c *ContactExchangeRequest) CanBeCancelledBy(userID UserID) bool {
+	return c.IsRequester(userID) && c.status == ContactExchangeStatusPending
+}
+
 // Getters
 func (c *ContactExchangeRequest) ID() ContactExchangeRequestID {
 	return c.id
@@ -313,7 +510,7 @@ func (c *ContactExchangeRequest) ToContactRequestData() ContactRequestData {
 	return ContactRequestData{
 		RequestID:            c.id.String(),
 		Status:               string(c.status),
-		Message:              c.message,
+		Message:              truncateContactExchangeMessage(c.message),
 		VerificationRequired: c.verificationRequired,
 		VerificationDetails:  c.verificationDetails,
 		ExpiresAt:            c.expiresAt,
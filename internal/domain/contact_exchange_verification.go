@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	// MaxVerificationAnswerLength caps a requester's answer to the security question, in characters
+	MaxVerificationAnswerLength = 500
+	// MaxVerificationPhotos caps how many photos a requester may send as proof
+	MaxVerificationPhotos = 5
+)
+
+// VerificationSubmission is the proof a requester gives for a request's verification method:
+// an answer for a security question and photos for photo proof. Admin approval accepts either,
+// for the admin to look at.
+type VerificationSubmission struct {
+	Answer    *string  `json:"answer,omitempty"`
+	PhotoURLs []string `json:"photo_urls,omitempty"`
+}
+
+func IsValidVerificationMethod(method VerificationMethod) bool {
+	switch method {
+	case VerificationMethodPhotoProof, VerificationMethodSecurityQuestion, VerificationMethodAdminApproval:
+		return true
+	}
+	return false
+}
+
+// validateVerificationDetails checks that a request requiring verification says how the
+// requester is to be verified, and asks a question when that is the method
+func validateVerificationDetails(required bool, details *VerificationDetails) error {
+	if !required {
+		return nil
+	}
+
+	if details == nil {
+		return ErrInvalidVerification("a verification method is required when verification is required")
+	}
+
+	if !IsValidVerificationMethod(details.Method) {
+		return ErrInvalidVerification("unknown verification method").WithDetail("method", string(details.Method))
+	}
+
+	if details.Method == VerificationMethodSecurityQuestion && (details.Question == nil || strings.TrimSpace(*details.Question) == "") {
+		return ErrInvalidVerification("a security question is required")
+	}
+
+	return nil
+}
+
+// validateFor checks that the submission holds the proof the method asks for and returns it
+// with its answer trimmed
+func (s VerificationSubmission) validateFor(method VerificationMethod) (VerificationSubmission, error) {
+	if s.Answer != nil {
+		answer := strings.TrimSpace(*s.Answer)
+		if answer == "" {
+			s.Answer = nil
+		} else {
+			if TextLength(answer) > MaxVerificationAnswerLength {
+				return VerificationSubmission{}, ErrInvalidVerification("answer is too long").WithDetail("max_length", MaxVerificationAnswerLength)
+			}
+			s.Answer = &answer
+		}
+	}
+
+	if len(s.PhotoURLs) > MaxVerificationPhotos {
+		return VerificationSubmission{}, ErrInvalidVerification("too many photos").WithDetail("max_photos", MaxVerificationPhotos)
+	}
+	for _, photoURL := range s.PhotoURLs {
+		parsed, err := url.ParseRequestURI(photoURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return VerificationSubmission{}, ErrInvalidPhotoURL(photoURL)
+		}
+	}
+
+	switch method {
+	case VerificationMethodSecurityQuestion:
+		if s.Answer == nil {
+			return VerificationSubmission{}, ErrInvalidVerification("an answer to the security question is required")
+		}
+	case VerificationMethodPhotoProof:
+		if len(s.PhotoURLs) == 0 {
+			return VerificationSubmission{}, ErrInvalidVerification("at least one photo is required")
+		}
+	default:
+		if s.Answer == nil && len(s.PhotoURLs) == 0 {
+			return VerificationSubmission{}, ErrInvalidVerification("an answer or at least one photo is required")
+		}
+	}
+
+	return s, nil
+}
+
+// SubmitVerification records the requester's proof. An answer to the security question or
+// photo proof completes verification, leaving it to the owner to judge the proof when deciding
+// whether to approve. Proof for admin approval waits for CompleteAdminVerification.
+func (c *ContactExchangeRequest) SubmitVerification(submission VerificationSubmission) error {
+	if err := c.checkVerifiable(); err != nil {
+		return err
+	}
+
+	method := c.VerificationMethod()
+	validated, err := submission.validateFor(method)
+	if err != nil {
+		return err
+	}
+
+	c.verificationSubmission = &validated
+	if method != VerificationMethodAdminApproval {
+		c.verificationCompleted = true
+	}
+	c.updatedAt = utcNow()
+
+	return nil
+}
+
+// CompleteAdminVerification marks a request verified by an admin, the only way requests
+// verified by admin approval are
+func (c *ContactExchangeRequest) CompleteAdminVerification() error {
+	if err := c.checkVerifiable(); err != nil {
+		return err
+	}
+
+	if c.VerificationMethod() != VerificationMethodAdminApproval {
+		return ErrInvalidVerification("request is not verified by admin approval")
+	}
+
+	c.verificationCompleted = true
+	c.updatedAt = utcNow()
+
+	return nil
+}
+
+func (c *ContactExchangeRequest) checkVerifiable() error {
+	if !c.verificationRequired || c.verificationDetails == nil {
+		return ErrInvalidVerification("request does not require verification")
+	}
+
+	if c.status != ContactExchangeStatusPending {
+		return ErrInvalidVerification("only pending requests can be verified").WithDetail("status", string(c.status))
+	}
+
+	if c.IsExpired() {
+		return ErrContactExchangeExpired()
+	}
+
+	if c.verificationCompleted {
+		return ErrInvalidVerification("request is already verified")
+	}
+
+	return nil
+}
+
+// RestoreVerification sets the stored verification state when rebuilding a request from persistence
+func (c *ContactExchangeRequest) RestoreVerification(completed bool, submission *VerificationSubmission) {
+	c.verificationCompleted = completed
+	c.verificationSubmission = submission
+}
+
+// VerificationMethod returns how the requester is verified, empty when they need not be
+func (c *ContactExchangeRequest) VerificationMethod() VerificationMethod {
+	if c.verificationDetails == nil {
+		return ""
+	}
+	return c.verificationDetails.Method
+}
+
+func (c *ContactExchangeRequest) VerificationCompleted() bool {
+	return c.verificationCompleted
+}
+
+func (c *ContactExchangeRequest) VerificationSubmission() *VerificationSubmission {
+	return c.verificationSubmission
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxEmailLength is the longest address SMTP allows
+const MaxEmailLength = 254
+
+// Ways an owner can ask to be contacted, each backed by a ContactInfo field
+const (
+	ContactMethodEmail = "email"
+	ContactMethodPhone = "phone"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`)
+	// E.164: a plus, a country code that does not start with 0, and at most 15 digits in all
+	e164Pattern = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+	// Separators people commonly type inside phone numbers
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+)
+
+// NormalizeEmail trims and lowercases an email address and checks that it looks like one
+func NormalizeEmail(email string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(email))
+	if len(normalized) > MaxEmailLength || !emailPattern.MatchString(normalized) {
+		return "", ErrInvalidContactInfo("email", "must be a valid email address")
+	}
+	return normalized, nil
+}
+
+// NormalizePhone formats a phone number as E.164, dropping separators and accepting the 00
+// international prefix in place of the plus. Numbers without a country code are rejected
+// since the country cannot be guessed.
+func NormalizePhone(phone string) (string, error) {
+	normalized := phoneSeparators.Replace(strings.TrimSpace(phone))
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+	if !e164Pattern.MatchString(normalized) {
+		return "", ErrInvalidContactInfo("phone", "must be an international number with its country code, like +14155550123")
+	}
+	return normalized, nil
+}
+
+// NormalizeContactInfo returns a copy of the contact info with its email and phone
+// normalized. Blank email and phone values are dropped, after which at least one of them must
+// be left and the preferred method must name one that is.
+func NormalizeContactInfo(info ContactInfo) (ContactInfo, error) {
+	if info.Email != nil {
+		if strings.TrimSpace(*info.Email) == "" {
+			info.Email = nil
+		} else {
+			email, err := NormalizeEmail(*info.Email)
+			if err != nil {
+				return ContactInfo{}, err
+			}
+			info.Email = &email
+		}
+	}
+
+	if info.Phone != nil {
+		if strings.TrimSpace(*info.Phone) == "" {
+			info.Phone = nil
+		} else {
+			phone, err := NormalizePhone(*info.Phone)
+			if err != nil {
+				return ContactInfo{}, err
+			}
+			info.Phone = &phone
+		}
+	}
+
+	if err := validateContactChannels(info); err != nil {
+		return ContactInfo{}, err
+	}
+
+	return info, nil
+}
+
+func validateContactChannels(info ContactInfo) error {
+	if info.Email == nil && info.Phone == nil {
+		return ErrNoContactChannel()
+	}
+
+	switch info.PreferredMethod {
+	case ContactMethodEmail:
+		if info.Email == nil {
+			return ErrInvalidContactInfo("email", "is required when it is the preferred method")
+		}
+	case ContactMethodPhone:
+		if info.Phone == nil {
+			return ErrInvalidContactInfo("phone", "is required when it is the preferred method")
+		}
+	default:
+		return ErrInvalidContactInfo("preferred_method", fmt.Sprintf("must be %s or %s", ContactMethodEmail, ContactMethodPhone))
+	}
+
+	return nil
+}
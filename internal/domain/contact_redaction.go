@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// redacted stands in for contact details in logs and formatted output
+const redacted = "[REDACTED]"
+
+// String keeps contact details out of logs; only the preferred method and which fields are
+// present are shown. JSON encoding is unaffected.
+func (c ContactInfo) String() string {
+	return formatRedactedContact("ContactInfo", c.Email, c.Phone, c.Message, c.PreferredMethod)
+}
+
+// GoString redacts %#v output the same way as String
+func (c ContactInfo) GoString() string {
+	return c.String()
+}
+
+// LogValue redacts contact details when logged through log/slog
+func (c ContactInfo) LogValue() slog.Value {
+	return redactedContactLogValue(c.Email, c.Phone, c.Message, c.PreferredMethod)
+}
+
+// String keeps encrypted contact details out of logs, see ContactInfo.String
+func (c EncryptedContactInfo) String() string {
+	return formatRedactedContact("EncryptedContactInfo", c.Email, c.Phone, c.Message, c.PreferredMethod)
+}
+
+// GoString redacts %#v output the same way as String
+func (c EncryptedContactInfo) GoString() string {
+	return c.String()
+}
+
+// LogValue redacts encrypted contact details when logged through log/slog
+func (c EncryptedContactInfo) LogValue() slog.Value {
+	return redactedContactLogValue(c.Email, c.Phone, c.Message, c.PreferredMethod)
+}
+
+func formatRedactedContact(typeName string, email, phone, message *string, preferredMethod string) string {
+	return fmt.Sprintf("%s{email:%s phone:%s message:%s preferred_method:%s}",
+		typeName, redactField(email), redactField(phone), redactField(message), preferredMethod)
+}
+
+func redactedContactLogValue(email, phone, message *string, preferredMethod string) slog.Value {
+	return slog.GroupValue(
+		slog.String("email", redactField(email)),
+		slog.String("phone", redactField(phone)),
+		slog.String("message", redactField(message)),
+		slog.String("preferred_method", preferredMethod),
+	)
+}
+
+// redactField shows whether an optional field is set without revealing its value
+func redactField(value *string) string {
+	if value == nil || *value == "" {
+		return "<none>"
+	}
+	return redacted
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PostCursor marks a position in a post listing ordered newest first by creation time, with
+// the post ID breaking ties between posts created at the same instant. A page that starts
+// after a cursor is unaffected by posts created or deleted ahead of it. In listings ordered
+// by bump time, CreatedAt holds the post's ListedAt instead.
+type PostCursor struct {
+	CreatedAt time.Time
+	ID        PostID
+}
+
+// CursorAfter returns the cursor for the page that follows post
+func CursorAfter(post *Post) PostCursor {
+	return PostCursor{CreatedAt: post.CreatedAt(), ID: post.ID()}
+}
+
+// CursorAfterSorted returns the cursor for the page that follows post in a listing in the
+// given order
+func CursorAfterSorted(post *Post, sort PostSort) PostCursor {
+	if sort == PostSortBumpedAt {
+		return PostCursor{CreatedAt: post.ListedAt(), ID: post.ID()}
+	}
+	return CursorAfter(post)
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c PostCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodePostCursor reads a token produced by PostCursor.Encode
+func DecodePostCursor(token string) (PostCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return PostCursor{}, ErrInvalidCursor
+	}
+
+	createdAtStr, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return PostCursor{}, ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return PostCursor{}, ErrInvalidCursor
+	}
+
+	id, err := PostIDFromString(idStr)
+	if err != nil {
+		return PostCursor{}, ErrInvalidCursor
+	}
+
+	return PostCursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -8,7 +10,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 )
 
@@ -31,6 +35,10 @@ type EncryptionService interface {
 
 	// GetActiveKeyFingerprint returns fingerprint of current active key
 	GetActiveKeyFingerprint() string
+
+	// RetireUnusedKeys retires every inactive key not listed as still in use and returns the
+	// fingerprints it retired
+	RetireUnusedKeys(inUse []string) ([]string, error)
 }
 
 // ContactInfo represents unencrypted contact information
@@ -60,6 +68,7 @@ type EncryptionKey struct {
 	IsActive     bool      `json:"is_active"`     // Whether this is the active key
 	CreatedAt    time.Time `json:"created_at"`    // Key creation time
 	ExpiresAt    *time.Time `json:"expires_at,omitempty"` // Optional key expiration
+	RetiredAt    *time.Time `json:"retired_at,omitempty"` // Set once no stored data is encrypted with the key
 }
 
 // EncryptionAuditLog represents audit trail for encryption operations
@@ -85,8 +94,19 @@ const (
 	EncryptionOperationTokenCreate EncryptionOperation = "token_create"
 	EncryptionOperationTokenValidate EncryptionOperation = "token_validate"
 	EncryptionOperationKeyRotation EncryptionOperation = "key_rotation"
+	EncryptionOperationReEncrypt   EncryptionOperation = "re_encrypt"
 )
 
+func (o EncryptionOperation) IsValid() bool {
+	switch o {
+	case EncryptionOperationEncrypt, EncryptionOperationDecrypt, EncryptionOperationTokenCreate,
+		EncryptionOperationTokenValidate, EncryptionOperationKeyRotation, EncryptionOperationReEncrypt:
+		return true
+	default:
+		return false
+	}
+}
+
 // RSAEncryptionService implements RSA-4096 encryption
 type RSAEncryptionService struct {
 	keyRepository KeyRepository
@@ -94,7 +114,59 @@ type RSAEncryptionService struct {
 	activeKey     *EncryptionKey
 }
 
-// NewRSAEncryptionService creates a new RSA encryption service
+// ErrNoActiveKey is returned by a KeyRepository that was reached but holds no active key
+var ErrNoActiveKey = errors.New("no active encryption key found")
+
+// EncryptionInitFailure says why the encryption service could not start
+type EncryptionInitFailure string
+
+const (
+	// EncryptionInitKeyStoreUnavailable means the key store could not be read, usually because
+	// the database is not reachable yet
+	EncryptionInitKeyStoreUnavailable EncryptionInitFailure = "key_store_unavailable"
+	// EncryptionInitKeyGenerationFailed means the key store has no active key and a new one
+	// could not be generated or saved
+	EncryptionInitKeyGenerationFailed EncryptionInitFailure = "key_generation_failed"
+)
+
+// EncryptionInitError reports a failure to load or create the active encryption key at startup
+type EncryptionInitError struct {
+	Failure  EncryptionInitFailure
+	Attempts int
+	Err      error
+}
+
+func (e *EncryptionInitError) Error() string {
+	switch e.Failure {
+	case EncryptionInitKeyStoreUnavailable:
+		return fmt.Sprintf("encryption service: cannot reach key store after %d attempt(s): %v", e.Attempts, e.Err)
+	case EncryptionInitKeyGenerationFailed:
+		return fmt.Sprintf("encryption service: no active key and failed to generate one: %v", e.Err)
+	default:
+		return fmt.Sprintf("encryption service: %s: %v", e.Failure, e.Err)
+	}
+}
+
+func (e *EncryptionInitError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether the failure may clear up on its own, such as a database that is
+// still starting
+func (e *EncryptionInitError) Retryable() bool {
+	return e.Failure == EncryptionInitKeyStoreUnavailable
+}
+
+// KeyLoadRetry bounds how long startup waits for the key store. Backoff doubles after each
+// failed attempt up to MaxBackoff.
+type KeyLoadRetry struct {
+	Attempts       int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// NewRSAEncryptionService creates a new RSA encryption service, generating a key if the key store
+// has none. Failures are returned as *EncryptionInitError.
 func NewRSAEncryptionService(keyRepo KeyRepository, auditLogger EncryptionAuditLogger) (*RSAEncryptionService, error) {
 	service := &RSAEncryptionService{
 		keyRepository: keyRepo,
@@ -104,13 +176,21 @@ func NewRSAEncryptionService(keyRepo KeyRepository, auditLogger EncryptionAuditL
 	// Try to load active key
 	activeKey, err := keyRepo.GetActiveKey()
 	if err != nil {
+		if !errors.Is(err, ErrNoActiveKey) {
+			return nil, &EncryptionInitError{Failure: EncryptionInitKeyStoreUnavailable, Attempts: 1, Err: err}
+		}
+
 		// If no active key exists, generate one
 		if err := service.generateInitialKey(); err != nil {
-			return nil, fmt.Errorf("failed to generate initial key: %w", err)
+			return nil, &EncryptionInitError{Failure: EncryptionInitKeyGenerationFailed, Attempts: 1, Err: err}
 		}
 		activeKey, err = keyRepo.GetActiveKey()
 		if err != nil {
-			return nil, fmt.Errorf("failed to load newly generated key: %w", err)
+			return nil, &EncryptionInitError{
+				Failure:  EncryptionInitKeyGenerationFailed,
+				Attempts: 1,
+				Err:      fmt.Errorf("failed to load newly generated key: %w", err),
+			}
 		}
 	}
 
@@ -118,6 +198,42 @@ func NewRSAEncryptionService(keyRepo KeyRepository, auditLogger EncryptionAuditL
 	return service, nil
 }
 
+// NewRSAEncryptionServiceWithRetry creates the encryption service, retrying with backoff while
+// the key store is unreachable so a database that is briefly down at startup does not crash-loop
+// the service. Key generation failures are not retried.
+func NewRSAEncryptionServiceWithRetry(keyRepo KeyRepository, auditLogger EncryptionAuditLogger, retry KeyLoadRetry) (*RSAEncryptionService, error) {
+	attempts := retry.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := retry.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		service, err := NewRSAEncryptionService(keyRepo, auditLogger)
+		if err == nil {
+			return service, nil
+		}
+
+		var initErr *EncryptionInitError
+		if !errors.As(err, &initErr) || !initErr.Retryable() {
+			return nil, err
+		}
+		initErr.Attempts = attempt
+		if attempt >= attempts {
+			return nil, initErr
+		}
+
+		log.Printf("Encryption key store unavailable (attempt %d of %d), retrying in %v: %v",
+			attempt, attempts, backoff, initErr.Err)
+		time.Sleep(backoff)
+
+		backoff *= 2
+		if retry.MaxBackoff > 0 && backoff > retry.MaxBackoff {
+			backoff = retry.MaxBackoff
+		}
+	}
+}
+
 // EncryptContactInfo encrypts contact information using current active key
 func (s *RSAEncryptionService) EncryptContactInfo(contactInfo ContactInfo) (*EncryptedContactInfo, error) {
 	// Serialize contact info to JSON
@@ -126,42 +242,58 @@ func (s *RSAEncryptionService) EncryptContactInfo(contactInfo ContactInfo) (*Enc
 		return nil, fmt.Errorf("failed to serialize contact info: %w", err)
 	}
 
-	// Encrypt using RSA-4096
-	encryptedData, err := s.encryptWithActiveKey(jsonData)
+	// RSA-4096 can only encrypt a few hundred bytes, so the payload is sealed with a one-off
+	// AES key and only that key is RSA encrypted
+	encryptedData, encryptedKey, nonce, err := s.sealEnvelope(jsonData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt contact info: %w", err)
 	}
 
-	// Create encrypted contact info
-	encrypted := &EncryptedContactInfo{
-		Email:           contactInfo.Email,
-		Phone:           contactInfo.Phone,
-		PreferredMethod: contactInfo.PreferredMethod,
-		Message:         contactInfo.Message,
-		SharingRestrictions: contactInfo.Restrictions,
-	}
-
-	// Store encrypted data as base64
+	// Store encrypted data as base64, leaving the email, phone and message only in the ciphertext
 	encryptedB64 := base64.StdEncoding.EncodeToString(encryptedData)
-	encrypted.Email = &encryptedB64
+	encryptedKeyB64 := base64.StdEncoding.EncodeToString(encryptedKey)
+	nonceB64 := base64.StdEncoding.EncodeToString(nonce)
 
-	return encrypted, nil
+	return &EncryptedContactInfo{
+		PreferredMethod:     contactInfo.PreferredMethod,
+		SharingRestrictions: contactInfo.Restrictions,
+		Ciphertext:          &encryptedB64,
+		EncryptedKey:        &encryptedKeyB64,
+		Nonce:               &nonceB64,
+		KeyFingerprint:      s.activeKey.Fingerprint,
+	}, nil
 }
 
 // DecryptContactInfo decrypts contact information using the appropriate key
 func (s *RSAEncryptionService) DecryptContactInfo(encryptedInfo *EncryptedContactInfo) (*ContactInfo, error) {
-	if encryptedInfo.Email == nil {
+	upgraded := *encryptedInfo
+	upgraded.UpgradeLegacyLayout()
+	if upgraded.Ciphertext == nil {
 		return nil, fmt.Errorf("no encrypted data found")
 	}
 
 	// Decode base64
-	encryptedData, err := base64.StdEncoding.DecodeString(*encryptedInfo.Email)
+	encryptedData, err := base64.StdEncoding.DecodeString(*upgraded.Ciphertext)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode encrypted data: %w", err)
 	}
 
-	// Decrypt using RSA-4096
-	decryptedData, err := s.decryptWithActiveKey(encryptedData)
+	// Get the key it was encrypted with, which may have been rotated out since
+	key := s.activeKey
+	if upgraded.KeyFingerprint != "" && upgraded.KeyFingerprint != s.activeKey.Fingerprint {
+		key, err = s.keyRepository.GetKeyByFingerprint(upgraded.KeyFingerprint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get decryption key: %w", err)
+		}
+	}
+
+	var decryptedData []byte
+	if upgraded.EncryptedKey == nil {
+		// Written before envelope encryption: the payload itself is RSA encrypted
+		decryptedData, err = s.decryptWithKey(encryptedData, key)
+	} else {
+		decryptedData, err = s.openEnvelope(encryptedData, upgraded.EncryptedKey, upgraded.Nonce, key)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt contact info: %w", err)
 	}
@@ -303,6 +435,33 @@ func (s *RSAEncryptionService) GetActiveKeyFingerprint() string {
 	return s.activeKey.Fingerprint
 }
 
+// RetireUnusedKeys retires every inactive key not listed as still in use and returns the
+// fingerprints it retired
+func (s *RSAEncryptionService) RetireUnusedKeys(inUse []string) ([]string, error) {
+	keys, err := s.keyRepository.ListKeys()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	used := make(map[string]bool, len(inUse))
+	for _, fingerprint := range inUse {
+		used[fingerprint] = true
+	}
+
+	var retired []string
+	for _, key := range keys {
+		if key.IsActive || key.RetiredAt != nil || used[key.Fingerprint] {
+			continue
+		}
+		if err := s.keyRepository.RetireKey(key.Fingerprint); err != nil {
+			return retired, fmt.Errorf("failed to retire key %s: %w", key.Fingerprint, err)
+		}
+		retired = append(retired, key.Fingerprint)
+	}
+
+	return retired, nil
+}
+
 // generateInitialKey generates the first RSA-4096 key pair
 func (s *RSAEncryptionService) generateInitialKey() error {
 	// Generate RSA-4096 key pair
@@ -375,9 +534,75 @@ func (s *RSAEncryptionService) encryptWithKey(data []byte, key *EncryptionKey) (
 	return rsa.EncryptOAEP(sha256.New(), rand.Reader, rsaPublicKey, data, nil)
 }
 
-// decryptWithActiveKey decrypts data using the active key
-func (s *RSAEncryptionService) decryptWithActiveKey(data []byte) ([]byte, error) {
-	return s.decryptWithKey(data, s.activeKey)
+// sealEnvelope encrypts data with a new AES-256-GCM key and encrypts that key with the active
+// RSA key
+func (s *RSAEncryptionService) sealEnvelope(data []byte) (ciphertext, encryptedKey, nonce []byte, err error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	encryptedKey, err = s.encryptWithActiveKey(dataKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encrypt data key: %w", err)
+	}
+
+	return gcm.Seal(nil, nonce, data, nil), encryptedKey, nonce, nil
+}
+
+// openEnvelope reverses sealEnvelope with the RSA key the data key was encrypted with, taking
+// the base64 encoded data key and nonce as stored
+func (s *RSAEncryptionService) openEnvelope(ciphertext []byte, encryptedKeyB64, nonceB64 *string, key *EncryptionKey) ([]byte, error) {
+	if nonceB64 == nil {
+		return nil, fmt.Errorf("encrypted data key has no nonce")
+	}
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(*encryptedKeyB64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data key: %w", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(*nonceB64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nonce: %w", err)
+	}
+
+	dataKey, err := s.decryptWithKey(encryptedKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce size %d", len(nonce))
+	}
+
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+// newGCM creates an AES-GCM cipher for the given key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
 }
 
 // decryptWithKey decrypts data using specified key
@@ -0,0 +1,48 @@
+package domain
+
+// PostTypes returns every valid post type
+func PostTypes() []PostType {
+	return []PostType{PostTypeLost, PostTypeFound}
+}
+
+// PostStatuses returns every valid post status
+func PostStatuses() []PostStatus {
+	return []PostStatus{
+		PostStatusDraft,
+		PostStatusActive,
+		PostStatusResolved,
+		PostStatusExpired,
+		PostStatusDeleted,
+		PostStatusArchived,
+	}
+}
+
+// ContactExchangeApprovalTypes returns every way an owner may share contact details
+func ContactExchangeApprovalTypes() []ContactExchangeApprovalType {
+	return []ContactExchangeApprovalType{
+		ContactExchangeApprovalTypeFull,
+		ContactExchangeApprovalTypePlatform,
+		ContactExchangeApprovalTypeLimited,
+	}
+}
+
+// DenialReasons returns every reason an owner may give for denying a contact exchange
+func DenialReasons() []DenialReason {
+	return []DenialReason{
+		DenialReasonNotOwner,
+		DenialReasonInsufficientVerification,
+		DenialReasonSuspiciousRequest,
+		DenialReasonPostResolved,
+		DenialReasonUserPreference,
+		DenialReasonOther,
+	}
+}
+
+// VerificationMethods returns every way a requester may be asked to prove ownership
+func VerificationMethods() []VerificationMethod {
+	return []VerificationMethod{
+		VerificationMethodPhotoProof,
+		VerificationMethodSecurityQuestion,
+		VerificationMethodAdminApproval,
+	}
+}
@@ -2,17 +2,26 @@ package domain
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 type PostErrorCode string
 
 const (
 	// Post validation errors
-	PostErrorInvalidType      PostErrorCode = "POST_INVALID_TYPE"
-	PostErrorInvalidStatus    PostErrorCode = "POST_INVALID_STATUS"
-	PostErrorInvalidTitle     PostErrorCode = "POST_INVALID_TITLE"
-	PostErrorInvalidLocation  PostErrorCode = "POST_INVALID_LOCATION"
-	PostErrorCannotTransition PostErrorCode = "POST_CANNOT_TRANSITION_STATUS"
+	PostErrorInvalidType        PostErrorCode = "POST_INVALID_TYPE"
+	PostErrorInvalidStatus      PostErrorCode = "POST_INVALID_STATUS"
+	PostErrorInvalidTitle       PostErrorCode = "POST_INVALID_TITLE"
+	PostErrorInvalidDescription PostErrorCode = "POST_INVALID_DESCRIPTION"
+	PostErrorInvalidLocation    PostErrorCode = "POST_INVALID_LOCATION"
+	PostErrorInvalidRadius      PostErrorCode = "POST_INVALID_RADIUS"
+	PostErrorCannotTransition   PostErrorCode = "POST_CANNOT_TRANSITION_STATUS"
+	PostErrorInvalidLanguage    PostErrorCode = "POST_INVALID_LANGUAGE"
+	PostErrorInvalidTags        PostErrorCode = "POST_INVALID_TAGS"
+	PostErrorNotBumpable        PostErrorCode = "POST_NOT_BUMPABLE"
+	PostErrorBumpTooSoon        PostErrorCode = "POST_BUMP_TOO_SOON"
+	PostErrorInvalidResolution  PostErrorCode = "POST_INVALID_RESOLUTION"
 
 	// Photo validation errors
 	PhotoErrorInvalidCount  PostErrorCode = "PHOTO_INVALID_COUNT"
@@ -24,11 +33,16 @@ const (
 	// Location validation errors
 	LocationErrorInvalidLatitude  PostErrorCode = "LOCATION_INVALID_LATITUDE"
 	LocationErrorInvalidLongitude PostErrorCode = "LOCATION_INVALID_LONGITUDE"
+	LocationErrorImplausible      PostErrorCode = "LOCATION_IMPLAUSIBLE"
+	LocationErrorUnknownLandmark  PostErrorCode = "LOCATION_UNKNOWN_LANDMARK"
 
 	// Business rule errors
 	BusinessErrorPostNotFound PostErrorCode = "BUSINESS_POST_NOT_FOUND"
 	BusinessErrorUnauthorized PostErrorCode = "BUSINESS_UNAUTHORIZED"
 	BusinessErrorPostExpired  PostErrorCode = "BUSINESS_POST_EXPIRED"
+	BusinessErrorInvalidOwner PostErrorCode = "BUSINESS_INVALID_OWNERSHIP_TRANSFER"
+	BusinessErrorNotResolved  PostErrorCode = "BUSINESS_POST_NOT_RESOLVED"
+	BusinessErrorPostModified PostErrorCode = "BUSINESS_POST_MODIFIED"
 
 	// Repository errors
 	RepositoryErrorNotFound   PostErrorCode = "REPOSITORY_NOT_FOUND"
@@ -36,12 +50,28 @@ const (
 	RepositoryErrorConnection PostErrorCode = "REPOSITORY_CONNECTION"
 
 	// Contact Exchange errors
-	ContactExchangeErrorInvalidStatus     PostErrorCode = "CONTACT_EXCHANGE_INVALID_STATUS"
-	ContactExchangeErrorExpired           PostErrorCode = "CONTACT_EXCHANGE_EXPIRED"
-	ContactExchangeErrorNotFound          PostErrorCode = "CONTACT_EXCHANGE_NOT_FOUND"
-	ContactExchangeErrorCannotRequestOwn  PostErrorCode = "CONTACT_EXCHANGE_CANNOT_REQUEST_OWN"
-	ContactExchangeErrorInvalidUserID     PostErrorCode = "CONTACT_EXCHANGE_INVALID_USER_ID"
-	ContactExchangeErrorInvalidPostID     PostErrorCode = "CONTACT_EXCHANGE_INVALID_POST_ID"
+	ContactExchangeErrorInvalidStatus       PostErrorCode = "CONTACT_EXCHANGE_INVALID_STATUS"
+	ContactExchangeErrorExpired             PostErrorCode = "CONTACT_EXCHANGE_EXPIRED"
+	ContactExchangeErrorNotFound            PostErrorCode = "CONTACT_EXCHANGE_NOT_FOUND"
+	ContactExchangeErrorCannotRequestOwn    PostErrorCode = "CONTACT_EXCHANGE_CANNOT_REQUEST_OWN"
+	ContactExchangeErrorInvalidUserID       PostErrorCode = "CONTACT_EXCHANGE_INVALID_USER_ID"
+	ContactExchangeErrorInvalidPostID       PostErrorCode = "CONTACT_EXCHANGE_INVALID_POST_ID"
+	ContactExchangeErrorInvalidExpiration   PostErrorCode = "CONTACT_EXCHANGE_INVALID_EXPIRATION"
+	ContactExchangeErrorMessageTooLong      PostErrorCode = "CONTACT_EXCHANGE_MESSAGE_TOO_LONG"
+	ContactExchangeErrorUnknownStatus       PostErrorCode = "CONTACT_EXCHANGE_UNKNOWN_STATUS"
+	ContactExchangeErrorInvalidContact      PostErrorCode = "CONTACT_EXCHANGE_INVALID_CONTACT_INFO"
+	ContactExchangeErrorNoContactChannel    PostErrorCode = "CONTACT_EXCHANGE_NO_CONTACT_CHANNEL"
+	ContactExchangeErrorRateLimited         PostErrorCode = "CONTACT_EXCHANGE_RATE_LIMITED"
+	ContactExchangeErrorAlreadyPending      PostErrorCode = "CONTACT_EXCHANGE_ALREADY_PENDING"
+	ContactExchangeErrorInvalidVerification PostErrorCode = "CONTACT_EXCHANGE_INVALID_VERIFICATION"
+	ContactExchangeErrorNotVerified         PostErrorCode = "CONTACT_EXCHANGE_NOT_VERIFIED"
+
+	// Claim errors
+	ClaimErrorNotFound         PostErrorCode = "CLAIM_NOT_FOUND"
+	ClaimErrorInvalidStatus    PostErrorCode = "CLAIM_INVALID_STATUS"
+	ClaimErrorInvalidProof     PostErrorCode = "CLAIM_INVALID_PROOF"
+	ClaimErrorCannotClaimOwn   PostErrorCode = "CLAIM_CANNOT_CLAIM_OWN"
+	ClaimErrorPostNotClaimable PostErrorCode = "CLAIM_POST_NOT_CLAIMABLE"
 )
 
 type PostError struct {
@@ -104,6 +134,55 @@ func ErrInvalidTitle() PostError {
 	)
 }
 
+func ErrInvalidDescription() PostError {
+	return NewPostError(
+		PostErrorInvalidDescription,
+		"Post description must be at most 2000 characters",
+	)
+}
+
+func ErrInvalidLanguage(language string) PostError {
+	return NewPostError(
+		PostErrorInvalidLanguage,
+		"Post language must be an ISO 639 language code such as 'en' or 'es'",
+	).WithDetail("provided_language", language)
+}
+
+func ErrTooManyTags(count int) PostError {
+	return NewPostError(
+		PostErrorInvalidTags,
+		fmt.Sprintf("Post may have at most %d tags", MaxTagsPerPost),
+	).WithDetail("provided_count", count)
+}
+
+func ErrInvalidTag(tag string) PostError {
+	return NewPostError(
+		PostErrorInvalidTags,
+		fmt.Sprintf("Post tags must be at most %d characters", MaxTagLength),
+	).WithDetail("provided_tag", tag)
+}
+
+func ErrPostNotBumpable(status PostStatus) PostError {
+	return NewPostError(
+		PostErrorNotBumpable,
+		"Only active posts can be bumped",
+	).WithDetail("status", string(status))
+}
+
+func ErrPostBumpTooSoon(postID PostID, nextBumpAt time.Time) PostError {
+	return NewPostError(
+		PostErrorBumpTooSoon,
+		"Post was bumped too recently",
+	).WithDetail("post_id", postID.String()).WithDetail("next_bump_at", nextBumpAt)
+}
+
+func ErrInvalidResolution(reason string) PostError {
+	return NewPostError(
+		PostErrorInvalidResolution,
+		"Resolution details are invalid",
+	).WithDetail("reason", reason)
+}
+
 func ErrInvalidLocation(latitude, longitude float64) PostError {
 	return NewPostError(
 		PostErrorInvalidLocation,
@@ -111,6 +190,13 @@ func ErrInvalidLocation(latitude, longitude float64) PostError {
 	).WithDetail("latitude", latitude).WithDetail("longitude", longitude)
 }
 
+func ErrInvalidRadius(radiusMeters int) PostError {
+	return NewPostError(
+		PostErrorInvalidRadius,
+		fmt.Sprintf("Radius must be between %d and %d meters", MinPostRadiusMeters, MaxPostRadiusMeters),
+	).WithDetail("radius_meters", radiusMeters)
+}
+
 func ErrCannotTransitionStatus(currentStatus, newStatus PostStatus) PostError {
 	return NewPostError(
 		PostErrorCannotTransition,
@@ -121,7 +207,7 @@ func ErrCannotTransitionStatus(currentStatus, newStatus PostStatus) PostError {
 func ErrInvalidPhotoCount(currentCount int) PostError {
 	return NewPostError(
 		PhotoErrorInvalidCount,
-		"Post must have between 1 and 10 photos",
+		fmt.Sprintf("Post must have between 1 and %d photos", MaxPhotosPerPost),
 	).WithDetail("current_count", currentCount)
 }
 
@@ -135,14 +221,14 @@ func ErrInvalidPhotoURL(url string) PostError {
 func ErrInvalidPhotoFormat(format string) PostError {
 	return NewPostError(
 		PhotoErrorInvalidFormat,
-		"Photo format is not supported. Allowed formats: jpg, jpeg, png, webp",
+		"Photo format is not supported. Allowed formats: "+strings.Join(AllowedPhotoFormats(), ", "),
 	).WithDetail("format", format)
 }
 
 func ErrInvalidDisplayOrder(order int) PostError {
 	return NewPostError(
 		PhotoErrorInvalidOrder,
-		"Photo display order must be between 1 and 10",
+		fmt.Sprintf("Photo display order must be between 1 and %d", MaxPhotosPerPost),
 	).WithDetail("display_order", order)
 }
 
@@ -167,6 +253,20 @@ func ErrInvalidLongitude(longitude float64) PostError {
 	).WithDetail("longitude", longitude)
 }
 
+func ErrImplausibleLocation(location Location, reason string) PostError {
+	return NewPostError(
+		LocationErrorImplausible,
+		"Location does not look like a real position: "+reason,
+	).WithDetail("latitude", location.Latitude).WithDetail("longitude", location.Longitude)
+}
+
+func ErrUnknownLandmark(name string) PostError {
+	return NewPostError(
+		LocationErrorUnknownLandmark,
+		"No landmark matches that name",
+	).WithDetail("name", name)
+}
+
 func ErrPostNotFound(postID PostID) PostError {
 	return NewPostError(
 		BusinessErrorPostNotFound,
@@ -174,6 +274,20 @@ func ErrPostNotFound(postID PostID) PostError {
 	).WithDetail("post_id", postID.String())
 }
 
+func ErrResolutionNotFound(postID PostID) PostError {
+	return NewPostError(
+		BusinessErrorNotResolved,
+		"Post has no recorded resolution",
+	).WithDetail("post_id", postID.String())
+}
+
+func ErrPostModified(postID PostID) PostError {
+	return NewPostError(
+		BusinessErrorPostModified,
+		"Post has been modified since the supplied version",
+	).WithDetail("post_id", postID.String())
+}
+
 func ErrUnauthorizedOperation(userID UserID, operation string) PostError {
 	return NewPostError(
 		BusinessErrorUnauthorized,
@@ -181,6 +295,13 @@ func ErrUnauthorizedOperation(userID UserID, operation string) PostError {
 	).WithDetail("user_id", userID.String()).WithDetail("operation", operation)
 }
 
+func ErrInvalidOwnershipTransfer(reason string) PostError {
+	return NewPostError(
+		BusinessErrorInvalidOwner,
+		"Post ownership cannot be transferred",
+	).WithDetail("reason", reason)
+}
+
 func ErrRepositoryNotFound(entityType string, id string) PostError {
 	return NewPostError(
 		RepositoryErrorNotFound,
@@ -257,6 +378,105 @@ func ErrInvalidPostID() PostError {
 	)
 }
 
+func ErrInvalidContactExchangeExpiration(hours, maxHours int) PostError {
+	return NewPostError(
+		ContactExchangeErrorInvalidExpiration,
+		fmt.Sprintf("Expiration must be between 1 and %d hours", maxHours),
+	).WithDetail("expiration_hours", hours)
+}
+
+func ErrUnknownContactExchangeStatus(providedStatus string) PostError {
+	return NewPostError(
+		ContactExchangeErrorUnknownStatus,
+		"Contact exchange status is not valid",
+	).WithDetail("provided_status", providedStatus)
+}
+
+func ErrContactExchangeMessageTooLong(length, maxLength int) PostError {
+	return NewPostError(
+		ContactExchangeErrorMessageTooLong,
+		fmt.Sprintf("Message must be at most %d characters", maxLength),
+	).WithDetail("length", length)
+}
+
+func ErrContactExchangeAlreadyPending(postID PostID) PostError {
+	return NewPostError(
+		ContactExchangeErrorAlreadyPending,
+		"A contact exchange request for this post is already pending",
+	).WithDetail("post_id", postID.String())
+}
+
+func ErrInvalidVerification(reason string) PostError {
+	return NewPostError(
+		ContactExchangeErrorInvalidVerification,
+		"Invalid verification: "+reason,
+	)
+}
+
+func ErrContactExchangeNotVerified(method VerificationMethod) PostError {
+	return NewPostError(
+		ContactExchangeErrorNotVerified,
+		"Contact exchange request cannot be approved before the requester is verified",
+	).WithDetail("verification_method", string(method))
+}
+
+func ErrContactExchangeRateLimited(message string, limit int) PostError {
+	return NewPostError(
+		ContactExchangeErrorRateLimited,
+		message,
+	).WithDetail("limit", limit)
+}
+
+func ErrInvalidContactInfo(field, reason string) PostError {
+	return NewPostError(
+		ContactExchangeErrorInvalidContact,
+		fmt.Sprintf("Contact %s %s", field, reason),
+	).WithDetail("field", field)
+}
+
+func ErrNoContactChannel() PostError {
+	return NewPostError(
+		ContactExchangeErrorNoContactChannel,
+		"Contact info must include an email or a phone number",
+	)
+}
+
+// Claim error functions
+func ErrClaimNotFound(claimID ClaimID) PostError {
+	return NewPostError(
+		ClaimErrorNotFound,
+		"Claim not found",
+	).WithDetail("claim_id", claimID.String())
+}
+
+func ErrInvalidClaimStatus(currentStatus, newStatus ClaimStatus) PostError {
+	return NewPostError(
+		ClaimErrorInvalidStatus,
+		"Cannot transition claim to the requested status",
+	).WithDetail("current_status", string(currentStatus)).WithDetail("new_status", string(newStatus))
+}
+
+func ErrInvalidClaimProof(reason string) PostError {
+	return NewPostError(
+		ClaimErrorInvalidProof,
+		"Claim proof is invalid",
+	).WithDetail("reason", reason)
+}
+
+func ErrCannotClaimOwnPost() PostError {
+	return NewPostError(
+		ClaimErrorCannotClaimOwn,
+		"Cannot claim an item on your own post",
+	)
+}
+
+func ErrPostNotClaimable(postID PostID) PostError {
+	return NewPostError(
+		ClaimErrorPostNotClaimable,
+		"Only active found posts accept claims",
+	).WithDetail("post_id", postID.String())
+}
+
 func NewContactExchangeError(code PostErrorCode, message string) PostError {
 	return NewPostError(code, message)
 }
@@ -9,16 +9,22 @@ import (
 type EventType string
 
 const (
-	EventTypePostCreated              EventType = "post.created"
-	EventTypePostUpdated              EventType = "post.updated"
-	EventTypePostResolved             EventType = "post.resolved"
-	EventTypePostDeleted              EventType = "post.deleted"
-	EventTypePhotoAdded               EventType = "post.photo.added"
-	EventTypePhotoRemoved             EventType = "post.photo.removed"
-	EventTypeContactExchangeRequested EventType = "contact.exchange.requested"
-	EventTypeContactExchangeApproved  EventType = "contact.exchange.approved"
-	EventTypeContactExchangeDenied    EventType = "contact.exchange.denied"
-	EventTypeContactExchangeExpired   EventType = "contact.exchange.expired"
+	EventTypePostCreated                 EventType = "post.created"
+	EventTypePostUpdated                 EventType = "post.updated"
+	EventTypePostResolved                EventType = "post.resolved"
+	EventTypePostDeleted                 EventType = "post.deleted"
+	EventTypePostBumped                  EventType = "post.bumped"
+	EventTypePhotoAdded                  EventType = "post.photo.added"
+	EventTypePhotoRemoved                EventType = "post.photo.removed"
+	EventTypePhotoProcessed              EventType = "post.photo.processed"
+	EventTypeContactExchangeRequested    EventType = "contact.exchange.requested"
+	EventTypeContactExchangeApproved     EventType = "contact.exchange.approved"
+	EventTypeContactExchangeDenied       EventType = "contact.exchange.denied"
+	EventTypeContactExchangeExpired      EventType = "contact.exchange.expired"
+	EventTypeContactExchangeDataPurged   EventType = "contact.exchange.data_purged"
+	EventTypeContactExchangeExpiringSoon EventType = "contact.exchange.expiring_soon"
+	EventTypeClaimCreated                EventType = "claim.created"
+	EventTypeClaimApproved               EventType = "claim.approved"
 )
 
 // Complete PostEvent structure following fn-contract specification
@@ -37,6 +43,26 @@ type PostEvent struct {
 	TenantID      *OrganizationID `json:"tenant_id,omitempty"`
 	Payload       interface{}     `json:"payload"`
 	Privacy       *PrivacyContext `json:"privacy,omitempty"`
+	Metadata      *EventMetadata  `json:"metadata,omitempty"`
+}
+
+// EventMetadata describes how an event came to be published rather than what happened
+type EventMetadata struct {
+	// Replay is set when the event was rebuilt from current state and published again, so
+	// consumers can treat it as idempotent rather than as a new occurrence
+	Replay       bool       `json:"replay"`
+	ReplayedAt   *time.Time `json:"replayed_at,omitempty"`
+	ReplayReason string     `json:"replay_reason,omitempty"`
+}
+
+// MarkReplay tags the event as a replay published on demand
+func (e *PostEvent) MarkReplay(reason string) {
+	now := time.Now()
+	e.Metadata = &EventMetadata{
+		Replay:       true,
+		ReplayedAt:   &now,
+		ReplayReason: reason,
+	}
 }
 
 // PrivacyContext contains privacy-safe contact exchange information
@@ -75,11 +101,13 @@ type PostData struct {
 	Photos         []PhotoData            `json:"photos"`
 	UserID         string                 `json:"user_id"`
 	OrganizationID *string                `json:"organization_id,omitempty"`
+	Language       *string                `json:"language,omitempty"`
 	Tags           []string               `json:"tags,omitempty"`
 	Metadata       *PostMetadata          `json:"metadata,omitempty"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
 	ResolvedAt     *time.Time             `json:"resolved_at,omitempty"`
+	BumpedAt       *time.Time             `json:"bumped_at,omitempty"`
 }
 
 type LocationData struct {
@@ -247,6 +275,15 @@ type PostUpdatedEventData struct {
 	Triggers     *EventTriggers         `json:"triggers,omitempty"`
 }
 
+// PostBumpedEventData announces that the owner moved a post back to the top of listings, so
+// indexes can re-rank it by its new bump time
+type PostBumpedEventData struct {
+	Post             PostData          `json:"post"`
+	Organization     *OrganizationData `json:"organization,omitempty"`
+	BumpedAt         time.Time         `json:"bumped_at"`
+	PreviousBumpedAt *time.Time        `json:"previous_bumped_at,omitempty"`
+}
+
 type PostStatusChangedEventData struct {
 	Post           PostData        `json:"post"`
 	User           PrivacySafeUser `json:"user"`
@@ -291,6 +328,15 @@ type PhotoRemovedEventData struct {
 	Triggers      *EventTriggers `json:"triggers,omitempty"`
 }
 
+// PhotoProcessedEventData announces that a photo's derived imagery is stored, so consumers
+// can render and index the final photo rather than the one announced by post.photo.added
+type PhotoProcessedEventData struct {
+	Post         PostData          `json:"post"`
+	Photo        PhotoData         `json:"photo"`
+	Organization *OrganizationData `json:"organization,omitempty"`
+	Triggers     *EventTriggers    `json:"triggers,omitempty"`
+}
+
 // Contact Exchange event data structures with complete fat event context
 type ContactExchangeRequestedEventData struct {
 	ContactRequest           ContactRequestData        `json:"contact_request"`
@@ -405,6 +451,52 @@ type ContactExchangeExpiredEventData struct {
 	Analytics         *ExpirationAnalytics `json:"analytics,omitempty"`
 }
 
+// ContactExchangeDataPurgedEventData reports that a request's encrypted contact information was removed
+type ContactExchangeDataPurgedEventData struct {
+	RequestID      string         `json:"request_id"`
+	PostID         string         `json:"post_id"`
+	PurgeReason    string         `json:"purge_reason"`
+	PurgedAt       time.Time      `json:"purged_at"`
+	CleanupActions CleanupActions `json:"cleanup_actions"`
+}
+
+// ContactExchangeExpiringSoonEventData reminds the requester and owner that a request, or the
+// contact access it granted, is about to expire
+type ContactExchangeExpiringSoonEventData struct {
+	ContactRequest           ContactRequestData        `json:"contact_request"`
+	HoursRemaining           float64                   `json:"hours_remaining"`
+	RelatedPost              PostData                  `json:"related_post"`
+	InvolvedUsers            InvolvedUsersExtended     `json:"involved_users"`
+	NotificationRequirements *NotificationRequirements `json:"notification_requirements,omitempty"`
+}
+
+// ClaimCreatedEventData tells the post owner a claimant has submitted proof for review
+type ClaimCreatedEventData struct {
+	Claim       ClaimData               `json:"claim"`
+	RelatedPost PostData                `json:"related_post"`
+	Claimant    PrivacySafeUserExtended `json:"claimant"`
+	Owner       PrivacySafeUserExtended `json:"owner"`
+}
+
+// ClaimApprovedEventData tells the claimant the owner accepted their proof
+type ClaimApprovedEventData struct {
+	Claim       ClaimData               `json:"claim"`
+	RelatedPost PostData                `json:"related_post"`
+	Claimant    PrivacySafeUserExtended `json:"claimant"`
+	Owner       PrivacySafeUserExtended `json:"owner"`
+}
+
+type ClaimData struct {
+	ClaimID                  string     `json:"claim_id"`
+	Status                   string     `json:"status"`
+	HasProofText             bool       `json:"has_proof_text"`
+	ProofPhotoCount          int        `json:"proof_photo_count"`
+	ContactExchangeRequestID *string    `json:"contact_exchange_request_id,omitempty"`
+	ReviewedAt               *time.Time `json:"reviewed_at,omitempty"`
+	RetainUntil              time.Time  `json:"retain_until"`
+	CreatedAt                time.Time  `json:"created_at"`
+}
+
 type ContactExpirationData struct {
 	RequestID        string    `json:"request_id"`
 	OriginalStatus   string    `json:"original_status"`
@@ -483,15 +575,16 @@ type CleanupActions struct {
 	RevokeContactAccess bool `json:"revoke_contact_access"`
 	ArchiveRequestData  bool `json:"archive_request_data"`
 	UpdateAnalytics     bool `json:"update_analytics"`
+	PurgeContactInfo    bool `json:"purge_contact_info,omitempty"`
 }
 
 func NewPostEvent(eventType EventType, postID PostID, userID UserID, tenantID *OrganizationID, payload interface{}) *PostEvent {
 	return &PostEvent{
 		ID:            uuid.New(),
 		EventType:     eventType,
-		EventVersion:  1,
+		EventVersion:  EventVersionFor(eventType),
 		Timestamp:     time.Now(),
-		SourceService: "fn-posts",
+		SourceService: ServiceName(),
 		AggregateID:   postID.String(),
 		AggregateType: "Post",
 		PostID:        postID,
@@ -512,9 +605,9 @@ func NewContactExchangeEvent(eventType EventType, requestID ContactExchangeReque
 	return &PostEvent{
 		ID:            uuid.New(),
 		EventType:     eventType,
-		EventVersion:  1,
+		EventVersion:  EventVersionFor(eventType),
 		Timestamp:     time.Now(),
-		SourceService: "fn-posts",
+		SourceService: ServiceName(),
 		AggregateID:   requestID.String(),
 		AggregateType: "ContactExchangeRequest",
 		PostID:        PostID{}, // Not applicable for contact exchange events
@@ -529,3 +622,20 @@ func NewContactExchangeEventWithCorrelation(eventType EventType, requestID Conta
 	event.CorrelationID = &correlationID
 	return event
 }
+
+// NewClaimEvent creates an event for a claim on the given post
+func NewClaimEvent(eventType EventType, claimID ClaimID, postID PostID, userID UserID, tenantID *OrganizationID, payload interface{}) *PostEvent {
+	return &PostEvent{
+		ID:            uuid.New(),
+		EventType:     eventType,
+		EventVersion:  EventVersionFor(eventType),
+		Timestamp:     time.Now(),
+		SourceService: ServiceName(),
+		AggregateID:   claimID.String(),
+		AggregateType: "Claim",
+		PostID:        postID,
+		UserID:        userID,
+		TenantID:      tenantID,
+		Payload:       payload,
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -25,6 +27,11 @@ func (p *Post) ToPostData() PostData {
 		description = &p.description
 	}
 
+	var language *string
+	if p.language != "" {
+		language = &p.language
+	}
+
 	var resolvedAt *time.Time
 	if p.status == PostStatusResolved {
 		resolvedAt = &p.updatedAt
@@ -41,12 +48,32 @@ func (p *Post) ToPostData() PostData {
 		Photos:         photos,
 		UserID:         p.createdBy.String(),
 		OrganizationID: orgID,
-		Tags:           []string{}, // TODO: Add tags support to Post domain
-		Metadata:       nil,        // TODO: Add metadata support to Post domain
+		Language:       language,
+		Tags:           p.Tags(),
+		Metadata:       p.metadata(),
 		CreatedAt:      p.createdAt,
 		UpdatedAt:      p.updatedAt,
 		ResolvedAt:     resolvedAt,
+		BumpedAt:       p.BumpedAt(),
+	}
+}
+
+// metadata carries the reward and urgency the author flagged with tags, or nil if neither is
+func (p *Post) metadata() *PostMetadata {
+	rewardOffered := slices.Contains(p.tags, TagReward)
+	urgent := slices.Contains(p.tags, TagUrgent)
+	if !rewardOffered && !urgent {
+		return nil
+	}
+
+	metadata := &PostMetadata{}
+	if rewardOffered {
+		metadata.RewardOffered = &rewardOffered
+	}
+	if urgent {
+		metadata.Urgency = StringPtr(TagUrgent)
 	}
+	return metadata
 }
 
 // ToPhotoData converts a Photo domain object to PhotoData for events
@@ -61,11 +88,11 @@ func (p *Photo) ToPhotoData() PhotoData {
 		PostID:       p.postID.String(),
 		OriginalURL:  p.url,
 		ThumbnailURL: thumbnailURL,
-		Filename:     "", // Not stored separately in Photo
+		Filename:     p.filename,
 		FileSize:     p.sizeBytes,
-		MimeType:     p.format,
-		Width:        0, // Not stored in Photo
-		Height:       0, // Not stored in Photo
+		MimeType:     PhotoMimeType(p.format),
+		Width:        p.width,
+		Height:       p.height,
 		Order:        p.displayOrder,
 		CreatedAt:    p.createdAt,
 	}
@@ -202,6 +229,42 @@ func CreateEventTriggersForPhotoAdded() *EventTriggers {
 	}
 }
 
+func CreateEventTriggersForPhotoProcessed() *EventTriggers {
+	return &EventTriggers{
+		AIProcessing:    false, // Already triggered when the photo was added
+		MatchProcessing: false,
+		Reindexing:      true,
+		Notifications:   true,
+	}
+}
+
+// Priorities fn-media-ai uses to order photo analysis
+const (
+	AIProcessingPriorityHigh   = "high"
+	AIProcessingPriorityNormal = "normal"
+)
+
+// AIProcessingPriorityFor puts posts offering a reward or flagged as urgent at the front of
+// the analysis queue
+func AIProcessingPriorityFor(post PostData) string {
+	if post.Metadata == nil {
+		return AIProcessingPriorityNormal
+	}
+
+	if post.Metadata.RewardOffered != nil && *post.Metadata.RewardOffered {
+		return AIProcessingPriorityHigh
+	}
+
+	if post.Metadata.Urgency != nil {
+		switch strings.ToLower(*post.Metadata.Urgency) {
+		case "high", "urgent":
+			return AIProcessingPriorityHigh
+		}
+	}
+
+	return AIProcessingPriorityNormal
+}
+
 // CreatePrivacyContext creates privacy context for events
 func CreatePrivacyContext(contactToken *ContactExchangeToken, privacyLevel string) *PrivacyContext {
 	var expiresAt *time.Time
@@ -285,7 +348,7 @@ func (cd *ContactDenial) ToContactDenialData() ContactDenialData {
 	return ContactDenialData{
 		RequestID:     cd.RequestID.String(),
 		DenialReason:  string(cd.DenialReason),
-		DenialMessage: cd.DenialMessage,
+		DenialMessage: truncateContactExchangeMessage(cd.DenialMessage),
 		DeniedAt:      cd.DeniedAt,
 		DenialSource:  cd.DenialSource,
 	}
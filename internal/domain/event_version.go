@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultEventVersion is the schema version of event types without a registered version
+const DefaultEventVersion = 1
+
+// defaultEventVersions holds the schema version each event type ships with. Bump an entry
+// when the payload of that type changes incompatibly.
+var defaultEventVersions = map[EventType]int{
+	EventTypePostCreated:                 1,
+	EventTypePostUpdated:                 1,
+	EventTypePostResolved:                1,
+	EventTypePostDeleted:                 1,
+	EventTypePostBumped:                  1,
+	EventTypePhotoAdded:                  1,
+	EventTypePhotoRemoved:                1,
+	EventTypePhotoProcessed:              1,
+	EventTypeContactExchangeRequested:    1,
+	EventTypeContactExchangeApproved:     1,
+	EventTypeContactExchangeDenied:       1,
+	EventTypeContactExchangeExpired:      1,
+	EventTypeContactExchangeDataPurged:   1,
+	EventTypeContactExchangeExpiringSoon: 1,
+	EventTypeClaimCreated:                1,
+	EventTypeClaimApproved:               1,
+}
+
+var (
+	eventVersionsMu sync.RWMutex
+	eventVersions   = defaultEventVersions
+)
+
+// SetEventVersions overrides the schema version of individual event types on top of the
+// built-in defaults. Passing nil restores the defaults.
+func SetEventVersions(overrides map[EventType]int) error {
+	versions := make(map[EventType]int, len(defaultEventVersions))
+	for eventType, version := range defaultEventVersions {
+		versions[eventType] = version
+	}
+
+	for eventType, version := range overrides {
+		if _, known := defaultEventVersions[eventType]; !known {
+			return fmt.Errorf("unknown event type in version overrides: %q", eventType)
+		}
+		if version < 1 {
+			return fmt.Errorf("event version for %s must be at least 1, got %d", eventType, version)
+		}
+		versions[eventType] = version
+	}
+
+	eventVersionsMu.Lock()
+	defer eventVersionsMu.Unlock()
+	eventVersions = versions
+
+	return nil
+}
+
+// EventVersionFor returns the schema version stamped on new events of the given type
+func EventVersionFor(eventType EventType) int {
+	eventVersionsMu.RLock()
+	defer eventVersionsMu.RUnlock()
+
+	if version, ok := eventVersions[eventType]; ok {
+		return version
+	}
+	return DefaultEventVersion
+}
+
+// ParseEventVersions reads "event.type=version" entries, as found in configuration
+func ParseEventVersions(entries []string) (map[EventType]int, error) {
+	versions := make(map[EventType]int, len(entries))
+	for _, entry := range entries {
+		eventType, versionStr, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid event version entry %q, expected type=version", entry)
+		}
+
+		version, err := strconv.Atoi(strings.TrimSpace(versionStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in event version entry %q: %w", entry, err)
+		}
+		versions[EventType(strings.TrimSpace(eventType))] = version
+	}
+	return versions, nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "strings"
+
+// MinLandmarkNameSimilarity is the trigram similarity, from 0 to 1, a landmark's name must
+// reach for a search term to resolve to it
+const MinLandmarkNameSimilarity = 0.3
+
+// MaxLandmarkNameLength bounds the names landmarks are stored and searched under
+const MaxLandmarkNameLength = 200
+
+// Landmark is a well known place, like Central Park, that posts can be searched around by name
+type Landmark struct {
+	Name     string
+	Location Location
+}
+
+// NormalizeLandmarkName lowercases a landmark name and collapses its whitespace, so that
+// "  central   PARK" is compared as "central park"
+func NormalizeLandmarkName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "strings"
+
+// ParseLanguage normalizes a language tag to the lowercase ISO 639 code it starts with, so
+// "es-MX" and "ES_mx" both become "es". An empty tag means no language and is returned as is.
+func ParseLanguage(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", nil
+	}
+
+	code := tag
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		code = tag[:i]
+	}
+	code = strings.ToLower(code)
+	if len(code) < 2 || len(code) > 3 {
+		return "", ErrInvalidLanguage(tag)
+	}
+	for _, r := range code {
+		if r < 'a' || r > 'z' {
+			return "", ErrInvalidLanguage(tag)
+		}
+	}
+
+	return code, nil
+}
@@ -3,6 +3,9 @@ package domain
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 type Location struct {
@@ -39,6 +42,75 @@ func (l Location) Validate() error {
 	return nil
 }
 
+// nullIslandToleranceDegrees is how close to (0,0) a point must be, about 11m, to be treated as
+// a failed GPS fix
+const nullIslandToleranceDegrees = 0.0001
+
+// LocationPlausibility controls the checks CheckPlausibility runs on submitted coordinates
+type LocationPlausibility struct {
+	// RejectNullIsland rejects (0,0), which clients report when they have no GPS fix
+	RejectNullIsland bool
+	// MaxDecimals rejects coordinates with more decimal places than any receiver measures.
+	// Zero disables the check.
+	MaxDecimals int
+}
+
+// DefaultLocationPlausibility rejects null island and leaves precision unchecked
+var DefaultLocationPlausibility = LocationPlausibility{RejectNullIsland: true}
+
+var (
+	locationPlausibilityMu sync.RWMutex
+	locationPlausibility   = DefaultLocationPlausibility
+)
+
+// SetLocationPlausibility replaces the checks run on submitted coordinates
+func SetLocationPlausibility(rules LocationPlausibility) error {
+	if rules.MaxDecimals < 0 {
+		return fmt.Errorf("max coordinate decimals must not be negative, got %d", rules.MaxDecimals)
+	}
+
+	locationPlausibilityMu.Lock()
+	defer locationPlausibilityMu.Unlock()
+	locationPlausibility = rules
+
+	return nil
+}
+
+// CurrentLocationPlausibility returns the checks run on submitted coordinates
+func CurrentLocationPlausibility() LocationPlausibility {
+	locationPlausibilityMu.RLock()
+	defer locationPlausibilityMu.RUnlock()
+	return locationPlausibility
+}
+
+// CheckPlausibility flags coordinates that are in range but almost certainly not a real
+// position: null island and values more precise than a GPS fix can be. It is meant for
+// newly submitted locations; stored ones are only checked with Validate.
+func (l Location) CheckPlausibility() error {
+	rules := CurrentLocationPlausibility()
+
+	if rules.RejectNullIsland &&
+		math.Abs(l.Latitude) < nullIslandToleranceDegrees && math.Abs(l.Longitude) < nullIslandToleranceDegrees {
+		return ErrImplausibleLocation(l, "coordinates are at (0,0), which usually means the device had no GPS fix")
+	}
+
+	if rules.MaxDecimals > 0 &&
+		(coordinateDecimals(l.Latitude) > rules.MaxDecimals || coordinateDecimals(l.Longitude) > rules.MaxDecimals) {
+		return ErrImplausibleLocation(l, fmt.Sprintf("coordinates have more than %d decimal places", rules.MaxDecimals))
+	}
+
+	return nil
+}
+
+// coordinateDecimals counts the decimal places in the shortest form of a coordinate
+func coordinateDecimals(value float64) int {
+	formatted := strconv.FormatFloat(value, 'f', -1, 64)
+	if dot := strings.IndexByte(formatted, '.'); dot >= 0 {
+		return len(formatted) - dot - 1
+	}
+	return 0
+}
+
 func (l Location) DistanceTo(other Location) Distance {
 	const earthRadiusKm = 6371.0
 
@@ -64,6 +136,157 @@ func (l Location) IsWithinRadius(other Location, radiusMeters float64) bool {
 	return distance.Meters <= radiusMeters
 }
 
+// CanonicalDecimals is the precision kept by Canonical, about 1.1m at the equator
+const CanonicalDecimals = 5
+
+// EqualsWithin reports whether two locations are the same place to within the given
+// great-circle distance, avoiding exact floating point comparison
+func (l Location) EqualsWithin(other Location, toleranceMeters float64) bool {
+	if toleranceMeters < 0 {
+		return false
+	}
+	return l.IsWithinRadius(other, toleranceMeters)
+}
+
+// Canonical returns a normalized form for use in dedup keys: coordinates rounded to
+// CanonicalDecimals, the antimeridian as -180 and a single longitude at the poles, so
+// different spellings of the same point compare equal
+func (l Location) Canonical() Location {
+	scale := math.Pow10(CanonicalDecimals)
+	round := func(value float64) float64 {
+		// Adding 0 turns -0 into 0
+		return math.Round(value*scale)/scale + 0
+	}
+
+	canonical := Location{
+		Latitude:  round(l.Latitude),
+		Longitude: round(l.Longitude),
+	}
+
+	if canonical.Longitude == 180 {
+		canonical.Longitude = -180
+	}
+	if math.Abs(canonical.Latitude) == 90 {
+		canonical.Longitude = 0
+	}
+
+	return canonical
+}
+
+// Key is a stable string form of the canonical location, suitable as a map or dedup key
+func (l Location) Key() string {
+	canonical := l.Canonical()
+	return fmt.Sprintf("%.*f,%.*f", CanonicalDecimals, canonical.Latitude, CanonicalDecimals, canonical.Longitude)
+}
+
+// Obfuscate snaps the location to the center of a grid cell of the given size in
+// degrees, hiding the exact point while keeping it in the right neighbourhood
+func (l Location) Obfuscate(precisionDegrees float64) Location {
+	if precisionDegrees <= 0 {
+		return l
+	}
+
+	snap := func(value, min, max float64) float64 {
+		snapped := math.Floor(value/precisionDegrees)*precisionDegrees + precisionDegrees/2
+		return math.Max(min, math.Min(max, snapped))
+	}
+
+	return Location{
+		Latitude:  snap(l.Latitude, -90, 90),
+		Longitude: snap(l.Longitude, -180, 180),
+	}
+}
+
+// GeoBoundingBox is an axis-aligned map area between two corners, in degrees
+type GeoBoundingBox struct {
+	SouthWest Location
+	NorthEast Location
+}
+
+func NewGeoBoundingBox(minLng, minLat, maxLng, maxLat float64) (GeoBoundingBox, error) {
+	box := GeoBoundingBox{
+		SouthWest: Location{Latitude: minLat, Longitude: minLng},
+		NorthEast: Location{Latitude: maxLat, Longitude: maxLng},
+	}
+
+	if err := box.Validate(); err != nil {
+		return GeoBoundingBox{}, err
+	}
+
+	return box, nil
+}
+
+func (b GeoBoundingBox) Validate() error {
+	if err := b.SouthWest.Validate(); err != nil {
+		return err
+	}
+
+	if err := b.NorthEast.Validate(); err != nil {
+		return err
+	}
+
+	if b.SouthWest.Latitude >= b.NorthEast.Latitude || b.SouthWest.Longitude >= b.NorthEast.Longitude {
+		return fmt.Errorf("bounding box must have min < max for both latitude and longitude")
+	}
+
+	return nil
+}
+
+func (b GeoBoundingBox) Width() float64 {
+	return b.NorthEast.Longitude - b.SouthWest.Longitude
+}
+
+func (b GeoBoundingBox) Height() float64 {
+	return b.NorthEast.Latitude - b.SouthWest.Latitude
+}
+
+const (
+	MinRoutePoints = 2
+	MaxRoutePoints = 100
+)
+
+// Route is an ordered path of locations, such as a commute
+type Route struct {
+	Points []Location
+}
+
+func NewRoute(points []Location) (Route, error) {
+	route := Route{Points: points}
+
+	if err := route.Validate(); err != nil {
+		return Route{}, err
+	}
+
+	return route, nil
+}
+
+func (r Route) Validate() error {
+	if len(r.Points) < MinRoutePoints {
+		return fmt.Errorf("route must have at least %d points", MinRoutePoints)
+	}
+
+	if len(r.Points) > MaxRoutePoints {
+		return fmt.Errorf("route must have at most %d points", MaxRoutePoints)
+	}
+
+	for i, point := range r.Points {
+		if err := point.Validate(); err != nil {
+			return fmt.Errorf("route point %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Length is the great-circle length of the route from its first point to its last
+func (r Route) Length() Distance {
+	var meters float64
+	for i := 1; i < len(r.Points); i++ {
+		meters += r.Points[i-1].DistanceTo(r.Points[i]).Meters
+	}
+	return Distance{Meters: meters}
+}
+
 func (d Distance) ToKilometers() float64 {
 	return d.Meters / 1000
 }
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// OutboxEvent is an event recorded in the same transaction as the change it announces,
+// waiting to be relayed to Kafka. Sequence is the order events were recorded in.
+type OutboxEvent struct {
+	Sequence      int64
+	Event         *PostEvent
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	CreatedAt     time.Time
+}
+
+// outboxPayloadTypes are the event payloads decoded back into their own type when relayed,
+// keyed by type name
+var outboxPayloadTypes = payloadTypesByName(
+	&PostCreatedEventData{},
+	&PostUpdatedEventData{},
+	&PostStatusChangedEventData{},
+	&PostBumpedEventData{},
+	&PhotoAddedEventData{},
+	&PhotoRemovedEventData{},
+	&PhotoProcessedEventData{},
+	&ContactExchangeRequestedEventData{},
+	&ContactExchangeApprovedEventData{},
+	&ContactExchangeDeniedEventData{},
+	&ContactExchangeExpiredEventData{},
+	&ContactExchangeDataPurgedEventData{},
+	&ContactExchangeExpiringSoonEventData{},
+	&ClaimCreatedEventData{},
+	&ClaimApprovedEventData{},
+)
+
+func payloadTypesByName(payloads ...interface{}) map[string]reflect.Type {
+	types := make(map[string]reflect.Type, len(payloads))
+	for _, payload := range payloads {
+		t := reflect.TypeOf(payload).Elem()
+		types[t.Name()] = t
+	}
+	return types
+}
+
+// EventPayloadType names the type of an event payload, so a stored payload can be decoded
+// into the same type again
+func EventPayloadType(payload interface{}) string {
+	if payload == nil {
+		return ""
+	}
+	t := reflect.TypeOf(payload)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// DecodeEventPayload decodes a stored payload into the type recorded for it. Payloads of any
+// other type are returned as raw JSON, which serializes back unchanged.
+func DecodeEventPayload(payloadType string, data json.RawMessage) (interface{}, error) {
+	t, ok := outboxPayloadTypes[payloadType]
+	if !ok {
+		return data, nil
+	}
+
+	payload := reflect.New(t).Interface()
+	if err := json.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %w", payloadType, err)
+	}
+	return payload, nil
+}
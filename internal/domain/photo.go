@@ -11,20 +11,27 @@ type Photo struct {
 	postID       PostID
 	url          string
 	thumbnailURL string
+	filename     string
 	caption      string
 	displayOrder int
 	format       string
 	sizeBytes    int64
+	width        int
+	height       int
 	createdAt    time.Time
 }
 
 type CreatePhotoRequest struct {
 	PostID       PostID
 	URL          string
+	ThumbnailURL string
+	Filename     string // Storage object name of the original
 	Caption      string
 	DisplayOrder int
 	Format       string
 	SizeBytes    int64
+	Width        int
+	Height       int
 }
 
 func NewPhoto(req CreatePhotoRequest) (*Photo, error) {
@@ -36,7 +43,7 @@ func NewPhoto(req CreatePhotoRequest) (*Photo, error) {
 		return nil, err
 	}
 
-	if req.DisplayOrder < 1 || req.DisplayOrder > 10 {
+	if req.DisplayOrder < 1 || req.DisplayOrder > MaxPhotosPerPost {
 		return nil, ErrInvalidDisplayOrder(req.DisplayOrder)
 	}
 
@@ -44,16 +51,20 @@ func NewPhoto(req CreatePhotoRequest) (*Photo, error) {
 		id:           NewPhotoID(),
 		postID:       req.PostID,
 		url:          req.URL,
+		thumbnailURL: req.ThumbnailURL,
+		filename:     req.Filename,
 		caption:      req.Caption,
 		displayOrder: req.DisplayOrder,
 		format:       strings.ToLower(req.Format),
 		sizeBytes:    req.SizeBytes,
-		createdAt:    time.Now(),
+		width:        max(req.Width, 0),
+		height:       max(req.Height, 0),
+		createdAt:    utcNow(),
 	}, nil
 }
 
 func (p *Photo) UpdateCaption(caption string) error {
-	if len(caption) > 500 {
+	if TextLength(caption) > MaxCaptionLength {
 		return errors.New("caption too long")
 	}
 	p.caption = caption
@@ -64,21 +75,51 @@ func (p *Photo) SetThumbnailURL(thumbnailURL string) {
 	p.thumbnailURL = thumbnailURL
 }
 
-func validatePhotoFormat(format string) error {
-	allowedFormats := map[string]bool{
-		"jpg":  true,
-		"jpeg": true,
-		"png":  true,
-		"webp": true,
+// SetDimensions records the pixel size of the original image; zero means unknown
+func (p *Photo) SetDimensions(width, height int) {
+	if width < 0 || height < 0 {
+		return
 	}
+	p.width = width
+	p.height = height
+}
 
-	if !allowedFormats[strings.ToLower(format)] {
+// RestoreFilename sets the storage object name when loading a photo from persistence
+func (p *Photo) RestoreFilename(filename string) {
+	p.filename = filename
+}
+
+// IsProcessed reports whether the thumbnail and dimensions derived from the original are stored
+func (p *Photo) IsProcessed() bool {
+	return p.thumbnailURL != "" && p.width > 0 && p.height > 0
+}
+
+func validatePhotoFormat(format string) error {
+	if !IsAllowedPhotoFormat(format) {
 		return ErrInvalidPhotoFormat(format)
 	}
 
 	return nil
 }
 
+// photoMimeTypes maps photo formats to the content type they are stored and served with
+var photoMimeTypes = map[string]string{
+	"jpg":  "image/jpeg",
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"webp": "image/webp",
+	"gif":  "image/gif",
+}
+
+// PhotoMimeType returns the MIME type of a photo format, or application/octet-stream for
+// unknown formats
+func PhotoMimeType(format string) string {
+	if mimeType, ok := photoMimeTypes[strings.ToLower(format)]; ok {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
+
 func (p *Photo) IsValidFormat() bool {
 	return validatePhotoFormat(p.format) == nil
 }
@@ -101,7 +142,7 @@ func ReconstructPhoto(
 		displayOrder: displayOrder,
 		format:       format,
 		sizeBytes:    sizeBytes,
-		createdAt:    createdAt,
+		createdAt:    createdAt.UTC(),
 	}
 }
 
@@ -121,6 +162,11 @@ func (p *Photo) ThumbnailURL() string {
 	return p.thumbnailURL
 }
 
+// Filename is the storage object name of the original, empty for photos stored before it was recorded
+func (p *Photo) Filename() string {
+	return p.filename
+}
+
 func (p *Photo) Caption() string {
 	return p.caption
 }
@@ -137,6 +183,14 @@ func (p *Photo) SizeBytes() int64 {
 	return p.sizeBytes
 }
 
+func (p *Photo) Width() int {
+	return p.width
+}
+
+func (p *Photo) Height() int {
+	return p.height
+}
+
 func (p *Photo) CreatedAt() time.Time {
 	return p.createdAt
 }
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SupportedPhotoFormats lists every format the service knows how to store and serve.
+// Deployments may narrow this list but never extend it.
+var SupportedPhotoFormats = []string{"jpg", "jpeg", "png", "webp", "gif"}
+
+var (
+	photoFormatsMu      sync.RWMutex
+	allowedPhotoFormats = SupportedPhotoFormats
+)
+
+// SetAllowedPhotoFormats replaces the photo format allowlist used by every validation site.
+// An empty list restores the default of all supported formats.
+func SetAllowedPhotoFormats(formats []string) error {
+	if len(formats) == 0 {
+		formats = SupportedPhotoFormats
+	}
+
+	allowed := make([]string, 0, len(formats))
+	for _, format := range formats {
+		format = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(format), "."))
+		if !isSupportedPhotoFormat(format) {
+			return fmt.Errorf("unsupported photo format in allowlist: %q", format)
+		}
+		allowed = append(allowed, format)
+	}
+
+	photoFormatsMu.Lock()
+	defer photoFormatsMu.Unlock()
+	allowedPhotoFormats = allowed
+
+	return nil
+}
+
+// AllowedPhotoFormats returns the formats currently accepted for upload
+func AllowedPhotoFormats() []string {
+	photoFormatsMu.RLock()
+	defer photoFormatsMu.RUnlock()
+
+	formats := make([]string, len(allowedPhotoFormats))
+	copy(formats, allowedPhotoFormats)
+	return formats
+}
+
+// IsAllowedPhotoFormat reports whether a file extension (with or without the dot) is accepted
+func IsAllowedPhotoFormat(format string) bool {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+
+	photoFormatsMu.RLock()
+	defer photoFormatsMu.RUnlock()
+
+	for _, allowed := range allowedPhotoFormats {
+		if allowed == format {
+			return true
+		}
+	}
+	return false
+}
+
+func isSupportedPhotoFormat(format string) bool {
+	for _, supported := range SupportedPhotoFormats {
+		if supported == format {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -14,25 +17,75 @@ const (
 )
 
 const (
+	PostStatusDraft    PostStatus = "draft"
 	PostStatusActive   PostStatus = "active"
 	PostStatusResolved PostStatus = "resolved"
 	PostStatusExpired  PostStatus = "expired"
 	PostStatusDeleted  PostStatus = "deleted"
+	PostStatusArchived PostStatus = "archived" // resolved and kept for records, hidden from default listings
 )
 
+// MaxPhotosPerPost is the most photos a single post may hold
+const MaxPhotosPerPost = 10
+
+// Bounds of the radius around a post's location it is shown within
+const (
+	MinPostRadiusMeters = 100
+	MaxPostRadiusMeters = 50000
+)
+
+// DefaultPostBumpIntervalHours is how often a post may be bumped when no interval is configured
+const DefaultPostBumpIntervalHours = 24
+
+var (
+	postBumpIntervalMu    sync.RWMutex
+	postBumpIntervalHours = DefaultPostBumpIntervalHours
+)
+
+// SetPostBumpIntervalHours sets how long a post must wait after its creation or last bump
+// before it may be bumped again. Zero restores the default.
+func SetPostBumpIntervalHours(hours int) error {
+	if hours == 0 {
+		hours = DefaultPostBumpIntervalHours
+	}
+	if hours < 0 {
+		return fmt.Errorf("post bump interval must be positive, got %d hours", hours)
+	}
+
+	postBumpIntervalMu.Lock()
+	defer postBumpIntervalMu.Unlock()
+	postBumpIntervalHours = hours
+
+	return nil
+}
+
+// PostBumpInterval returns the shortest time allowed between bumps of a post
+func PostBumpInterval() time.Duration {
+	postBumpIntervalMu.RLock()
+	defer postBumpIntervalMu.RUnlock()
+	return time.Duration(postBumpIntervalHours) * time.Hour
+}
+
 type Post struct {
 	id             PostID
 	title          string
 	description    string
 	photos         []Photo
+	coverPhotoID   *PhotoID
 	location       Location
 	radiusMeters   int
 	status         PostStatus
 	postType       PostType
 	createdBy      UserID
 	organizationID *OrganizationID
-	createdAt      time.Time
-	updatedAt      time.Time
+	// language is the ISO 639 code of the post text, empty when unknown
+	language string
+	// tags are lowercase labels set by the author, used for filtering
+	tags []string
+	// bumpedAt is when the owner last moved the post back to the top of listings, nil if never
+	bumpedAt  *time.Time
+	createdAt time.Time
+	updatedAt time.Time
 }
 
 func NewPost(
@@ -52,7 +105,15 @@ func NewPost(
 		return nil, ErrInvalidTitle()
 	}
 
-	if len(photos) < 1 || len(photos) > 10 {
+	if err := validateTitle(title); err != nil {
+		return nil, err
+	}
+
+	if err := validateDescription(description); err != nil {
+		return nil, err
+	}
+
+	if len(photos) < 1 || len(photos) > MaxPhotosPerPost {
 		return nil, ErrInvalidPhotoCount(len(photos))
 	}
 
@@ -60,11 +121,11 @@ func NewPost(
 		return nil, err
 	}
 
-	if radiusMeters < 100 || radiusMeters > 50000 {
+	if radiusMeters < MinPostRadiusMeters || radiusMeters > MaxPostRadiusMeters {
 		radiusMeters = 1000
 	}
 
-	now := time.Now()
+	now := utcNow()
 
 	return &Post{
 		id:             NewPostID(),
@@ -82,6 +143,59 @@ func NewPost(
 	}, nil
 }
 
+// NewDraftPost creates a private, work-in-progress post. Drafts may be saved without a
+// title or photos; completeness is enforced when the draft is published.
+func NewDraftPost(
+	title, description string,
+	photos []Photo,
+	location Location,
+	radiusMeters int,
+	postType PostType,
+	createdBy UserID,
+	organizationID *OrganizationID,
+) (*Post, error) {
+	if err := validatePostType(postType); err != nil {
+		return nil, err
+	}
+
+	if err := validateTitle(title); err != nil {
+		return nil, err
+	}
+
+	if err := validateDescription(description); err != nil {
+		return nil, err
+	}
+
+	if len(photos) > MaxPhotosPerPost {
+		return nil, ErrInvalidPhotoCount(len(photos))
+	}
+
+	if err := location.Validate(); err != nil {
+		return nil, err
+	}
+
+	if radiusMeters < MinPostRadiusMeters || radiusMeters > MaxPostRadiusMeters {
+		radiusMeters = 1000
+	}
+
+	now := utcNow()
+
+	return &Post{
+		id:             NewPostID(),
+		title:          title,
+		description:    description,
+		photos:         photos,
+		location:       location,
+		radiusMeters:   radiusMeters,
+		status:         PostStatusDraft,
+		postType:       postType,
+		createdBy:      createdBy,
+		organizationID: organizationID,
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
 func ReconstructPost(
 	id PostID,
 	title, description string,
@@ -105,50 +219,265 @@ func ReconstructPost(
 		postType:       postType,
 		createdBy:      createdBy,
 		organizationID: organizationID,
-		createdAt:      createdAt,
-		updatedAt:      updatedAt,
+		createdAt:      createdAt.UTC(),
+		updatedAt:      updatedAt.UTC(),
 	}
 }
 
 func (p *Post) AddPhoto(photo Photo) error {
-	if len(p.photos) >= 10 {
+	if len(p.photos) >= MaxPhotosPerPost {
 		return ErrInvalidPhotoCount(len(p.photos))
 	}
 
 	p.photos = append(p.photos, photo)
-	p.updatedAt = time.Now()
+	p.updatedAt = utcNow()
 	return nil
 }
 
+// CompactPhotoOrder renumbers the photos into a dense 1..N display order, keeping their relative
+// order, and reports whether any photo moved
+func (p *Post) CompactPhotoOrder() bool {
+	sort.SliceStable(p.photos, func(i, j int) bool {
+		return p.photos[i].displayOrder < p.photos[j].displayOrder
+	})
+
+	changed := false
+	for i := range p.photos {
+		if p.photos[i].displayOrder != i+1 {
+			p.photos[i].displayOrder = i + 1
+			changed = true
+		}
+	}
+	return changed
+}
+
 func (p *Post) RemovePhoto(photoID PhotoID) error {
 	for i, photo := range p.photos {
 		if photo.ID().Equals(photoID) {
 			p.photos = append(p.photos[:i], p.photos[i+1:]...)
-			p.updatedAt = time.Now()
+			if p.coverPhotoID != nil && p.coverPhotoID.Equals(photoID) {
+				p.coverPhotoID = nil
+			}
+			p.updatedAt = utcNow()
 			return nil
 		}
 	}
 	return errors.New("photo not found")
 }
 
+// SetCoverPhoto picks which of the post's photos represents it on cards and lists
+func (p *Post) SetCoverPhoto(photoID PhotoID) error {
+	for _, photo := range p.photos {
+		if photo.ID().Equals(photoID) {
+			p.coverPhotoID = &photoID
+			p.updatedAt = utcNow()
+			return nil
+		}
+	}
+	return ErrPhotoNotFound(photoID)
+}
+
+// SetLanguage records the language the post is written in. An empty language clears it.
+func (p *Post) SetLanguage(language string) error {
+	code, err := ParseLanguage(language)
+	if err != nil {
+		return err
+	}
+	p.language = code
+	return nil
+}
+
+// RestoreLanguage sets the stored language when rebuilding a post from persistence
+func (p *Post) RestoreLanguage(language string) {
+	p.language = language
+}
+
+// SetTags replaces the post's tags after normalizing them to lowercase. Nil or empty tags clear them.
+func (p *Post) SetTags(tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	p.tags = normalized
+	return nil
+}
+
+// RestoreTags sets the stored tags when rebuilding a post from persistence
+func (p *Post) RestoreTags(tags []string) {
+	p.tags = tags
+}
+
+// RestoreBumpedAt sets the stored bump time when rebuilding a post from persistence
+func (p *Post) RestoreBumpedAt(bumpedAt *time.Time) {
+	p.bumpedAt = utcPtr(bumpedAt)
+}
+
+// RestoreCoverPhoto sets the stored cover photo when rebuilding a post from persistence
+func (p *Post) RestoreCoverPhoto(photoID *PhotoID) {
+	p.coverPhotoID = photoID
+}
+
 func (p *Post) UpdateStatus(newStatus PostStatus) error {
 	if err := p.validateStatusTransition(newStatus); err != nil {
 		return err
 	}
 
 	p.status = newStatus
-	p.updatedAt = time.Now()
+	p.updatedAt = utcNow()
 	return nil
 }
 
-func (p *Post) Update(title, description string) error {
-	if title == "" {
+// Publish makes a draft visible to everyone once it has everything an active post needs
+func (p *Post) Publish() error {
+	if p.status != PostStatusDraft {
+		return ErrCannotTransitionStatus(p.status, PostStatusActive)
+	}
+
+	if p.title == "" {
 		return ErrInvalidTitle()
 	}
 
-	p.title = title
-	p.description = description
-	p.updatedAt = time.Now()
+	if err := validateTitle(p.title); err != nil {
+		return err
+	}
+
+	if err := p.location.Validate(); err != nil {
+		return err
+	}
+
+	if len(p.photos) < 1 || len(p.photos) > MaxPhotosPerPost {
+		return ErrInvalidPhotoCount(len(p.photos))
+	}
+
+	p.status = PostStatusActive
+	p.updatedAt = utcNow()
+	return nil
+}
+
+// Bump moves an active post back to the top of listings ordered by bump time, leaving its
+// creation time alone. A post may be bumped once per PostBumpInterval, counted from its last
+// bump or, before the first one, from its creation.
+func (p *Post) Bump() error {
+	if p.status != PostStatusActive {
+		return ErrPostNotBumpable(p.status)
+	}
+
+	now := utcNow()
+	if next := p.NextBumpAt(); now.Before(next) {
+		return ErrPostBumpTooSoon(p.id, next)
+	}
+
+	p.bumpedAt = &now
+	p.updatedAt = now
+	return nil
+}
+
+// TransferOwnership reassigns an organization post to another user. Membership of the new
+// owner is checked by the caller, which has access to the organization context.
+func (p *Post) TransferOwnership(newOwner UserID) error {
+	if p.organizationID == nil {
+		return ErrInvalidOwnershipTransfer("post does not belong to an organization")
+	}
+	if p.status == PostStatusDeleted {
+		return ErrInvalidOwnershipTransfer("post is deleted")
+	}
+	if p.createdBy.Equals(newOwner) {
+		return ErrInvalidOwnershipTransfer("post already belongs to this user")
+	}
+
+	p.createdBy = newOwner
+	p.updatedAt = utcNow()
+	return nil
+}
+
+func (p *Post) IsDraft() bool {
+	return p.status == PostStatusDraft
+}
+
+func (p *Post) IsArchived() bool {
+	return p.status == PostStatusArchived
+}
+
+// IsVisibleTo reports whether a user may see the post; drafts are private to their author
+func (p *Post) IsVisibleTo(userID UserID) bool {
+	return !p.IsDraft() || p.createdBy.Equals(userID)
+}
+
+// PostUpdate holds the fields to change on a post. Nil fields keep their current value; nil
+// Tags keep the current tags and an empty slice clears them.
+type PostUpdate struct {
+	Title        *string
+	Description  *string
+	Location     *Location
+	RadiusMeters *int
+	Type         *PostType
+	Tags         []string
+}
+
+// Update changes the fields set in the update. Every field is validated before any is
+// applied, so a rejected update leaves the post as it was.
+func (p *Post) Update(update PostUpdate) error {
+	if update.Title != nil {
+		if *update.Title == "" && !p.IsDraft() {
+			return ErrInvalidTitle()
+		}
+		if err := validateTitle(*update.Title); err != nil {
+			return err
+		}
+	}
+
+	if update.Description != nil {
+		if err := validateDescription(*update.Description); err != nil {
+			return err
+		}
+	}
+
+	if update.Location != nil {
+		if err := update.Location.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if update.RadiusMeters != nil {
+		if *update.RadiusMeters < MinPostRadiusMeters || *update.RadiusMeters > MaxPostRadiusMeters {
+			return ErrInvalidRadius(*update.RadiusMeters)
+		}
+	}
+
+	if update.Type != nil {
+		if err := validatePostType(*update.Type); err != nil {
+			return err
+		}
+	}
+
+	var tags []string
+	if update.Tags != nil {
+		normalized, err := NormalizeTags(update.Tags)
+		if err != nil {
+			return err
+		}
+		tags = normalized
+	}
+
+	if update.Title != nil {
+		p.title = *update.Title
+	}
+	if update.Description != nil {
+		p.description = *update.Description
+	}
+	if update.Location != nil {
+		p.location = *update.Location
+	}
+	if update.RadiusMeters != nil {
+		p.radiusMeters = *update.RadiusMeters
+	}
+	if update.Type != nil {
+		p.postType = *update.Type
+	}
+	if tags != nil {
+		p.tags = tags
+	}
+	p.updatedAt = utcNow()
 	return nil
 }
 
@@ -158,9 +487,11 @@ func (p *Post) IsExpired(expiryDuration time.Duration) bool {
 
 func (p *Post) validateStatusTransition(newStatus PostStatus) error {
 	validTransitions := map[PostStatus][]PostStatus{
+		PostStatusDraft:    {PostStatusDeleted}, // drafts become active through Publish
 		PostStatusActive:   {PostStatusResolved, PostStatusExpired, PostStatusDeleted},
-		PostStatusResolved: {PostStatusActive, PostStatusDeleted},
+		PostStatusResolved: {PostStatusActive, PostStatusArchived, PostStatusDeleted},
 		PostStatusExpired:  {PostStatusActive, PostStatusDeleted},
+		PostStatusArchived: {PostStatusDeleted},
 		PostStatusDeleted:  {},
 	}
 
@@ -179,23 +510,48 @@ func (p *Post) validateStatusTransition(newStatus PostStatus) error {
 }
 
 func validatePostType(postType PostType) error {
-	switch postType {
+	if !postType.IsValid() {
+		return ErrInvalidPostType(string(postType))
+	}
+	return nil
+}
+
+func (pt PostType) IsValid() bool {
+	switch pt {
 	case PostTypeLost, PostTypeFound:
-		return nil
+		return true
 	default:
-		return ErrInvalidPostType(string(postType))
+		return false
+	}
+}
+
+// PostTypeFromString parses a post type as found in requests and query parameters
+func PostTypeFromString(s string) (PostType, error) {
+	postType := PostType(s)
+	if err := validatePostType(postType); err != nil {
+		return "", err
 	}
+	return postType, nil
 }
 
 func (ps PostStatus) IsValid() bool {
 	switch ps {
-	case PostStatusActive, PostStatusResolved, PostStatusExpired, PostStatusDeleted:
+	case PostStatusDraft, PostStatusActive, PostStatusResolved, PostStatusExpired, PostStatusDeleted, PostStatusArchived:
 		return true
 	default:
 		return false
 	}
 }
 
+// PostStatusFromString parses a post status as found in requests and query parameters
+func PostStatusFromString(s string) (PostStatus, error) {
+	status := PostStatus(s)
+	if !status.IsValid() {
+		return "", ErrInvalidPostStatus(s)
+	}
+	return status, nil
+}
+
 func (p *Post) ID() PostID {
 	return p.id
 }
@@ -236,6 +592,64 @@ func (p *Post) OrganizationID() *OrganizationID {
 	return p.organizationID
 }
 
+// CoverPhotoID returns the chosen cover photo, falling back to the photo with the lowest
+// display order. It is nil only for posts without photos.
+func (p *Post) CoverPhotoID() *PhotoID {
+	if p.coverPhotoID != nil {
+		id := *p.coverPhotoID
+		return &id
+	}
+
+	var cover *Photo
+	for i := range p.photos {
+		if cover == nil || p.photos[i].DisplayOrder() < cover.DisplayOrder() {
+			cover = &p.photos[i]
+		}
+	}
+	if cover == nil {
+		return nil
+	}
+
+	id := cover.ID()
+	return &id
+}
+
+// ChosenCoverPhotoID returns the cover set through SetCoverPhoto, or nil when defaulted
+func (p *Post) ChosenCoverPhotoID() *PhotoID {
+	return p.coverPhotoID
+}
+
+func (p *Post) Language() string {
+	return p.language
+}
+
+// Tags returns a copy of the post's tags, empty rather than nil when it has none
+func (p *Post) Tags() []string {
+	return append([]string{}, p.tags...)
+}
+
+// BumpedAt returns when the post was last bumped, or nil if it never was
+func (p *Post) BumpedAt() *time.Time {
+	if p.bumpedAt == nil {
+		return nil
+	}
+	bumpedAt := *p.bumpedAt
+	return &bumpedAt
+}
+
+// ListedAt is the time bump ordered listings sort the post by: its last bump, or its creation
+func (p *Post) ListedAt() time.Time {
+	if p.bumpedAt != nil {
+		return *p.bumpedAt
+	}
+	return p.createdAt
+}
+
+// NextBumpAt returns the earliest time the post may be bumped again
+func (p *Post) NextBumpAt() time.Time {
+	return p.ListedAt().Add(PostBumpInterval())
+}
+
 func (p *Post) CreatedAt() time.Time {
 	return p.createdAt
 }
@@ -2,17 +2,87 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
 )
 
+type primaryReadsKey struct{}
+
+// WithPrimaryReads marks the context so repositories serve reads from the primary
+// database instead of a replica, giving read-after-write consistency
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// PrimaryReadsRequested reports whether the context opted into primary reads
+func PrimaryReadsRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(primaryReadsKey{}).(bool)
+	return requested
+}
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion makes post updates and deletes on the context conditional on the post
+// still being at the version last modified at updatedAt. A write that finds the post changed
+// fails with ErrPostModified instead of overwriting the newer version.
+func WithExpectedVersion(ctx context.Context, updatedAt time.Time) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, updatedAt)
+}
+
+// ExpectedVersion returns the version post writes on the context are conditional on, if any
+func ExpectedVersion(ctx context.Context) (time.Time, bool) {
+	updatedAt, ok := ctx.Value(expectedVersionKey{}).(time.Time)
+	return updatedAt, ok
+}
+
 type PostRepository interface {
 	Save(ctx context.Context, post *Post) error
+	// SaveBatch saves all posts atomically; either every post is stored or none is
+	SaveBatch(ctx context.Context, posts []*Post) error
 	FindByID(ctx context.Context, id PostID) (*Post, error)
-	FindByUserID(ctx context.Context, userID UserID, limit, offset int) ([]*Post, error)
-	FindNearby(ctx context.Context, location Location, radius Distance, postType *PostType, limit, offset int) ([]*Post, error)
+	// List queries load at most maxPhotos photos per post, 0 meaning all; FindByID always loads every photo
+	FindByUserID(ctx context.Context, userID UserID, includeDrafts bool, limit, offset, maxPhotos int) ([]*Post, error)
+	// CountByUserID counts the posts FindByUserID pages through; deleted posts are never included
+	CountByUserID(ctx context.Context, userID UserID, includeDrafts bool) (int64, error)
+	// FindNearby finds active posts around a point. When respectPostRadius is set a post is
+	// only returned if the searcher is also inside the post's own radius. Only the
+	// maxCandidates nearest matches are paged through, 0 meaning all of them.
+	FindNearby(ctx context.Context, location Location, radius Distance, postType *PostType, respectPostRadius bool, maxCandidates, limit, offset, maxPhotos int) ([]*Post, error)
+	// FindAlongRoute finds active posts within corridor of a route, ordered by where along
+	// the route they lie
+	FindAlongRoute(ctx context.Context, route Route, corridor Distance, postType *PostType, limit, offset, maxPhotos int) ([]*Post, error)
+	// Update and Delete fail with ErrPostModified when the context expects a version of the
+	// post that is no longer current (see WithExpectedVersion)
 	Update(ctx context.Context, post *Post) error
+	// Bump stores the post's bump time unless the post was created or bumped again less than
+	// minInterval before it, which it reports by returning false. Concurrent bumps of one post
+	// therefore store only one of them.
+	Bump(ctx context.Context, post *Post, minInterval time.Duration) (bool, error)
 	Delete(ctx context.Context, id PostID) error
 	List(ctx context.Context, filters PostFilters) ([]*Post, error)
+	// ListPage lists like List and also reports whether more posts follow the page
+	ListPage(ctx context.Context, filters PostFilters) ([]*Post, bool, error)
 	Count(ctx context.Context, filters PostFilters) (int64, error)
+	// Heatmap counts published posts per grid cell inside a bounding box
+	Heatmap(ctx context.Context, filters HeatmapFilters) ([]HeatmapCell, error)
+	// CountDistinctPosters counts the users with a published post in the organization created
+	// at or after since
+	CountDistinctPosters(ctx context.Context, orgID OrganizationID, since time.Time) (int64, error)
+	// SaveResolution records how a post was resolved, replacing any earlier resolution of it
+	SaveResolution(ctx context.Context, postID PostID, resolution *ResolutionData) error
+	// DeleteResolution forgets a post's resolution once it is reopened
+	DeleteResolution(ctx context.Context, postID PostID) error
+	// FindResolution returns ErrResolutionNotFound when the post has no recorded resolution
+	FindResolution(ctx context.Context, postID PostID) (*ResolutionData, error)
+	ResolutionStats(ctx context.Context, filter ResolutionStatsFilter) (*ResolutionStats, error)
+}
+
+type LandmarkRepository interface {
+	// FindByName resolves a possibly misspelled or partial name to the landmark whose name is
+	// most similar to it, returning ErrUnknownLandmark when none is similar enough
+	FindByName(ctx context.Context, name string) (*Landmark, error)
 }
 
 type PhotoRepository interface {
@@ -20,6 +90,9 @@ type PhotoRepository interface {
 	FindByID(ctx context.Context, id PhotoID) (*Photo, error)
 	FindByPostID(ctx context.Context, postID PostID) ([]*Photo, error)
 	Update(ctx context.Context, photo *Photo) error
+	UpdateThumbnail(ctx context.Context, id PhotoID, thumbnailURL string, width, height int) error
+	// RenumberPhotos sets each photo's display order to its position in photos
+	RenumberPhotos(ctx context.Context, postID PostID, photos []Photo) error
 	Delete(ctx context.Context, id PhotoID) error
 }
 
@@ -27,20 +100,83 @@ type EventPublisher interface {
 	PublishEvent(ctx context.Context, event *PostEvent) error
 }
 
+// OutboxRepository records events in the transaction of the change they announce and tracks
+// their relay to Kafka
+type OutboxRepository interface {
+	Append(ctx context.Context, event *PostEvent) error
+	// FindPending finds unpublished events due for an attempt, oldest first. Events whose
+	// aggregate has an earlier event still waiting for a retry are left out, so per-aggregate
+	// order is kept.
+	FindPending(ctx context.Context, now time.Time, limit int) ([]*OutboxEvent, error)
+	MarkPublished(ctx context.Context, sequence int64, publishedAt time.Time) error
+	// MarkFailed counts a failed attempt and holds the event back until nextAttemptAt
+	MarkFailed(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error
+	// MarkDeadLettered records that the event went to the dead letter topic instead of being
+	// published. It is not retried and no longer holds back the rest of its aggregate.
+	MarkDeadLettered(ctx context.Context, sequence int64, deadLetteredAt time.Time, lastError string) error
+}
+
+// TransactionManager runs a unit of work in a single database transaction, committed when fn
+// returns nil. Repositories called with the context given to fn take part in the transaction.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Locker keeps work that must not overlap across instances of the service to one instance at
+// a time. TryWithLock runs fn while holding the named lock and reports whether it ran; fn is
+// skipped when another instance holds the lock.
+type Locker interface {
+	TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
+
 // ContactExchangeRepository manages contact exchange requests
 type ContactExchangeRepository interface {
 	Save(ctx context.Context, request *ContactExchangeRequest) error
 	FindByID(ctx context.Context, id ContactExchangeRequestID) (*ContactExchangeRequest, error)
 	FindByPostID(ctx context.Context, postID PostID) ([]*ContactExchangeRequest, error)
 	FindByRequesterUserID(ctx context.Context, userID UserID, limit, offset int) ([]*ContactExchangeRequest, error)
+	// FindPendingByRequester returns the requester's pending request for the post, or nil when
+	// there is none
+	FindPendingByRequester(ctx context.Context, postID PostID, requesterID UserID) (*ContactExchangeRequest, error)
 	FindByOwnerUserID(ctx context.Context, userID UserID, limit, offset int) ([]*ContactExchangeRequest, error)
+	// FindStatusesForUser returns the status of each of the given requests the user is a
+	// requester or owner on; other and unknown IDs are left out
+	FindStatusesForUser(ctx context.Context, ids []ContactExchangeRequestID, userID UserID) (map[ContactExchangeRequestID]ContactExchangeStatus, error)
+	// FindEncryptedWithOtherKey finds approved, unexpired requests whose contact info was not
+	// encrypted with the given key
+	FindEncryptedWithOtherKey(ctx context.Context, keyFingerprint string, limit int) ([]*ContactExchangeRequest, error)
+	// FindKeyFingerprintsInUse lists the keys that stored contact info is encrypted with, with an
+	// empty fingerprint standing for contact info stored before keys were recorded
+	FindKeyFingerprintsInUse(ctx context.Context) ([]string, error)
 	FindExpired(ctx context.Context, limit int) ([]*ContactExchangeRequest, error)
+	// FindPurgeable finds expired requests that still hold encrypted contact information
+	FindPurgeable(ctx context.Context, limit int) ([]*ContactExchangeRequest, error)
+	// FindExpiringForUser finds pending and approved requests the user is a requester or owner
+	// on that expire within the given window, soonest first
+	FindExpiringForUser(ctx context.Context, userID UserID, within time.Duration) ([]*ContactExchangeRequest, error)
+	// FindDueForExpiryReminder finds pending and approved requests expiring within the given
+	// window that have not had an expiry reminder sent yet
+	FindDueForExpiryReminder(ctx context.Context, within time.Duration, limit int) ([]*ContactExchangeRequest, error)
+	MarkExpiryReminderSent(ctx context.Context, id ContactExchangeRequestID, sentAt time.Time) error
+	// CountByRequester counts the requests the user created for the post at or after since, and
+	// the user's unexpired pending requests across all posts
+	CountByRequester(ctx context.Context, requesterID UserID, postID PostID, since time.Time) (RequesterRequestCounts, error)
 	Update(ctx context.Context, request *ContactExchangeRequest) error
 	Delete(ctx context.Context, id ContactExchangeRequestID) error
 	List(ctx context.Context, filters ContactExchangeFilters) ([]*ContactExchangeRequest, error)
 	Count(ctx context.Context, filters ContactExchangeFilters) (int64, error)
 }
 
+// ClaimRepository manages claims made on found posts
+type ClaimRepository interface {
+	Save(ctx context.Context, claim *Claim) error
+	FindByID(ctx context.Context, id ClaimID) (*Claim, error)
+	FindByPostID(ctx context.Context, postID PostID, limit, offset int) ([]*Claim, error)
+	Update(ctx context.Context, claim *Claim) error
+	// DeletePastRetention removes up to limit claims whose retention ended before the given time
+	DeletePastRetention(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
 // UserContextRepository provides privacy-safe user context for events
 type UserContextRepository interface {
 	GetPrivacySafeUser(ctx context.Context, userID UserID) (*PrivacySafeUser, error)
@@ -62,12 +198,15 @@ type KeyRepository interface {
 	ListKeys() ([]*EncryptionKey, error)
 	MarkKeyInactive(fingerprint string) error
 	SetActiveKey(fingerprint string) error
+	// RetireKey marks an inactive key as no longer protecting any stored data
+	RetireKey(fingerprint string) error
 }
 
 // EncryptionAuditLogger logs encryption operations for compliance
 type EncryptionAuditLogger interface {
 	LogOperation(log *EncryptionAuditLog) error
-	GetAuditTrail(userID UserID, requestID *ContactExchangeRequestID, limit int) ([]*EncryptionAuditLog, error)
+	GetAuditTrail(filters EncryptionAuditFilters) ([]*EncryptionAuditLog, error)
+	CountAuditTrail(filters EncryptionAuditFilters) (int64, error)
 }
 
 type PostFilters struct {
@@ -75,12 +214,48 @@ type PostFilters struct {
 	Type           *PostType
 	UserID         *UserID
 	OrganizationID *OrganizationID
-	Location       *Location
-	RadiusMeters   *int
-	CreatedAfter   *string
-	CreatedBefore  *string
-	Limit          int
-	Offset         int
+	// OrganizationIDs matches posts belonging to any of the given organizations
+	OrganizationIDs []OrganizationID
+	Location        *Location
+	RadiusMeters    *int
+	CreatedAfter    *string
+	CreatedBefore   *string
+	// Language matches posts written in the given ISO 639 language
+	Language *string
+	// Tags matches posts carrying every one of the given tags
+	Tags []string
+	// ViewerID is the requesting user; drafts are only listed for their author
+	ViewerID *UserID
+	// AllDrafts lists every author's drafts rather than only the viewer's, for organization admins
+	AllDrafts bool
+	// MaxPhotos caps the photos loaded per post; 0 loads all of them
+	MaxPhotos int
+	Limit     int
+	Offset    int
+	// Sort orders the listing; newest created first when empty
+	Sort PostSort
+	// Cursor continues a listing after a post instead of skipping Offset rows; Offset is
+	// ignored when it is set
+	Cursor *PostCursor
+}
+
+// PostSort is the order of a post listing. Both orders put the newest posts first.
+type PostSort string
+
+const (
+	PostSortCreatedAt PostSort = "created_at"
+	// PostSortBumpedAt orders posts by their last bump, or their creation if never bumped
+	PostSortBumpedAt PostSort = "bumped_at"
+)
+
+// PostSortFromString parses a listing order as found in query parameters
+func PostSortFromString(s string) (PostSort, error) {
+	switch sort := PostSort(s); sort {
+	case PostSortCreatedAt, PostSortBumpedAt:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("invalid post sort: %q", s)
+	}
 }
 
 func (f *PostFilters) SetDefaults() {
@@ -93,6 +268,85 @@ func (f *PostFilters) SetDefaults() {
 	if f.Offset < 0 {
 		f.Offset = 0
 	}
+	if f.Sort == "" {
+		f.Sort = PostSortCreatedAt
+	}
+}
+
+const (
+	DefaultHeatmapGridDegrees = 0.01
+	MinHeatmapGridDegrees     = 0.0001
+	MaxHeatmapCells           = 10000
+
+	// MinHeatmapCellPosts is the fewest posts a cell must hold to be returned, so a lone
+	// post cannot be located through its cell
+	MinHeatmapCellPosts = 3
+)
+
+// HeatmapStatuses are the statuses whose posts are public and may be counted in heatmaps
+var HeatmapStatuses = []PostStatus{PostStatusActive, PostStatusResolved, PostStatusExpired}
+
+// HeatmapFilters selects the posts aggregated into a density grid
+type HeatmapFilters struct {
+	BoundingBox   GeoBoundingBox
+	GridDegrees   float64
+	Type          *PostType
+	Status        *PostStatus
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+func (f *HeatmapFilters) SetDefaults() {
+	if f.GridDegrees <= 0 {
+		f.GridDegrees = DefaultHeatmapGridDegrees
+	}
+	if f.Status == nil {
+		status := PostStatusActive
+		f.Status = &status
+	}
+}
+
+// ClampGrid coarsens the grid to at least minDegrees, so cells are never finer than the
+// precision locations are obfuscated to
+func (f *HeatmapFilters) ClampGrid(minDegrees float64) {
+	if f.GridDegrees < minDegrees {
+		f.GridDegrees = minDegrees
+	}
+}
+
+// Validate rejects boxes that are malformed or would produce an unreasonably large grid
+func (f HeatmapFilters) Validate() error {
+	if err := f.BoundingBox.Validate(); err != nil {
+		return err
+	}
+
+	if f.GridDegrees < MinHeatmapGridDegrees {
+		return fmt.Errorf("grid must be at least %g degrees", MinHeatmapGridDegrees)
+	}
+
+	columns := math.Ceil(f.BoundingBox.Width() / f.GridDegrees)
+	rows := math.Ceil(f.BoundingBox.Height() / f.GridDegrees)
+	if columns*rows > MaxHeatmapCells {
+		return fmt.Errorf("grid of %g degrees is too fine for this bounding box (max %d cells)", f.GridDegrees, MaxHeatmapCells)
+	}
+
+	if f.Status != nil && !slices.Contains(HeatmapStatuses, *f.Status) {
+		return fmt.Errorf("%s posts are not included in heatmaps", *f.Status)
+	}
+
+	return nil
+}
+
+// HeatmapCell is the centroid of a grid cell and how many posts fall inside it
+type HeatmapCell struct {
+	Location Location
+	Count    int64
+}
+
+// RequesterRequestCounts is what request creation is rate limited on
+type RequesterRequestCounts struct {
+	ForPostSince int64
+	Pending      int64
 }
 
 type ContactExchangeFilters struct {
@@ -120,3 +374,27 @@ func (f *ContactExchangeFilters) SetDefaults() {
 		f.Offset = 0
 	}
 }
+
+// EncryptionAuditFilters narrows an audit trail query for forensic review
+type EncryptionAuditFilters struct {
+	UserID    *UserID
+	RequestID *ContactExchangeRequestID
+	Operation *EncryptionOperation
+	Success   *bool
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+func (f *EncryptionAuditFilters) SetDefaults() {
+	if f.Limit <= 0 {
+		f.Limit = 100
+	}
+	if f.Limit > 1000 {
+		f.Limit = 1000
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
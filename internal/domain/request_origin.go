@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// RequestOrigin identifies where a request came from, for audit trails
+type RequestOrigin struct {
+	// IPAddress is the client address, resolved through trusted proxies only
+	IPAddress string
+	UserAgent string
+}
+
+type requestOriginKey struct{}
+
+// WithRequestOrigin attaches the origin of the current request to the context
+func WithRequestOrigin(ctx context.Context, origin RequestOrigin) context.Context {
+	return context.WithValue(ctx, requestOriginKey{}, origin)
+}
+
+// RequestOriginFromContext returns the request origin, if the context carries one
+func RequestOriginFromContext(ctx context.Context) (RequestOrigin, bool) {
+	origin, ok := ctx.Value(requestOriginKey{}).(RequestOrigin)
+	return origin, ok
+}
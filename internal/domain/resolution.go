@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// How a resolved post's item was reunited with its owner
+const (
+	ResolutionTypeMatched   = "matched"    // through a match or an approved claim
+	ResolutionTypeSelfFound = "self_found" // the owner found the item on their own
+	ResolutionTypeOther     = "other"
+)
+
+const (
+	MinUserSatisfactionScore = 1
+	MaxUserSatisfactionScore = 5
+)
+
+// ResolutionDetails is what the user resolving a post reports about how it was resolved
+type ResolutionDetails struct {
+	Type                  string
+	MatchID               *string
+	UserSatisfactionScore *int
+}
+
+// Validate checks the details before anything is changed; an empty type is allowed
+func (d ResolutionDetails) Validate() error {
+	if d.Type != "" && !IsValidResolutionType(d.Type) {
+		return ErrInvalidResolution(fmt.Sprintf("unknown resolution type %q", d.Type))
+	}
+
+	if score := d.UserSatisfactionScore; score != nil && (*score < MinUserSatisfactionScore || *score > MaxUserSatisfactionScore) {
+		return ErrInvalidResolution(fmt.Sprintf("user satisfaction score must be between %d and %d", MinUserSatisfactionScore, MaxUserSatisfactionScore))
+	}
+
+	return nil
+}
+
+func IsValidResolutionType(resolutionType string) bool {
+	switch resolutionType {
+	case ResolutionTypeMatched, ResolutionTypeSelfFound, ResolutionTypeOther:
+		return true
+	}
+	return false
+}
+
+// NewResolutionData records how a post that was just resolved got there. Time to resolution is
+// measured from the post's creation to the status change, in whole hours. Details without a
+// type are recorded as ResolutionTypeOther.
+func NewResolutionData(post *Post, details ResolutionDetails) (*ResolutionData, error) {
+	if post.Status() != PostStatusResolved {
+		return nil, ErrInvalidResolution("only resolved posts have a resolution")
+	}
+
+	if err := details.Validate(); err != nil {
+		return nil, err
+	}
+
+	resolutionType := details.Type
+	if resolutionType == "" {
+		resolutionType = ResolutionTypeOther
+	}
+
+	resolvedAt := post.UpdatedAt()
+	return &ResolutionData{
+		MatchID:        details.MatchID,
+		ResolutionType: resolutionType,
+		SuccessMetrics: &SuccessMetrics{
+			TimeToResolution:      int(resolvedAt.Sub(post.CreatedAt()).Hours()),
+			UserSatisfactionScore: details.UserSatisfactionScore,
+		},
+		ResolvedAt: resolvedAt,
+	}, nil
+}
+
+// ResolutionStatsFilter selects the resolutions of an organization's posts resolved at or
+// after From and before To; either bound may be left open
+type ResolutionStatsFilter struct {
+	OrganizationID OrganizationID
+	From           *time.Time
+	To             *time.Time
+}
+
+// ResolutionTypeStats summarizes the resolutions of one type
+type ResolutionTypeStats struct {
+	Count                        int64   `json:"count"`
+	AverageTimeToResolutionHours float64 `json:"average_time_to_resolution_hours"`
+}
+
+// ResolutionStats summarizes how an organization's posts were resolved
+type ResolutionStats struct {
+	Total                        int64                          `json:"total"`
+	AverageTimeToResolutionHours float64                        `json:"average_time_to_resolution_hours"`
+	ByType                       map[string]ResolutionTypeStats `json:"by_type"`
+}
+
+// NewResolutionStats combines per type summaries into overall stats, weighting each type's
+// average by its count
+func NewResolutionStats(byType map[string]ResolutionTypeStats) *ResolutionStats {
+	stats := &ResolutionStats{ByType: byType}
+	if stats.ByType == nil {
+		stats.ByType = map[string]ResolutionTypeStats{}
+	}
+
+	var totalHours float64
+	for _, typeStats := range stats.ByType {
+		stats.Total += typeStats.Count
+		totalHours += typeStats.AverageTimeToResolutionHours * float64(typeStats.Count)
+	}
+	if stats.Total > 0 {
+		stats.AverageTimeToResolutionHours = totalHours / float64(stats.Total)
+	}
+
+	return stats
+}
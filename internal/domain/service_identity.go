@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultServiceName is the name events are stamped with unless a deployment overrides it
+const DefaultServiceName = "fn-posts"
+
+// DefaultServiceVersion is reported when no version was configured or embedded at build time
+const DefaultServiceVersion = "dev"
+
+var (
+	serviceIdentityMu sync.RWMutex
+	serviceName       = DefaultServiceName
+	serviceVersion    = DefaultServiceVersion
+)
+
+// SetServiceIdentity sets the name and version reported as the source of every published
+// event. Empty values restore the defaults.
+func SetServiceIdentity(name, version string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultServiceName
+	}
+	if strings.ContainsAny(name, " \t\n") {
+		return fmt.Errorf("service name must not contain whitespace, got %q", name)
+	}
+
+	version = strings.TrimSpace(version)
+	if version == "" {
+		version = DefaultServiceVersion
+	}
+
+	serviceIdentityMu.Lock()
+	defer serviceIdentityMu.Unlock()
+	serviceName = name
+	serviceVersion = version
+
+	return nil
+}
+
+// ServiceName returns the name events are published under
+func ServiceName() string {
+	serviceIdentityMu.RLock()
+	defer serviceIdentityMu.RUnlock()
+	return serviceName
+}
+
+// ServiceVersion returns the version of the publishing service
+func ServiceVersion() string {
+	serviceIdentityMu.RLock()
+	defer serviceIdentityMu.RUnlock()
+	return serviceVersion
+}
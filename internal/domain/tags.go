@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTagsPerPost is the most tags a single post may hold
+const MaxTagsPerPost = 20
+
+// MaxTagLength is the longest tag accepted, in characters
+const MaxTagLength = 50
+
+// Tags authors use to flag a reward or urgency; they are carried into event metadata
+const (
+	TagReward = "reward"
+	TagUrgent = "urgent"
+)
+
+// NormalizeTags trims and lowercases tags, dropping empty ones and duplicates while keeping
+// the order they were given in
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, ErrInvalidTag(tag)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTagsPerPost {
+		return nil, ErrTooManyTags(len(normalized))
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "unicode/utf8"
+
+// Text limits are counted in characters (runes), not bytes, so non-Latin text gets the same room
+const (
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 2000
+	MaxCaptionLength     = 500
+)
+
+// TextLength returns the number of characters in s
+func TextLength(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// TruncateText shortens s to at most maxLength characters without splitting a multibyte character
+func TruncateText(s string, maxLength int) string {
+	if maxLength <= 0 {
+		return ""
+	}
+
+	count := 0
+	for i := range s {
+		if count == maxLength {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
+
+func validateTitle(title string) error {
+	if TextLength(title) > MaxTitleLength {
+		return ErrInvalidTitle()
+	}
+	return nil
+}
+
+func validateDescription(description string) error {
+	if TextLength(description) > MaxDescriptionLength {
+		return ErrInvalidDescription()
+	}
+	return nil
+}
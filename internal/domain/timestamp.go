@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// TimestampLayout is the format timestamps are exchanged in: API responses, query
+// parameters and event headers
+const TimestampLayout = time.RFC3339
+
+// FormatTimestamp renders t in TimestampLayout
+func FormatTimestamp(t time.Time) string {
+	return t.Format(TimestampLayout)
+}
+
+// ParseTimestamp reads a timestamp written in TimestampLayout
+func ParseTimestamp(s string) (time.Time, error) {
+	return time.Parse(TimestampLayout, s)
+}
@@ -1,12 +1,22 @@
 package domain
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// scanUUID reads a uuid column into id, leaving the zero UUID for NULL
+func scanUUID(id *uuid.UUID, src interface{}) error {
+	if src == nil {
+		*id = uuid.Nil
+		return nil
+	}
+	return id.Scan(src)
+}
+
 type PostID struct {
 	value uuid.UUID
 }
@@ -23,6 +33,10 @@ func PostIDFromString(s string) (PostID, error) {
 	return PostID{value: id}, nil
 }
 
+func PostIDFromUUID(id uuid.UUID) PostID {
+	return PostID{value: id}
+}
+
 func (p PostID) String() string {
 	return p.value.String()
 }
@@ -39,6 +53,17 @@ func (p PostID) Equals(other PostID) bool {
 	return p.value == other.value
 }
 
+// Scan implements sql.Scanner; NULL scans as the zero ID, and into a nil *PostID when scanned
+// through a pointer
+func (p *PostID) Scan(src interface{}) error {
+	return scanUUID(&p.value, src)
+}
+
+// Value implements driver.Valuer; a nil *PostID is stored as NULL
+func (p PostID) Value() (driver.Value, error) {
+	return p.value.String(), nil
+}
+
 type UserID struct {
 	value uuid.UUID
 }
@@ -75,6 +100,17 @@ func (u UserID) Equals(other UserID) bool {
 	return u.value == other.value
 }
 
+// Scan implements sql.Scanner; NULL scans as the zero ID, and into a nil *UserID when scanned
+// through a pointer
+func (u *UserID) Scan(src interface{}) error {
+	return scanUUID(&u.value, src)
+}
+
+// Value implements driver.Valuer; a nil *UserID is stored as NULL
+func (u UserID) Value() (driver.Value, error) {
+	return u.value.String(), nil
+}
+
 type OrganizationID struct {
 	value uuid.UUID
 }
@@ -111,6 +147,17 @@ func (o OrganizationID) Equals(other OrganizationID) bool {
 	return o.value == other.value
 }
 
+// Scan implements sql.Scanner; NULL scans as the zero ID, and into a nil *OrganizationID when scanned
+// through a pointer
+func (o *OrganizationID) Scan(src interface{}) error {
+	return scanUUID(&o.value, src)
+}
+
+// Value implements driver.Valuer; a nil *OrganizationID is stored as NULL
+func (o OrganizationID) Value() (driver.Value, error) {
+	return o.value.String(), nil
+}
+
 type PhotoID struct {
 	value uuid.UUID
 }
@@ -147,6 +194,17 @@ func (p PhotoID) Equals(other PhotoID) bool {
 	return p.value == other.value
 }
 
+// Scan implements sql.Scanner; NULL scans as the zero ID, and into a nil *PhotoID when scanned
+// through a pointer
+func (p *PhotoID) Scan(src interface{}) error {
+	return scanUUID(&p.value, src)
+}
+
+// Value implements driver.Valuer; a nil *PhotoID is stored as NULL
+func (p PhotoID) Value() (driver.Value, error) {
+	return p.value.String(), nil
+}
+
 // ContactExchangeRequestID represents a unique contact exchange request identifier
 type ContactExchangeRequestID struct {
 	value uuid.UUID
@@ -184,6 +242,43 @@ func (c ContactExchangeRequestID) Equals(other ContactExchangeRequestID) bool {
 	return c.value == other.value
 }
 
+// ClaimID represents a unique claim identifier
+type ClaimID struct {
+	value uuid.UUID
+}
+
+func NewClaimID() ClaimID {
+	return ClaimID{value: uuid.New()}
+}
+
+func ClaimIDFromString(s string) (ClaimID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return ClaimID{}, fmt.Errorf("invalid claim ID: %w", err)
+	}
+	return ClaimID{value: id}, nil
+}
+
+func ClaimIDFromUUID(id uuid.UUID) ClaimID {
+	return ClaimID{value: id}
+}
+
+func (c ClaimID) String() string {
+	return c.value.String()
+}
+
+func (c ClaimID) UUID() uuid.UUID {
+	return c.value
+}
+
+func (c ClaimID) IsZero() bool {
+	return c.value == uuid.Nil
+}
+
+func (c ClaimID) Equals(other ClaimID) bool {
+	return c.value == other.value
+}
+
 // PrivacySafeUser represents user context without PII for event publishing
 type PrivacySafeUser struct {
 	UserID       UserID                  `json:"user_id"`
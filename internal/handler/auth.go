@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// UserIDKey is the gin context key AuthMiddleware stores the caller's user ID under, as a string
+const UserIDKey = "user_id"
+
+// DevUserIDHeader names the caller directly, without a token. It is only honored in dev mode.
+const DevUserIDHeader = "X-User-ID"
+
+// minHS256SecretBytes is the shortest shared secret go-jose accepts for HS256
+const minHS256SecretBytes = 32
+
+// tokenClockSkew is how far exp and nbf may be off before a token is refused
+const tokenClockSkew = 30 * time.Second
+
+// ErrInvalidToken is returned for bearer tokens that are malformed, badly signed, expired or
+// issued for someone else
+var ErrInvalidToken = errors.New("invalid token")
+
+// TokenVerifier checks bearer JWTs signed with the configured algorithm and key and reads the
+// user ID from their subject
+type TokenVerifier struct {
+	algorithm jose.SignatureAlgorithm
+	key       interface{}
+	issuer    string
+	audience  string
+}
+
+// NewTokenVerifier builds a verifier for the configured algorithm: HS256 with the shared
+// secret, or RS256 with a PEM encoded RSA public key
+func NewTokenVerifier(cfg config.AuthConfig) (*TokenVerifier, error) {
+	verifier := &TokenVerifier{
+		algorithm: jose.SignatureAlgorithm(strings.ToUpper(cfg.JWTAlgorithm)),
+		issuer:    cfg.JWTIssuer,
+		audience:  cfg.JWTAudience,
+	}
+
+	switch verifier.algorithm {
+	case jose.HS256:
+		if len(cfg.JWTSecret) < minHS256SecretBytes {
+			return nil, fmt.Errorf("HS256 token verification needs a JWT secret of at least %d bytes", minHS256SecretBytes)
+		}
+		verifier.key = []byte(cfg.JWTSecret)
+	case jose.RS256:
+		key, err := parseRSAPublicKey(cfg.JWTPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RS256 public key: %w", err)
+		}
+		verifier.key = key
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q, must be HS256 or RS256", cfg.JWTAlgorithm)
+	}
+
+	return verifier, nil
+}
+
+func parseRSAPublicKey(data string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return rsaKey, nil
+}
+
+// UserID verifies the token and returns the user named by its subject. Tokens must carry an
+// expiry, and the issuer and audience when those are configured.
+func (v *TokenVerifier) UserID(token string) (domain.UserID, error) {
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{v.algorithm})
+	if err != nil {
+		return domain.UserID{}, ErrInvalidToken
+	}
+
+	var claims jwt.Claims
+	if err := parsed.Claims(v.key, &claims); err != nil {
+		return domain.UserID{}, ErrInvalidToken
+	}
+
+	expected := jwt.Expected{Issuer: v.issuer, Time: time.Now()}
+	if v.audience != "" {
+		expected.AnyAudience = jwt.Audience{v.audience}
+	}
+	if claims.Expiry == nil || claims.ValidateWithLeeway(expected, tokenClockSkew) != nil {
+		return domain.UserID{}, ErrInvalidToken
+	}
+
+	userID, err := domain.UserIDFromString(claims.Subject)
+	if err != nil {
+		return domain.UserID{}, ErrInvalidToken
+	}
+	return userID, nil
+}
+
+// AuthMiddleware requires a valid "Authorization: Bearer" token and stores its subject as the
+// caller's user ID. In dev mode a request without a token may name its user in X-User-ID
+// instead. Without a verifier every token is refused.
+func AuthMiddleware(verifier *TokenVerifier, devMode bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, ok := bearerToken(c.GetHeader("Authorization")); ok {
+			if verifier == nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+				return
+			}
+
+			userID, err := verifier.UserID(token)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+				return
+			}
+
+			c.Set(UserIDKey, userID.String())
+			c.Next()
+			return
+		}
+
+		if devMode {
+			if userID, err := domain.UserIDFromString(c.GetHeader(DevUserIDHeader)); err == nil {
+				c.Set(UserIDKey, userID.String())
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// AuthenticatedUserID returns the user AuthMiddleware authenticated, or the zero ID when the
+// route is not behind it
+func AuthenticatedUserID(c *gin.Context) domain.UserID {
+	if userIDStr := c.GetString(UserIDKey); userIDStr != "" {
+		if userID, err := domain.UserIDFromString(userIDStr); err == nil {
+			return userID
+		}
+	}
+
+	// No authenticated user: callers treat the zero ID as anonymous
+	return domain.UserID{}
+}
@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler/pagination"
+	"github.com/jsarabia/fn-posts/internal/service"
+)
+
+type ClaimHandler struct {
+	claimService *service.ClaimService
+}
+
+func NewClaimHandler(claimService *service.ClaimService) *ClaimHandler {
+	return &ClaimHandler{
+		claimService: claimService,
+	}
+}
+
+type CreateClaimRequest struct {
+	ProofText      string   `json:"proof_text"`
+	ProofPhotoURLs []string `json:"proof_photo_urls"`
+}
+
+type ApproveClaimRequest struct {
+	StartContactExchange bool `json:"start_contact_exchange"`
+}
+
+type ClaimResponse struct {
+	ID                       string   `json:"id"`
+	PostID                   string   `json:"post_id"`
+	ClaimantUserID           string   `json:"claimant_user_id"`
+	Status                   string   `json:"status"`
+	ProofText                string   `json:"proof_text,omitempty"`
+	ProofPhotoURLs           []string `json:"proof_photo_urls,omitempty"`
+	ContactExchangeRequestID *string  `json:"contact_exchange_request_id,omitempty"`
+	ReviewedAt               *string  `json:"reviewed_at,omitempty"`
+	RetainUntil              string   `json:"retain_until"`
+	CreatedAt                string   `json:"created_at"`
+}
+
+// CreateClaim lets a claimant submit proof of ownership for the item on a found post
+func (h *ClaimHandler) CreateClaim(c *gin.Context) {
+	postID, err := domain.PostIDFromString(c.Param("postId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID.IsZero() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var req CreateClaimRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	claim, err := h.claimService.CreateClaim(c.Request.Context(), service.CreateClaimCommand{
+		PostID:         postID,
+		ClaimantUserID: userID,
+		ProofText:      req.ProofText,
+		ProofPhotoURLs: req.ProofPhotoURLs,
+	})
+	if err != nil {
+		respondClaimError(c, err, "Failed to create claim")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toClaimResponse(claim))
+}
+
+// ListClaims returns the claims made on a post to its owner
+func (h *ClaimHandler) ListClaims(c *gin.Context) {
+	postID, err := domain.PostIDFromString(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID.IsZero() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	limit, offset, err := pagination.Parse(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := h.claimService.ListClaims(c.Request.Context(), postID, userID, limit, offset)
+	if err != nil {
+		respondClaimError(c, err, "Failed to list claims")
+		return
+	}
+
+	responses := make([]ClaimResponse, 0, len(claims))
+	for _, claim := range claims {
+		responses = append(responses, toClaimResponse(claim))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"claims": responses,
+		"pagination": gin.H{
+			"limit":  limit,
+			"offset": offset,
+		},
+	})
+}
+
+// ApproveClaim accepts a claim, optionally starting a contact exchange pre-filled with its proof
+func (h *ClaimHandler) ApproveClaim(c *gin.Context) {
+	cmd, ok := h.bindReviewCommand(c)
+	if !ok {
+		return
+	}
+
+	var req ApproveClaimRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+			return
+		}
+	}
+	cmd.StartContactExchange = req.StartContactExchange
+
+	claim, _, err := h.claimService.ApproveClaim(c.Request.Context(), cmd)
+	if err != nil {
+		respondClaimError(c, err, "Failed to approve claim")
+		return
+	}
+
+	c.JSON(http.StatusOK, toClaimResponse(claim))
+}
+
+// RejectClaim turns down a claim
+func (h *ClaimHandler) RejectClaim(c *gin.Context) {
+	cmd, ok := h.bindReviewCommand(c)
+	if !ok {
+		return
+	}
+
+	claim, err := h.claimService.RejectClaim(c.Request.Context(), cmd)
+	if err != nil {
+		respondClaimError(c, err, "Failed to reject claim")
+		return
+	}
+
+	c.JSON(http.StatusOK, toClaimResponse(claim))
+}
+
+func (h *ClaimHandler) bindReviewCommand(c *gin.Context) (service.ReviewClaimCommand, bool) {
+	postID, err := domain.PostIDFromString(c.Param("postId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return service.ReviewClaimCommand{}, false
+	}
+
+	claimID, err := domain.ClaimIDFromString(c.Param("claimId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid claim ID"})
+		return service.ReviewClaimCommand{}, false
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID.IsZero() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return service.ReviewClaimCommand{}, false
+	}
+
+	return service.ReviewClaimCommand{
+		PostID:      postID,
+		ClaimID:     claimID,
+		OwnerUserID: userID,
+	}, true
+}
+
+// respondClaimError maps claim flow errors to responses
+func respondClaimError(c *gin.Context, err error, fallback string) {
+	var postErr domain.PostError
+	if !errors.As(err, &postErr) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+		return
+	}
+
+	switch postErr.Code {
+	case domain.ClaimErrorNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Claim not found"})
+	case domain.BusinessErrorPostNotFound, domain.RepositoryErrorNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+	case domain.BusinessErrorUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner can review claims"})
+	case domain.ClaimErrorInvalidStatus:
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: postErr.Message,
+			Code:  string(postErr.Code),
+		})
+	default:
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error: postErr.Message,
+			Code:  string(postErr.Code),
+		})
+	}
+}
+
+func toClaimResponse(claim *domain.Claim) ClaimResponse {
+	response := ClaimResponse{
+		ID:             claim.ID().String(),
+		PostID:         claim.PostID().String(),
+		ClaimantUserID: claim.ClaimantUserID().String(),
+		Status:         string(claim.Status()),
+		ProofText:      claim.ProofText(),
+		ProofPhotoURLs: claim.ProofPhotoURLs(),
+		RetainUntil:    domain.FormatTimestamp(claim.RetainUntil()),
+		CreatedAt:      domain.FormatTimestamp(claim.CreatedAt()),
+	}
+
+	if claim.ContactExchangeRequestID() != nil {
+		requestID := claim.ContactExchangeRequestID().String()
+		response.ContactExchangeRequestID = &requestID
+	}
+
+	if claim.ReviewedAt() != nil {
+		reviewedAt := domain.FormatTimestamp(*claim.ReviewedAt())
+		response.ReviewedAt = &reviewedAt
+	}
+
+	return response
+}
+
+func (h *ClaimHandler) getUserIDFromContext(c *gin.Context) domain.UserID {
+	return AuthenticatedUserID(c)
+}
@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler/pagination"
 	"github.com/jsarabia/fn-posts/internal/service"
 )
 
@@ -20,16 +24,20 @@ func NewContactExchangeHandler(contactExchangeService *service.ContactExchangeSe
 	}
 }
 
-// RegisterRoutes registers contact exchange routes
+// RegisterRoutes registers the contact exchange routes open to users. The encryption audit
+// trail covers every user, so it is only served on the internal routes.
 func (h *ContactExchangeHandler) RegisterRoutes(router *gin.RouterGroup) {
 	contacts := router.Group("/contacts")
 	{
 		contacts.POST("/exchange", h.CreateContactExchangeRequest)
 		contacts.GET("/exchange/:id", h.GetContactExchangeRequest)
+		contacts.POST("/exchange/:id/verify", h.SubmitVerification)
 		contacts.POST("/exchange/:id/approve", h.ApproveContactExchange)
 		contacts.POST("/exchange/:id/deny", h.DenyContactExchange)
 		contacts.DELETE("/exchange/:id", h.CancelContactExchange)
 		contacts.GET("/exchange", h.ListContactExchangeRequests)
+		contacts.GET("/exchange/expiring", h.ListExpiringContactExchangeRequests)
+		contacts.POST("/exchange/status", h.GetContactExchangeStatuses)
 	}
 }
 
@@ -60,11 +68,10 @@ type ContactInfoDTO struct {
 	Restrictions    *SharingRestrictionsDTO  `json:"restrictions,omitempty"`
 }
 
+// EncryptedContactInfoDTO describes shared contact info without revealing it; the details
+// themselves stay encrypted
 type EncryptedContactInfoDTO struct {
-	Email               *string                  `json:"email,omitempty"`
-	Phone               *string                  `json:"phone,omitempty"`
 	PreferredMethod     string                   `json:"preferred_method" binding:"required"`
-	Message             *string                  `json:"message,omitempty"`
 	SharingRestrictions *SharingRestrictionsDTO  `json:"sharing_restrictions,omitempty"`
 }
 
@@ -74,27 +81,43 @@ type SharingRestrictionsDTO struct {
 	PlatformMediated  bool `json:"platform_mediated"`
 }
 
+// VerificationSubmissionDTO is the requester's proof: an answer to the security question or
+// photos, either of which admin approval accepts
+type VerificationSubmissionDTO struct {
+	Answer    *string  `json:"answer,omitempty"`
+	PhotoURLs []string `json:"photo_urls,omitempty"`
+}
+
 type DenyContactExchangeRequestDTO struct {
 	DenialReason  string  `json:"denial_reason" binding:"required"`
 	DenialMessage *string `json:"denial_message,omitempty"`
 }
 
+// maxStatusLookupRequests caps how many requests one status lookup may ask about
+const maxStatusLookupRequests = 100
+
+type ContactExchangeStatusLookupDTO struct {
+	RequestIDs []string `json:"request_ids" binding:"required,min=1,max=100"`
+}
+
 type ContactExchangeResponseDTO struct {
-	ID                   string                      `json:"id"`
-	PostID               string                      `json:"post_id"`
-	RequesterUserID      string                      `json:"requester_user_id"`
-	OwnerUserID          string                      `json:"owner_user_id"`
-	Status               string                      `json:"status"`
-	Message              *string                     `json:"message,omitempty"`
-	VerificationRequired bool                        `json:"verification_required"`
-	VerificationDetails  *VerificationDetailsDTO     `json:"verification_details,omitempty"`
-	ApprovalType         *string                     `json:"approval_type,omitempty"`
-	DenialReason         *string                     `json:"denial_reason,omitempty"`
-	DenialMessage        *string                     `json:"denial_message,omitempty"`
-	EncryptedContactInfo *EncryptedContactInfoDTO    `json:"contact_info,omitempty"`
-	ExpiresAt            string                      `json:"expires_at"`
-	CreatedAt            string                      `json:"created_at"`
-	UpdatedAt            string                      `json:"updated_at"`
+	ID                    string                     `json:"id"`
+	PostID                string                     `json:"post_id"`
+	RequesterUserID       string                     `json:"requester_user_id"`
+	OwnerUserID           string                     `json:"owner_user_id"`
+	Status                string                     `json:"status"`
+	Message               *string                    `json:"message,omitempty"`
+	VerificationRequired  bool                       `json:"verification_required"`
+	VerificationDetails   *VerificationDetailsDTO    `json:"verification_details,omitempty"`
+	VerificationCompleted bool                       `json:"verification_completed"`
+	VerificationProof     *VerificationSubmissionDTO `json:"verification_proof,omitempty"`
+	ApprovalType          *string                    `json:"approval_type,omitempty"`
+	DenialReason          *string                    `json:"denial_reason,omitempty"`
+	DenialMessage         *string                    `json:"denial_message,omitempty"`
+	EncryptedContactInfo  *EncryptedContactInfoDTO   `json:"contact_info,omitempty"`
+	ExpiresAt             string                     `json:"expires_at"`
+	CreatedAt             string                     `json:"created_at"`
+	UpdatedAt             string                     `json:"updated_at"`
 }
 
 // CreateContactExchangeRequest creates a new contact exchange request
@@ -105,6 +128,10 @@ func (h *ContactExchangeHandler) CreateContactExchangeRequest(c *gin.Context) {
 		return
 	}
 
+	if rejectInvalidMessage(c, req.Message) {
+		return
+	}
+
 	// Get user ID from context (assumes authentication middleware sets this)
 	userIDStr, exists := c.Get("user_id")
 	if !exists {
@@ -137,7 +164,13 @@ func (h *ContactExchangeHandler) CreateContactExchangeRequest(c *gin.Context) {
 	// Set default expiration if not provided
 	expirationHours := req.ExpirationHours
 	if expirationHours <= 0 {
-		expirationHours = 72 // Default 3 days
+		expirationHours = domain.DefaultContactExchangeExpirationHours
+	}
+	if maxHours := domain.MaxContactExchangeExpirationHours(); expirationHours > maxHours {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("expiration_hours must be at most %d", maxHours),
+		})
+		return
 	}
 
 	cmd := service.CreateContactExchangeCommand{
@@ -151,6 +184,20 @@ func (h *ContactExchangeHandler) CreateContactExchangeRequest(c *gin.Context) {
 
 	request, err := h.contactExchangeService.CreateContactExchangeRequest(c.Request.Context(), cmd)
 	if err != nil {
+		var postErr domain.PostError
+		if errors.As(err, &postErr) && postErr.Code == domain.ContactExchangeErrorAlreadyPending {
+			response := gin.H{"error": postErr.Message, "code": string(postErr.Code)}
+			// The existing request is unknown when a concurrent create won the race in the database
+			if request != nil {
+				response["request"] = h.toContactExchangeResponseDTO(request)
+			}
+			c.JSON(http.StatusConflict, response)
+			return
+		}
+		if errors.As(err, &postErr) && postErr.Code == domain.ContactExchangeErrorRateLimited {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": postErr.Message, "code": string(postErr.Code)})
+			return
+		}
 		if domain.IsPostError(err) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -163,7 +210,7 @@ func (h *ContactExchangeHandler) CreateContactExchangeRequest(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
-// GetContactExchangeRequest retrieves a contact exchange request by ID
+// GetContactExchangeRequest retrieves a contact exchange request by ID for one of its participants
 func (h *ContactExchangeHandler) GetContactExchangeRequest(c *gin.Context) {
 	requestID, err := domain.ContactExchangeRequestIDFromString(c.Param("id"))
 	if err != nil {
@@ -171,17 +218,7 @@ func (h *ContactExchangeHandler) GetContactExchangeRequest(c *gin.Context) {
 		return
 	}
 
-	request, err := h.contactExchangeService.GetContactExchangeRequest(c.Request.Context(), requestID)
-	if err != nil {
-		if domain.IsPostErrorCode(err, domain.ContactExchangeErrorNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Contact exchange request not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve contact exchange request"})
-		return
-	}
-
-	// Check if user is authorized to view this request
+	// Authenticate before the lookup so the response never depends on whether the request exists
 	userIDStr, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -194,8 +231,19 @@ func (h *ContactExchangeHandler) GetContactExchangeRequest(c *gin.Context) {
 		return
 	}
 
-	if !userID.Equals(request.RequesterUserID()) && !userID.Equals(request.OwnerUserID()) {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this request"})
+	request, err := h.contactExchangeService.GetContactExchangeRequest(c.Request.Context(), requestID)
+	if err != nil {
+		if domain.IsPostErrorCode(err, domain.ContactExchangeErrorNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contact exchange request not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve contact exchange request"})
+		return
+	}
+
+	// Non-participants get the same 404 as a missing request so they cannot probe for existence
+	if !request.CanBeViewedBy(userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Contact exchange request not found"})
 		return
 	}
 
@@ -217,6 +265,10 @@ func (h *ContactExchangeHandler) ApproveContactExchange(c *gin.Context) {
 		return
 	}
 
+	if rejectInvalidContactInfo(c, req.ContactInfo) || rejectInvalidMessage(c, req.ContactInfo.Message) {
+		return
+	}
+
 	// Get user ID from context
 	userIDStr, exists := c.Get("user_id")
 	if !exists {
@@ -241,12 +293,16 @@ func (h *ContactExchangeHandler) ApproveContactExchange(c *gin.Context) {
 		return
 	}
 
-	// Verify user is the owner
-	if !userID.Equals(request.OwnerUserID()) {
+	if !request.IsOwner(userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner can approve contact exchange requests"})
 		return
 	}
 
+	if !request.CanBeApprovedBy(userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Can only approve pending requests that have not expired"})
+		return
+	}
+
 	// Convert contact info
 	var contactInfo *domain.ContactInfo
 	if req.ContactInfo != nil {
@@ -276,6 +332,10 @@ func (h *ContactExchangeHandler) ApproveContactExchange(c *gin.Context) {
 
 	updatedRequest, err := h.contactExchangeService.ApproveContactExchange(c.Request.Context(), cmd)
 	if err != nil {
+		if domain.IsPostErrorCode(err, domain.ContactExchangeErrorNotVerified) {
+			c.JSON(http.StatusConflict, gin.H{"error": "The requester has not completed verification yet", "code": string(domain.ContactExchangeErrorNotVerified)})
+			return
+		}
 		if domain.IsPostError(err) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -288,6 +348,64 @@ func (h *ContactExchangeHandler) ApproveContactExchange(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// SubmitVerification records the requester's verification proof, or an organization admin's
+// approval of a request verified by admin approval
+func (h *ContactExchangeHandler) SubmitVerification(c *gin.Context) {
+	requestID, err := domain.ContactExchangeRequestIDFromString(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		return
+	}
+
+	var req VerificationSubmissionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	// Get user ID from context
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userID, err := domain.UserIDFromString(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	request, err := h.contactExchangeService.SubmitVerification(c.Request.Context(), service.SubmitVerificationCommand{
+		RequestID: requestID,
+		UserID:    userID,
+		Submission: domain.VerificationSubmission{
+			Answer:    req.Answer,
+			PhotoURLs: req.PhotoURLs,
+		},
+	})
+	if err != nil {
+		var postErr domain.PostError
+		if !errors.As(err, &postErr) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit verification"})
+			return
+		}
+		switch postErr.Code {
+		case domain.ContactExchangeErrorNotFound, domain.RepositoryErrorNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Contact exchange request not found"})
+		case domain.BusinessErrorUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the requester, or an admin for admin approval, can verify this request"})
+		case domain.ContactExchangeErrorInvalidVerification, domain.PhotoErrorInvalidURL:
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": postErr.Message, "code": string(postErr.Code)})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": postErr.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toContactExchangeResponseDTO(request))
+}
+
 // DenyContactExchange denies a contact exchange request
 func (h *ContactExchangeHandler) DenyContactExchange(c *gin.Context) {
 	requestID, err := domain.ContactExchangeRequestIDFromString(c.Param("id"))
@@ -302,6 +420,10 @@ func (h *ContactExchangeHandler) DenyContactExchange(c *gin.Context) {
 		return
 	}
 
+	if rejectInvalidMessage(c, req.DenialMessage) {
+		return
+	}
+
 	// Get user ID from context
 	userIDStr, exists := c.Get("user_id")
 	if !exists {
@@ -326,12 +448,16 @@ func (h *ContactExchangeHandler) DenyContactExchange(c *gin.Context) {
 		return
 	}
 
-	// Verify user is the owner
-	if !userID.Equals(request.OwnerUserID()) {
+	if !request.IsOwner(userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner can deny contact exchange requests"})
 		return
 	}
 
+	if !request.CanBeDeniedBy(userID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Can only deny pending requests"})
+		return
+	}
+
 	cmd := service.DenyContactExchangeCommand{
 		RequestID:     requestID,
 		DenialReason:  domain.DenialReason(req.DenialReason),
@@ -384,13 +510,12 @@ func (h *ContactExchangeHandler) CancelContactExchange(c *gin.Context) {
 		return
 	}
 
-	// Verify user is the requester and request is still pending
-	if !userID.Equals(request.RequesterUserID()) {
+	if !request.IsRequester(userID) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Only the requester can cancel this request"})
 		return
 	}
 
-	if request.Status() != domain.ContactExchangeStatusPending {
+	if !request.CanBeCancelledBy(userID) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Can only cancel pending requests"})
 		return
 	}
@@ -450,7 +575,11 @@ func (h *ContactExchangeHandler) ListContactExchangeRequests(c *gin.Context) {
 
 	// Status filter
 	if status := c.Query("status"); status != "" {
-		contactStatus := domain.ContactExchangeStatus(status)
+		contactStatus, err := domain.ContactExchangeStatusFromString(status)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Must be one of 'pending', 'approved', 'denied' or 'expired'"})
+			return
+		}
 		filters.Status = &contactStatus
 	}
 
@@ -465,23 +594,13 @@ func (h *ContactExchangeHandler) ListContactExchangeRequests(c *gin.Context) {
 	}
 
 	// Pagination
-	if limitStr := c.Query("limit"); limitStr != "" {
-		limit, err := strconv.Atoi(limitStr)
-		if err != nil || limit <= 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
-			return
-		}
-		filters.Limit = limit
-	}
-
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		offset, err := strconv.Atoi(offsetStr)
-		if err != nil || offset < 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
-			return
-		}
-		filters.Offset = offset
+	limit, offset, err := pagination.Parse(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
+	filters.Limit = limit
+	filters.Offset = offset
 
 	requests, err := h.contactExchangeService.ListContactExchangeRequests(c.Request.Context(), filters)
 	if err != nil {
@@ -503,18 +622,225 @@ func (h *ContactExchangeHandler) ListContactExchangeRequests(c *gin.Context) {
 	})
 }
 
+// ListExpiringContactExchangeRequests lists the pending and approved requests the user takes
+// part in that expire within within_hours, soonest first
+func (h *ContactExchangeHandler) ListExpiringContactExchangeRequests(c *gin.Context) {
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userID, err := domain.UserIDFromString(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	// Zero lets the service fall back to the configured reminder lead
+	var within time.Duration
+	if withinStr := c.Query("within_hours"); withinStr != "" {
+		hours, err := strconv.Atoi(withinStr)
+		if err != nil || hours <= 0 || hours > domain.MaxContactExchangeExpirationHours() {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("within_hours must be between 1 and %d", domain.MaxContactExchangeExpirationHours()),
+			})
+			return
+		}
+		within = time.Duration(hours) * time.Hour
+	}
+
+	requests, err := h.contactExchangeService.FindExpiringRequests(c.Request.Context(), userID, within)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list expiring contact exchange requests"})
+		return
+	}
+
+	responses := make([]ContactExchangeResponseDTO, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, h.toContactExchangeResponseDTO(request))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requests": responses})
+}
+
+// SendExpiryReminders runs the expiry reminder job. It is called by the scheduler through the
+// internal API and reports how many reminders went out.
+func (h *ContactExchangeHandler) SendExpiryReminders(c *gin.Context) {
+	result, err := h.contactExchangeService.SendExpiryReminders(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send expiry reminders", "result": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// maxReEncryptionBatchSize bounds how many requests a re-encryption run holds in memory at once
+const maxReEncryptionBatchSize = 1000
+
+// ReEncryptContactInfo moves stored contact info onto the active encryption key after a key
+// rotation and retires keys nothing uses anymore. It is triggered by an operator through the
+// internal API; batch_size defaults to the expiration batch size.
+func (h *ContactExchangeHandler) ReEncryptContactInfo(c *gin.Context) {
+	var batchSize int
+	if batchSizeStr := c.Query("batch_size"); batchSizeStr != "" {
+		size, err := strconv.Atoi(batchSizeStr)
+		if err != nil || size <= 0 || size > maxReEncryptionBatchSize {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("batch_size must be between 1 and %d", maxReEncryptionBatchSize),
+			})
+			return
+		}
+		batchSize = size
+	}
+
+	result, err := h.contactExchangeService.ReEncryptAll(c.Request.Context(), batchSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to re-encrypt contact info", "result": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// auditTrailPageBounds allows larger pages than other list endpoints, since the audit
+// trail is pulled in bulk for forensic review
+var auditTrailPageBounds = pagination.Bounds{DefaultLimit: 100, MaxLimit: 1000}
+
+// GetEncryptionAuditTrail returns encryption audit logs for forensic review
+func (h *ContactExchangeHandler) GetEncryptionAuditTrail(c *gin.Context) {
+	filters := domain.EncryptionAuditFilters{}
+
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := domain.UserIDFromString(userIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		filters.UserID = &userID
+	}
+
+	if requestIDStr := c.Query("request_id"); requestIDStr != "" {
+		requestID, err := domain.ContactExchangeRequestIDFromString(requestIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+			return
+		}
+		filters.RequestID = &requestID
+	}
+
+	if operation := c.Query("operation"); operation != "" {
+		op := domain.EncryptionOperation(operation)
+		if !op.IsValid() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation parameter"})
+			return
+		}
+		filters.Operation = &op
+	}
+
+	if successStr := c.Query("success"); successStr != "" {
+		success, err := strconv.ParseBool(successStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid success parameter"})
+			return
+		}
+		filters.Success = &success
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := domain.ParseTimestamp(fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from parameter, expected RFC3339 timestamp"})
+			return
+		}
+		filters.From = &from
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := domain.ParseTimestamp(toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to parameter, expected RFC3339 timestamp"})
+			return
+		}
+		filters.To = &to
+	}
+
+	if filters.From != nil && filters.To != nil && filters.From.After(*filters.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+
+	limit, offset, err := pagination.ParseWithBounds(c, auditTrailPageBounds)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filters.Limit = limit
+	filters.Offset = offset
+
+	filters.SetDefaults()
+
+	logs, total, err := h.contactExchangeService.GetEncryptionAuditTrail(c.Request.Context(), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve audit trail"})
+		return
+	}
+
+	if logs == nil {
+		logs = []*domain.EncryptionAuditLog{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"logs": logs,
+		"pagination": gin.H{
+			"limit":  filters.Limit,
+			"offset": filters.Offset,
+			"total":  total,
+		},
+	})
+}
+
+// rejectInvalidMessage responds with 422 when a message is longer than the configured limit
+func rejectInvalidMessage(c *gin.Context, message *string) bool {
+	var postErr domain.PostError
+	if err := domain.ValidateContactExchangeMessage(message); !errors.As(err, &postErr) {
+		return false
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": postErr.Message, "code": string(postErr.Code)})
+	return true
+}
+
+// rejectInvalidContactInfo responds with why contact info is missing, cannot be normalized or
+// does not offer its preferred method, before any of the request is looked up
+func rejectInvalidContactInfo(c *gin.Context, info *ContactInfoDTO) bool {
+	var postErr domain.PostError
+	var err error = domain.ErrNoContactChannel()
+	if info != nil {
+		_, err = domain.NormalizeContactInfo(domain.ContactInfo{Email: info.Email, Phone: info.Phone, PreferredMethod: info.PreferredMethod})
+	}
+	if !errors.As(err, &postErr) {
+		return false
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": postErr.Message, "code": string(postErr.Code)})
+	return true
+}
+
 func (h *ContactExchangeHandler) toContactExchangeResponseDTO(request *domain.ContactExchangeRequest) ContactExchangeResponseDTO {
 	response := ContactExchangeResponseDTO{
-		ID:                   request.ID().String(),
-		PostID:               request.PostID().String(),
-		RequesterUserID:      request.RequesterUserID().String(),
-		OwnerUserID:          request.OwnerUserID().String(),
-		Status:               string(request.Status()),
-		Message:              request.Message(),
-		VerificationRequired: request.VerificationRequired(),
-		ExpiresAt:            request.ExpiresAt().Format("2006-01-02T15:04:05Z07:00"),
-		CreatedAt:            request.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:            request.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		ID:                    request.ID().String(),
+		PostID:                request.PostID().String(),
+		RequesterUserID:       request.RequesterUserID().String(),
+		OwnerUserID:           request.OwnerUserID().String(),
+		Status:                string(request.Status()),
+		Message:               request.Message(),
+		VerificationRequired:  request.VerificationRequired(),
+		VerificationCompleted: request.VerificationCompleted(),
+		ExpiresAt:             domain.FormatTimestamp(request.ExpiresAt()),
+		CreatedAt:             domain.FormatTimestamp(request.CreatedAt()),
+		UpdatedAt:             domain.FormatTimestamp(request.UpdatedAt()),
 	}
 
 	if request.VerificationDetails() != nil {
@@ -525,6 +851,13 @@ func (h *ContactExchangeHandler) toContactExchangeResponseDTO(request *domain.Co
 		}
 	}
 
+	if submission := request.VerificationSubmission(); submission != nil {
+		response.VerificationProof = &VerificationSubmissionDTO{
+			Answer:    submission.Answer,
+			PhotoURLs: submission.PhotoURLs,
+		}
+	}
+
 	if request.ApprovalType() != nil {
 		approvalType := string(*request.ApprovalType())
 		response.ApprovalType = &approvalType
@@ -538,10 +871,7 @@ func (h *ContactExchangeHandler) toContactExchangeResponseDTO(request *domain.Co
 
 	if request.EncryptedContactInfo() != nil {
 		contactInfo := &EncryptedContactInfoDTO{
-			Email:           request.EncryptedContactInfo().Email,
-			Phone:           request.EncryptedContactInfo().Phone,
 			PreferredMethod: request.EncryptedContactInfo().PreferredMethod,
-			Message:         request.EncryptedContactInfo().Message,
 		}
 
 		if request.EncryptedContactInfo().SharingRestrictions != nil {
@@ -560,4 +890,52 @@ func (h *ContactExchangeHandler) toContactExchangeResponseDTO(request *domain.Co
 
 func stringPtr(s string) *string {
 	return &s
-}
\ No newline at end of file
+}
+
+// GetContactExchangeStatuses reports the status of several requests at once. Requests the
+// caller is not a requester or owner on, and unknown ones, are left out of the result.
+func (h *ContactExchangeHandler) GetContactExchangeStatuses(c *gin.Context) {
+	var req ContactExchangeStatusLookupDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   fmt.Sprintf("request_ids must list between 1 and %d request IDs", maxStatusLookupRequests),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	userIDStr, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userID, err := domain.UserIDFromString(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	requestIDs := make([]domain.ContactExchangeRequestID, 0, len(req.RequestIDs))
+	for _, idStr := range req.RequestIDs {
+		requestID, err := domain.ContactExchangeRequestIDFromString(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID", "details": idStr})
+			return
+		}
+		requestIDs = append(requestIDs, requestID)
+	}
+
+	statuses, err := h.contactExchangeService.GetRequestStatuses(c.Request.Context(), requestIDs, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up contact exchange request statuses"})
+		return
+	}
+
+	response := make(map[string]string, len(statuses))
+	for requestID, status := range statuses {
+		response[requestID.String()] = string(status)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"statuses": response})
+}
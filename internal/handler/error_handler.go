@@ -51,7 +51,7 @@ func handlePostError(c *gin.Context, err domain.PostError) {
 			Error: err.Message,
 			Code:  string(err.Code),
 		})
-	case "UNAUTHORIZED":
+	case "UNAUTHORIZED", domain.BusinessErrorUnauthorized:
 		c.JSON(http.StatusForbidden, ErrorResponse{
 			Error: err.Message,
 			Code:  string(err.Code),
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
+)
+
+// maxImportEvents bounds how many legacy posts one import request may carry
+const maxImportEvents = 1000
+
+// ImportHandler lets a migration import posts from the legacy system in bulk through the
+// internal API, instead of creating them one HTTP call at a time
+type ImportHandler struct {
+	importService *anti_corruption.PostImportService
+}
+
+func NewImportHandler(importService *anti_corruption.PostImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+type ImportPostsRequest struct {
+	Events []anti_corruption.ExternalPostCreatedEvent `json:"events" binding:"required,min=1,max=1000"`
+}
+
+// ImportPosts stores the posts of legacy created events and reports the records that could
+// not be imported, which never stop the rest
+func (h *ImportHandler) ImportPosts(c *gin.Context) {
+	var req ImportPostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "max_events": maxImportEvents})
+		return
+	}
+
+	report, err := h.importService.ImportPosts(c.Request.Context(), req.Events)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import posts", "report": report})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+)
+
+// ReadOnlyMode blocks writes while maintenance such as a migration runs. Its state is held per
+// instance, so it has to be switched on every replica.
+type ReadOnlyMode struct {
+	enabled    atomic.Bool
+	retryAfter time.Duration
+}
+
+func NewReadOnlyMode(cfg config.MaintenanceConfig) *ReadOnlyMode {
+	mode := &ReadOnlyMode{retryAfter: time.Duration(cfg.RetryAfterSeconds) * time.Second}
+	mode.enabled.Store(cfg.ReadOnly)
+	return mode
+}
+
+// Enabled reports whether writes are currently refused
+func (m *ReadOnlyMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled switches read-only mode on or off
+func (m *ReadOnlyMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// RetryAfter is how long clients are asked to wait before retrying a refused write
+func (m *ReadOnlyMode) RetryAfter() time.Duration {
+	return m.retryAfter
+}
+
+// MaintenanceHandler lets operators inspect and switch read-only mode
+type MaintenanceHandler struct {
+	readOnly *ReadOnlyMode
+}
+
+func NewMaintenanceHandler(readOnly *ReadOnlyMode) *MaintenanceHandler {
+	return &MaintenanceHandler{readOnly: readOnly}
+}
+
+type SetReadOnlyRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+type ReadOnlyResponse struct {
+	ReadOnly bool `json:"read_only"`
+}
+
+// GetReadOnly reports whether the service is refusing writes
+func (h *MaintenanceHandler) GetReadOnly(c *gin.Context) {
+	c.JSON(http.StatusOK, ReadOnlyResponse{ReadOnly: h.readOnly.Enabled()})
+}
+
+// SetReadOnly turns read-only mode on before a migration and off once it is done
+func (h *MaintenanceHandler) SetReadOnly(c *gin.Context) {
+	var req SetReadOnlyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.readOnly.SetEnabled(*req.Enabled)
+	log.Printf("Read-only mode set to %t", *req.Enabled)
+
+	c.JSON(http.StatusOK, ReadOnlyResponse{ReadOnly: h.readOnly.Enabled()})
+}
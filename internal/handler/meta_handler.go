@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// EnumsResponse lists the values clients may send for each enumerated field
+type EnumsResponse struct {
+	PostTypes                    []domain.PostType                    `json:"post_types"`
+	PostStatuses                 []domain.PostStatus                  `json:"post_statuses"`
+	ContactExchangeApprovalTypes []domain.ContactExchangeApprovalType `json:"contact_exchange_approval_types"`
+	DenialReasons                []domain.DenialReason                `json:"denial_reasons"`
+	VerificationMethods          []domain.VerificationMethod          `json:"verification_methods"`
+}
+
+// GetEnums returns the valid values of every enum so clients can build their choices
+// from the server instead of hardcoding them
+func GetEnums(c *gin.Context) {
+	c.JSON(http.StatusOK, EnumsResponse{
+		PostTypes:                    domain.PostTypes(),
+		PostStatuses:                 domain.PostStatuses(),
+		ContactExchangeApprovalTypes: domain.ContactExchangeApprovalTypes(),
+		DenialReasons:                domain.DenialReasons(),
+		VerificationMethods:          domain.VerificationMethods(),
+	})
+}
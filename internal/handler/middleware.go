@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// ReadConsistencyHeader lets clients request primary reads, e.g. right after a write
+const ReadConsistencyHeader = "X-Read-Consistency"
+
+// ReadConsistencyMiddleware routes all reads of mutating requests, and of requests
+// sending "X-Read-Consistency: strong", to the primary database
+func ReadConsistencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if requiresPrimaryReads(c.Request) {
+			c.Request = c.Request.WithContext(domain.WithPrimaryReads(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
+
+func requiresPrimaryReads(r *http.Request) bool {
+	if isSafeMethod(r.Method) {
+		return strings.EqualFold(r.Header.Get(ReadConsistencyHeader), "strong")
+	}
+	return true
+}
+
+// ClientIP returns the address of the client that made the request. X-Forwarded-For is only
+// honored when the request arrived from one of the engine's trusted proxies, so the result
+// cannot be spoofed by the client itself.
+func ClientIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// RequestOriginMiddleware records the client IP and user agent on the request context, where
+// services pick them up for audit logs
+func RequestOriginMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := domain.RequestOrigin{
+			IPAddress: ClientIP(c),
+			UserAgent: c.Request.UserAgent(),
+		}
+		c.Request = c.Request.WithContext(domain.WithRequestOrigin(c.Request.Context(), origin))
+		c.Next()
+	}
+}
+
+// ReadOnlyMiddleware refuses requests that may write with 503 and a Retry-After header while
+// read-only mode is on. Reads keep being served.
+func ReadOnlyMiddleware(mode *ReadOnlyMode) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !mode.Enabled() || isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(mode.RetryAfter().Seconds())))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Service is read-only for maintenance, please try again later"})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// InternalTokenHeader carries the shared secret on service-to-service requests
+const InternalTokenHeader = "X-Internal-Token"
+
+// InternalAuthMiddleware restricts a route group to callers presenting the internal API token.
+// With no token configured the routes are unreachable rather than open.
+func InternalAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := c.GetHeader(InternalTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid internal token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// UploadDeadlineMiddleware widens the connection read and write deadlines for routes that
+// receive large multipart bodies, so the server-wide timeouts can stay short for everything
+// else. Writers that cannot change deadlines, such as test recorders, are left as they are.
+func UploadDeadlineMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout > 0 {
+			deadline := time.Now().Add(timeout)
+			rc := http.NewResponseController(c.Writer)
+			_ = rc.SetReadDeadline(deadline)
+			_ = rc.SetWriteDeadline(deadline)
+		}
+		c.Next()
+	}
+}
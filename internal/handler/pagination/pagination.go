@@ -0,0 +1,50 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultLimit is the page size used when the client does not send one
+	DefaultLimit = 20
+	// MaxLimit is the largest page size accepted by list endpoints
+	MaxLimit = 100
+)
+
+// Bounds controls the default and maximum page size for an endpoint
+type Bounds struct {
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// DefaultBounds applies to every list endpoint unless it has a reason to differ
+var DefaultBounds = Bounds{DefaultLimit: DefaultLimit, MaxLimit: MaxLimit}
+
+// Parse reads the limit and offset query parameters using DefaultBounds
+func Parse(c *gin.Context) (limit, offset int, err error) {
+	return ParseWithBounds(c, DefaultBounds)
+}
+
+// ParseWithBounds reads the limit and offset query parameters. Missing values fall back to
+// the defaults; malformed or out-of-range values are rejected rather than clamped.
+func ParseWithBounds(c *gin.Context, bounds Bounds) (limit, offset int, err error) {
+	limit = bounds.DefaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > bounds.MaxLimit {
+			return 0, 0, fmt.Errorf("limit must be an integer between 1 and %d", bounds.MaxLimit)
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("offset must be a non-negative integer")
+		}
+	}
+
+	return limit, offset, nil
+}
@@ -1,26 +1,52 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/domain"
 	"github.com/jsarabia/fn-posts/internal/service"
 )
 
 type PhotoHandler struct {
-	postService *service.PostService
-	storage     StorageInterface
+	postService          *service.PostService
+	storage              StorageInterface
+	uploadConcurrency    int
+	maxFileSize          int64
+	metadataCacheControl string
 }
 
-func NewPhotoHandler(postService *service.PostService, storage StorageInterface) *PhotoHandler {
+func NewPhotoHandler(postService *service.PostService, storage StorageInterface, cfg *config.Config) *PhotoHandler {
+	uploadConcurrency := cfg.StorageConfig.UploadConcurrency
+	if uploadConcurrency <= 0 {
+		uploadConcurrency = 1
+	}
+
 	return &PhotoHandler{
-		postService: postService,
-		storage:     storage,
+		postService:          postService,
+		storage:              storage,
+		uploadConcurrency:    uploadConcurrency,
+		maxFileSize:          service.MaxFileSizeBytes(cfg.StorageConfig),
+		metadataCacheControl: photoMetadataCacheControl(cfg.StorageConfig.PhotoMetadataMaxAgeSeconds),
+	}
+}
+
+// photoMetadataCacheControl lets shared caches keep photo metadata for maxAge seconds. Captions
+// can still change, so without a max age clients must revalidate.
+func photoMetadataCacheControl(maxAge int) string {
+	if maxAge <= 0 {
+		return "no-cache"
 	}
+	return fmt.Sprintf("public, max-age=%d", maxAge)
 }
 
 type UploadPhotoResponse struct {
@@ -49,8 +75,8 @@ func (h *PhotoHandler) UploadPhoto(c *gin.Context) {
 		return
 	}
 
-	if len(files) > 10 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Maximum 10 photos allowed"})
+	if len(files) > domain.MaxPhotosPerPost {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Maximum %d photos allowed", domain.MaxPhotosPerPost)})
 		return
 	}
 
@@ -61,65 +87,85 @@ func (h *PhotoHandler) UploadPhoto(c *gin.Context) {
 	}
 
 	// Get organization ID from form or context
-	var organizationID *domain.OrganizationID
+	var orgIDPtr *uuid.UUID
 	if orgIDStr := c.PostForm("organization_id"); orgIDStr != "" {
 		if orgID, err := domain.OrganizationIDFromString(orgIDStr); err == nil {
-			organizationID = &orgID
+			orgUUID := orgID.UUID()
+			orgIDPtr = &orgUUID
 		}
 	}
 
+	uploads := h.uploadFiles(c.Request.Context(), files, postID, orgIDPtr)
+
 	var uploadedPhotos []UploadPhotoResponse
-	var errors []string
+	var uploadErrors []string
+	circuitOpen := false
 
+	// Photos are saved in the order they were sent so display_order follows the request,
+	// however the uploads finished
 	for i, fileHeader := range files {
-		file, err := fileHeader.Open()
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to open file %s: %v", fileHeader.Filename, err))
+		upload := uploads[i]
+
+		if circuitOpen || errors.Is(upload.err, service.ErrCircuitOpen) {
+			circuitOpen = true
+			if upload.result != nil {
+				h.storage.DeletePhoto(c.Request.Context(), upload.result.Filename)
+			}
 			continue
 		}
-		defer file.Close()
 
-		// Process upload
-		var orgIDPtr *uuid.UUID
-		if organizationID != nil {
-			orgID := organizationID.UUID()
-			orgIDPtr = &orgID
-		}
-		result, err := h.storage.UploadPhoto(c.Request.Context(), file, fileHeader, postID.UUID(), orgIDPtr)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to upload %s: %v", fileHeader.Filename, err))
+		if upload.err != nil {
+			uploadErrors = append(uploadErrors, upload.failure)
 			continue
 		}
 
 		// Create photo domain object
 		photoReq := domain.CreatePhotoRequest{
 			PostID:       postID,
-			URL:          result.URL,
+			URL:          upload.result.URL,
+			ThumbnailURL: upload.result.ThumbnailURL,
+			Filename:     upload.result.Filename,
 			Caption:      c.PostForm(fmt.Sprintf("caption_%d", i)),
 			DisplayOrder: i + 1,
-			Format:       result.Format,
-			SizeBytes:    result.Size,
+			Format:       upload.result.Format,
+			SizeBytes:    upload.result.Size,
+			Width:        upload.result.Width,
+			Height:       upload.result.Height,
 		}
 
-		photo, err := h.postService.AddPhotoToPost(c.Request.Context(), postID, photoReq)
+		photo, err := h.postService.AddPhotoToPost(c.Request.Context(), postID, userID, photoReq)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to save photo %s: %v", fileHeader.Filename, err))
+			// The caller may not add photos to this post at all, so none of the files are kept
+			var postErr domain.PostError
+			if errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized {
+				h.deleteUploads(c.Request.Context(), uploads[i:])
+				c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner or an organization admin can add photos"})
+				return
+			}
+			if errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorPostNotFound {
+				h.deleteUploads(c.Request.Context(), uploads[i:])
+				c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+				return
+			}
+
+			uploadErrors = append(uploadErrors, fmt.Sprintf("Failed to save photo %s: %v", fileHeader.Filename, err))
 			// Clean up uploaded file
-			h.storage.DeletePhoto(c.Request.Context(), result.Filename)
+			h.storage.DeletePhoto(c.Request.Context(), upload.result.Filename)
 			continue
 		}
 
 		uploadedPhotos = append(uploadedPhotos, UploadPhotoResponse{
-			Photo: PhotoResponse{
-				ID:           photo.ID().UUID(),
-				URL:          photo.URL(),
-				ThumbnailURL: photo.ThumbnailURL(),
-				Caption:      photo.Caption(),
-				DisplayOrder: photo.DisplayOrder(),
-				CreatedAt:    photo.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-			},
-			URL: result.URL,
+			Photo: toPhotoResponse(photo),
+			URL:   upload.result.URL,
+		})
+	}
+
+	if circuitOpen {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":           "Photo storage is temporarily unavailable",
+			"uploaded_photos": uploadedPhotos,
 		})
+		return
 	}
 
 	response := gin.H{
@@ -128,14 +174,84 @@ func (h *PhotoHandler) UploadPhoto(c *gin.Context) {
 		"total_count":     len(files),
 	}
 
-	if len(errors) > 0 {
-		response["errors"] = errors
+	if len(uploadErrors) > 0 {
+		response["errors"] = uploadErrors
 		c.JSON(http.StatusPartialContent, response)
 	} else {
 		c.JSON(http.StatusCreated, response)
 	}
 }
 
+// photoUpload is the outcome of sending one file to storage
+type photoUpload struct {
+	result  *service.UploadResult
+	err     error
+	failure string // Message reported to the client when err is set
+}
+
+// uploadFiles sends files to storage with at most uploadConcurrency in flight. Outcomes are
+// indexed like files. Once the storage circuit opens, uploads that have not started are skipped.
+func (h *PhotoHandler) uploadFiles(ctx context.Context, files []*multipart.FileHeader, postID domain.PostID, orgID *uuid.UUID) []photoUpload {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	uploads := make([]photoUpload, len(files))
+	slots := make(chan struct{}, h.uploadConcurrency)
+
+	var wg sync.WaitGroup
+	for i, fileHeader := range files {
+		wg.Add(1)
+		go func(i int, fileHeader *multipart.FileHeader) {
+			defer wg.Done()
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				uploads[i] = photoUpload{err: ctx.Err(), failure: fmt.Sprintf("Failed to upload %s: %v", fileHeader.Filename, ctx.Err())}
+				return
+			}
+
+			uploads[i] = h.uploadFile(ctx, fileHeader, postID, orgID)
+			if errors.Is(uploads[i].err, service.ErrCircuitOpen) {
+				cancel()
+			}
+		}(i, fileHeader)
+	}
+	wg.Wait()
+
+	return uploads
+}
+
+// deleteUploads removes the files of uploads that reached storage
+func (h *PhotoHandler) deleteUploads(ctx context.Context, uploads []photoUpload) {
+	for _, upload := range uploads {
+		if upload.result != nil {
+			h.storage.DeletePhoto(ctx, upload.result.Filename)
+		}
+	}
+}
+
+func (h *PhotoHandler) uploadFile(ctx context.Context, fileHeader *multipart.FileHeader, postID domain.PostID, orgID *uuid.UUID) photoUpload {
+	// Oversized files are refused before anything is sent to storage
+	if err := service.ValidateFileSize(fileHeader.Size, h.maxFileSize); err != nil {
+		return photoUpload{err: err, failure: fmt.Sprintf("Failed to upload %s: %v", fileHeader.Filename, err)}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return photoUpload{err: err, failure: fmt.Sprintf("Failed to open file %s: %v", fileHeader.Filename, err)}
+	}
+	defer file.Close()
+
+	result, err := h.storage.UploadPhoto(ctx, file, fileHeader, postID.UUID(), orgID)
+	if err != nil {
+		return photoUpload{err: err, failure: fmt.Sprintf("Failed to upload %s: %v", fileHeader.Filename, err)}
+	}
+
+	return photoUpload{result: result}
+}
+
 func (h *PhotoHandler) DeletePhoto(c *gin.Context) {
 	postIDStr := c.Param("postId")
 	postID, err := domain.PostIDFromString(postIDStr)
@@ -168,15 +284,149 @@ func (h *PhotoHandler) DeletePhoto(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
-func (h *PhotoHandler) getUserIDFromContext(c *gin.Context) domain.UserID {
-	// This would typically be set by authentication middleware
-	// For now, return a dummy UUID or get from header
-	if userIDStr := c.GetHeader("X-User-ID"); userIDStr != "" {
-		if userID, err := domain.UserIDFromString(userIDStr); err == nil {
-			return userID
+func (h *PhotoHandler) GetPhoto(c *gin.Context) {
+	postIDStr := c.Param("id")
+	postID, err := domain.PostIDFromString(postIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	photoIDStr := c.Param("photoId")
+	photoID, err := domain.PhotoIDFromString(photoIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid photo ID"})
+		return
+	}
+
+	photo, err := h.postService.GetPhoto(c.Request.Context(), postID, photoID, h.getUserIDFromContext(c))
+	if err != nil {
+		var postErr domain.PostError
+		if errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorPostNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Photo not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get photo"})
+		return
+	}
+
+	// Photos still processing are polled for their thumbnail, so they must not be cached
+	if photo.IsProcessed() {
+		c.Header("Cache-Control", h.metadataCacheControl)
+	} else {
+		c.Header("Cache-Control", "no-cache")
+	}
+	c.JSON(http.StatusOK, toPhotoResponse(photo))
+}
+
+type ListPhotosResponse struct {
+	Photos []PhotoResponse `json:"photos"`
+	// Processing counts the post's photos still waiting for a thumbnail, regardless of the filter
+	Processing int `json:"processing"`
+}
+
+// ListPhotos returns a post's photos with their processing status, so upload progress views can
+// show which photos are still in flight. ?processing=true keeps only those, false only the
+// finished ones.
+func (h *PhotoHandler) ListPhotos(c *gin.Context) {
+	postID, err := domain.PostIDFromString(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	var processingFilter *bool
+	if processingStr := c.Query("processing"); processingStr != "" {
+		processing, err := strconv.ParseBool(processingStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid processing filter. Must be 'true' or 'false'"})
+			return
+		}
+		processingFilter = &processing
+	}
+
+	post, err := h.postService.GetPostByID(c.Request.Context(), postID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if !post.IsVisibleTo(h.getUserIDFromContext(c)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		return
+	}
+
+	response := ListPhotosResponse{Photos: []PhotoResponse{}}
+	for _, photo := range post.Photos() {
+		processing := !photo.IsProcessed()
+		if processing {
+			response.Processing++
 		}
+		if processingFilter != nil && *processingFilter != processing {
+			continue
+		}
+		response.Photos = append(response.Photos, toPhotoResponse(&photo))
 	}
 
-	// In a real implementation, this would be extracted from JWT token
-	return domain.NewUserID() // Temporary for development
+	c.Header("Cache-Control", "no-cache")
+	c.JSON(http.StatusOK, response)
+}
+
+type UpdatePhotoCaptionRequest struct {
+	Caption string `json:"caption"`
+}
+
+func (h *PhotoHandler) UpdatePhotoCaption(c *gin.Context) {
+	postIDStr := c.Param("id")
+	postID, err := domain.PostIDFromString(postIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	photoIDStr := c.Param("photoId")
+	photoID, err := domain.PhotoIDFromString(photoIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid photo ID"})
+		return
+	}
+
+	var req UpdatePhotoCaptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	photo, err := h.postService.UpdatePhotoCaption(c.Request.Context(), postID, photoID, h.getUserIDFromContext(c), req.Caption)
+	if err != nil {
+		var postErr domain.PostError
+		if errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner or an organization admin can edit its photos"})
+			return
+		}
+		if errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorPostNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Photo not found"})
+			return
+		}
+		if strings.Contains(err.Error(), "invalid caption") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update photo"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toPhotoResponse(photo))
+}
+
+func (h *PhotoHandler) getUserIDFromContext(c *gin.Context) domain.UserID {
+	return AuthenticatedUserID(c)
 }
@@ -1,61 +1,143 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler/pagination"
 	"github.com/jsarabia/fn-posts/internal/service"
 )
 
+const invalidPostTypeMessage = "Invalid post type. Must be 'lost' or 'found'"
+
+const invalidPostStatusMessage = "Invalid status. Must be one of 'draft', 'active', 'resolved', 'expired', 'deleted' or 'archived'"
+
+const invalidSortMessage = "Invalid sort. Must be 'created_at' or 'bumped_at'"
+
+const invalidLanguageMessage = "Invalid language. Must be an ISO 639 language code such as 'en' or 'es'"
+
+var invalidTagsMessage = fmt.Sprintf("Invalid tags. A post may have at most %d tags of up to %d characters each", domain.MaxTagsPerPost, domain.MaxTagLength)
+
 type PostHandler struct {
-	postService *service.PostService
-	storage     StorageInterface
+	postService     *service.PostService
+	storage         StorageInterface
+	locationPrivacy config.LocationPrivacyConfig
+	search          config.SearchConfig
+	listing         config.ListingConfig
 }
 
-func NewPostHandler(postService *service.PostService, storage StorageInterface) *PostHandler {
+func NewPostHandler(postService *service.PostService, storage StorageInterface, cfg *config.Config) *PostHandler {
 	return &PostHandler{
-		postService: postService,
-		storage:     storage,
+		postService:     postService,
+		storage:         storage,
+		locationPrivacy: cfg.LocationPrivacy,
+		search:          cfg.Search,
+		listing:         cfg.Listing,
 	}
 }
 
 type CreatePostRequest struct {
-	Title          string  `form:"title" binding:"required,min=1,max=200"`
-	Description    string  `form:"description" binding:"max=2000"`
-	Latitude       float64 `form:"latitude" binding:"required,min=-90,max=90"`
-	Longitude      float64 `form:"longitude" binding:"required,min=-180,max=180"`
-	RadiusMeters   int     `form:"radius_meters" binding:"min=100,max=50000"`
-	Type           string  `form:"type" binding:"required"`
-	OrganizationID string  `form:"organization_id"`
+	Title          string   `form:"title" binding:"max=200"`
+	Description    string   `form:"description" binding:"max=2000"`
+	Latitude       *float64 `form:"latitude" binding:"required,latitude"`
+	Longitude      *float64 `form:"longitude" binding:"required,longitude"`
+	RadiusMeters   int      `form:"radius_meters" binding:"min=100,max=50000"`
+	Type           string   `form:"type" binding:"required"`
+	OrganizationID string   `form:"organization_id"`
+	Draft          bool     `form:"draft"`
+	// Language of the post text; the creator's preferred language when omitted
+	Language string `form:"language"`
+	// Tags are repeated form fields, stored lowercase
+	Tags []string `form:"tags"`
+	// AllowImplausibleLocation skips the null island and precision checks for a real
+	// position that happens to trip them
+	AllowImplausibleLocation bool `form:"allow_implausible_location"`
+}
+
+type SetCoverPhotoRequest struct {
+	PhotoID string `json:"photo_id" binding:"required"`
 }
 
 type UpdatePostRequest struct {
 	Title       string `json:"title" binding:"required,min=1,max=200"`
 	Description string `json:"description" binding:"max=2000"`
+	// Tags replace the post's tags when present; omit them to keep the current ones
+	Tags []string `json:"tags"`
+}
+
+// PatchPostRequest changes only the fields present in the body
+type PatchPostRequest struct {
+	Title        *string          `json:"title" binding:"omitempty,max=200"`
+	Description  *string          `json:"description" binding:"omitempty,max=2000"`
+	Location     *LocationRequest `json:"location"`
+	RadiusMeters *int             `json:"radius_meters"`
+	Type         *string          `json:"type"`
+	// AllowImplausibleLocation skips the null island and precision checks for a real
+	// position that happens to trip them
+	AllowImplausibleLocation bool `json:"allow_implausible_location"`
+}
+
+type LocationRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required,latitude"`
+	Longitude *float64 `json:"longitude" binding:"required,longitude"`
+}
+
+type TransferPostRequest struct {
+	NewOwnerID string `json:"new_owner_id" binding:"required"`
+}
+
+type ReemitPostEventRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+type ReemitPostEventResponse struct {
+	EventID   uuid.UUID        `json:"event_id"`
+	EventType domain.EventType `json:"event_type"`
+	PostID    uuid.UUID        `json:"post_id"`
 }
 
 type UpdatePostStatusRequest struct {
-	Status domain.PostStatus `json:"status" binding:"required"`
+	Status     domain.PostStatus      `json:"status" binding:"required"`
+	Resolution *PostResolutionRequest `json:"resolution,omitempty"`
+}
+
+// PostResolutionRequest describes how a post being resolved was resolved
+type PostResolutionRequest struct {
+	Type                  string  `json:"type" binding:"required"`
+	MatchID               *string `json:"match_id,omitempty" binding:"omitempty,max=255"`
+	UserSatisfactionScore *int    `json:"user_satisfaction_score,omitempty"`
 }
 
 type PostResponse struct {
-	ID             uuid.UUID         `json:"id"`
-	Title          string            `json:"title"`
-	Description    string            `json:"description"`
-	Photos         []PhotoResponse   `json:"photos"`
-	Location       domain.Location   `json:"location"`
-	RadiusMeters   int               `json:"radius_meters"`
-	Status         domain.PostStatus `json:"status"`
-	Type           domain.PostType   `json:"type"`
-	CreatedBy      uuid.UUID         `json:"created_by"`
-	OrganizationID *uuid.UUID        `json:"organization_id,omitempty"`
-	CreatedAt      string            `json:"created_at"`
-	UpdatedAt      string            `json:"updated_at"`
+	ID                  uuid.UUID         `json:"id"`
+	Title               string            `json:"title"`
+	Description         string            `json:"description"`
+	Photos              []PhotoResponse   `json:"photos"`
+	CoverPhotoID        *uuid.UUID        `json:"cover_photo_id,omitempty"`
+	Location            domain.Location   `json:"location"`
+	LocationApproximate bool              `json:"location_approximate,omitempty"`
+	RadiusMeters        int               `json:"radius_meters"`
+	Status              domain.PostStatus `json:"status"`
+	Type                domain.PostType   `json:"type"`
+	CreatedBy           uuid.UUID         `json:"created_by"`
+	OrganizationID      *uuid.UUID        `json:"organization_id,omitempty"`
+	Language            string            `json:"language,omitempty"`
+	Tags                []string          `json:"tags"`
+	CreatedAt           string            `json:"created_at"`
+	UpdatedAt           string            `json:"updated_at"`
+	// BumpedAt is when the owner last bumped the post, omitted if never
+	BumpedAt string `json:"bumped_at,omitempty"`
 }
 
 type PhotoResponse struct {
@@ -64,7 +146,12 @@ type PhotoResponse struct {
 	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
 	Caption      string    `json:"caption,omitempty"`
 	DisplayOrder int       `json:"display_order"`
-	CreatedAt    string    `json:"created_at"`
+	Format       string    `json:"format,omitempty"`
+	Width        int       `json:"width,omitempty"`
+	Height       int       `json:"height,omitempty"`
+	// ProcessingStatus is "processing" until the thumbnail and dimensions are stored, then "ready"
+	ProcessingStatus string `json:"processing_status"`
+	CreatedAt        string `json:"created_at"`
 }
 
 type ListPostsResponse struct {
@@ -72,6 +159,10 @@ type ListPostsResponse struct {
 	Total  int64          `json:"total"`
 	Limit  int            `json:"limit"`
 	Offset int            `json:"offset"`
+	// HasMore reports whether another page follows, without relying on Total
+	HasMore bool `json:"has_more"`
+	// NextCursor fetches the following page with ?cursor=; it is omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func (h *PostHandler) CreatePost(c *gin.Context) {
@@ -83,10 +174,19 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 
 	var req CreatePostRequest
 	if err := c.ShouldBind(&req); err != nil {
+		if respondLocationValidationError(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	// Drafts may be saved without a title; it is required again at publish time
+	if req.Title == "" && !req.Draft {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Title is required"})
+		return
+	}
+
 	userID := h.getUserIDFromContext(c)
 	if userID.IsZero() {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -94,16 +194,23 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 	}
 
 	// Create location from latitude/longitude
-	location, err := domain.NewLocation(req.Latitude, req.Longitude)
+	location, err := domain.NewLocation(*req.Latitude, *req.Longitude)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location coordinates"})
 		return
 	}
 
+	if !req.AllowImplausibleLocation {
+		if err := location.CheckPlausibility(); err != nil {
+			respondImplausibleLocation(c, err)
+			return
+		}
+	}
+
 	// Parse post type
-	postType := domain.PostType(req.Type)
-	if postType != domain.PostTypeLost && postType != domain.PostTypeFound {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post type. Must be 'lost' or 'found'"})
+	postType, err := domain.PostTypeFromString(req.Type)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidPostTypeMessage})
 		return
 	}
 
@@ -118,12 +225,28 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		organizationID = &orgID
 	}
 
-	// Process photo uploads (1-10 photos required)
+	language, err := domain.ParseLanguage(req.Language)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidLanguageMessage})
+		return
+	}
+
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidTagsMessage})
+		return
+	}
+
+	// Process photo uploads (at least one photo required, drafts may have none yet)
 	form := c.Request.MultipartForm
 	files := form.File["photos"]
 
-	if len(files) < 1 || len(files) > 10 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Must upload between 1 and 10 photos"})
+	minPhotos := 1
+	if req.Draft {
+		minPhotos = 0
+	}
+	if len(files) < minPhotos || len(files) > domain.MaxPhotosPerPost {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Must upload between %d and %d photos", minPhotos, domain.MaxPhotosPerPost)})
 		return
 	}
 
@@ -131,7 +254,7 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 	for i, fileHeader := range files {
 		// Validate file format
 		if !h.isValidPhotoFormat(fileHeader.Filename) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid photo format. Supported: jpg, jpeg, png, webp"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid photo format. Supported: " + strings.Join(domain.AllowedPhotoFormats(), ", ")})
 			return
 		}
 
@@ -162,10 +285,14 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		// Create photo domain object with real GCS URL
 		photoReq := domain.CreatePhotoRequest{
 			URL:          result.URL,
+			ThumbnailURL: result.ThumbnailURL,
+			Filename:     result.Filename,
 			Caption:      c.PostForm("captions[" + strconv.Itoa(i) + "]"), // Optional captions
 			DisplayOrder: i + 1,
 			Format:       result.Format,
 			SizeBytes:    result.Size,
+			Width:        result.Width,
+			Height:       result.Height,
 		}
 
 		photo, err := domain.NewPhoto(photoReq)
@@ -178,7 +305,11 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 	}
 
 	// Create post with photos
-	post, err := h.postService.CreatePost(
+	createPost := h.postService.CreatePost
+	if req.Draft {
+		createPost = h.postService.CreateDraftPost
+	}
+	post, err := createPost(
 		c.Request.Context(),
 		req.Title,
 		req.Description,
@@ -188,13 +319,15 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 		postType,
 		userID,
 		organizationID,
+		language,
+		tags,
 	)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, h.toPostResponse(post))
+	c.JSON(http.StatusCreated, h.toPostResponse(post, userID))
 }
 
 func (h *PostHandler) GetPost(c *gin.Context) {
@@ -211,60 +344,177 @@ func (h *PostHandler) GetPost(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, h.toPostResponse(post))
+	viewerID := h.getUserIDFromContext(c)
+	if !post.IsVisibleTo(viewerID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		return
+	}
+
+	setPostValidators(c, post)
+	c.JSON(http.StatusOK, h.toPostResponse(post, viewerID))
 }
 
-func (h *PostHandler) UpdatePost(c *gin.Context) {
-	idStr := c.Param("id")
+// HeadPost tells clients whether a post still exists, with its validators, without sending
+// it. Posts the caller cannot see are reported missing as they are by GetPost.
+func (h *PostHandler) HeadPost(c *gin.Context) {
+	id, err := domain.PostIDFromString(c.Param("id"))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.postService.GetPostByID(c.Request.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	if !post.IsVisibleTo(h.getUserIDFromContext(c)) {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	setPostValidators(c, post)
+	c.Status(http.StatusOK)
+}
+
+// PublishPost turns the caller's draft into an active post
+func (h *PostHandler) PublishPost(c *gin.Context) {
+	idStr := c.Param("postId")
 	id, err := domain.PostIDFromString(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
 		return
 	}
 
-	var req UpdatePostRequest
+	userID := h.getUserIDFromContext(c)
+	post, err := h.postService.PublishPost(c.Request.Context(), id, userID)
+	if err != nil {
+		var postErr domain.PostError
+		switch {
+		case errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		case errors.As(err, &postErr):
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Error: postErr.Message,
+				Code:  string(postErr.Code),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to publish post"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toPostResponse(post, userID))
+}
+
+// TransferPost reassigns an organization post to another member; only organization admins may do so
+func (h *PostHandler) TransferPost(c *gin.Context) {
+	idStr := c.Param("postId")
+	id, err := domain.PostIDFromString(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	var req TransferPostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	post, err := h.postService.UpdatePost(c.Request.Context(), id, req.Title, req.Description)
+	newOwnerID, err := domain.UserIDFromString(req.NewOwnerID)
 	if err != nil {
-		HandleError(c, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid new owner ID"})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	post, err := h.postService.TransferPostOwnership(c.Request.Context(), id, userID, newOwnerID)
+	if err != nil {
+		var postErr domain.PostError
+		switch {
+		case errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only organization admins can transfer posts"})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		case errors.As(err, &postErr):
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Error: postErr.Message,
+				Code:  string(postErr.Code),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to transfer post"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, h.toPostResponse(post))
+	setPostValidators(c, post)
+	c.JSON(http.StatusOK, h.toPostResponse(post, userID))
 }
 
-func (h *PostHandler) UpdatePostStatus(c *gin.Context) {
-	idStr := c.Param("id")
+// BumpPost moves the caller's active post back to the top of listings sorted by bump time.
+// A post may be bumped once per configured interval; earlier attempts get 429 with the time
+// the next bump is allowed.
+func (h *PostHandler) BumpPost(c *gin.Context) {
+	idStr := c.Param("postId")
 	id, err := domain.PostIDFromString(idStr)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
 		return
 	}
 
-	var req UpdatePostStatusRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	userID := h.getUserIDFromContext(c)
+	if userID.IsZero() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
 	}
 
-	post, err := h.postService.UpdatePostStatus(c.Request.Context(), id, req.Status)
+	post, err := h.postService.BumpPost(c.Request.Context(), id, userID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		var postErr domain.PostError
+		switch {
+		case errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorPostNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
-			return
+		case errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner can bump it"})
+		case errors.As(err, &postErr) && postErr.Code == domain.PostErrorBumpTooSoon:
+			nextBumpAt, _ := postErr.Details["next_bump_at"].(time.Time)
+			retryAfter := int(time.Until(nextBumpAt).Seconds()) + 1
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":        postErr.Message,
+				"code":         string(postErr.Code),
+				"next_bump_at": domain.FormatTimestamp(nextBumpAt),
+			})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		case errors.As(err, &postErr):
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Error: postErr.Message,
+				Code:  string(postErr.Code),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to bump post"})
 		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, h.toPostResponse(post))
+	setPostValidators(c, post)
+	c.JSON(http.StatusOK, h.toPostResponse(post, userID))
 }
 
-func (h *PostHandler) DeletePost(c *gin.Context) {
+// GetPostEvent returns the fat post created payload rebuilt from the post's current state
+func (h *PostHandler) GetPostEvent(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := domain.PostIDFromString(idStr)
 	if err != nil {
@@ -272,255 +522,1143 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 		return
 	}
 
-	if err := h.postService.DeletePost(c.Request.Context(), id); err != nil {
+	eventData, err := h.postService.GetPostCreatedEventData(c.Request.Context(), id)
+	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete post"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build post event"})
 		return
 	}
 
-	c.JSON(http.StatusNoContent, nil)
+	c.JSON(http.StatusOK, eventData)
 }
 
-func (h *PostHandler) ListPosts(c *gin.Context) {
-	filters := h.parseFiltersFromQuery(c)
-
-	posts, err := h.postService.ListPosts(c.Request.Context(), filters)
+// ReemitPostEvent publishes a post's fat event again, rebuilt from its current state and
+// tagged as a replay, for a consumer that missed the original
+func (h *PostHandler) ReemitPostEvent(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := domain.PostIDFromString(idStr)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list posts"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
 		return
 	}
 
-	total, err := h.postService.CountPosts(c.Request.Context(), filters)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count posts"})
-		return
+	var req ReemitPostEventRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+			return
+		}
 	}
 
-	response := ListPostsResponse{
-		Posts:  h.toPostResponses(posts),
-		Total:  total,
-		Limit:  filters.Limit,
-		Offset: filters.Offset,
+	event, err := h.postService.ReemitPostEvent(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to re-emit post event"})
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusAccepted, ReemitPostEventResponse{
+		EventID:   event.ID,
+		EventType: event.EventType,
+		PostID:    event.PostID.UUID(),
+	})
 }
 
-func (h *PostHandler) SearchNearbyPosts(c *gin.Context) {
-	latStr := c.Query("lat")
-	lngStr := c.Query("lng")
-	radiusStr := c.Query("radius")
+func (h *PostHandler) UpdatePost(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := domain.PostIDFromString(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
 
-	if latStr == "" || lngStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lat and lng parameters are required"})
+	var req UpdatePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	lat, err := strconv.ParseFloat(latStr, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid latitude"})
+	if req.Tags != nil {
+		if _, err := domain.NormalizeTags(req.Tags); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidTagsMessage})
+			return
+		}
+	}
+
+	if !h.checkPostPreconditions(c, id) {
 		return
 	}
 
-	lng, err := strconv.ParseFloat(lngStr, 64)
+	userID := h.getUserIDFromContext(c)
+	post, err := h.postService.UpdatePost(c.Request.Context(), id, userID, domain.PostUpdate{
+		Title:       &req.Title,
+		Description: &req.Description,
+		Tags:        req.Tags,
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid longitude"})
+		if respondPostModified(c, err) {
+			return
+		}
+		HandleError(c, err)
 		return
 	}
 
-	location, err := domain.NewLocation(lat, lng)
+	setPostValidators(c, post)
+	c.JSON(http.StatusOK, h.toPostResponse(post, userID))
+}
+
+// PatchPost changes the title, description, location, radius or type of a post. Only the
+// fields sent are changed; the rest keep their current value.
+func (h *PostHandler) PatchPost(c *gin.Context) {
+	id, err := domain.PostIDFromString(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
 		return
 	}
 
-	radius := 1000 // Default 1km
-	if radiusStr != "" {
-		if r, err := strconv.Atoi(radiusStr); err == nil {
-			radius = r
+	var req PatchPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if respondLocationValidationError(c, err) {
+			return
 		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	var postType *domain.PostType
-	if typeStr := c.Query("type"); typeStr != "" {
-		pt := domain.PostType(typeStr)
-		postType = &pt
+	update := domain.PostUpdate{
+		Title:        req.Title,
+		Description:  req.Description,
+		RadiusMeters: req.RadiusMeters,
 	}
 
-	limit := 20
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
+	if req.Location != nil {
+		location, err := domain.NewLocation(*req.Location.Latitude, *req.Location.Longitude)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location coordinates"})
+			return
+		}
+		if !req.AllowImplausibleLocation {
+			if err := location.CheckPlausibility(); err != nil {
+				respondImplausibleLocation(c, err)
+				return
+			}
 		}
+		update.Location = &location
 	}
 
-	offset := 0
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
+	if req.Type != nil {
+		postType, err := domain.PostTypeFromString(*req.Type)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidPostTypeMessage})
+			return
 		}
+		update.Type = &postType
+	}
+
+	if update.Title == nil && update.Description == nil && update.Location == nil &&
+		update.RadiusMeters == nil && update.Type == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		return
+	}
+
+	if !h.checkPostPreconditions(c, id) {
+		return
 	}
 
-	posts, err := h.postService.SearchNearbyPosts(c.Request.Context(), location, radius, postType, limit, offset)
+	userID := h.getUserIDFromContext(c)
+	post, err := h.postService.UpdatePost(c.Request.Context(), id, userID, update)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search nearby posts"})
+		if respondPostModified(c, err) {
+			return
+		}
+		var postErr domain.PostError
+		switch {
+		case errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner or an organization admin can edit it"})
+		case strings.Contains(err.Error(), "not found"):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		case errors.As(err, &postErr):
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Error: postErr.Message,
+				Code:  string(postErr.Code),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update post"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"posts":  h.toPostResponses(posts),
-		"count":  len(posts),
-		"limit":  limit,
-		"offset": offset,
-	})
+	setPostValidators(c, post)
+	c.JSON(http.StatusOK, h.toPostResponse(post, userID))
 }
 
-func (h *PostHandler) GetUserPosts(c *gin.Context) {
-	userIDStr := c.Param("userId")
-	userID, err := domain.UserIDFromString(userIDStr)
+// SetCoverPhoto makes one of the post's photos its cover
+func (h *PostHandler) SetCoverPhoto(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := domain.PostIDFromString(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
 		return
 	}
 
-	limit := 20
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
+	var req SetCoverPhotoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	offset := 0
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	photoID, err := domain.PhotoIDFromString(req.PhotoID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid photo ID"})
+		return
+	}
+
+	if !h.checkPostPreconditions(c, id) {
+		return
 	}
 
-	posts, err := h.postService.GetPostsByUser(c.Request.Context(), userID, limit, offset)
+	userID := h.getUserIDFromContext(c)
+	post, err := h.postService.SetCoverPhoto(c.Request.Context(), id, photoID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user posts"})
+		if respondPostModified(c, err) {
+			return
+		}
+		var postErr domain.PostError
+		switch {
+		case errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner or an organization admin can choose its cover photo"})
+		case errors.As(err, &postErr) && postErr.Code == domain.PhotoErrorNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Photo not found"})
+		case errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorPostNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set cover photo"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"posts":  h.toPostResponses(posts),
-		"count":  len(posts),
-		"limit":  limit,
-		"offset": offset,
-	})
+	setPostValidators(c, post)
+	c.JSON(http.StatusOK, h.toPostResponse(post, userID))
 }
 
-func (h *PostHandler) parseFiltersFromQuery(c *gin.Context) domain.PostFilters {
-	filters := domain.PostFilters{}
+func (h *PostHandler) UpdatePostStatus(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := domain.PostIDFromString(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
 
-	if status := c.Query("status"); status != "" {
-		s := domain.PostStatus(status)
-		filters.Status = &s
+	var req UpdatePostStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	if postType := c.Query("type"); postType != "" {
-		t := domain.PostType(postType)
-		filters.Type = &t
+	if !req.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidPostStatusMessage})
+		return
 	}
 
-	if userIDStr := c.Query("user_id"); userIDStr != "" {
-		if userID, err := domain.UserIDFromString(userIDStr); err == nil {
-			filters.UserID = &userID
-		}
+	if !h.checkPostPreconditions(c, id) {
+		return
 	}
 
-	if orgIDStr := c.Query("organization_id"); orgIDStr != "" {
-		if orgID, err := domain.OrganizationIDFromString(orgIDStr); err == nil {
-			filters.OrganizationID = &orgID
+	var resolution *domain.ResolutionDetails
+	if req.Resolution != nil {
+		resolution = &domain.ResolutionDetails{
+			Type:                  req.Resolution.Type,
+			MatchID:               req.Resolution.MatchID,
+			UserSatisfactionScore: req.Resolution.UserSatisfactionScore,
 		}
 	}
 
-	if limit := c.Query("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil {
-			filters.Limit = l
+	userID := h.getUserIDFromContext(c)
+	post, err := h.postService.UpdatePostStatus(c.Request.Context(), id, userID, req.Status, resolution)
+	if err != nil {
+		if respondPostModified(c, err) {
+			return
+		}
+		var postErr domain.PostError
+		if errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner or an organization admin can change its status"})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
 		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	if offset := c.Query("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil {
-			filters.Offset = o
-		}
+	if post.IsArchived() {
+		h.archivePhotos(c.Request.Context(), post)
 	}
 
-	return filters
+	setPostValidators(c, post)
+	c.JSON(http.StatusOK, h.toPostResponse(post, userID))
 }
 
-func (h *PostHandler) toPostResponse(post *domain.Post) PostResponse {
-	photos := make([]PhotoResponse, len(post.Photos()))
-	for i, photo := range post.Photos() {
-		photos[i] = PhotoResponse{
-			ID:           photo.ID().UUID(),
-			URL:          photo.URL(),
-			ThumbnailURL: photo.ThumbnailURL(),
-			Caption:      photo.Caption(),
-			DisplayOrder: photo.DisplayOrder(),
-			CreatedAt:    photo.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+// archivePhotos moves an archived post's photos to cold storage. Failures are logged rather
+// than returned since the status change has already been saved.
+func (h *PostHandler) archivePhotos(ctx context.Context, post *domain.Post) {
+	for _, photo := range post.Photos() {
+		for _, url := range []string{photo.URL(), photo.ThumbnailURL()} {
+			if url == "" {
+				continue
+			}
+			if err := h.storage.ArchivePhoto(ctx, url); err != nil {
+				log.Printf("Failed to archive photo %s of post %s: %v", photo.ID(), post.ID(), err)
+			}
 		}
 	}
+}
 
-	var orgID *uuid.UUID
-	if post.OrganizationID() != nil {
+func (h *PostHandler) DeletePost(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := domain.PostIDFromString(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	if !h.checkPostPreconditions(c, id) {
+		return
+	}
+
+	if err := h.postService.DeletePost(c.Request.Context(), id, h.getUserIDFromContext(c)); err != nil {
+		if respondPostModified(c, err) {
+			return
+		}
+		var postErr domain.PostError
+		if errors.As(err, &postErr) && postErr.Code == domain.BusinessErrorUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the post owner or an organization admin can delete it"})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete post"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (h *PostHandler) ListPosts(c *gin.Context) {
+	filters, err := h.parseFiltersFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithPostPage(c, filters)
+}
+
+// ListOrganizationPosts lists an organization's posts to its members. The organization and
+// the draft visibility of the member's role are enforced whatever the query asks for.
+func (h *PostHandler) ListOrganizationPosts(c *gin.Context) {
+	orgID, err := domain.OrganizationIDFromString(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID.IsZero() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	filters, err := h.parseFiltersFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filters, err = h.postService.OrganizationPostFilters(c.Request.Context(), orgID, userID, filters)
+	if err != nil {
+		if domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only organization members can list its posts"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list organization posts"})
+		return
+	}
+
+	h.respondWithPostPage(c, filters)
+}
+
+// respondWithPostPage writes one page of posts matching filters with its total and next cursor
+func (h *PostHandler) respondWithPostPage(c *gin.Context, filters domain.PostFilters) {
+	posts, next, err := h.postService.ListPostsPage(c.Request.Context(), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list posts"})
+		return
+	}
+
+	total, err := h.postService.CountPosts(c.Request.Context(), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count posts"})
+		return
+	}
+
+	response := ListPostsResponse{
+		Posts:  h.toPostResponses(posts, h.getUserIDFromContext(c)),
+		Total:  total,
+		Limit:  filters.Limit,
+		Offset: filters.Offset,
+	}
+	if next != nil {
+		response.HasMore = true
+		response.NextCursor = next.Encode()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *PostHandler) SearchNearbyPosts(c *gin.Context) {
+	latStr := c.Query("lat")
+	lngStr := c.Query("lng")
+
+	if latStr == "" || lngStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lat and lng parameters are required"})
+		return
+	}
+
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid latitude"})
+		return
+	}
+
+	lng, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid longitude"})
+		return
+	}
+
+	location, err := domain.NewLocation(lat, lng)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	search, ok := h.parseNearbySearch(c)
+	if !ok {
+		return
+	}
+
+	posts, hasMore, err := h.postService.SearchNearbyPosts(c.Request.Context(), location, search.radius, search.postType, search.respectPostRadius, h.search.MaxNearbyCandidates, search.limit, search.offset, search.maxPhotos)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search nearby posts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, search.response(h.toPostResponses(posts, h.getUserIDFromContext(c)), hasMore))
+}
+
+// LandmarkResponse is the landmark a search term resolved to and the center searched around
+type LandmarkResponse struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// SearchPostsNearLandmark searches around a landmark named in the path, like
+// /posts/near-landmark/central%20park, taking the same query parameters as SearchNearbyPosts
+func (h *PostHandler) SearchPostsNearLandmark(c *gin.Context) {
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Landmark name is required"})
+		return
+	}
+
+	search, ok := h.parseNearbySearch(c)
+	if !ok {
+		return
+	}
+
+	landmark, posts, hasMore, err := h.postService.SearchPostsNearLandmark(c.Request.Context(), name, search.radius, search.postType, search.respectPostRadius, h.search.MaxNearbyCandidates, search.limit, search.offset, search.maxPhotos)
+	if err != nil {
+		if domain.IsPostErrorCode(err, domain.LocationErrorUnknownLandmark) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No landmark matches that name", "code": string(domain.LocationErrorUnknownLandmark)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search posts near landmark"})
+		return
+	}
+
+	response := search.response(h.toPostResponses(posts, h.getUserIDFromContext(c)), hasMore)
+	response["landmark"] = LandmarkResponse{
+		Name:      landmark.Name,
+		Latitude:  landmark.Location.Latitude,
+		Longitude: landmark.Location.Longitude,
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// nearbySearch holds the query parameters a search around a point takes besides the point
+type nearbySearch struct {
+	radius            int
+	postType          *domain.PostType
+	respectPostRadius bool
+	limit             int
+	offset            int
+	maxPhotos         int
+}
+
+// parseNearbySearch reads the radius, type, respect_post_radius, paging and photo parameters,
+// answering 400 and reporting false when one is invalid
+func (h *PostHandler) parseNearbySearch(c *gin.Context) (nearbySearch, bool) {
+	search := nearbySearch{radius: h.search.DefaultRadiusMeters}
+	if radiusStr := c.Query("radius"); radiusStr != "" {
+		if r, err := strconv.Atoi(radiusStr); err == nil && r > 0 {
+			search.radius = r
+		}
+	}
+	if search.radius > h.search.MaxRadiusMeters {
+		search.radius = h.search.MaxRadiusMeters
+	}
+
+	if typeStr := c.Query("type"); typeStr != "" {
+		pt, err := domain.PostTypeFromString(typeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidPostTypeMessage})
+			return nearbySearch{}, false
+		}
+		search.postType = &pt
+	}
+
+	// Posts are limited to their own radius as configured, unless the request says otherwise
+	search.respectPostRadius = h.search.RespectPostRadius
+	if respectStr := c.Query("respect_post_radius"); respectStr != "" {
+		respect, err := strconv.ParseBool(respectStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid respect_post_radius parameter. Must be 'true' or 'false'"})
+			return nearbySearch{}, false
+		}
+		search.respectPostRadius = respect
+	}
+
+	var err error
+	search.limit, search.offset, err = pagination.Parse(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nearbySearch{}, false
+	}
+
+	search.maxPhotos, err = h.maxPhotosFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nearbySearch{}, false
+	}
+
+	return search, true
+}
+
+func (s nearbySearch) response(posts []PostResponse, hasMore bool) gin.H {
+	return gin.H{
+		"posts":         posts,
+		"count":         len(posts),
+		"radius_meters": s.radius,
+		"limit":         s.limit,
+		"offset":        s.offset,
+		"has_more":      hasMore,
+	}
+}
+
+type RoutePointRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required,latitude"`
+	Longitude *float64 `json:"longitude" binding:"required,longitude"`
+}
+
+type AlongRouteSearchRequest struct {
+	Points         []RoutePointRequest `json:"points" binding:"required,dive"`
+	CorridorMeters int                 `json:"corridor_meters"`
+	Type           string              `json:"type"`
+}
+
+// SearchPostsAlongRoute finds active posts near an ordered list of route points, returned
+// in the order they are passed along the route
+func (h *PostHandler) SearchPostsAlongRoute(c *gin.Context) {
+	var req AlongRouteSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		if respondLocationValidationError(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format", "details": err.Error()})
+		return
+	}
+
+	points := make([]domain.Location, len(req.Points))
+	for i, point := range req.Points {
+		points[i] = domain.Location{Latitude: *point.Latitude, Longitude: *point.Longitude}
+	}
+
+	route, err := domain.NewRoute(points)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	corridor := h.search.DefaultCorridorMeters
+	if req.CorridorMeters > 0 {
+		corridor = req.CorridorMeters
+	}
+	if corridor > h.search.MaxCorridorMeters {
+		corridor = h.search.MaxCorridorMeters
+	}
+
+	var postType *domain.PostType
+	if req.Type != "" {
+		pt, err := domain.PostTypeFromString(req.Type)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidPostTypeMessage})
+			return
+		}
+		postType = &pt
+	}
+
+	limit, offset, err := pagination.Parse(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	maxPhotos, err := h.maxPhotosFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	posts, err := h.postService.SearchPostsAlongRoute(c.Request.Context(), route, corridor, postType, limit, offset, maxPhotos)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search posts along route"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts":               h.toPostResponses(posts, h.getUserIDFromContext(c)),
+		"count":               len(posts),
+		"corridor_meters":     corridor,
+		"route_length_meters": route.Length().Meters,
+		"limit":               limit,
+		"offset":              offset,
+	})
+}
+
+type HeatmapCellResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Count     int64   `json:"count"`
+}
+
+// GetHeatmap returns post counts per grid cell so clients can render density maps.
+// bbox is "minLng,minLat,maxLng,maxLat" and grid is the cell size in degrees.
+func (h *PostHandler) GetHeatmap(c *gin.Context) {
+	bboxStr := c.Query("bbox")
+	if bboxStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bbox parameter is required"})
+		return
+	}
+
+	var coords [4]float64
+	parts := strings.Split(bboxStr, ",")
+	if len(parts) != 4 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bbox must be minLng,minLat,maxLng,maxLat"})
+		return
+	}
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "bbox must be minLng,minLat,maxLng,maxLat"})
+			return
+		}
+		coords[i] = value
+	}
+
+	box, err := domain.NewGeoBoundingBox(coords[0], coords[1], coords[2], coords[3])
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filters := domain.HeatmapFilters{BoundingBox: box}
+
+	if gridStr := c.Query("grid"); gridStr != "" {
+		grid, err := strconv.ParseFloat(gridStr, 64)
+		if err != nil || grid <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid grid size"})
+			return
+		}
+		filters.GridDegrees = grid
+	}
+
+	if postType := c.Query("type"); postType != "" {
+		t, err := domain.PostTypeFromString(postType)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidPostTypeMessage})
+			return
+		}
+		filters.Type = &t
+	}
+
+	if status := c.Query("status"); status != "" {
+		s, err := domain.PostStatusFromString(status)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidPostStatusMessage})
+			return
+		}
+		filters.Status = &s
+	}
+
+	if after := c.Query("created_after"); after != "" {
+		t, err := domain.ParseTimestamp(after)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "created_after must be an RFC3339 timestamp"})
+			return
+		}
+		filters.CreatedAfter = &t
+	}
+
+	if before := c.Query("created_before"); before != "" {
+		t, err := domain.ParseTimestamp(before)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "created_before must be an RFC3339 timestamp"})
+			return
+		}
+		filters.CreatedBefore = &t
+	}
+
+	filters.SetDefaults()
+	filters.ClampGrid(h.locationPrivacy.PrecisionDegrees)
+
+	cells, err := h.postService.GetHeatmap(c.Request.Context(), filters)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid heatmap request") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build heatmap"})
+		return
+	}
+
+	response := make([]HeatmapCellResponse, len(cells))
+	var total int64
+	for i, cell := range cells {
+		response[i] = HeatmapCellResponse{
+			Latitude:  cell.Location.Latitude,
+			Longitude: cell.Location.Longitude,
+			Count:     cell.Count,
+		}
+		total += cell.Count
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cells": response,
+		"total": total,
+		"grid":  filters.GridDegrees,
+		"bbox":  coords,
+	})
+}
+
+func (h *PostHandler) GetUserPosts(c *gin.Context) {
+	userIDStr := c.Param("userId")
+	userID, err := domain.UserIDFromString(userIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	limit, offset, err := pagination.Parse(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	viewerID := h.getUserIDFromContext(c)
+	maxPhotos, err := h.maxPhotosFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	posts, err := h.postService.GetPostsByUser(c.Request.Context(), userID, viewerID, limit, offset, maxPhotos)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user posts"})
+		return
+	}
+
+	total, err := h.postService.CountPostsByUser(c.Request.Context(), userID, viewerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count user posts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts":  h.toPostResponses(posts, viewerID),
+		"count":  len(posts),
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// OrganizationStatsResponse reports posting activity in an organization
+type OrganizationStatsResponse struct {
+	OrganizationID  string `json:"organization_id"`
+	Since           string `json:"since"`
+	DistinctPosters int64  `json:"distinct_posters"`
+}
+
+// GetOrganizationStats reports engagement for an organization dashboard. Activity is counted
+// from the start of the current month (UTC) unless since is given.
+func (h *PostHandler) GetOrganizationStats(c *gin.Context) {
+	orgID, err := domain.OrganizationIDFromString(c.Param("orgId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID.IsZero() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		since, err = domain.ParseTimestamp(sinceStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC3339 timestamp"})
+			return
+		}
+	}
+
+	stats, err := h.postService.GetOrganizationStats(c.Request.Context(), orgID, userID, since)
+	if err != nil {
+		if domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only organization members can view its stats"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get organization stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, OrganizationStatsResponse{
+		OrganizationID:  stats.OrganizationID.String(),
+		Since:           domain.FormatTimestamp(stats.Since),
+		DistinctPosters: stats.DistinctPosters,
+	})
+}
+
+// GetPostResolution returns the recorded resolution of a resolved post
+func (h *PostHandler) GetPostResolution(c *gin.Context) {
+	id, err := domain.PostIDFromString(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	resolution, err := h.postService.GetPostResolution(c.Request.Context(), id, h.getUserIDFromContext(c))
+	if err != nil {
+		if domain.IsPostErrorCode(err, domain.BusinessErrorNotResolved) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post has no recorded resolution"})
+			return
+		}
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get post resolution"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resolution)
+}
+
+// ResolutionStatsResponse reports how an organization's posts were resolved in a date range
+type ResolutionStatsResponse struct {
+	OrganizationID string  `json:"organization_id"`
+	From           *string `json:"from,omitempty"`
+	To             *string `json:"to,omitempty"`
+	*domain.ResolutionStats
+}
+
+// GetResolutionStats reports average time to resolution and a breakdown by resolution type
+// for the posts of organization_id resolved at or after from and before to. Both bounds are
+// optional RFC3339 timestamps.
+func (h *PostHandler) GetResolutionStats(c *gin.Context) {
+	orgID, err := domain.OrganizationIDFromString(c.Query("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "organization_id must be a valid organization ID"})
+		return
+	}
+
+	userID := h.getUserIDFromContext(c)
+	if userID.IsZero() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	filter := domain.ResolutionStatsFilter{OrganizationID: orgID}
+	if filter.From, err = parseOptionalTimestamp(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC3339 timestamp"})
+		return
+	}
+	if filter.To, err = parseOptionalTimestamp(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC3339 timestamp"})
+		return
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+
+	stats, err := h.postService.GetResolutionStats(c.Request.Context(), filter, userID)
+	if err != nil {
+		if domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only organization members can view its resolution stats"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get resolution stats"})
+		return
+	}
+
+	response := ResolutionStatsResponse{OrganizationID: orgID.String(), ResolutionStats: stats}
+	if filter.From != nil {
+		from := domain.FormatTimestamp(*filter.From)
+		response.From = &from
+	}
+	if filter.To != nil {
+		to := domain.FormatTimestamp(*filter.To)
+		response.To = &to
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// parseOptionalTimestamp parses an RFC3339 query value, treating an empty one as absent
+func parseOptionalTimestamp(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := domain.ParseTimestamp(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (h *PostHandler) parseFiltersFromQuery(c *gin.Context) (domain.PostFilters, error) {
+	viewerID := h.getUserIDFromContext(c)
+	filters := domain.PostFilters{ViewerID: &viewerID}
+
+	if status := c.Query("status"); status != "" {
+		s, err := domain.PostStatusFromString(status)
+		if err != nil {
+			return filters, errors.New(invalidPostStatusMessage)
+		}
+		filters.Status = &s
+	}
+
+	if postType := c.Query("type"); postType != "" {
+		t, err := domain.PostTypeFromString(postType)
+		if err != nil {
+			return filters, errors.New(invalidPostTypeMessage)
+		}
+		filters.Type = &t
+	}
+
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		if userID, err := domain.UserIDFromString(userIDStr); err == nil {
+			filters.UserID = &userID
+		}
+	}
+
+	if sortStr := c.Query("sort"); sortStr != "" {
+		sort, err := domain.PostSortFromString(sortStr)
+		if err != nil {
+			return filters, errors.New(invalidSortMessage)
+		}
+		filters.Sort = sort
+	}
+
+	if languageStr := c.Query("language"); languageStr != "" {
+		language, err := domain.ParseLanguage(languageStr)
+		if err != nil {
+			return filters, errors.New(invalidLanguageMessage)
+		}
+		filters.Language = &language
+	}
+
+	// A repeated tag lists posts carrying all of the given tags
+	if tagStrs := c.QueryArray("tag"); len(tagStrs) > 0 {
+		tags, err := domain.NormalizeTags(tagStrs)
+		if err != nil {
+			return filters, errors.New(invalidTagsMessage)
+		}
+		filters.Tags = tags
+	}
+
+	// A repeated organization_id lists posts across several organizations
+	var orgIDs []domain.OrganizationID
+	for _, orgIDStr := range c.QueryArray("organization_id") {
+		if orgID, err := domain.OrganizationIDFromString(orgIDStr); err == nil {
+			orgIDs = append(orgIDs, orgID)
+		}
+	}
+	if len(orgIDs) == 1 {
+		filters.OrganizationID = &orgIDs[0]
+	} else if len(orgIDs) > 1 {
+		filters.OrganizationIDs = orgIDs
+	}
+
+	limit, offset, err := pagination.Parse(c)
+	if err != nil {
+		return filters, err
+	}
+	filters.Limit = limit
+	filters.Offset = offset
+
+	// A cursor continues from the previous page and replaces the offset
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := domain.DecodePostCursor(cursorStr)
+		if err != nil {
+			return filters, errors.New("Invalid cursor")
+		}
+		filters.Cursor = &cursor
+		filters.Offset = 0
+	}
+
+	filters.MaxPhotos, err = h.maxPhotosFromQuery(c)
+	if err != nil {
+		return filters, err
+	}
+
+	return filters, nil
+}
+
+// maxPhotosFromQuery returns how many photos per post a list view should include: the
+// max_photos query parameter when given, otherwise the configured default (0 for all)
+func (h *PostHandler) maxPhotosFromQuery(c *gin.Context) (int, error) {
+	maxPhotosStr := c.Query("max_photos")
+	if maxPhotosStr == "" {
+		return h.listing.MaxPhotosPerPost, nil
+	}
+
+	maxPhotos, err := strconv.Atoi(maxPhotosStr)
+	if err != nil || maxPhotos < 1 || maxPhotos > domain.MaxPhotosPerPost {
+		return 0, fmt.Errorf("max_photos must be an integer between 1 and %d", domain.MaxPhotosPerPost)
+	}
+
+	return maxPhotos, nil
+}
+
+// toPostResponse builds the API representation of a post. Non-owners get an
+// obfuscated location when location privacy is enabled.
+func (h *PostHandler) toPostResponse(post *domain.Post, viewerID domain.UserID) PostResponse {
+	photos := make([]PhotoResponse, len(post.Photos()))
+	for i, photo := range post.Photos() {
+		photos[i] = toPhotoResponse(&photo)
+	}
+
+	var orgID *uuid.UUID
+	if post.OrganizationID() != nil {
 		id := post.OrganizationID().UUID()
 		orgID = &id
 	}
 
-	return PostResponse{
-		ID:             post.ID().UUID(),
-		Title:          post.Title(),
-		Description:    post.Description(),
-		Photos:         photos,
-		Location:       post.Location(),
-		RadiusMeters:   post.RadiusMeters(),
-		Status:         post.Status(),
-		Type:           post.PostType(),
-		CreatedBy:      post.CreatedBy().UUID(),
-		OrganizationID: orgID,
-		CreatedAt:      post.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:      post.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	var coverPhotoID *uuid.UUID
+	if post.CoverPhotoID() != nil {
+		id := post.CoverPhotoID().UUID()
+		coverPhotoID = &id
+	}
+
+	location := post.Location()
+	approximate := false
+	if h.locationPrivacy.ObfuscationEnabled && !viewerID.Equals(post.CreatedBy()) {
+		location = location.Obfuscate(h.locationPrivacy.PrecisionDegrees)
+		approximate = true
+	}
+
+	response := PostResponse{
+		ID:                  post.ID().UUID(),
+		Title:               post.Title(),
+		Description:         post.Description(),
+		Photos:              photos,
+		CoverPhotoID:        coverPhotoID,
+		Location:            location,
+		LocationApproximate: approximate,
+		RadiusMeters:        post.RadiusMeters(),
+		Status:              post.Status(),
+		Type:                post.PostType(),
+		CreatedBy:           post.CreatedBy().UUID(),
+		OrganizationID:      orgID,
+		Language:            post.Language(),
+		Tags:                post.Tags(),
+		CreatedAt:           domain.FormatTimestamp(post.CreatedAt()),
+		UpdatedAt:           domain.FormatTimestamp(post.UpdatedAt()),
+	}
+	if bumpedAt := post.BumpedAt(); bumpedAt != nil {
+		response.BumpedAt = domain.FormatTimestamp(*bumpedAt)
+	}
+
+	return response
+}
+
+func toPhotoResponse(photo *domain.Photo) PhotoResponse {
+	return PhotoResponse{
+		ID:               photo.ID().UUID(),
+		URL:              photo.URL(),
+		ThumbnailURL:     photo.ThumbnailURL(),
+		Caption:          photo.Caption(),
+		DisplayOrder:     photo.DisplayOrder(),
+		Format:           photo.Format(),
+		Width:            photo.Width(),
+		Height:           photo.Height(),
+		ProcessingStatus: photoProcessingStatus(photo),
+		CreatedAt:        domain.FormatTimestamp(photo.CreatedAt()),
+	}
+}
+
+const (
+	PhotoProcessingStatusProcessing = "processing"
+	PhotoProcessingStatusReady      = "ready"
+)
+
+func photoProcessingStatus(photo *domain.Photo) string {
+	if photo.IsProcessed() {
+		return PhotoProcessingStatusReady
 	}
+	return PhotoProcessingStatusProcessing
 }
 
-func (h *PostHandler) toPostResponses(posts []*domain.Post) []PostResponse {
+func (h *PostHandler) toPostResponses(posts []*domain.Post, viewerID domain.UserID) []PostResponse {
 	responses := make([]PostResponse, len(posts))
 	for i, post := range posts {
-		responses[i] = h.toPostResponse(post)
+		responses[i] = h.toPostResponse(post, viewerID)
 	}
 	return responses
 }
 
 func (h *PostHandler) getUserIDFromContext(c *gin.Context) domain.UserID {
-	// This would typically be set by authentication middleware
-	// For now, return a dummy UUID or get from header
-	if userIDStr := c.GetHeader("X-User-ID"); userIDStr != "" {
-		if userID, err := domain.UserIDFromString(userIDStr); err == nil {
-			return userID
-		}
-	}
-
-	// In a real implementation, this would be extracted from JWT token
-	return domain.NewUserID() // Temporary for development
+	return AuthenticatedUserID(c)
 }
 
 // Helper methods for photo handling
 
 func (h *PostHandler) isValidPhotoFormat(filename string) bool {
-	ext := strings.ToLower(filepath.Ext(filename))
-	validFormats := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".webp": true,
-	}
-	return validFormats[ext]
+	return domain.IsAllowedPhotoFormat(filepath.Ext(filename))
 }
 
 func (h *PostHandler) getFileExtension(filename string) string {
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// postETag derives a strong validator from the post's last modification time.
+// It is truncated to microseconds, the precision Postgres stores, so the tag of a
+// freshly updated post matches the one computed after reloading it.
+func postETag(post *domain.Post) string {
+	return `"` + strconv.FormatInt(post.UpdatedAt().UnixMicro(), 36) + `"`
+}
+
+// setPostValidators exposes the post version so clients can send it back on writes
+func setPostValidators(c *gin.Context, post *domain.Post) {
+	c.Header("ETag", postETag(post))
+	c.Header("Last-Modified", post.UpdatedAt().UTC().Format(http.TimeFormat))
+}
+
+// checkPostPreconditions evaluates If-Match and If-Unmodified-Since against the current
+// post before a write. It writes the response and returns false when the request must not
+// proceed. Requests without conditional headers skip the lookup entirely. When the
+// preconditions hold, the write is made conditional on the version they were checked
+// against, so a change committed in between fails it rather than being overwritten.
+func (h *PostHandler) checkPostPreconditions(c *gin.Context, id domain.PostID) bool {
+	ifMatch := c.GetHeader("If-Match")
+	ifUnmodifiedSince := c.GetHeader("If-Unmodified-Since")
+	if ifMatch == "" && ifUnmodifiedSince == "" {
+		return true
+	}
+
+	// A replica may lag behind the version the write will be checked against
+	post, err := h.postService.GetPostByID(domain.WithPrimaryReads(c.Request.Context()), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check preconditions"})
+		return false
+	}
+
+	// If-Match takes precedence; If-Unmodified-Since is only consulted without it (RFC 9110 13.2.2)
+	met := true
+	if ifMatch != "" {
+		met = etagMatches(ifMatch, postETag(post))
+	} else if since, err := http.ParseTime(ifUnmodifiedSince); err == nil {
+		met = !post.UpdatedAt().Truncate(time.Second).After(since)
+	}
+
+	if !met {
+		setPostValidators(c, post)
+		c.JSON(http.StatusPreconditionFailed, ErrorResponse{
+			Error: "Post has been modified since the supplied version",
+			Code:  "PRECONDITION_FAILED",
+		})
+		return false
+	}
+
+	// "*" matches whatever version is current, so it does not pin one
+	if strings.TrimSpace(ifMatch) != "*" {
+		c.Request = c.Request.WithContext(domain.WithExpectedVersion(c.Request.Context(), post.UpdatedAt()))
+	}
+	return true
+}
+
+// respondPostModified answers 412 when a conditional write found the post changed after its
+// preconditions were checked, and reports whether it did
+func respondPostModified(c *gin.Context, err error) bool {
+	var postErr domain.PostError
+	if !errors.As(err, &postErr) || postErr.Code != domain.BusinessErrorPostModified {
+		return false
+	}
+
+	c.JSON(http.StatusPreconditionFailed, ErrorResponse{
+		Error: "Post has been modified since the supplied version",
+		Code:  "PRECONDITION_FAILED",
+	})
+	return true
+}
+
+// etagMatches applies the strong comparison required for If-Match; weak tags never match
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -3,9 +3,11 @@ package handler
 import (
 	"context"
 	"mime/multipart"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/service"
 )
 
@@ -15,32 +17,96 @@ type StorageInterface interface {
 	DeletePhoto(ctx context.Context, filename string) error
 	GetPhotoURL(filename string) string
 	GenerateThumbnail(ctx context.Context, originalURL string, postID uuid.UUID, organizationID *uuid.UUID) (string, error)
+	ArchivePhoto(ctx context.Context, photoURL string) error
 }
 
-func SetupRoutes(router *gin.RouterGroup, postService *service.PostService, storageService StorageInterface) {
-	// Initialize handlers
-	postHandler := NewPostHandler(postService, storageService)
-	photoHandler := NewPhotoHandler(postService, storageService)
+// Handlers are the handlers the API routes are served by
+type Handlers struct {
+	Post            *PostHandler
+	Photo           *PhotoHandler
+	ContactExchange *ContactExchangeHandler
+	Claim           *ClaimHandler
+	Import          *ImportHandler
+}
+
+// SetupRoutes registers every API route on router. Routes other than internal ones need the
+// caller authenticated by auth, and writes among them are refused while readOnly is on.
+func SetupRoutes(router *gin.RouterGroup, handlers Handlers, auth gin.HandlerFunc, readOnly *ReadOnlyMode, cfg *config.Config) {
+	postHandler, photoHandler := handlers.Post, handlers.Photo
+
+	// Multipart upload routes outlive the server-wide read and write timeouts
+	uploadDeadline := UploadDeadlineMiddleware(time.Duration(cfg.Server.UploadTimeoutSeconds) * time.Second)
+
+	// Route searches are sent as POST but only read, so they stay available while read-only
+	router.POST("/posts/along-route", auth, postHandler.SearchPostsAlongRoute)
+
+	public := router.Group("", auth, ReadOnlyMiddleware(readOnly))
 
 	// Posts routes
-	posts := router.Group("/posts")
+	posts := public.Group("/posts")
 	{
-		posts.POST("", postHandler.CreatePost)
+		posts.POST("", uploadDeadline, postHandler.CreatePost)
 		posts.GET("", postHandler.ListPosts)
 		posts.GET("/nearby", postHandler.SearchNearbyPosts)
+		posts.GET("/near-landmark/:name", postHandler.SearchPostsNearLandmark)
+		posts.GET("/heatmap", postHandler.GetHeatmap)
+		posts.GET("/resolutions/stats", postHandler.GetResolutionStats)
 		posts.GET("/:id", postHandler.GetPost)
+		posts.HEAD("/:id", postHandler.HeadPost)
 		posts.PUT("/:id", postHandler.UpdatePost)
+		posts.PATCH("/:id", postHandler.PatchPost)
 		posts.PATCH("/:id/status", postHandler.UpdatePostStatus)
+		posts.GET("/:id/resolution", postHandler.GetPostResolution)
+		posts.PUT("/:id/cover", postHandler.SetCoverPhoto)
+		posts.POST("/:postId/transfer", postHandler.TransferPost)
+		posts.POST("/:postId/bump", postHandler.BumpPost)
 		posts.DELETE("/:id", postHandler.DeletePost)
 
 		// Photo routes (sub-resource of posts)
-		posts.POST("/:postId/photos", photoHandler.UploadPhoto)
-		// posts.DELETE("/:postId/photos/:photoId", photoHandler.DeletePhoto) // TODO: Fix route conflict
+		posts.POST("/:postId/photos", uploadDeadline, photoHandler.UploadPhoto)
+		posts.POST("/:postId/publish", postHandler.PublishPost)
+		posts.GET("/:id/photos", photoHandler.ListPhotos)
+		posts.GET("/:id/photos/:photoId", photoHandler.GetPhoto)
+		posts.PATCH("/:id/photos/:photoId", photoHandler.UpdatePhotoCaption)
+
+		// Claim routes (ownership proof on found posts)
+		posts.POST("/:postId/claims", handlers.Claim.CreateClaim)
+		posts.GET("/:id/claims", handlers.Claim.ListClaims)
+		posts.POST("/:postId/claims/:claimId/approve", handlers.Claim.ApproveClaim)
+		posts.POST("/:postId/claims/:claimId/reject", handlers.Claim.RejectClaim)
 	}
 
+	router.GET("/meta/enums", GetEnums)
+
 	// Users routes
-	users := router.Group("/users")
+	users := public.Group("/users")
 	{
 		users.GET("/:userId/posts", postHandler.GetUserPosts)
 	}
+
+	// Organization routes
+	organizations := public.Group("/organizations")
+	{
+		organizations.GET("/:orgId/stats", postHandler.GetOrganizationStats)
+		organizations.GET("/:orgId/posts", postHandler.ListOrganizationPosts)
+	}
+
+	// Contact exchange routes
+	handlers.ContactExchange.RegisterRoutes(public)
+
+	// Service-to-service routes
+	internalRoutes := router.Group("/internal")
+	internalRoutes.Use(InternalAuthMiddleware(cfg.InternalAPIToken))
+	{
+		internalRoutes.GET("/posts/:id/event", postHandler.GetPostEvent)
+		internalRoutes.POST("/posts/:id/reemit", postHandler.ReemitPostEvent)
+		internalRoutes.POST("/posts/import", handlers.Import.ImportPosts)
+		internalRoutes.POST("/contacts/exchange/expiry-reminders", handlers.ContactExchange.SendExpiryReminders)
+		internalRoutes.POST("/contacts/exchange/re-encrypt", handlers.ContactExchange.ReEncryptContactInfo)
+		internalRoutes.GET("/audit/encryption", handlers.ContactExchange.GetEncryptionAuditTrail)
+
+		maintenance := NewMaintenanceHandler(readOnly)
+		internalRoutes.GET("/maintenance/read-only", maintenance.GetReadOnly)
+		internalRoutes.PUT("/maintenance/read-only", maintenance.SetReadOnly)
+	}
 }
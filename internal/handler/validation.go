@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// FieldValidationResponse reports request fields that failed validation
+type FieldValidationResponse struct {
+	Error  string            `json:"error"`
+	Code   string            `json:"code"`
+	Fields map[string]string `json:"fields"`
+}
+
+type locationField struct {
+	name    string
+	message string
+}
+
+// locationFields maps coordinate struct fields to their form names and messages
+var locationFields = map[string]locationField{
+	"Latitude":  {name: "latitude", message: "must be a number between -90 and 90"},
+	"Longitude": {name: "longitude", message: "must be a number between -180 and 180"},
+}
+
+// respondLocationValidationError writes a 422 with field-level details when binding
+// failed on coordinates, so clients get the same error shape for every bad location.
+// It reports whether a response was written.
+func respondLocationValidationError(c *gin.Context, err error) bool {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return false
+	}
+
+	fields := make(map[string]string)
+	for _, fieldErr := range validationErrors {
+		field, ok := locationFields[fieldErr.StructField()]
+		if !ok {
+			continue
+		}
+		if fieldErr.Tag() == "required" {
+			fields[field.name] = "is required"
+		} else {
+			fields[field.name] = field.message
+		}
+	}
+
+	if len(fields) == 0 {
+		return false
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, FieldValidationResponse{
+		Error:  "Invalid location",
+		Code:   "INVALID_LOCATION",
+		Fields: fields,
+	})
+	return true
+}
+
+// respondImplausibleLocation writes a 422 in the same shape as other location errors for
+// coordinates that are in range but fail the plausibility checks. The distinct code lets
+// clients offer allow_implausible_location for a real position.
+func respondImplausibleLocation(c *gin.Context, err error) {
+	message, code := err.Error(), string(domain.LocationErrorImplausible)
+	var postErr domain.PostError
+	if errors.As(err, &postErr) {
+		message, code = postErr.Message, string(postErr.Code)
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, FieldValidationResponse{
+		Error: "Invalid location",
+		Code:  code,
+		Fields: map[string]string{
+			"latitude":  message,
+			"longitude": message,
+		},
+	})
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+type PostgresClaimRepository struct {
+	db *DBRouter
+}
+
+func NewPostgresClaimRepository(db *DBRouter) *PostgresClaimRepository {
+	return &PostgresClaimRepository{db: db}
+}
+
+const claimColumns = `
+	id, post_id, claimant_user_id, owner_user_id, status, proof_text, proof_photo_urls,
+	contact_exchange_request_id, reviewed_at, retain_until, created_at, updated_at`
+
+func (r *PostgresClaimRepository) Save(ctx context.Context, claim *domain.Claim) error {
+	query := `
+		INSERT INTO post_claims (` + claimColumns + `
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)`
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query,
+		claim.ID().UUID(),
+		claim.PostID().UUID(),
+		claim.ClaimantUserID().UUID(),
+		claim.OwnerUserID().UUID(),
+		string(claim.Status()),
+		claim.ProofText(),
+		pq.Array(claim.ProofPhotoURLs()),
+		contactExchangeRequestUUID(claim),
+		claim.ReviewedAt(),
+		claim.RetainUntil(),
+		claim.CreatedAt(),
+		claim.UpdatedAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save claim: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresClaimRepository) FindByID(ctx context.Context, id domain.ClaimID) (*domain.Claim, error) {
+	query := `SELECT ` + claimColumns + ` FROM post_claims WHERE id = $1`
+
+	row := r.db.Reader(ctx).QueryRowContext(ctx, query, id.UUID())
+
+	claim, err := r.scanClaim(row)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrClaimNotFound(id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+func (r *PostgresClaimRepository) FindByPostID(ctx context.Context, postID domain.PostID, limit, offset int) ([]*domain.Claim, error) {
+	query := `
+		SELECT ` + claimColumns + `
+		FROM post_claims
+		WHERE post_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, postID.UUID(), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find claims by post ID: %w", err)
+	}
+	defer rows.Close()
+
+	var claims []*domain.Claim
+	for rows.Next() {
+		claim, err := r.scanClaim(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan claim: %w", err)
+		}
+		claims = append(claims, claim)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+func (r *PostgresClaimRepository) Update(ctx context.Context, claim *domain.Claim) error {
+	query := `
+		UPDATE post_claims SET
+			status = $2,
+			contact_exchange_request_id = $3,
+			reviewed_at = $4,
+			retain_until = $5,
+			updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query,
+		claim.ID().UUID(),
+		string(claim.Status()),
+		contactExchangeRequestUUID(claim),
+		claim.ReviewedAt(),
+		claim.RetainUntil(),
+		claim.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update claim: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrClaimNotFound(claim.ID())
+	}
+
+	return nil
+}
+
+func (r *PostgresClaimRepository) DeletePastRetention(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM post_claims
+		WHERE id IN (
+			SELECT id FROM post_claims
+			WHERE retain_until < $1
+			ORDER BY retain_until ASC
+			LIMIT $2
+		)`
+
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete claims past retention: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// claimScanner is satisfied by both *sql.Row and *sql.Rows
+type claimScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *PostgresClaimRepository) scanClaim(row claimScanner) (*domain.Claim, error) {
+	var id, postID, claimantUserID, ownerUserID uuid.UUID
+	var status, proofText string
+	var proofPhotoURLs []string
+	var contactExchangeRequestID uuid.NullUUID
+	var reviewedAt sql.NullTime
+	var retainUntil, createdAt, updatedAt time.Time
+
+	err := row.Scan(
+		&id, &postID, &claimantUserID, &ownerUserID, &status, &proofText, pq.Array(&proofPhotoURLs),
+		&contactExchangeRequestID, &reviewedAt, &retainUntil, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var requestID *domain.ContactExchangeRequestID
+	if contactExchangeRequestID.Valid {
+		linked := domain.ContactExchangeRequestIDFromUUID(contactExchangeRequestID.UUID)
+		requestID = &linked
+	}
+
+	var reviewed *time.Time
+	if reviewedAt.Valid {
+		reviewed = &reviewedAt.Time
+	}
+
+	return domain.ReconstructClaim(
+		domain.ClaimIDFromUUID(id),
+		domain.PostIDFromUUID(postID),
+		domain.UserIDFromUUID(claimantUserID),
+		domain.UserIDFromUUID(ownerUserID),
+		domain.ClaimStatus(status),
+		proofText,
+		proofPhotoURLs,
+		requestID,
+		reviewed,
+		retainUntil,
+		createdAt,
+		updatedAt,
+	), nil
+}
+
+func contactExchangeRequestUUID(claim *domain.Claim) *uuid.UUID {
+	if claim.ContactExchangeRequestID() == nil {
+		return nil
+	}
+	id := claim.ContactExchangeRequestID().UUID()
+	return &id
+}
@@ -4,18 +4,26 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	uniqueViolation = "23505"
+	// onePendingRequestIndex keeps a requester to one pending request per post
+	onePendingRequestIndex = "idx_contact_exchange_one_pending"
 )
 
 type PostgresContactExchangeRepository struct {
-	db *sql.DB
+	db *DBRouter
 }
 
-func NewPostgresContactExchangeRepository(db *sql.DB) *PostgresContactExchangeRepository {
+func NewPostgresContactExchangeRepository(db *DBRouter) *PostgresContactExchangeRepository {
 	return &PostgresContactExchangeRepository{db: db}
 }
 
@@ -69,7 +77,7 @@ func (r *PostgresContactExchangeRepository) Save(ctx context.Context, request *d
 		}
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query,
 		request.ID().UUID(),
 		request.PostID().UUID(),
 		request.RequesterUserID().UUID(),
@@ -89,6 +97,10 @@ func (r *PostgresContactExchangeRepository) Save(ctx context.Context, request *d
 		request.UpdatedAt(),
 	)
 
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == onePendingRequestIndex {
+		return domain.ErrContactExchangeAlreadyPending(request.PostID())
+	}
 	if err != nil {
 		return fmt.Errorf("failed to save contact exchange request: %w", err)
 	}
@@ -101,11 +113,11 @@ func (r *PostgresContactExchangeRepository) FindByID(ctx context.Context, id dom
 		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
 			   verification_required, verification_method, verification_question, verification_requirements,
 			   approval_type, denial_reason, denial_message, encrypted_contact_info,
-			   expires_at, created_at, updated_at
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
 		FROM contact_exchange_requests
 		WHERE id = $1`
 
-	row := r.db.QueryRowContext(ctx, query, id.UUID())
+	row := r.db.Reader(ctx).QueryRowContext(ctx, query, id.UUID())
 	return r.scanContactExchangeRequest(row)
 }
 
@@ -114,12 +126,12 @@ func (r *PostgresContactExchangeRepository) FindByPostID(ctx context.Context, po
 		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
 			   verification_required, verification_method, verification_question, verification_requirements,
 			   approval_type, denial_reason, denial_message, encrypted_contact_info,
-			   expires_at, created_at, updated_at
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
 		FROM contact_exchange_requests
 		WHERE post_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, postID.UUID())
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, postID.UUID())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find contact exchange requests by post ID: %w", err)
 	}
@@ -128,18 +140,42 @@ func (r *PostgresContactExchangeRepository) FindByPostID(ctx context.Context, po
 	return r.scanContactExchangeRequests(rows)
 }
 
+func (r *PostgresContactExchangeRepository) FindPendingByRequester(ctx context.Context, postID domain.PostID, requesterID domain.UserID) (*domain.ContactExchangeRequest, error) {
+	query := `
+		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
+			   verification_required, verification_method, verification_question, verification_requirements,
+			   approval_type, denial_reason, denial_message, encrypted_contact_info,
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
+		FROM contact_exchange_requests
+		WHERE post_id = $1 AND requester_user_id = $2 AND status = 'pending'
+		LIMIT 1`
+
+	// Read from the primary so a request created a moment ago is found even when replicas lag
+	rows, err := r.db.Writer(ctx).QueryContext(ctx, query, postID.UUID(), requesterID.UUID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending contact exchange request: %w", err)
+	}
+	defer rows.Close()
+
+	requests, err := r.scanContactExchangeRequests(rows)
+	if err != nil || len(requests) == 0 {
+		return nil, err
+	}
+	return requests[0], nil
+}
+
 func (r *PostgresContactExchangeRepository) FindByRequesterUserID(ctx context.Context, userID domain.UserID, limit, offset int) ([]*domain.ContactExchangeRequest, error) {
 	query := `
 		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
 			   verification_required, verification_method, verification_question, verification_requirements,
 			   approval_type, denial_reason, denial_message, encrypted_contact_info,
-			   expires_at, created_at, updated_at
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
 		FROM contact_exchange_requests
 		WHERE requester_user_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, userID.UUID(), limit, offset)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, userID.UUID(), limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find contact exchange requests by requester user ID: %w", err)
 	}
@@ -153,13 +189,13 @@ func (r *PostgresContactExchangeRepository) FindByOwnerUserID(ctx context.Contex
 		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
 			   verification_required, verification_method, verification_question, verification_requirements,
 			   approval_type, denial_reason, denial_message, encrypted_contact_info,
-			   expires_at, created_at, updated_at
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
 		FROM contact_exchange_requests
 		WHERE owner_user_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, userID.UUID(), limit, offset)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, userID.UUID(), limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find contact exchange requests by owner user ID: %w", err)
 	}
@@ -168,19 +204,118 @@ func (r *PostgresContactExchangeRepository) FindByOwnerUserID(ctx context.Contex
 	return r.scanContactExchangeRequests(rows)
 }
 
+// FindStatusesForUser looks up the statuses of many requests in one query
+func (r *PostgresContactExchangeRepository) FindStatusesForUser(ctx context.Context, ids []domain.ContactExchangeRequestID, userID domain.UserID) (map[domain.ContactExchangeRequestID]domain.ContactExchangeStatus, error) {
+	statuses := make(map[domain.ContactExchangeRequestID]domain.ContactExchangeStatus, len(ids))
+	if len(ids) == 0 {
+		return statuses, nil
+	}
+
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+
+	query := `
+		SELECT id, status
+		FROM contact_exchange_requests
+		WHERE id = ANY($1::uuid[])
+		  AND (requester_user_id = $2 OR owner_user_id = $2)`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, pq.Array(values), userID.UUID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contact exchange request statuses: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, fmt.Errorf("failed to scan contact exchange request status: %w", err)
+		}
+		requestID, err := domain.ContactExchangeRequestIDFromString(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contact exchange request ID: %w", err)
+		}
+		statuses[requestID] = domain.ContactExchangeStatus(status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read contact exchange request statuses: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// FindEncryptedWithOtherKey finds approved, unexpired requests whose contact info is encrypted
+// with a key other than keyFingerprint, including contact info stored before keys were recorded
+func (r *PostgresContactExchangeRepository) FindEncryptedWithOtherKey(ctx context.Context, keyFingerprint string, limit int) ([]*domain.ContactExchangeRequest, error) {
+	query := `
+		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
+			   verification_required, verification_method, verification_question, verification_requirements,
+			   approval_type, denial_reason, denial_message, encrypted_contact_info,
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
+		FROM contact_exchange_requests
+		WHERE status = 'approved'
+		  AND expires_at > NOW()
+		  AND encrypted_contact_info IS NOT NULL
+		  AND encrypted_contact_info->>'key_fingerprint' IS DISTINCT FROM $1
+		ORDER BY id
+		LIMIT $2`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, keyFingerprint, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contact exchange requests encrypted with another key: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanContactExchangeRequests(rows)
+}
+
+// FindKeyFingerprintsInUse lists the keys stored contact info is encrypted with, whatever the
+// request's status
+func (r *PostgresContactExchangeRepository) FindKeyFingerprintsInUse(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT DISTINCT COALESCE(encrypted_contact_info->>'key_fingerprint', '')
+		FROM contact_exchange_requests
+		WHERE encrypted_contact_info IS NOT NULL`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find encryption keys in use: %w", err)
+	}
+	defer rows.Close()
+
+	var fingerprints []string
+	for rows.Next() {
+		var fingerprint string
+		if err := rows.Scan(&fingerprint); err != nil {
+			return nil, fmt.Errorf("failed to scan encryption key fingerprint: %w", err)
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read encryption keys in use: %w", err)
+	}
+
+	return fingerprints, nil
+}
+
+// FindExpired returns pending and approved requests whose expiry has passed. expires_at is a
+// timestamptz, so the comparison with NOW() is between instants and does not depend on the
+// session time zone; it matches ContactExchangeRequest.IsExpiredAt.
 func (r *PostgresContactExchangeRepository) FindExpired(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error) {
 	query := `
 		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
 			   verification_required, verification_method, verification_question, verification_requirements,
 			   approval_type, denial_reason, denial_message, encrypted_contact_info,
-			   expires_at, created_at, updated_at
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
 		FROM contact_exchange_requests
 		WHERE expires_at <= NOW()
 		  AND status IN ('pending', 'approved')
 		ORDER BY expires_at ASC
 		LIMIT $1`
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find expired contact exchange requests: %w", err)
 	}
@@ -189,6 +324,111 @@ func (r *PostgresContactExchangeRepository) FindExpired(ctx context.Context, lim
 	return r.scanContactExchangeRequests(rows)
 }
 
+func (r *PostgresContactExchangeRepository) FindPurgeable(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error) {
+	query := `
+		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
+			   verification_required, verification_method, verification_question, verification_requirements,
+			   approval_type, denial_reason, denial_message, encrypted_contact_info,
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
+		FROM contact_exchange_requests
+		WHERE status = 'expired'
+		  AND encrypted_contact_info IS NOT NULL
+		ORDER BY expires_at ASC
+		LIMIT $1`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find purgeable contact exchange requests: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanContactExchangeRequests(rows)
+}
+
+func (r *PostgresContactExchangeRepository) FindExpiringForUser(ctx context.Context, userID domain.UserID, within time.Duration) ([]*domain.ContactExchangeRequest, error) {
+	query := `
+		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
+			   verification_required, verification_method, verification_question, verification_requirements,
+			   approval_type, denial_reason, denial_message, encrypted_contact_info,
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
+		FROM contact_exchange_requests
+		WHERE (requester_user_id = $1 OR owner_user_id = $1)
+		  AND status IN ('pending', 'approved')
+		  AND expires_at > NOW()
+		  AND expires_at <= NOW() + make_interval(secs => $2)
+		ORDER BY expires_at ASC`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, userID.UUID(), within.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expiring contact exchange requests for user: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanContactExchangeRequests(rows)
+}
+
+func (r *PostgresContactExchangeRepository) FindDueForExpiryReminder(ctx context.Context, within time.Duration, limit int) ([]*domain.ContactExchangeRequest, error) {
+	query := `
+		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
+			   verification_required, verification_method, verification_question, verification_requirements,
+			   approval_type, denial_reason, denial_message, encrypted_contact_info,
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
+		FROM contact_exchange_requests
+		WHERE status IN ('pending', 'approved')
+		  AND expiry_reminder_sent_at IS NULL
+		  AND expires_at > NOW()
+		  AND expires_at <= NOW() + make_interval(secs => $1)
+		ORDER BY expires_at ASC
+		LIMIT $2`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, within.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contact exchange requests due for expiry reminder: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanContactExchangeRequests(rows)
+}
+
+func (r *PostgresContactExchangeRepository) MarkExpiryReminderSent(ctx context.Context, id domain.ContactExchangeRequestID, sentAt time.Time) error {
+	query := `UPDATE contact_exchange_requests SET expiry_reminder_sent_at = $2 WHERE id = $1`
+
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, id.UUID(), sentAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark expiry reminder sent: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrContactExchangeNotFound(id)
+	}
+
+	return nil
+}
+
+// CountByRequester reads from the primary so requests created a moment ago are counted even
+// when replicas lag behind
+func (r *PostgresContactExchangeRepository) CountByRequester(ctx context.Context, requesterID domain.UserID, postID domain.PostID, since time.Time) (domain.RequesterRequestCounts, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE post_id = $2 AND created_at >= $3),
+			COUNT(*) FILTER (WHERE status = 'pending' AND expires_at > NOW())
+		FROM contact_exchange_requests
+		WHERE requester_user_id = $1`
+
+	var counts domain.RequesterRequestCounts
+	err := r.db.Writer(ctx).QueryRowContext(ctx, query, requesterID.UUID(), postID.UUID(), since).Scan(&counts.ForPostSince, &counts.Pending)
+	if err != nil {
+		return counts, fmt.Errorf("failed to count requester's contact exchange requests: %w", err)
+	}
+
+	return counts, nil
+}
+
 func (r *PostgresContactExchangeRepository) Update(ctx context.Context, request *domain.ContactExchangeRequest) error {
 	query := `
 		UPDATE contact_exchange_requests SET
@@ -203,7 +443,9 @@ func (r *PostgresContactExchangeRepository) Update(ctx context.Context, request
 			denial_message = $10,
 			encrypted_contact_info = $11,
 			expires_at = $12,
-			updated_at = $13
+			updated_at = $13,
+			verification_completed = $14,
+			verification_submission = $15
 		WHERE id = $1`
 
 	var verificationMethod *string
@@ -245,7 +487,16 @@ func (r *PostgresContactExchangeRepository) Update(ctx context.Context, request
 		}
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	var verificationSubmission []byte
+	if request.VerificationSubmission() != nil {
+		var err error
+		verificationSubmission, err = json.Marshal(request.VerificationSubmission())
+		if err != nil {
+			return fmt.Errorf("failed to marshal verification submission: %w", err)
+		}
+	}
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query,
 		request.ID().UUID(),
 		string(request.Status()),
 		request.Message(),
@@ -259,6 +510,8 @@ func (r *PostgresContactExchangeRepository) Update(ctx context.Context, request
 		encryptedContactInfo,
 		request.ExpiresAt(),
 		request.UpdatedAt(),
+		request.VerificationCompleted(),
+		verificationSubmission,
 	)
 
 	if err != nil {
@@ -271,7 +524,7 @@ func (r *PostgresContactExchangeRepository) Update(ctx context.Context, request
 func (r *PostgresContactExchangeRepository) Delete(ctx context.Context, id domain.ContactExchangeRequestID) error {
 	query := `DELETE FROM contact_exchange_requests WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id.UUID())
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, id.UUID())
 	if err != nil {
 		return fmt.Errorf("failed to delete contact exchange request: %w", err)
 	}
@@ -295,7 +548,7 @@ func (r *PostgresContactExchangeRepository) List(ctx context.Context, filters do
 		SELECT id, post_id, requester_user_id, owner_user_id, status, message,
 			   verification_required, verification_method, verification_question, verification_requirements,
 			   approval_type, denial_reason, denial_message, encrypted_contact_info,
-			   expires_at, created_at, updated_at
+			   expires_at, created_at, updated_at, verification_completed, verification_submission
 		FROM contact_exchange_requests`
 
 	whereClause, args := r.buildWhereClause(filters)
@@ -306,7 +559,7 @@ func (r *PostgresContactExchangeRepository) List(ctx context.Context, filters do
 	query += " ORDER BY created_at DESC LIMIT $" + fmt.Sprintf("%d", len(args)+1) + " OFFSET $" + fmt.Sprintf("%d", len(args)+2)
 	args = append(args, filters.Limit, filters.Offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list contact exchange requests: %w", err)
 	}
@@ -324,7 +577,7 @@ func (r *PostgresContactExchangeRepository) Count(ctx context.Context, filters d
 	}
 
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := r.db.Reader(ctx).QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count contact exchange requests: %w", err)
 	}
@@ -398,12 +651,14 @@ func (r *PostgresContactExchangeRepository) scanContactExchangeRequest(row *sql.
 	var approvalType, denialReason, denialMessage *string
 	var encryptedContactInfo []byte
 	var expiresAt, createdAt, updatedAt time.Time
+	var verificationCompleted bool
+	var verificationSubmission []byte
 
 	err := row.Scan(
 		&id, &postID, &requesterUserID, &ownerUserID, &status, &message,
 		&verificationRequired, &verificationMethod, &verificationQuestion, &verificationRequirements,
 		&approvalType, &denialReason, &denialMessage, &encryptedContactInfo,
-		&expiresAt, &createdAt, &updatedAt,
+		&expiresAt, &createdAt, &updatedAt, &verificationCompleted, &verificationSubmission,
 	)
 
 	if err != nil {
@@ -417,7 +672,7 @@ func (r *PostgresContactExchangeRepository) scanContactExchangeRequest(row *sql.
 		id, postID, requesterUserID, ownerUserID, status, message,
 		verificationRequired, verificationMethod, verificationQuestion, verificationRequirements,
 		approvalType, denialReason, denialMessage, encryptedContactInfo,
-		expiresAt, createdAt, updatedAt,
+		expiresAt, createdAt, updatedAt, verificationCompleted, verificationSubmission,
 	)
 }
 
@@ -434,12 +689,14 @@ func (r *PostgresContactExchangeRepository) scanContactExchangeRequests(rows *sq
 		var approvalType, denialReason, denialMessage *string
 		var encryptedContactInfo []byte
 		var expiresAt, createdAt, updatedAt time.Time
+		var verificationCompleted bool
+		var verificationSubmission []byte
 
 		err := rows.Scan(
 			&id, &postID, &requesterUserID, &ownerUserID, &status, &message,
 			&verificationRequired, &verificationMethod, &verificationQuestion, &verificationRequirements,
 			&approvalType, &denialReason, &denialMessage, &encryptedContactInfo,
-			&expiresAt, &createdAt, &updatedAt,
+			&expiresAt, &createdAt, &updatedAt, &verificationCompleted, &verificationSubmission,
 		)
 
 		if err != nil {
@@ -450,7 +707,7 @@ func (r *PostgresContactExchangeRepository) scanContactExchangeRequests(rows *sq
 			id, postID, requesterUserID, ownerUserID, status, message,
 			verificationRequired, verificationMethod, verificationQuestion, verificationRequirements,
 			approvalType, denialReason, denialMessage, encryptedContactInfo,
-			expiresAt, createdAt, updatedAt,
+			expiresAt, createdAt, updatedAt, verificationCompleted, verificationSubmission,
 		)
 
 		if err != nil {
@@ -471,7 +728,7 @@ func (r *PostgresContactExchangeRepository) buildContactExchangeRequest(
 	id, postID, requesterUserID, ownerUserID string, status string, message *string,
 	verificationRequired bool, verificationMethod, verificationQuestion *string, verificationRequirements []byte,
 	approvalType, denialReason, denialMessage *string, encryptedContactInfo []byte,
-	expiresAt, createdAt, updatedAt time.Time,
+	expiresAt, createdAt, updatedAt time.Time, verificationCompleted bool, verificationSubmission []byte,
 ) (*domain.ContactExchangeRequest, error) {
 
 	requestID, err := domain.ContactExchangeRequestIDFromString(id)
@@ -528,9 +785,20 @@ func (r *PostgresContactExchangeRepository) buildContactExchangeRequest(
 		if err := json.Unmarshal(encryptedContactInfo, parsedEncryptedContactInfo); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal encrypted contact info: %w", err)
 		}
+		// Rows written before the ciphertext had its own field also hold plaintext; the next
+		// update stores them in the new layout
+		parsedEncryptedContactInfo.UpgradeLegacyLayout()
+	}
+
+	var parsedVerificationSubmission *domain.VerificationSubmission
+	if len(verificationSubmission) > 0 {
+		parsedVerificationSubmission = &domain.VerificationSubmission{}
+		if err := json.Unmarshal(verificationSubmission, parsedVerificationSubmission); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal verification submission: %w", err)
+		}
 	}
 
-	return domain.ReconstructContactExchangeRequest(
+	request := domain.ReconstructContactExchangeRequest(
 		requestID,
 		postUUID,
 		requesterUUID,
@@ -546,5 +814,8 @@ func (r *PostgresContactExchangeRepository) buildContactExchangeRequest(
 		expiresAt,
 		createdAt,
 		updatedAt,
-	), nil
+	)
+	request.RestoreVerification(verificationCompleted, parsedVerificationSubmission)
+
+	return request, nil
 }
\ No newline at end of file
@@ -1,11 +1,14 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/jsarabia/fn-posts/internal/domain"
 	_ "github.com/lib/pq"
 )
 
@@ -29,6 +32,122 @@ func NewDatabase(databaseURL string) (*sql.DB, error) {
 	return db, nil
 }
 
+// DBRouter sends writes to the primary database and reads to an optional replica
+type DBRouter struct {
+	primary *sql.DB
+	replica *sql.DB
+}
+
+// NewDBRouter creates a router; a nil replica means all reads go to the primary
+func NewDBRouter(primary, replica *sql.DB) *DBRouter {
+	return &DBRouter{
+		primary: primary,
+		replica: replica,
+	}
+}
+
+// Primary returns the read-write database
+func (r *DBRouter) Primary() *sql.DB {
+	return r.primary
+}
+
+// Reader returns the database to use for reads. The primary is used when no replica
+// is configured or the context requested primary reads.
+func (r *DBRouter) Reader(ctx context.Context) *sql.DB {
+	if r.replica == nil || domain.PrimaryReadsRequested(ctx) {
+		return r.primary
+	}
+	return r.replica
+}
+
+// sqlExecutor is satisfied by both *sql.DB and *sql.Tx
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txContextKey carries the transaction started by WithinTransaction
+type txContextKey struct{}
+
+// Writer returns the database to use for writes: the transaction on the context, or the
+// primary outside one
+func (r *DBRouter) Writer(ctx context.Context) sqlExecutor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return r.primary
+}
+
+// WithinTransaction runs fn in a transaction on the primary, committed when fn returns nil.
+// Writes made through Writer with the context given to fn take part in it, and nested calls
+// join the transaction already under way.
+func (r *DBRouter) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.primary.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.ErrRepositoryConnection("begin transaction").WithCause(err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.ErrRepositoryConnection("commit transaction").WithCause(err)
+	}
+	return nil
+}
+
+// TryWithLock runs fn while holding a Postgres advisory lock on name. The lock is taken on a
+// connection of its own and held until fn returns, so instances sharing the database never
+// run fn at the same time; an instance that finds the lock taken skips fn.
+func (r *DBRouter) TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := r.primary.Conn(ctx)
+	if err != nil {
+		return false, domain.ErrRepositoryConnection("reserve lock connection").WithCause(err)
+	}
+	defer conn.Close()
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&acquired); err != nil {
+		return false, domain.ErrRepositoryConnection("acquire lock").WithCause(err)
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	defer func() {
+		// Unlock even when ctx is done. A connection that could not unlock would keep holding the
+		// lock in the pool, so it is discarded instead, which releases the lock.
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, name); err != nil {
+			log.Printf("Warning: failed to release lock %s, discarding its connection: %v", name, err)
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	return true, fn(ctx)
+}
+
+// HasReplica reports whether a read replica is configured
+func (r *DBRouter) HasReplica() bool {
+	return r.replica != nil
+}
+
+// Close closes the primary and replica connections
+func (r *DBRouter) Close() error {
+	if r.replica != nil {
+		if err := r.replica.Close(); err != nil {
+			return err
+		}
+	}
+	return r.primary.Close()
+}
+
 func (r *PostgresPostRepository) Close() error {
 	return r.db.Close()
 }
@@ -21,6 +21,7 @@ type PostDTO struct {
 	OrganizationID sql.NullString  `json:"organization_id,omitempty" db:"organization_id"`
 	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+	CoverPhotoID   sql.NullString  `json:"cover_photo_id,omitempty" db:"cover_photo_id"`
 	Distance       sql.NullFloat64 `json:"distance,omitempty" db:"distance"`
 }
 
@@ -64,6 +65,14 @@ func (dto *PostDTO) ToDomain(photos []domain.Photo) (*domain.Post, error) {
 		photos,
 	)
 
+	if dto.CoverPhotoID.Valid {
+		coverPhotoID, err := domain.PhotoIDFromString(dto.CoverPhotoID.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cover photo ID: %w", err)
+		}
+		post.RestoreCoverPhoto(&coverPhotoID)
+	}
+
 	return post, nil
 }
 
@@ -89,5 +98,12 @@ func FromDomainPost(post *domain.Post) *PostDTO {
 		}
 	}
 
+	if post.ChosenCoverPhotoID() != nil {
+		dto.CoverPhotoID = sql.NullString{
+			String: post.ChosenCoverPhotoID().String(),
+			Valid:  true,
+		}
+	}
+
 	return dto
 }
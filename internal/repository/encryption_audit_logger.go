@@ -60,10 +60,8 @@ func (l *PostgresEncryptionAuditLogger) LogOperation(log *domain.EncryptionAudit
 	return nil
 }
 
-func (l *PostgresEncryptionAuditLogger) GetAuditTrail(userID domain.UserID, requestID *domain.ContactExchangeRequestID, limit int) ([]*domain.EncryptionAuditLog, error) {
-	if limit <= 0 {
-		limit = 100
-	}
+func (l *PostgresEncryptionAuditLogger) GetAuditTrail(filters domain.EncryptionAuditFilters) ([]*domain.EncryptionAuditLog, error) {
+	filters.SetDefaults()
 
 	baseQuery := `
 		SELECT id, operation, user_id, request_id, key_fingerprint, success,
@@ -72,23 +70,12 @@ func (l *PostgresEncryptionAuditLogger) GetAuditTrail(userID domain.UserID, requ
 		WHERE 1=1
 	`
 
-	args := []interface{}{}
-	argIndex := 1
-
-	if !userID.IsZero() {
-		baseQuery += fmt.Sprintf(" AND user_id = $%d", argIndex)
-		args = append(args, userID.String())
-		argIndex++
-	}
-
-	if requestID != nil {
-		baseQuery += fmt.Sprintf(" AND request_id = $%d", argIndex)
-		args = append(args, requestID.String())
-		argIndex++
-	}
+	whereClause, args := l.buildWhereClause(filters)
+	baseQuery += whereClause
 
-	baseQuery += fmt.Sprintf(" ORDER BY timestamp DESC LIMIT $%d", argIndex)
-	args = append(args, limit)
+	argIndex := len(args) + 1
+	baseQuery += fmt.Sprintf(" ORDER BY timestamp DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filters.Limit, filters.Offset)
 
 	rows, err := l.db.Query(baseQuery, args...)
 	if err != nil {
@@ -165,6 +152,64 @@ func (l *PostgresEncryptionAuditLogger) GetAuditTrail(userID domain.UserID, requ
 	return logs, nil
 }
 
+// CountAuditTrail counts audit log entries matching the filters, ignoring pagination
+func (l *PostgresEncryptionAuditLogger) CountAuditTrail(filters domain.EncryptionAuditFilters) (int64, error) {
+	query := "SELECT COUNT(*) FROM encryption_audit_logs WHERE 1=1"
+
+	whereClause, args := l.buildWhereClause(filters)
+	query += whereClause
+
+	var count int64
+	if err := l.db.QueryRow(query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	return count, nil
+}
+
+func (l *PostgresEncryptionAuditLogger) buildWhereClause(filters domain.EncryptionAuditFilters) (string, []interface{}) {
+	clause := ""
+	args := []interface{}{}
+	argIndex := 1
+
+	if filters.UserID != nil && !filters.UserID.IsZero() {
+		clause += fmt.Sprintf(" AND user_id = $%d", argIndex)
+		args = append(args, filters.UserID.String())
+		argIndex++
+	}
+
+	if filters.RequestID != nil {
+		clause += fmt.Sprintf(" AND request_id = $%d", argIndex)
+		args = append(args, filters.RequestID.String())
+		argIndex++
+	}
+
+	if filters.Operation != nil {
+		clause += fmt.Sprintf(" AND operation = $%d", argIndex)
+		args = append(args, string(*filters.Operation))
+		argIndex++
+	}
+
+	if filters.Success != nil {
+		clause += fmt.Sprintf(" AND success = $%d", argIndex)
+		args = append(args, *filters.Success)
+		argIndex++
+	}
+
+	if filters.From != nil {
+		clause += fmt.Sprintf(" AND timestamp >= $%d", argIndex)
+		args = append(args, *filters.From)
+		argIndex++
+	}
+
+	if filters.To != nil {
+		clause += fmt.Sprintf(" AND timestamp <= $%d", argIndex)
+		args = append(args, *filters.To)
+	}
+
+	return clause, args
+}
+
 // LogEncryptionSuccess logs a successful encryption operation
 func (l *PostgresEncryptionAuditLogger) LogEncryptionSuccess(userID domain.UserID, requestID *domain.ContactExchangeRequestID, keyFingerprint string, ipAddress, userAgent *string) error {
 	log := &domain.EncryptionAuditLog{
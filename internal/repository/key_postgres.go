@@ -47,7 +47,7 @@ func (r *PostgresKeyRepository) SaveKey(key *domain.EncryptionKey) error {
 
 func (r *PostgresKeyRepository) GetActiveKey() (*domain.EncryptionKey, error) {
 	query := `
-		SELECT id, fingerprint, private_key, public_key, is_active, created_at, expires_at
+		SELECT id, fingerprint, private_key, public_key, is_active, created_at, expires_at, retired_at
 		FROM encryption_keys
 		WHERE is_active = true
 		ORDER BY created_at DESC
@@ -55,7 +55,7 @@ func (r *PostgresKeyRepository) GetActiveKey() (*domain.EncryptionKey, error) {
 	`
 
 	var key domain.EncryptionKey
-	var expiresAt sql.NullTime
+	var expiresAt, retiredAt sql.NullTime
 
 	err := r.db.QueryRow(query).Scan(
 		&key.ID,
@@ -65,11 +65,12 @@ func (r *PostgresKeyRepository) GetActiveKey() (*domain.EncryptionKey, error) {
 		&key.IsActive,
 		&key.CreatedAt,
 		&expiresAt,
+		&retiredAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("no active encryption key found")
+			return nil, domain.ErrNoActiveKey
 		}
 		return nil, fmt.Errorf("failed to get active encryption key: %w", err)
 	}
@@ -77,19 +78,22 @@ func (r *PostgresKeyRepository) GetActiveKey() (*domain.EncryptionKey, error) {
 	if expiresAt.Valid {
 		key.ExpiresAt = &expiresAt.Time
 	}
+	if retiredAt.Valid {
+		key.RetiredAt = &retiredAt.Time
+	}
 
 	return &key, nil
 }
 
 func (r *PostgresKeyRepository) GetKeyByFingerprint(fingerprint string) (*domain.EncryptionKey, error) {
 	query := `
-		SELECT id, fingerprint, private_key, public_key, is_active, created_at, expires_at
+		SELECT id, fingerprint, private_key, public_key, is_active, created_at, expires_at, retired_at
 		FROM encryption_keys
 		WHERE fingerprint = $1
 	`
 
 	var key domain.EncryptionKey
-	var expiresAt sql.NullTime
+	var expiresAt, retiredAt sql.NullTime
 
 	err := r.db.QueryRow(query, fingerprint).Scan(
 		&key.ID,
@@ -99,6 +103,7 @@ func (r *PostgresKeyRepository) GetKeyByFingerprint(fingerprint string) (*domain
 		&key.IsActive,
 		&key.CreatedAt,
 		&expiresAt,
+		&retiredAt,
 	)
 
 	if err != nil {
@@ -111,13 +116,16 @@ func (r *PostgresKeyRepository) GetKeyByFingerprint(fingerprint string) (*domain
 	if expiresAt.Valid {
 		key.ExpiresAt = &expiresAt.Time
 	}
+	if retiredAt.Valid {
+		key.RetiredAt = &retiredAt.Time
+	}
 
 	return &key, nil
 }
 
 func (r *PostgresKeyRepository) ListKeys() ([]*domain.EncryptionKey, error) {
 	query := `
-		SELECT id, fingerprint, private_key, public_key, is_active, created_at, expires_at
+		SELECT id, fingerprint, private_key, public_key, is_active, created_at, expires_at, retired_at
 		FROM encryption_keys
 		ORDER BY created_at DESC
 	`
@@ -132,7 +140,7 @@ func (r *PostgresKeyRepository) ListKeys() ([]*domain.EncryptionKey, error) {
 
 	for rows.Next() {
 		var key domain.EncryptionKey
-		var expiresAt sql.NullTime
+		var expiresAt, retiredAt sql.NullTime
 
 		err := rows.Scan(
 			&key.ID,
@@ -142,6 +150,7 @@ func (r *PostgresKeyRepository) ListKeys() ([]*domain.EncryptionKey, error) {
 			&key.IsActive,
 			&key.CreatedAt,
 			&expiresAt,
+			&retiredAt,
 		)
 
 		if err != nil {
@@ -151,6 +160,9 @@ func (r *PostgresKeyRepository) ListKeys() ([]*domain.EncryptionKey, error) {
 		if expiresAt.Valid {
 			key.ExpiresAt = &expiresAt.Time
 		}
+		if retiredAt.Valid {
+			key.RetiredAt = &retiredAt.Time
+		}
 
 		keys = append(keys, &key)
 	}
@@ -182,6 +194,27 @@ func (r *PostgresKeyRepository) MarkKeyInactive(fingerprint string) error {
 	return nil
 }
 
+// RetireKey marks an inactive key as retired; the active key can never be retired
+func (r *PostgresKeyRepository) RetireKey(fingerprint string) error {
+	query := `UPDATE encryption_keys SET retired_at = NOW() WHERE fingerprint = $1 AND is_active = false AND retired_at IS NULL`
+
+	result, err := r.db.Exec(query, fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to retire key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no inactive, unretired key found with fingerprint %s", fingerprint)
+	}
+
+	return nil
+}
+
 func (r *PostgresKeyRepository) SetActiveKey(fingerprint string) error {
 	tx, err := r.db.Begin()
 	if err != nil {
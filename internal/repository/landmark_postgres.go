@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+type PostgresLandmarkRepository struct {
+	db *DBRouter
+}
+
+func NewPostgresLandmarkRepository(db *DBRouter) *PostgresLandmarkRepository {
+	return &PostgresLandmarkRepository{db: db}
+}
+
+func (r *PostgresLandmarkRepository) FindByName(ctx context.Context, name string) (*domain.Landmark, error) {
+	normalized := domain.NormalizeLandmarkName(name)
+	if normalized == "" || len(normalized) > domain.MaxLandmarkNameLength {
+		return nil, domain.ErrUnknownLandmark(name)
+	}
+
+	// Names containing the term, like "central" for "Central Park", match outright, and names
+	// with enough trigrams in common catch misspellings. An exact match always ranks first.
+	query := `
+		SELECT name, ST_Y(location) AS latitude, ST_X(location) AS longitude
+		FROM landmarks
+		WHERE strpos(normalized_name, $1) > 0
+		   OR similarity(normalized_name, $1) >= $2
+		ORDER BY normalized_name = $1 DESC, similarity(normalized_name, $1) DESC, name
+		LIMIT 1`
+
+	var landmark domain.Landmark
+	err := r.db.Reader(ctx).QueryRowContext(ctx, query, normalized, domain.MinLandmarkNameSimilarity).Scan(
+		&landmark.Name,
+		&landmark.Location.Latitude,
+		&landmark.Location.Longitude,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrUnknownLandmark(name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find landmark: %w", err)
+	}
+
+	return &landmark, nil
+}
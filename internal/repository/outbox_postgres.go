@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+type PostgresOutboxRepository struct {
+	db *DBRouter
+}
+
+func NewPostgresOutboxRepository(db *DBRouter) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{db: db}
+}
+
+// storedEvent reads back an event's JSON with its payload left raw until its type is known
+type storedEvent struct {
+	domain.PostEvent
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Append records the event in the transaction on the context, if any. The post, user and
+// tenant IDs do not serialize to JSON, so they are kept in their own columns.
+func (r *PostgresOutboxRepository) Append(ctx context.Context, event *domain.PostEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox_events (
+			event_id, event_type, aggregate_type, aggregate_id,
+			post_id, user_id, tenant_id, payload_type, event, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		)`
+
+	_, err = r.db.Writer(ctx).ExecContext(ctx, query,
+		event.ID,
+		string(event.EventType),
+		event.AggregateType,
+		event.AggregateID,
+		event.PostID,
+		event.UserID,
+		event.TenantID,
+		domain.EventPayloadType(event.Payload),
+		data,
+		time.Now(),
+	)
+	if err != nil {
+		return domain.ErrRepositoryConnection("append outbox event").WithCause(err)
+	}
+
+	return nil
+}
+
+func (r *PostgresOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxEvent, error) {
+	// Read from the primary; a lagging replica would hand out events already published
+	query := `
+		SELECT sequence, post_id, user_id, tenant_id, payload_type, event,
+			attempts, next_attempt_at, last_error, created_at
+		FROM outbox_events o
+		WHERE published_at IS NULL
+			AND dead_lettered_at IS NULL
+			AND next_attempt_at <= $1
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox_events earlier
+				WHERE earlier.aggregate_id = o.aggregate_id
+					AND earlier.published_at IS NULL
+					AND earlier.dead_lettered_at IS NULL
+					AND earlier.sequence < o.sequence
+					AND earlier.next_attempt_at > $1
+			)
+		ORDER BY sequence ASC
+		LIMIT $2`
+
+	rows, err := r.db.Primary().QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.OutboxEvent
+	for rows.Next() {
+		event, err := r.scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *PostgresOutboxRepository) MarkPublished(ctx context.Context, sequence int64, publishedAt time.Time) error {
+	query := `UPDATE outbox_events SET published_at = $2, last_error = NULL WHERE sequence = $1`
+
+	if _, err := r.db.Writer(ctx).ExecContext(ctx, query, sequence, publishedAt); err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresOutboxRepository) MarkFailed(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error {
+	query := `
+		UPDATE outbox_events SET
+			attempts = attempts + 1,
+			next_attempt_at = $2,
+			last_error = $3
+		WHERE sequence = $1`
+
+	if _, err := r.db.Writer(ctx).ExecContext(ctx, query, sequence, nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresOutboxRepository) MarkDeadLettered(ctx context.Context, sequence int64, deadLetteredAt time.Time, lastError string) error {
+	query := `
+		UPDATE outbox_events SET
+			attempts = attempts + 1,
+			dead_lettered_at = $2,
+			last_error = $3
+		WHERE sequence = $1`
+
+	if _, err := r.db.Writer(ctx).ExecContext(ctx, query, sequence, deadLetteredAt, lastError); err != nil {
+		return fmt.Errorf("failed to mark outbox event dead-lettered: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresOutboxRepository) scanOutboxEvent(rows *sql.Rows) (*domain.OutboxEvent, error) {
+	var outboxEvent domain.OutboxEvent
+	var postID domain.PostID
+	var userID domain.UserID
+	var tenantID *domain.OrganizationID
+	var payloadType string
+	var data []byte
+	var lastError sql.NullString
+
+	err := rows.Scan(
+		&outboxEvent.Sequence, &postID, &userID, &tenantID, &payloadType, &data,
+		&outboxEvent.Attempts, &outboxEvent.NextAttemptAt, &lastError, &outboxEvent.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+	}
+
+	var stored storedEvent
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal outbox event %d: %w", outboxEvent.Sequence, err)
+	}
+
+	payload, err := domain.DecodeEventPayload(payloadType, stored.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode outbox event %d: %w", outboxEvent.Sequence, err)
+	}
+
+	event := stored.PostEvent
+	event.PostID = postID
+	event.UserID = userID
+	event.TenantID = tenantID
+	event.Payload = payload
+	outboxEvent.Event = &event
+
+	if lastError.Valid {
+		outboxEvent.LastError = &lastError.String
+	}
+
+	return &outboxEvent, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+func (r *PostgresPostRepository) SaveResolution(ctx context.Context, postID domain.PostID, resolution *domain.ResolutionData) error {
+	query := `
+		INSERT INTO resolutions (
+			post_id, resolution_type, match_id, time_to_resolution_hours,
+			match_accuracy, user_satisfaction_score, resolved_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (post_id) DO UPDATE SET
+			resolution_type = EXCLUDED.resolution_type,
+			match_id = EXCLUDED.match_id,
+			time_to_resolution_hours = EXCLUDED.time_to_resolution_hours,
+			match_accuracy = EXCLUDED.match_accuracy,
+			user_satisfaction_score = EXCLUDED.user_satisfaction_score,
+			resolved_at = EXCLUDED.resolved_at`
+
+	metrics := resolution.SuccessMetrics
+	if metrics == nil {
+		metrics = &domain.SuccessMetrics{}
+	}
+
+	_, err := r.db.Writer(ctx).ExecContext(ctx, query,
+		postID.UUID(),
+		resolution.ResolutionType,
+		resolution.MatchID,
+		metrics.TimeToResolution,
+		metrics.MatchAccuracy,
+		metrics.UserSatisfactionScore,
+		resolution.ResolvedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save resolution: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresPostRepository) DeleteResolution(ctx context.Context, postID domain.PostID) error {
+	if _, err := r.db.Writer(ctx).ExecContext(ctx, `DELETE FROM resolutions WHERE post_id = $1`, postID.UUID()); err != nil {
+		return fmt.Errorf("failed to delete resolution: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresPostRepository) FindResolution(ctx context.Context, postID domain.PostID) (*domain.ResolutionData, error) {
+	query := `
+		SELECT resolution_type, match_id, time_to_resolution_hours,
+			match_accuracy, user_satisfaction_score, resolved_at
+		FROM resolutions
+		WHERE post_id = $1`
+
+	var resolution domain.ResolutionData
+	var matchID sql.NullString
+	var matchAccuracy sql.NullFloat64
+	var satisfaction sql.NullInt64
+	metrics := &domain.SuccessMetrics{}
+
+	err := r.db.Reader(ctx).QueryRowContext(ctx, query, postID.UUID()).Scan(
+		&resolution.ResolutionType,
+		&matchID,
+		&metrics.TimeToResolution,
+		&matchAccuracy,
+		&satisfaction,
+		&resolution.ResolvedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrResolutionNotFound(postID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resolution: %w", err)
+	}
+
+	if matchID.Valid {
+		resolution.MatchID = &matchID.String
+	}
+	if matchAccuracy.Valid {
+		metrics.MatchAccuracy = &matchAccuracy.Float64
+	}
+	if satisfaction.Valid {
+		score := int(satisfaction.Int64)
+		metrics.UserSatisfactionScore = &score
+	}
+	resolution.SuccessMetrics = metrics
+	resolution.ResolvedAt = resolution.ResolvedAt.UTC()
+
+	return &resolution, nil
+}
+
+// ResolutionStats groups by resolution type; the overall figures are combined from the groups.
+// Resolutions are attributed to the organization the post belongs to now.
+func (r *PostgresPostRepository) ResolutionStats(ctx context.Context, filter domain.ResolutionStatsFilter) (*domain.ResolutionStats, error) {
+	conditions := []string{"p.organization_id = $1"}
+	args := []interface{}{filter.OrganizationID.UUID()}
+
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("res.resolved_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("res.resolved_at < $%d", len(args)))
+	}
+
+	query := `
+		SELECT res.resolution_type, COUNT(*), AVG(res.time_to_resolution_hours)
+		FROM resolutions res
+		JOIN posts p ON p.id = res.post_id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		GROUP BY res.resolution_type`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resolution stats: %w", err)
+	}
+	defer rows.Close()
+
+	byType := make(map[string]domain.ResolutionTypeStats)
+	for rows.Next() {
+		var resolutionType string
+		var typeStats domain.ResolutionTypeStats
+		if err := rows.Scan(&resolutionType, &typeStats.Count, &typeStats.AverageTimeToResolutionHours); err != nil {
+			return nil, fmt.Errorf("failed to scan resolution stats: %w", err)
+		}
+		byType[resolutionType] = typeStats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read resolution stats: %w", err)
+	}
+
+	return domain.NewResolutionStats(byType), nil
+}
@@ -10,10 +10,10 @@ import (
 )
 
 type PostgresPhotoRepository struct {
-	db *sql.DB
+	db *DBRouter
 }
 
-func NewPostgresPhotoRepository(db *sql.DB) *PostgresPhotoRepository {
+func NewPostgresPhotoRepository(db *DBRouter) *PostgresPhotoRepository {
 	return &PostgresPhotoRepository{db: db}
 }
 
@@ -21,14 +21,15 @@ func (r *PostgresPhotoRepository) Save(ctx context.Context, photo *domain.Photo)
 	query := `
 		INSERT INTO post_photos (
 			id, post_id, url, thumbnail_url, caption,
-			display_order, format, size_bytes, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			display_order, format, size_bytes, width, height, created_at, filename
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''))`
 
-	_, err := r.db.ExecContext(
+	_, err := r.db.Writer(ctx).ExecContext(
 		ctx, query,
-		photo.ID, photo.PostID, photo.URL, photo.ThumbnailURL,
-		photo.Caption, photo.DisplayOrder, photo.Format,
-		photo.SizeBytes, photo.CreatedAt,
+		photo.ID(), photo.PostID(), photo.URL(), photo.ThumbnailURL(),
+		photo.Caption(), photo.DisplayOrder(), photo.Format(),
+		photo.SizeBytes(), nullableDimension(photo.Width()), nullableDimension(photo.Height()),
+		photo.CreatedAt(), photo.Filename(),
 	)
 
 	if err != nil {
@@ -41,24 +42,27 @@ func (r *PostgresPhotoRepository) Save(ctx context.Context, photo *domain.Photo)
 func (r *PostgresPhotoRepository) FindByID(ctx context.Context, id domain.PhotoID) (*domain.Photo, error) {
 	query := `
 		SELECT id, post_id, url, thumbnail_url, caption,
-		       display_order, format, size_bytes, created_at
+		       display_order, format, size_bytes, width, height, created_at,
+		       COALESCE(filename, '')
 		FROM post_photos
 		WHERE id = $1`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.Reader(ctx).QueryRowContext(ctx, query, id)
 
 	var photoID domain.PhotoID
 	var postID domain.PostID
 	var url, caption, format string
 	var displayOrder int
 	var sizeBytes int64
+	var width, height sql.NullInt64
 	var createdAt time.Time
 	var thumbnailURL sql.NullString
+	var filename string
 
 	err := row.Scan(
 		&photoID, &postID, &url, &thumbnailURL,
 		&caption, &displayOrder, &format,
-		&sizeBytes, &createdAt,
+		&sizeBytes, &width, &height, &createdAt, &filename,
 	)
 
 	if err != nil {
@@ -77,6 +81,8 @@ func (r *PostgresPhotoRepository) FindByID(ctx context.Context, id domain.PhotoI
 		photoID, postID, url, thumbnailURLStr, caption,
 		displayOrder, format, sizeBytes, createdAt,
 	)
+	photo.SetDimensions(int(width.Int64), int(height.Int64))
+	photo.RestoreFilename(filename)
 
 	return photo, nil
 }
@@ -84,12 +90,13 @@ func (r *PostgresPhotoRepository) FindByID(ctx context.Context, id domain.PhotoI
 func (r *PostgresPhotoRepository) FindByPostID(ctx context.Context, postID domain.PostID) ([]*domain.Photo, error) {
 	query := `
 		SELECT id, post_id, url, thumbnail_url, caption,
-		       display_order, format, size_bytes, created_at
+		       display_order, format, size_bytes, width, height, created_at,
+		       COALESCE(filename, '')
 		FROM post_photos
 		WHERE post_id = $1
 		ORDER BY display_order`
 
-	rows, err := r.db.QueryContext(ctx, query, postID)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, postID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find photos by post ID: %w", err)
 	}
@@ -102,13 +109,15 @@ func (r *PostgresPhotoRepository) FindByPostID(ctx context.Context, postID domai
 		var url, caption, format string
 		var displayOrder int
 		var sizeBytes int64
+		var width, height sql.NullInt64
 		var createdAt time.Time
 		var thumbnailURL sql.NullString
+		var filename string
 
 		err := rows.Scan(
 			&photoID, &postID, &url, &thumbnailURL,
 			&caption, &displayOrder, &format,
-			&sizeBytes, &createdAt,
+			&sizeBytes, &width, &height, &createdAt, &filename,
 		)
 
 		if err != nil {
@@ -124,6 +133,8 @@ func (r *PostgresPhotoRepository) FindByPostID(ctx context.Context, postID domai
 			photoID, postID, url, thumbnailURLStr, caption,
 			displayOrder, format, sizeBytes, createdAt,
 		)
+		photo.SetDimensions(int(width.Int64), int(height.Int64))
+		photo.RestoreFilename(filename)
 
 		photos = append(photos, photo)
 	}
@@ -135,13 +146,15 @@ func (r *PostgresPhotoRepository) Update(ctx context.Context, photo *domain.Phot
 	query := `
 		UPDATE post_photos SET
 			url = $2, thumbnail_url = $3, caption = $4,
-			display_order = $5, format = $6, size_bytes = $7
+			display_order = $5, format = $6, size_bytes = $7,
+			width = $8, height = $9
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(
+	result, err := r.db.Writer(ctx).ExecContext(
 		ctx, query,
-		photo.ID, photo.URL, photo.ThumbnailURL, photo.Caption,
-		photo.DisplayOrder, photo.Format, photo.SizeBytes,
+		photo.ID(), photo.URL(), photo.ThumbnailURL(), photo.Caption(),
+		photo.DisplayOrder(), photo.Format(), photo.SizeBytes(),
+		nullableDimension(photo.Width()), nullableDimension(photo.Height()),
 	)
 
 	if err != nil {
@@ -160,10 +173,60 @@ func (r *PostgresPhotoRepository) Update(ctx context.Context, photo *domain.Phot
 	return nil
 }
 
+// UpdateThumbnail stores the result of asynchronous thumbnail generation without
+// touching fields the user may have edited in the meantime
+func (r *PostgresPhotoRepository) UpdateThumbnail(ctx context.Context, id domain.PhotoID, thumbnailURL string, width, height int) error {
+	query := `
+		UPDATE post_photos SET
+			thumbnail_url = $2,
+			width = COALESCE($3, width),
+			height = COALESCE($4, height)
+		WHERE id = $1`
+
+	result, err := r.db.Writer(ctx).ExecContext(
+		ctx, query,
+		id, thumbnailURL, nullableDimension(width), nullableDimension(height),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update photo thumbnail: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("photo not found")
+	}
+
+	return nil
+}
+
+// RenumberPhotos rewrites the display order of a post's photos in one transaction. The unique
+// (post_id, display_order) constraint is deferred to commit so photos can trade places.
+func (r *PostgresPhotoRepository) RenumberPhotos(ctx context.Context, postID domain.PostID, photos []domain.Photo) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		tx := r.db.Writer(ctx)
+		if _, err := tx.ExecContext(ctx, `SET CONSTRAINTS post_photos_post_display_order_key DEFERRED`); err != nil {
+			return fmt.Errorf("failed to defer display order constraint: %w", err)
+		}
+
+		query := `UPDATE post_photos SET display_order = $3 WHERE id = $1 AND post_id = $2`
+		for i, photo := range photos {
+			if _, err := tx.ExecContext(ctx, query, photo.ID(), postID, i+1); err != nil {
+				return fmt.Errorf("failed to renumber photo %s: %w", photo.ID(), err)
+			}
+		}
+		return nil
+	})
+}
+
 func (r *PostgresPhotoRepository) Delete(ctx context.Context, id domain.PhotoID) error {
 	query := `DELETE FROM post_photos WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete photo: %w", err)
 	}
@@ -179,3 +242,8 @@ func (r *PostgresPhotoRepository) Delete(ctx context.Context, id domain.PhotoID)
 
 	return nil
 }
+
+// nullableDimension maps unknown (zero) image dimensions to NULL
+func nullableDimension(v int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(v), Valid: v > 0}
+}
@@ -8,33 +8,53 @@ import (
 	"time"
 
 	"github.com/jsarabia/fn-posts/internal/domain"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type PostgresPostRepository struct {
-	db *sql.DB
+	db *DBRouter
 }
 
-func NewPostgresPostRepository(db *sql.DB) *PostgresPostRepository {
+func NewPostgresPostRepository(db *DBRouter) *PostgresPostRepository {
 	return &PostgresPostRepository{db: db}
 }
 
+// Save inserts the post and its photos in a single transaction, so a photo that fails to save
+// leaves no post behind. It joins the caller's transaction when there is one.
 func (r *PostgresPostRepository) Save(ctx context.Context, post *domain.Post) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		return r.insertPost(ctx, r.db.Writer(ctx), post)
+	})
+}
+
+// SaveBatch inserts all posts and their photos in a single transaction
+func (r *PostgresPostRepository) SaveBatch(ctx context.Context, posts []*domain.Post) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, post := range posts {
+			if err := r.insertPost(ctx, r.db.Writer(ctx), post); err != nil {
+				return fmt.Errorf("failed to save post %s: %w", post.ID(), err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *PostgresPostRepository) insertPost(ctx context.Context, exec sqlExecutor, post *domain.Post) error {
 	query := `
 		INSERT INTO posts (
 			id, title, description, location, radius_meters,
-			status, type, user_id, organization_id, created_at, updated_at
+			status, type, user_id, organization_id, created_at, updated_at, language, tags
 		) VALUES (
 			$1, $2, $3, ST_SetSRID(ST_MakePoint($4, $5), 4326), $6,
-			$7, $8, $9, $10, $11, $12
+			$7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14
 		)`
 
-	_, err := r.db.ExecContext(
+	_, err := exec.ExecContext(
 		ctx, query,
 		post.ID(), post.Title(), post.Description(),
 		post.Location().Longitude, post.Location().Latitude, post.RadiusMeters(),
 		post.Status(), post.PostType(), post.CreatedBy(), post.OrganizationID(),
-		post.CreatedAt(), post.UpdatedAt(),
+		post.CreatedAt(), post.UpdatedAt(), post.Language(), pq.Array(post.Tags()),
 	)
 
 	if err != nil {
@@ -43,7 +63,7 @@ func (r *PostgresPostRepository) Save(ctx context.Context, post *domain.Post) er
 
 	// Save photos if any
 	for _, photo := range post.Photos() {
-		if err := r.savePhoto(ctx, &photo); err != nil {
+		if err := r.savePhoto(ctx, exec, &photo); err != nil {
 			return fmt.Errorf("failed to save photo: %w", err)
 		}
 	}
@@ -58,11 +78,11 @@ func (r *PostgresPostRepository) FindByID(ctx context.Context, id domain.PostID)
 			ST_X(location) as longitude,
 			ST_Y(location) as latitude,
 			radius_meters, status, type, user_id, organization_id,
-			created_at, updated_at
+			created_at, updated_at, cover_photo_id, COALESCE(language, ''), tags, bumped_at
 		FROM posts
 		WHERE id = $1`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.db.Reader(ctx).QueryRowContext(ctx, query, id)
 
 	var postID domain.PostID
 	var title, description string
@@ -73,13 +93,17 @@ func (r *PostgresPostRepository) FindByID(ctx context.Context, id domain.PostID)
 	var createdBy domain.UserID
 	var organizationID *domain.OrganizationID
 	var createdAt, updatedAt time.Time
+	var coverPhotoID *domain.PhotoID
+	var language string
+	var tags pq.StringArray
+	var bumpedAt *time.Time
 
 	err := row.Scan(
 		&postID, &title, &description,
 		&longitude, &latitude,
 		&radiusMeters, &status, &postType,
 		&createdBy, &organizationID,
-		&createdAt, &updatedAt,
+		&createdAt, &updatedAt, &coverPhotoID, &language, &tags, &bumpedAt,
 	)
 
 	if err != nil {
@@ -105,48 +129,83 @@ func (r *PostgresPostRepository) FindByID(ctx context.Context, id domain.PostID)
 		status, postType, createdBy, organizationID,
 		createdAt, updatedAt, photos,
 	)
+	post.RestoreCoverPhoto(coverPhotoID)
+	post.RestoreLanguage(language)
+	post.RestoreTags(tags)
+	post.RestoreBumpedAt(bumpedAt)
 
 	return post, nil
 }
 
-func (r *PostgresPostRepository) FindByUserID(ctx context.Context, userID domain.UserID, limit, offset int) ([]*domain.Post, error) {
+func (r *PostgresPostRepository) FindByUserID(ctx context.Context, userID domain.UserID, includeDrafts bool, limit, offset, maxPhotos int) ([]*domain.Post, error) {
 	query := `
 		SELECT
 			id, title, description,
 			ST_X(location) as longitude,
 			ST_Y(location) as latitude,
 			radius_meters, status, type, user_id, organization_id,
-			created_at, updated_at
-		FROM posts
-		WHERE user_id = $1
+			created_at, updated_at, cover_photo_id, COALESCE(language, ''), tags, bumped_at
+		FROM posts` + userPostsCondition(includeDrafts) + `
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find posts by user: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanPosts(ctx, rows)
+	return r.scanPosts(ctx, rows, maxPhotos)
+}
+
+func (r *PostgresPostRepository) CountByUserID(ctx context.Context, userID domain.UserID, includeDrafts bool) (int64, error) {
+	query := `SELECT COUNT(*) FROM posts` + userPostsCondition(includeDrafts)
+
+	var count int64
+	if err := r.db.Reader(ctx).QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count posts by user: %w", err)
+	}
+
+	return count, nil
 }
 
-func (r *PostgresPostRepository) FindNearby(ctx context.Context, location domain.Location, radius domain.Distance, postType *domain.PostType, limit, offset int) ([]*domain.Post, error) {
+// userPostsCondition selects a user's ($1) posts, leaving out deleted ones and, unless the
+// user is looking at their own posts, drafts
+func userPostsCondition(includeDrafts bool) string {
+	condition := `
+		WHERE user_id = $1
+		AND status <> 'deleted'`
+	if !includeDrafts {
+		condition += `
+		AND status <> 'draft'`
+	}
+	return condition
+}
+
+func (r *PostgresPostRepository) FindNearby(ctx context.Context, location domain.Location, radius domain.Distance, postType *domain.PostType, respectPostRadius bool, maxCandidates, limit, offset, maxPhotos int) ([]*domain.Post, error) {
 	baseQuery := `
 		SELECT
 			id, title, description,
 			ST_X(location) as longitude,
 			ST_Y(location) as latitude,
 			radius_meters, status, type, user_id, organization_id,
-			created_at, updated_at,
-			ST_Distance(location, ST_SetSRID(ST_MakePoint($1, $2), 4326)) as distance
-		FROM posts
+			created_at, updated_at, cover_photo_id, COALESCE(language, ''), tags, bumped_at,
+			ST_Distance(location::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography) as distance
+		FROM posts`
+
+	// Distances are in meters on the geography. Respecting the post radius only adds the
+	// post's own radius as a second bound.
+	searchRadius := "$3"
+	if respectPostRadius {
+		searchRadius = "LEAST($3, radius_meters)"
+	}
+	baseQuery += fmt.Sprintf(`
 		WHERE ST_DWithin(
-			location,
-			ST_SetSRID(ST_MakePoint($1, $2), 4326),
-			$3
+			location::geography,
+			ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography,
+			%s
 		)
-		AND status = 'active'`
+		AND status = 'active'`, searchRadius)
 
 	args := []interface{}{location.Longitude, location.Latitude, radius.Meters}
 	argIndex := 4
@@ -157,16 +216,75 @@ func (r *PostgresPostRepository) FindNearby(ctx context.Context, location domain
 		argIndex++
 	}
 
+	if maxCandidates > 0 {
+		// A wide radius in a dense area can match most of the table. The KNN ordering walks
+		// the spatial index nearest first and stops after maxCandidates rows, so only those
+		// are sorted and paged.
+		baseQuery = fmt.Sprintf(`
+		SELECT * FROM (%s
+		ORDER BY location <-> ST_SetSRID(ST_MakePoint($1, $2), 4326)
+		LIMIT $%d
+		) candidates`, baseQuery, argIndex)
+		args = append(args, maxCandidates)
+		argIndex++
+	}
+
 	baseQuery += fmt.Sprintf(" ORDER BY distance LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
 	args = append(args, limit, offset)
 
-	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, baseQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find nearby posts: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanPostsWithDistance(ctx, rows)
+	return r.scanPostsWithDistance(ctx, rows, maxPhotos)
+}
+
+func (r *PostgresPostRepository) FindAlongRoute(ctx context.Context, route domain.Route, corridor domain.Distance, postType *domain.PostType, limit, offset, maxPhotos int) ([]*domain.Post, error) {
+	args := make([]interface{}, 0, 2*len(route.Points)+4)
+	points := make([]string, len(route.Points))
+	for i, point := range route.Points {
+		points[i] = fmt.Sprintf("ST_MakePoint($%d, $%d)", 2*i+1, 2*i+2)
+		args = append(args, point.Longitude, point.Latitude)
+	}
+	argIndex := len(args) + 1
+
+	// The corridor is measured in meters on the geography; position is the fraction (0-1)
+	// of the route travelled before the point closest to the post
+	query := fmt.Sprintf(`
+		WITH route AS (
+			SELECT ST_SetSRID(ST_MakeLine(ARRAY[%s]), 4326) AS line
+		)
+		SELECT
+			id, title, description,
+			ST_X(location) as longitude,
+			ST_Y(location) as latitude,
+			radius_meters, status, type, user_id, organization_id,
+			created_at, updated_at, cover_photo_id, COALESCE(language, ''), tags, bumped_at,
+			ST_LineLocatePoint(route.line, location) as position
+		FROM posts, route
+		WHERE ST_DWithin(location::geography, route.line::geography, $%d)
+		AND status = 'active'`, strings.Join(points, ", "), argIndex)
+	args = append(args, corridor.Meters)
+	argIndex++
+
+	if postType != nil {
+		query += fmt.Sprintf(" AND type = $%d", argIndex)
+		args = append(args, *postType)
+		argIndex++
+	}
+
+	query += fmt.Sprintf(" ORDER BY position, id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find posts along route: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanPostsWithDistance(ctx, rows, maxPhotos)
 }
 
 func (r *PostgresPostRepository) Update(ctx context.Context, post *domain.Post) error {
@@ -174,16 +292,23 @@ func (r *PostgresPostRepository) Update(ctx context.Context, post *domain.Post)
 		UPDATE posts SET
 			title = $2, description = $3,
 			location = ST_SetSRID(ST_MakePoint($4, $5), 4326),
-			radius_meters = $6, status = $7, updated_at = $8
+			radius_meters = $6, status = $7, updated_at = $8,
+			cover_photo_id = $9, user_id = $10, tags = $11, type = $12
 		WHERE id = $1`
 
-	result, err := r.db.ExecContext(
-		ctx, query,
+	args := []interface{}{
 		post.ID(), post.Title(), post.Description(),
 		post.Location().Longitude, post.Location().Latitude,
 		post.RadiusMeters(), post.Status(), post.UpdatedAt(),
-	)
+		post.ChosenCoverPhotoID(), post.CreatedBy(), pq.Array(post.Tags()), post.PostType(),
+	}
+	expectedVersion, conditional := domain.ExpectedVersion(ctx)
+	if conditional {
+		query += " AND updated_at = $13"
+		args = append(args, expectedVersion)
+	}
 
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to update post: %w", err)
 	}
@@ -194,16 +319,53 @@ func (r *PostgresPostRepository) Update(ctx context.Context, post *domain.Post)
 	}
 
 	if rowsAffected == 0 {
+		if conditional {
+			return domain.ErrPostModified(post.ID())
+		}
 		return fmt.Errorf("post not found")
 	}
 
 	return nil
 }
 
+// Bump stores the post's bump time only while the post is active and was last listed at
+// least minInterval earlier, so the interval also holds between concurrent bumps
+func (r *PostgresPostRepository) Bump(ctx context.Context, post *domain.Post, minInterval time.Duration) (bool, error) {
+	bumpedAt := post.BumpedAt()
+	if bumpedAt == nil {
+		return false, fmt.Errorf("post %s has not been bumped", post.ID())
+	}
+
+	query := `
+		UPDATE posts SET bumped_at = $2, updated_at = $3
+		WHERE id = $1
+			AND status = 'active'
+			AND COALESCE(bumped_at, created_at) <= $4`
+
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query,
+		post.ID(), *bumpedAt, post.UpdatedAt(), bumpedAt.Add(-minInterval))
+	if err != nil {
+		return false, fmt.Errorf("failed to bump post: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
 func (r *PostgresPostRepository) Delete(ctx context.Context, id domain.PostID) error {
 	query := `UPDATE posts SET status = 'deleted', updated_at = NOW() WHERE id = $1`
+	args := []interface{}{id}
+	expectedVersion, conditional := domain.ExpectedVersion(ctx)
+	if conditional {
+		query += " AND updated_at = $2"
+		args = append(args, expectedVersion)
+	}
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.Writer(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
@@ -214,6 +376,9 @@ func (r *PostgresPostRepository) Delete(ctx context.Context, id domain.PostID) e
 	}
 
 	if rowsAffected == 0 {
+		if conditional {
+			return domain.ErrPostModified(id)
+		}
 		return fmt.Errorf("post not found")
 	}
 
@@ -222,23 +387,44 @@ func (r *PostgresPostRepository) Delete(ctx context.Context, id domain.PostID) e
 
 func (r *PostgresPostRepository) List(ctx context.Context, filters domain.PostFilters) ([]*domain.Post, error) {
 	filters.SetDefaults()
+	return r.list(ctx, filters)
+}
+
+func (r *PostgresPostRepository) ListPage(ctx context.Context, filters domain.PostFilters) ([]*domain.Post, bool, error) {
+	filters.SetDefaults()
+	pageSize := filters.Limit
+
+	// Ask for one post more than the page to learn whether another page follows
+	filters.Limit++
+	posts, err := r.list(ctx, filters)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(posts) > pageSize {
+		return posts[:pageSize], true, nil
+	}
+	return posts, false, nil
+}
 
+// list runs the list query with filters as given, without applying defaults
+func (r *PostgresPostRepository) list(ctx context.Context, filters domain.PostFilters) ([]*domain.Post, error) {
 	query, args := r.buildListQuery(filters)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list posts: %w", err)
 	}
 	defer rows.Close()
 
-	return r.scanPosts(ctx, rows)
+	return r.scanPosts(ctx, rows, filters.MaxPhotos)
 }
 
 func (r *PostgresPostRepository) Count(ctx context.Context, filters domain.PostFilters) (int64, error) {
 	query, args := r.buildCountQuery(filters)
 
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := r.db.Reader(ctx).QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count posts: %w", err)
 	}
@@ -246,6 +432,100 @@ func (r *PostgresPostRepository) Count(ctx context.Context, filters domain.PostF
 	return count, nil
 }
 
+func (r *PostgresPostRepository) CountDistinctPosters(ctx context.Context, orgID domain.OrganizationID, since time.Time) (int64, error) {
+	// Matches idx_posts_org_posters so the count is answered from the index alone
+	query := `
+		SELECT COUNT(DISTINCT user_id)
+		FROM posts
+		WHERE organization_id = $1
+		AND created_at >= $2
+		AND status NOT IN ('draft', 'deleted')`
+
+	var count int64
+	if err := r.db.Reader(ctx).QueryRowContext(ctx, query, orgID.UUID(), since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count distinct posters: %w", err)
+	}
+
+	return count, nil
+}
+
+// Heatmap snaps each post to the nearest grid point and counts posts per point, so
+// every returned location is the centroid of its grid cell. Only public posts are counted
+// and cells with fewer than domain.MinHeatmapCellPosts posts are left out.
+func (r *PostgresPostRepository) Heatmap(ctx context.Context, filters domain.HeatmapFilters) ([]domain.HeatmapCell, error) {
+	box := filters.BoundingBox
+	query := `
+		SELECT ST_X(cell) AS longitude, ST_Y(cell) AS latitude, COUNT(*) AS count
+		FROM (
+			SELECT ST_SnapToGrid(location, $5) AS cell
+			FROM posts
+			WHERE location && ST_MakeEnvelope($1, $2, $3, $4, 4326)
+			AND status = ANY($6)`
+
+	statuses := make([]string, len(domain.HeatmapStatuses))
+	for i, status := range domain.HeatmapStatuses {
+		statuses[i] = string(status)
+	}
+	args := []interface{}{
+		box.SouthWest.Longitude, box.SouthWest.Latitude,
+		box.NorthEast.Longitude, box.NorthEast.Latitude,
+		filters.GridDegrees, pq.Array(statuses),
+	}
+	argIndex := 7
+
+	if filters.Status != nil {
+		query += fmt.Sprintf(" AND status = $%d", argIndex)
+		args = append(args, *filters.Status)
+		argIndex++
+	}
+
+	if filters.Type != nil {
+		query += fmt.Sprintf(" AND type = $%d", argIndex)
+		args = append(args, *filters.Type)
+		argIndex++
+	}
+
+	if filters.CreatedAfter != nil {
+		query += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		args = append(args, *filters.CreatedAfter)
+		argIndex++
+	}
+
+	if filters.CreatedBefore != nil {
+		query += fmt.Sprintf(" AND created_at < $%d", argIndex)
+		args = append(args, *filters.CreatedBefore)
+		argIndex++
+	}
+
+	query += fmt.Sprintf(`
+		) grid
+		GROUP BY longitude, latitude
+		HAVING COUNT(*) >= $%d
+		ORDER BY count DESC`, argIndex)
+	args = append(args, domain.MinHeatmapCellPosts)
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build heatmap: %w", err)
+	}
+	defer rows.Close()
+
+	var cells []domain.HeatmapCell
+	for rows.Next() {
+		var cell domain.HeatmapCell
+		if err := rows.Scan(&cell.Location.Longitude, &cell.Location.Latitude, &cell.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan heatmap cell: %w", err)
+		}
+		cells = append(cells, cell)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read heatmap: %w", err)
+	}
+
+	return cells, nil
+}
+
 func (r *PostgresPostRepository) buildListQuery(filters domain.PostFilters) (string, []interface{}) {
 	baseQuery := `
 		SELECT
@@ -253,7 +533,7 @@ func (r *PostgresPostRepository) buildListQuery(filters domain.PostFilters) (str
 			ST_X(location) as longitude,
 			ST_Y(location) as latitude,
 			radius_meters, status, type, user_id, organization_id,
-			created_at, updated_at
+			created_at, updated_at, cover_photo_id, COALESCE(language, ''), tags, bumped_at
 		FROM posts WHERE 1=1`
 
 	conditions := []string{}
@@ -264,6 +544,9 @@ func (r *PostgresPostRepository) buildListQuery(filters domain.PostFilters) (str
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
 		args = append(args, *filters.Status)
 		argIndex++
+	} else {
+		// Archived posts only show up when asked for explicitly
+		conditions = append(conditions, "status <> 'archived'")
 	}
 
 	if filters.Type != nil {
@@ -284,6 +567,26 @@ func (r *PostgresPostRepository) buildListQuery(filters domain.PostFilters) (str
 		argIndex++
 	}
 
+	if filters.Language != nil {
+		conditions = append(conditions, fmt.Sprintf("language = $%d", argIndex))
+		args = append(args, *filters.Language)
+		argIndex++
+	}
+
+	if len(filters.Tags) > 0 {
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d::text[]", argIndex))
+		args = append(args, pq.Array(filters.Tags))
+		argIndex++
+	}
+
+	if len(filters.OrganizationIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("organization_id = ANY($%d::uuid[])", argIndex))
+		args = append(args, organizationIDArray(filters.OrganizationIDs))
+		argIndex++
+	}
+
+	conditions, args, argIndex = appendDraftVisibility(filters, conditions, args, argIndex)
+
 	if filters.Location != nil && filters.RadiusMeters != nil {
 		conditions = append(conditions, fmt.Sprintf(
 			"ST_DWithin(location, ST_SetSRID(ST_MakePoint($%d, $%d), 4326), $%d)",
@@ -292,17 +595,62 @@ func (r *PostgresPostRepository) buildListQuery(filters domain.PostFilters) (str
 		argIndex += 3
 	}
 
+	sortColumn := postSortColumn(filters.Sort)
+
+	offset := filters.Offset
+	if filters.Cursor != nil {
+		// Keyset pagination: continue strictly after the cursor in (sort column, id) order
+		conditions = append(conditions, fmt.Sprintf("(%s, id) < ($%d, $%d)", sortColumn, argIndex, argIndex+1))
+		args = append(args, filters.Cursor.CreatedAt, filters.Cursor.ID)
+		argIndex += 2
+		offset = 0
+	}
+
 	if len(conditions) > 0 {
 		baseQuery += " AND " + strings.Join(conditions, " AND ")
 	}
 
-	baseQuery += " ORDER BY created_at DESC"
+	// id breaks ties between posts listed at the same instant so pages never overlap
+	baseQuery += fmt.Sprintf(" ORDER BY %s DESC, id DESC", sortColumn)
 	baseQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, filters.Limit, filters.Offset)
+	args = append(args, filters.Limit, offset)
 
 	return baseQuery, args
 }
 
+// postSortColumn is the expression a listing in the given order sorts by
+func postSortColumn(sort domain.PostSort) string {
+	if sort == domain.PostSortBumpedAt {
+		return "COALESCE(bumped_at, created_at)"
+	}
+	return "created_at"
+}
+
+// appendDraftVisibility hides drafts from everyone except their author, unless the filters
+// ask for every draft
+func appendDraftVisibility(filters domain.PostFilters, conditions []string, args []interface{}, argIndex int) ([]string, []interface{}, int) {
+	if filters.AllDrafts {
+		return conditions, args, argIndex
+	}
+
+	if filters.ViewerID == nil {
+		return append(conditions, "status <> 'draft'"), args, argIndex
+	}
+
+	conditions = append(conditions, fmt.Sprintf("(status <> 'draft' OR user_id = $%d)", argIndex))
+	args = append(args, *filters.ViewerID)
+	return conditions, args, argIndex + 1
+}
+
+// organizationIDArray converts organization IDs into a Postgres array parameter
+func organizationIDArray(ids []domain.OrganizationID) interface{} {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return pq.Array(values)
+}
+
 func (r *PostgresPostRepository) buildCountQuery(filters domain.PostFilters) (string, []interface{}) {
 	baseQuery := "SELECT COUNT(*) FROM posts WHERE 1=1"
 
@@ -314,6 +662,9 @@ func (r *PostgresPostRepository) buildCountQuery(filters domain.PostFilters) (st
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
 		args = append(args, *filters.Status)
 		argIndex++
+	} else {
+		// Archived posts only show up when asked for explicitly
+		conditions = append(conditions, "status <> 'archived'")
 	}
 
 	if filters.Type != nil {
@@ -334,6 +685,26 @@ func (r *PostgresPostRepository) buildCountQuery(filters domain.PostFilters) (st
 		argIndex++
 	}
 
+	if filters.Language != nil {
+		conditions = append(conditions, fmt.Sprintf("language = $%d", argIndex))
+		args = append(args, *filters.Language)
+		argIndex++
+	}
+
+	if len(filters.Tags) > 0 {
+		conditions = append(conditions, fmt.Sprintf("tags @> $%d::text[]", argIndex))
+		args = append(args, pq.Array(filters.Tags))
+		argIndex++
+	}
+
+	if len(filters.OrganizationIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf("organization_id = ANY($%d::uuid[])", argIndex))
+		args = append(args, organizationIDArray(filters.OrganizationIDs))
+		argIndex++
+	}
+
+	conditions, args, _ = appendDraftVisibility(filters, conditions, args, argIndex)
+
 	if len(conditions) > 0 {
 		baseQuery += " AND " + strings.Join(conditions, " AND ")
 	}
@@ -378,75 +749,44 @@ func (r *PostgresPostRepository) scanPost(row *sql.Row) (*domain.Post, error) {
 	return post, nil
 }
 
-func (r *PostgresPostRepository) scanPosts(ctx context.Context, rows *sql.Rows) ([]*domain.Post, error) {
-	var posts []*domain.Post
+// scanPosts reads list rows and loads at most maxPhotos photos per post (0 loads all)
+func (r *PostgresPostRepository) scanPosts(ctx context.Context, rows *sql.Rows, maxPhotos int) ([]*domain.Post, error) {
+	var scanned []postRow
 
 	for rows.Next() {
-		var id domain.PostID
-		var title, description string
-		var longitude, latitude float64
-		var radiusMeters int
-		var status domain.PostStatus
-		var postType domain.PostType
-		var createdBy domain.UserID
-		var organizationID *domain.OrganizationID
-		var createdAt, updatedAt time.Time
+		var row postRow
 
 		err := rows.Scan(
-			&id, &title, &description,
-			&longitude, &latitude,
-			&radiusMeters, &status, &postType,
-			&createdBy, &organizationID,
-			&createdAt, &updatedAt,
+			&row.id, &row.title, &row.description,
+			&row.longitude, &row.latitude,
+			&row.radiusMeters, &row.status, &row.postType,
+			&row.createdBy, &row.organizationID,
+			&row.createdAt, &row.updatedAt, &row.coverPhotoID, &row.language, &row.tags, &row.bumpedAt,
 		)
 
 		if err != nil {
 			return nil, err
 		}
 
-		location := domain.Location{
-			Latitude:  latitude,
-			Longitude: longitude,
-		}
-
-		// Load photos for each post
-		photos, err := r.findPhotosByPostID(ctx, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load photos for post %s: %w", id, err)
-		}
-
-		post := domain.ReconstructPost(
-			id, title, description, location, radiusMeters,
-			status, postType, createdBy, organizationID,
-			createdAt, updatedAt, photos,
-		)
-
-		posts = append(posts, post)
+		scanned = append(scanned, row)
 	}
 
-	return posts, nil
+	return r.reconstructPosts(ctx, scanned, maxPhotos)
 }
 
-func (r *PostgresPostRepository) scanPostsWithDistance(ctx context.Context, rows *sql.Rows) ([]*domain.Post, error) {
-	var posts []*domain.Post
+func (r *PostgresPostRepository) scanPostsWithDistance(ctx context.Context, rows *sql.Rows, maxPhotos int) ([]*domain.Post, error) {
+	var scanned []postRow
 
 	for rows.Next() {
-		var id domain.PostID
-		var title, description string
-		var longitude, latitude, distance float64
-		var radiusMeters int
-		var status domain.PostStatus
-		var postType domain.PostType
-		var createdBy domain.UserID
-		var organizationID *domain.OrganizationID
-		var createdAt, updatedAt time.Time
+		var row postRow
+		var distance float64
 
 		err := rows.Scan(
-			&id, &title, &description,
-			&longitude, &latitude,
-			&radiusMeters, &status, &postType,
-			&createdBy, &organizationID,
-			&createdAt, &updatedAt,
+			&row.id, &row.title, &row.description,
+			&row.longitude, &row.latitude,
+			&row.radiusMeters, &row.status, &row.postType,
+			&row.createdBy, &row.organizationID,
+			&row.createdAt, &row.updatedAt, &row.coverPhotoID, &row.language, &row.tags, &row.bumpedAt,
 			&distance,
 		)
 
@@ -454,22 +794,58 @@ func (r *PostgresPostRepository) scanPostsWithDistance(ctx context.Context, rows
 			return nil, err
 		}
 
-		location := domain.Location{
-			Latitude:  latitude,
-			Longitude: longitude,
-		}
+		scanned = append(scanned, row)
+	}
 
-		// Load photos for each post
-		photos, err := r.findPhotosByPostID(ctx, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load photos for post %s: %w", id, err)
+	return r.reconstructPosts(ctx, scanned, maxPhotos)
+}
+
+// postRow holds the columns of a posts row until its photos are loaded
+type postRow struct {
+	id                  domain.PostID
+	title, description  string
+	longitude, latitude float64
+	radiusMeters        int
+	status              domain.PostStatus
+	postType            domain.PostType
+	createdBy           domain.UserID
+	organizationID      *domain.OrganizationID
+	createdAt           time.Time
+	updatedAt           time.Time
+	coverPhotoID        *domain.PhotoID
+	language            string
+	tags                pq.StringArray
+	bumpedAt            *time.Time
+}
+
+// reconstructPosts loads the photos of every row in one query and builds the aggregates
+func (r *PostgresPostRepository) reconstructPosts(ctx context.Context, scanned []postRow, maxPhotos int) ([]*domain.Post, error) {
+	ids := make([]domain.PostID, len(scanned))
+	for i, row := range scanned {
+		ids[i] = row.id
+	}
+
+	photosByPost, err := r.findPhotosByPostIDs(ctx, ids, maxPhotos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load photos: %w", err)
+	}
+
+	posts := make([]*domain.Post, 0, len(scanned))
+	for _, row := range scanned {
+		location := domain.Location{
+			Latitude:  row.latitude,
+			Longitude: row.longitude,
 		}
 
 		post := domain.ReconstructPost(
-			id, title, description, location, radiusMeters,
-			status, postType, createdBy, organizationID,
-			createdAt, updatedAt, photos,
+			row.id, row.title, row.description, location, row.radiusMeters,
+			row.status, row.postType, row.createdBy, row.organizationID,
+			row.createdAt, row.updatedAt, photosByPost[row.id],
 		)
+		post.RestoreCoverPhoto(row.coverPhotoID)
+		post.RestoreLanguage(row.language)
+		post.RestoreTags(row.tags)
+		post.RestoreBumpedAt(row.bumpedAt)
 
 		posts = append(posts, post)
 	}
@@ -477,18 +853,19 @@ func (r *PostgresPostRepository) scanPostsWithDistance(ctx context.Context, rows
 	return posts, nil
 }
 
-func (r *PostgresPostRepository) savePhoto(ctx context.Context, photo *domain.Photo) error {
+func (r *PostgresPostRepository) savePhoto(ctx context.Context, exec sqlExecutor, photo *domain.Photo) error {
 	query := `
 		INSERT INTO post_photos (
 			id, post_id, url, thumbnail_url, caption,
-			display_order, format, size_bytes, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			display_order, format, size_bytes, width, height, created_at, filename
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''))`
 
-	_, err := r.db.ExecContext(
+	_, err := exec.ExecContext(
 		ctx, query,
 		photo.ID(), photo.PostID(), photo.URL(), photo.ThumbnailURL(),
 		photo.Caption(), photo.DisplayOrder(), photo.Format(),
-		photo.SizeBytes(), photo.CreatedAt(),
+		photo.SizeBytes(), nullableDimension(photo.Width()), nullableDimension(photo.Height()),
+		photo.CreatedAt(), photo.Filename(),
 	)
 
 	return err
@@ -497,17 +874,69 @@ func (r *PostgresPostRepository) savePhoto(ctx context.Context, photo *domain.Ph
 func (r *PostgresPostRepository) findPhotosByPostID(ctx context.Context, postID domain.PostID) ([]domain.Photo, error) {
 	query := `
 		SELECT id, post_id, url, thumbnail_url, caption,
-		       display_order, format, size_bytes, created_at
+		       display_order, format, size_bytes, width, height, created_at,
+		       COALESCE(filename, '')
 		FROM post_photos
 		WHERE post_id = $1
 		ORDER BY display_order`
 
-	rows, err := r.db.QueryContext(ctx, query, postID)
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, postID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanPhotos(rows)
+}
+
+// findPhotosByPostIDs loads the photos of several posts at once. When capped to maxPhotos
+// per post (0 keeps them all) the cover photo is always kept, followed by display order.
+func (r *PostgresPostRepository) findPhotosByPostIDs(ctx context.Context, postIDs []domain.PostID, maxPhotos int) (map[domain.PostID][]domain.Photo, error) {
+	photosByPost := make(map[domain.PostID][]domain.Photo, len(postIDs))
+	if len(postIDs) == 0 {
+		return photosByPost, nil
+	}
+
+	ids := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT id, post_id, url, thumbnail_url, caption,
+		       display_order, format, size_bytes, width, height, created_at,
+		       COALESCE(filename, '')
+		FROM (
+			SELECT pp.*, ROW_NUMBER() OVER (
+				PARTITION BY pp.post_id
+				ORDER BY (pp.id = p.cover_photo_id) IS TRUE DESC, pp.display_order
+			) AS photo_rank
+			FROM post_photos pp
+			JOIN posts p ON p.id = pp.post_id
+			WHERE pp.post_id = ANY($1::uuid[])
+		) ranked
+		WHERE $2 = 0 OR photo_rank <= $2
+		ORDER BY post_id, display_order`
+
+	rows, err := r.db.Reader(ctx).QueryContext(ctx, query, pq.Array(ids), maxPhotos)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	photos, err := scanPhotos(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, photo := range photos {
+		photosByPost[photo.PostID()] = append(photosByPost[photo.PostID()], photo)
+	}
+
+	return photosByPost, nil
+}
+
+func scanPhotos(rows *sql.Rows) ([]domain.Photo, error) {
 	var photos []domain.Photo
 	for rows.Next() {
 		var photoID domain.PhotoID
@@ -518,12 +947,14 @@ func (r *PostgresPostRepository) findPhotosByPostID(ctx context.Context, postID
 		var displayOrder int
 		var format string
 		var sizeBytes int64
+		var width, height sql.NullInt64
 		var createdAt time.Time
+		var filename string
 
 		err := rows.Scan(
 			&photoID, &postID, &url, &thumbnailURL,
 			&caption, &displayOrder, &format,
-			&sizeBytes, &createdAt,
+			&sizeBytes, &width, &height, &createdAt, &filename,
 		)
 
 		if err != nil {
@@ -539,6 +970,8 @@ func (r *PostgresPostRepository) findPhotosByPostID(ctx context.Context, postID
 			photoID, postID, url, thumbnailURLStr, caption,
 			displayOrder, format, sizeBytes, createdAt,
 		)
+		photo.SetDimensions(int(width.Int64), int(height.Int64))
+		photo.RestoreFilename(filename)
 
 		photos = append(photos, *photo)
 	}
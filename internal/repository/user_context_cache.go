@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// userCacheSweepThreshold is the cache size above which expired entries are swept on write
+const userCacheSweepThreshold = 1024
+
+// CachingUserContextRepository remembers privacy-safe users for a short TTL, so building
+// several events for the same users within a batch or request costs a single lookup
+type CachingUserContextRepository struct {
+	next domain.UserContextRepository
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[domain.UserID]cachedUser
+}
+
+type cachedUser struct {
+	user      *domain.PrivacySafeUser
+	expiresAt time.Time
+}
+
+func NewCachingUserContextRepository(next domain.UserContextRepository, ttl time.Duration) *CachingUserContextRepository {
+	return &CachingUserContextRepository{
+		next:    next,
+		ttl:     ttl,
+		entries: make(map[domain.UserID]cachedUser),
+	}
+}
+
+func (r *CachingUserContextRepository) GetPrivacySafeUser(ctx context.Context, userID domain.UserID) (*domain.PrivacySafeUser, error) {
+	if user, ok := r.lookup(userID, time.Now()); ok {
+		return user, nil
+	}
+
+	user, err := r.next.GetPrivacySafeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(map[domain.UserID]*domain.PrivacySafeUser{userID: user})
+	return user, nil
+}
+
+// GetPrivacySafeUsers serves cached users and fetches the rest in one batch
+func (r *CachingUserContextRepository) GetPrivacySafeUsers(ctx context.Context, userIDs []domain.UserID) (map[domain.UserID]*domain.PrivacySafeUser, error) {
+	result := make(map[domain.UserID]*domain.PrivacySafeUser, len(userIDs))
+	seen := make(map[domain.UserID]bool, len(userIDs))
+	var missing []domain.UserID
+
+	now := time.Now()
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if user, ok := r.lookup(userID, now); ok {
+			result[userID] = user
+			continue
+		}
+		missing = append(missing, userID)
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := r.next.GetPrivacySafeUsers(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	r.store(fetched)
+
+	for userID, user := range fetched {
+		result[userID] = user
+	}
+	return result, nil
+}
+
+func (r *CachingUserContextRepository) lookup(userID domain.UserID, now time.Time) (*domain.PrivacySafeUser, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[userID]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(r.entries, userID)
+		return nil, false
+	}
+	return entry.user, true
+}
+
+func (r *CachingUserContextRepository) store(users map[domain.UserID]*domain.PrivacySafeUser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if len(r.entries) >= userCacheSweepThreshold {
+		for userID, entry := range r.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(r.entries, userID)
+			}
+		}
+	}
+
+	expiresAt := now.Add(r.ttl)
+	for userID, user := range users {
+		r.entries[userID] = cachedUser{user: user, expiresAt: expiresAt}
+	}
+}
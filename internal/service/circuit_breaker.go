@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a call is rejected because the breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState represents the state of a circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerStats is a point-in-time snapshot of a breaker, exposed for monitoring
+type CircuitBreakerStats struct {
+	Name                string       `json:"name"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	TotalSuccesses      int64        `json:"total_successes"`
+	TotalFailures       int64        `json:"total_failures"`
+	TotalRejected       int64        `json:"total_rejected"`
+	TimesOpened         int64        `json:"times_opened"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+}
+
+// CircuitBreaker trips after a number of consecutive failures and rejects calls
+// until the reset timeout elapses, after which a single probe call is allowed through.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	resetTimeout     time.Duration
+	now              func() time.Time
+
+	mu                  sync.Mutex
+	state               CircuitState
+	consecutiveFailures int
+	openedAt            time.Time
+	probeInFlight       bool
+
+	totalSuccesses int64
+	totalFailures  int64
+	totalRejected  int64
+	timesOpened    int64
+}
+
+// NewCircuitBreaker creates a new circuit breaker
+func NewCircuitBreaker(name string, failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = 5
+	}
+	if resetTimeout <= 0 {
+		resetTimeout = 30 * time.Second
+	}
+
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+		now:              time.Now,
+		state:            CircuitClosed,
+	}
+}
+
+// SetClock replaces the time source the reset timeout is measured with, so tests can move
+// time forward instead of waiting
+func (cb *CircuitBreaker) SetClock(now func() time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.now = now
+}
+
+// Execute runs fn if the breaker allows it and records the outcome
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	if err := cb.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	if errors.Is(err, context.Canceled) {
+		// The caller went away; that says nothing about backend health
+		cb.release()
+		return err
+	}
+	cb.record(err)
+	return err
+}
+
+// Stats returns a snapshot of the breaker state and counters
+func (cb *CircuitBreaker) Stats() CircuitBreakerStats {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	stats := CircuitBreakerStats{
+		Name:                cb.name,
+		State:               cb.currentState(),
+		ConsecutiveFailures: cb.consecutiveFailures,
+		TotalSuccesses:      cb.totalSuccesses,
+		TotalFailures:       cb.totalFailures,
+		TotalRejected:       cb.totalRejected,
+		TimesOpened:         cb.timesOpened,
+	}
+	if cb.state != CircuitClosed {
+		openedAt := cb.openedAt
+		stats.OpenedAt = &openedAt
+	}
+
+	return stats
+}
+
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.currentState() {
+	case CircuitOpen:
+		cb.totalRejected++
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		// Only one probe at a time while half-open
+		if cb.probeInFlight {
+			cb.totalRejected++
+			return ErrCircuitOpen
+		}
+		cb.state = CircuitHalfOpen
+		cb.probeInFlight = true
+	}
+
+	return nil
+}
+
+func (cb *CircuitBreaker) release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probeInFlight = false
+}
+
+func (cb *CircuitBreaker) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probeInFlight = false
+
+	if err == nil {
+		cb.totalSuccesses++
+		cb.consecutiveFailures = 0
+		cb.state = CircuitClosed
+		return
+	}
+
+	cb.totalFailures++
+	cb.consecutiveFailures++
+
+	if cb.state == CircuitHalfOpen || cb.consecutiveFailures >= cb.failureThreshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+		cb.timesOpened++
+	}
+}
+
+// currentState resolves an expired open state to half-open. Caller must hold mu.
+func (cb *CircuitBreaker) currentState() CircuitState {
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.resetTimeout {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// claimPurgeBatchSize bounds how many claims a single delete removes during a purge run
+const claimPurgeBatchSize = 500
+
+// ClaimService lets claimants prove ownership of an item reported on a found post, and the
+// post owner review those claims before any contact is exchanged
+type ClaimService struct {
+	claimRepo              domain.ClaimRepository
+	postRepo               domain.PostRepository
+	userContextRepo        domain.UserContextRepository
+	eventPublisher         domain.EventPublisher
+	contactExchangeService *ContactExchangeService
+	config                 config.ClaimConfig
+}
+
+func NewClaimService(
+	claimRepo domain.ClaimRepository,
+	postRepo domain.PostRepository,
+	userContextRepo domain.UserContextRepository,
+	eventPublisher domain.EventPublisher,
+	contactExchangeService *ContactExchangeService,
+	cfg config.ClaimConfig,
+) *ClaimService {
+	return &ClaimService{
+		claimRepo:              claimRepo,
+		postRepo:               postRepo,
+		userContextRepo:        userContextRepo,
+		eventPublisher:         eventPublisher,
+		contactExchangeService: contactExchangeService,
+		config:                 cfg,
+	}
+}
+
+type CreateClaimCommand struct {
+	PostID         domain.PostID
+	ClaimantUserID domain.UserID
+	ProofText      string
+	ProofPhotoURLs []string
+}
+
+type ReviewClaimCommand struct {
+	PostID      domain.PostID
+	ClaimID     domain.ClaimID
+	OwnerUserID domain.UserID
+	// StartContactExchange opens a contact exchange request on behalf of the claimant, pre-filled
+	// with their proof, when the claim is approved
+	StartContactExchange bool
+}
+
+// CreateClaim records a claimant's proof of ownership on an active found post
+func (s *ClaimService) CreateClaim(ctx context.Context, cmd CreateClaimCommand) (*domain.Claim, error) {
+	post, err := s.postRepo.FindByID(ctx, cmd.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if post.PostType() != domain.PostTypeFound || post.Status() != domain.PostStatusActive {
+		return nil, domain.ErrPostNotClaimable(cmd.PostID)
+	}
+
+	claim, err := domain.NewClaim(
+		cmd.PostID,
+		cmd.ClaimantUserID,
+		post.CreatedBy(),
+		cmd.ProofText,
+		cmd.ProofPhotoURLs,
+		s.retention(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.claimRepo.Save(ctx, claim); err != nil {
+		return nil, fmt.Errorf("failed to save claim: %w", err)
+	}
+
+	claimant, owner, err := s.participants(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+
+	eventData := &domain.ClaimCreatedEventData{
+		Claim:       claim.ToClaimData(),
+		RelatedPost: post.ToPostData(),
+		Claimant:    domain.ToPrivacySafeUserExtendedFromUser(claimant),
+		Owner:       domain.ToPrivacySafeUserExtendedFromUser(owner),
+	}
+	s.publish(ctx, domain.EventTypeClaimCreated, claim, cmd.ClaimantUserID, post, eventData)
+
+	return claim, nil
+}
+
+// ListClaims returns the claims made on a post. Only the post owner may review them.
+func (s *ClaimService) ListClaims(ctx context.Context, postID domain.PostID, userID domain.UserID, limit, offset int) ([]*domain.Claim, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if !post.CreatedBy().Equals(userID) {
+		return nil, domain.ErrUnauthorizedOperation(userID, "list claims")
+	}
+
+	return s.claimRepo.FindByPostID(ctx, postID, limit, offset)
+}
+
+// ApproveClaim accepts a claim and, when asked to, starts a contact exchange for the claimant.
+// The returned contact exchange request is nil unless one was started.
+func (s *ClaimService) ApproveClaim(ctx context.Context, cmd ReviewClaimCommand) (*domain.Claim, *domain.ContactExchangeRequest, error) {
+	claim, err := s.findReviewableClaim(ctx, cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := claim.Approve(); err != nil {
+		return nil, nil, err
+	}
+
+	var request *domain.ContactExchangeRequest
+	if cmd.StartContactExchange {
+		request, err = s.contactExchangeService.CreateContactExchangeRequest(ctx, CreateContactExchangeCommand{
+			PostID:          claim.PostID(),
+			RequesterUserID: claim.ClaimantUserID(),
+			Message:         claimContactExchangeMessage(claim),
+		})
+		// A claimant who already asked for the owner's contact gets that request linked instead
+		if err != nil && !(request != nil && domain.IsPostErrorCode(err, domain.ContactExchangeErrorAlreadyPending)) {
+			return nil, nil, fmt.Errorf("failed to start contact exchange: %w", err)
+		}
+		claim.LinkContactExchange(request.ID())
+	}
+
+	if err := s.claimRepo.Update(ctx, claim); err != nil {
+		return nil, nil, fmt.Errorf("failed to update claim: %w", err)
+	}
+
+	post, err := s.postRepo.FindByID(ctx, claim.PostID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	claimant, owner, err := s.participants(ctx, claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eventData := &domain.ClaimApprovedEventData{
+		Claim:       claim.ToClaimData(),
+		RelatedPost: post.ToPostData(),
+		Claimant:    domain.ToPrivacySafeUserExtendedFromUser(claimant),
+		Owner:       domain.ToPrivacySafeUserExtendedFromUser(owner),
+	}
+	s.publish(ctx, domain.EventTypeClaimApproved, claim, cmd.OwnerUserID, post, eventData)
+
+	return claim, request, nil
+}
+
+// RejectClaim turns down a claim
+func (s *ClaimService) RejectClaim(ctx context.Context, cmd ReviewClaimCommand) (*domain.Claim, error) {
+	claim, err := s.findReviewableClaim(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := claim.Reject(); err != nil {
+		return nil, err
+	}
+
+	if err := s.claimRepo.Update(ctx, claim); err != nil {
+		return nil, fmt.Errorf("failed to update claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+// PurgeExpiredClaims deletes claims whose retention period has ended and returns how many
+// were removed
+func (s *ClaimService) PurgeExpiredClaims(ctx context.Context) (int64, error) {
+	now := time.Now()
+
+	var purged int64
+	for {
+		deleted, err := s.claimRepo.DeletePastRetention(ctx, now, claimPurgeBatchSize)
+		purged += deleted
+		if err != nil {
+			return purged, err
+		}
+		if deleted < claimPurgeBatchSize {
+			return purged, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+	}
+}
+
+// findReviewableClaim loads a claim for review by the post owner. Claims on other posts, or
+// reviewed by anyone but the owner, are reported as not found so their existence is not revealed.
+func (s *ClaimService) findReviewableClaim(ctx context.Context, cmd ReviewClaimCommand) (*domain.Claim, error) {
+	claim, err := s.claimRepo.FindByID(domain.WithPrimaryReads(ctx), cmd.ClaimID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claim.PostID().Equals(cmd.PostID) || !claim.IsOwner(cmd.OwnerUserID) {
+		return nil, domain.ErrClaimNotFound(cmd.ClaimID)
+	}
+
+	return claim, nil
+}
+
+func (s *ClaimService) retention() time.Duration {
+	if s.config.RetentionDays <= 0 {
+		return domain.DefaultClaimRetentionDays * 24 * time.Hour
+	}
+	return time.Duration(s.config.RetentionDays) * 24 * time.Hour
+}
+
+func (s *ClaimService) participants(ctx context.Context, claim *domain.Claim) (*domain.PrivacySafeUser, *domain.PrivacySafeUser, error) {
+	claimant, err := s.userContextRepo.GetPrivacySafeUser(ctx, claim.ClaimantUserID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get claimant user context: %w", err)
+	}
+
+	owner, err := s.userContextRepo.GetPrivacySafeUser(ctx, claim.OwnerUserID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get owner user context: %w", err)
+	}
+
+	return claimant, owner, nil
+}
+
+func (s *ClaimService) publish(ctx context.Context, eventType domain.EventType, claim *domain.Claim, userID domain.UserID, post *domain.Post, payload interface{}) {
+	event := domain.NewClaimEvent(eventType, claim.ID(), claim.PostID(), userID, post.OrganizationID(), payload)
+
+	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+		// Log error but don't fail the operation
+		fmt.Printf("Warning: failed to publish %s event: %v\n", eventType, err)
+	}
+}
+
+// claimContactExchangeMessage pre-fills the contact exchange message with the claimant's proof,
+// cut to fit the contact exchange message limit
+func claimContactExchangeMessage(claim *domain.Claim) *string {
+	if claim.ProofText() == "" {
+		return nil
+	}
+	message := domain.TruncateText(claim.ProofText(), domain.MaxContactExchangeMessageLength())
+	return &message
+}
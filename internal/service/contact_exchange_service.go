@@ -5,16 +5,36 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/domain"
 )
 
+const (
+	defaultExpirationBatchSize  = 100
+	defaultExpirationTimeBudget = time.Minute
+	defaultReminderLead         = 24 * time.Hour
+
+	defaultMaxRequestsPerPostPerHour = 3
+	defaultMaxPendingRequests        = 10
+	// finalReminderLead is how long before expiry the scheduled last reminder goes out
+	finalReminderLead = time.Hour
+)
+
+const (
+	expiringSoonTemplate       = "contact_exchange_expiring_soon"
+	expiringFinalTemplate      = "contact_exchange_expiring_final"
+	reminderConditionUnexpired = "request_not_expired"
+)
+
 type ContactExchangeService struct {
 	contactExchangeRepo domain.ContactExchangeRepository
 	postRepo            domain.PostRepository
 	userContextRepo     domain.UserContextRepository
 	eventPublisher      domain.EventPublisher
+	transactions        domain.TransactionManager
 	encryptionService   domain.EncryptionService
 	auditLogger         domain.EncryptionAuditLogger
+	config              config.ContactExchangeConfig
 }
 
 func NewContactExchangeService(
@@ -22,16 +42,20 @@ func NewContactExchangeService(
 	postRepo domain.PostRepository,
 	userContextRepo domain.UserContextRepository,
 	eventPublisher domain.EventPublisher,
+	transactions domain.TransactionManager,
 	encryptionService domain.EncryptionService,
 	auditLogger domain.EncryptionAuditLogger,
+	cfg config.ContactExchangeConfig,
 ) *ContactExchangeService {
 	return &ContactExchangeService{
 		contactExchangeRepo: contactExchangeRepo,
 		postRepo:            postRepo,
 		userContextRepo:     userContextRepo,
 		eventPublisher:      eventPublisher,
+		transactions:        transactions,
 		encryptionService:   encryptionService,
 		auditLogger:         auditLogger,
+		config:              cfg,
 	}
 }
 
@@ -50,12 +74,23 @@ type ApproveContactExchangeCommand struct {
 	ContactInfo  *domain.ContactInfo
 }
 
+// SubmitVerificationCommand carries the requester's proof, or an organization admin's sign-off
+// on a request verified by admin approval, in which case the submission is ignored
+type SubmitVerificationCommand struct {
+	RequestID  domain.ContactExchangeRequestID
+	UserID     domain.UserID
+	Submission domain.VerificationSubmission
+}
+
 type DenyContactExchangeCommand struct {
 	RequestID     domain.ContactExchangeRequestID
 	DenialReason  domain.DenialReason
 	DenialMessage *string
 }
 
+// CreateContactExchangeRequest opens a request from the requester to the post's owner. A
+// requester may only have one pending request per post; when they already do, that request is
+// returned together with an ErrContactExchangeAlreadyPending error.
 func (s *ContactExchangeService) CreateContactExchangeRequest(ctx context.Context, cmd CreateContactExchangeCommand) (*domain.ContactExchangeRequest, error) {
 	// Validate post exists and get owner
 	post, err := s.postRepo.FindByID(ctx, cmd.PostID)
@@ -67,6 +102,18 @@ func (s *ContactExchangeService) CreateContactExchangeRequest(ctx context.Contex
 		return nil, domain.NewPostError(domain.BusinessErrorPostNotFound, "Post is not active")
 	}
 
+	existing, err := s.pendingRequestFor(ctx, cmd.PostID, cmd.RequesterUserID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, domain.ErrContactExchangeAlreadyPending(cmd.PostID).WithDetail("request_id", existing.ID().String())
+	}
+
+	if err := s.checkRequestRateLimit(ctx, cmd.RequesterUserID, cmd.PostID); err != nil {
+		return nil, err
+	}
+
 	// Create contact exchange request
 	request, err := domain.NewContactExchangeRequest(
 		cmd.PostID,
@@ -81,21 +128,9 @@ func (s *ContactExchangeService) CreateContactExchangeRequest(ctx context.Contex
 		return nil, err
 	}
 
-	// Save request
-	if err := s.contactExchangeRepo.Save(ctx, request); err != nil {
-		return nil, fmt.Errorf("failed to save contact exchange request: %w", err)
-	}
-
 	// Get privacy-safe user contexts for event
-	requester, err := s.userContextRepo.GetPrivacySafeUser(ctx, cmd.RequesterUserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get requester user context: %w", err)
-	}
-
-	owner, err := s.userContextRepo.GetPrivacySafeUser(ctx, post.CreatedBy())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get owner user context: %w", err)
-	}
+	requester := s.eventUser(ctx, cmd.RequesterUserID, "requester")
+	owner := s.eventUser(ctx, post.CreatedBy(), "owner")
 
 	// Publish ContactExchangeRequested event
 	eventData := &domain.ContactExchangeRequestedEventData{
@@ -116,14 +151,61 @@ func (s *ContactExchangeService) CreateContactExchangeRequest(ctx context.Contex
 		eventData,
 	)
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to publish ContactExchangeRequested event: %v\n", err)
+	// Save request together with its event
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.contactExchangeRepo.Save(ctx, request); err != nil {
+			return fmt.Errorf("failed to save contact exchange request: %w", err)
+		}
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish ContactExchangeRequested event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return request, nil
 }
 
+// pendingRequestFor returns the requester's pending request for the post, or nil when there is
+// none. A pending request that has run past its expiry is expired on the spot rather than
+// returned, so it no longer holds the post's one pending slot.
+func (s *ContactExchangeService) pendingRequestFor(ctx context.Context, postID domain.PostID, requesterID domain.UserID) (*domain.ContactExchangeRequest, error) {
+	existing, err := s.contactExchangeRepo.FindPendingByRequester(ctx, postID, requesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pending contact exchange request: %w", err)
+	}
+	if existing == nil || !existing.IsExpired() {
+		return existing, nil
+	}
+
+	if err := s.expireContactExchangeRequest(ctx, existing, nil); err != nil {
+		return nil, fmt.Errorf("failed to expire stale contact exchange request: %w", err)
+	}
+	return nil, nil
+}
+
+// checkRequestRateLimit refuses a new request once the requester has made the allowed number
+// of requests for the post in the last hour, or has the allowed number of requests pending
+func (s *ContactExchangeService) checkRequestRateLimit(ctx context.Context, requesterID domain.UserID, postID domain.PostID) error {
+	counts, err := s.contactExchangeRepo.CountByRequester(ctx, requesterID, postID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return fmt.Errorf("failed to count requester's contact exchange requests: %w", err)
+	}
+
+	if limit := s.maxRequestsPerPostPerHour(); counts.ForPostSince >= int64(limit) {
+		return domain.ErrContactExchangeRateLimited(
+			fmt.Sprintf("At most %d contact exchange requests per post may be made in an hour", limit), limit)
+	}
+	if limit := s.maxPendingRequests(); counts.Pending >= int64(limit) {
+		return domain.ErrContactExchangeRateLimited(
+			fmt.Sprintf("At most %d contact exchange requests may be pending at once", limit), limit)
+	}
+
+	return nil
+}
+
 func (s *ContactExchangeService) ApproveContactExchange(ctx context.Context, cmd ApproveContactExchangeCommand) (*domain.ContactExchangeRequest, error) {
 	// Find request
 	request, err := s.contactExchangeRepo.FindByID(ctx, cmd.RequestID)
@@ -131,13 +213,31 @@ func (s *ContactExchangeService) ApproveContactExchange(ctx context.Context, cmd
 		return nil, fmt.Errorf("failed to find contact exchange request: %w", err)
 	}
 
+	// Checked before anything is encrypted; Approve checks it again
+	if request.VerificationRequired() && !request.VerificationCompleted() {
+		return nil, domain.ErrContactExchangeNotVerified(request.VerificationMethod())
+	}
+
+	if cmd.ContactInfo == nil {
+		return nil, domain.ErrNoContactChannel()
+	}
+
+	if err := domain.ValidateContactExchangeMessage(cmd.ContactInfo.Message); err != nil {
+		return nil, err
+	}
+
+	contactInfo, err := domain.NormalizeContactInfo(*cmd.ContactInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	// Encrypt contact information using RSA-4096
-	encryptedContactInfo, err := s.encryptionService.EncryptContactInfo(*cmd.ContactInfo)
+	encryptedContactInfo, err := s.encryptionService.EncryptContactInfo(contactInfo)
 	if err != nil {
 		// Log encryption failure
 		requestID := request.ID()
 		errorMessage := err.Error()
-		s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+		s.logAudit(ctx, &domain.EncryptionAuditLog{
 			Operation:      domain.EncryptionOperationEncrypt,
 			UserID:         request.OwnerUserID(),
 			RequestID:      &requestID,
@@ -150,7 +250,7 @@ func (s *ContactExchangeService) ApproveContactExchange(ctx context.Context, cmd
 
 	// Log successful encryption
 	requestID := request.ID()
-	s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+	s.logAudit(ctx, &domain.EncryptionAuditLog{
 		Operation:      domain.EncryptionOperationEncrypt,
 		UserID:         request.OwnerUserID(),
 		RequestID:      &requestID,
@@ -163,26 +263,14 @@ func (s *ContactExchangeService) ApproveContactExchange(ctx context.Context, cmd
 		return nil, err
 	}
 
-	// Update request
-	if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
-		return nil, fmt.Errorf("failed to update contact exchange request: %w", err)
-	}
-
 	// Get related post and user contexts for event
 	post, err := s.postRepo.FindByID(ctx, request.PostID())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find post: %w", err)
 	}
 
-	requester, err := s.userContextRepo.GetPrivacySafeUser(ctx, request.RequesterUserID())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get requester user context: %w", err)
-	}
-
-	owner, err := s.userContextRepo.GetPrivacySafeUser(ctx, request.OwnerUserID())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get owner user context: %w", err)
-	}
+	requester := s.eventUser(ctx, request.RequesterUserID(), "requester")
+	owner := s.eventUser(ctx, request.OwnerUserID(), "owner")
 
 	// Publish ContactExchangeApproved event
 	contactApproval := &domain.ContactApproval{
@@ -215,47 +303,97 @@ func (s *ContactExchangeService) ApproveContactExchange(ctx context.Context, cmd
 		eventData,
 	)
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to publish ContactExchangeApproved event: %v\n", err)
+	// Update request together with its event
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
+			return fmt.Errorf("failed to update contact exchange request: %w", err)
+		}
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish ContactExchangeApproved event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return request, nil
 }
 
-func (s *ContactExchangeService) DenyContactExchange(ctx context.Context, cmd DenyContactExchangeCommand) (*domain.ContactExchangeRequest, error) {
-	// Find request
+// SubmitVerification records the requester's proof of ownership. A security question answer or
+// photo proof verifies the request right away. Requests verified by admin approval keep the
+// proof for an admin of the post's organization, whose own call completes verification.
+func (s *ContactExchangeService) SubmitVerification(ctx context.Context, cmd SubmitVerificationCommand) (*domain.ContactExchangeRequest, error) {
 	request, err := s.contactExchangeRepo.FindByID(ctx, cmd.RequestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find contact exchange request: %w", err)
 	}
 
-	// Deny request
-	if err := request.Deny(cmd.DenialReason, cmd.DenialMessage); err != nil {
+	if request.IsRequester(cmd.UserID) {
+		err = request.SubmitVerification(cmd.Submission)
+	} else {
+		isAdmin, adminErr := s.isPostOrganizationAdmin(ctx, request.PostID(), cmd.UserID)
+		if adminErr != nil {
+			return nil, adminErr
+		}
+		if !isAdmin {
+			return nil, domain.ErrUnauthorizedOperation(cmd.UserID, "verify_contact_exchange")
+		}
+		err = request.CompleteAdminVerification()
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	// Update request
 	if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
 		return nil, fmt.Errorf("failed to update contact exchange request: %w", err)
 	}
 
-	// Get related post and user contexts for event
-	post, err := s.postRepo.FindByID(ctx, request.PostID())
+	return request, nil
+}
+
+// isPostOrganizationAdmin reports whether the user is an admin of the organization the post
+// belongs to; posts outside an organization have no admins
+func (s *ContactExchangeService) isPostOrganizationAdmin(ctx context.Context, postID domain.PostID, userID domain.UserID) (bool, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find post: %w", err)
+		return false, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	orgID := post.OrganizationID()
+	if orgID == nil {
+		return false, nil
 	}
 
-	requester, err := s.userContextRepo.GetPrivacySafeUser(ctx, request.RequesterUserID())
+	user, err := s.userContextRepo.GetPrivacySafeUser(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get requester user context: %w", err)
+		return false, fmt.Errorf("failed to get user context: %w", err)
 	}
 
-	owner, err := s.userContextRepo.GetPrivacySafeUser(ctx, request.OwnerUserID())
+	return isOrganizationMember(user, *orgID) && user.Organization.Role == domain.OrganizationRoleAdmin, nil
+}
+
+func (s *ContactExchangeService) DenyContactExchange(ctx context.Context, cmd DenyContactExchangeCommand) (*domain.ContactExchangeRequest, error) {
+	// Find request
+	request, err := s.contactExchangeRepo.FindByID(ctx, cmd.RequestID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get owner user context: %w", err)
+		return nil, fmt.Errorf("failed to find contact exchange request: %w", err)
+	}
+
+	// Deny request
+	if err := request.Deny(cmd.DenialReason, cmd.DenialMessage); err != nil {
+		return nil, err
 	}
 
+	// Get related post and user contexts for event
+	post, err := s.postRepo.FindByID(ctx, request.PostID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	requester := s.eventUser(ctx, request.RequesterUserID(), "requester")
+	owner := s.eventUser(ctx, request.OwnerUserID(), "owner")
+
 	// Publish ContactExchangeDenied event
 	contactDenial := &domain.ContactDenial{
 		RequestID:     request.ID(),
@@ -283,9 +421,18 @@ func (s *ContactExchangeService) DenyContactExchange(ctx context.Context, cmd De
 		eventData,
 	)
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to publish ContactExchangeDenied event: %v\n", err)
+	// Update request together with its event
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
+			return fmt.Errorf("failed to update contact exchange request: %w", err)
+		}
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish ContactExchangeDenied event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return request, nil
@@ -299,6 +446,51 @@ func (s *ContactExchangeService) ListContactExchangeRequests(ctx context.Context
 	return s.contactExchangeRepo.List(ctx, filters)
 }
 
+// FindExpiringRequests returns the pending and approved requests a user takes part in, as
+// requester or owner, that expire within the given window. A zero window uses the reminder lead.
+func (s *ContactExchangeService) FindExpiringRequests(ctx context.Context, userID domain.UserID, within time.Duration) ([]*domain.ContactExchangeRequest, error) {
+	if within <= 0 {
+		within = s.reminderLead()
+	}
+	return s.contactExchangeRepo.FindExpiringForUser(ctx, userID, within)
+}
+
+// GetRequestStatuses returns the status of each given request the user takes part in, as
+// requester or owner. Requests they are not part of are left out rather than reported.
+func (s *ContactExchangeService) GetRequestStatuses(ctx context.Context, ids []domain.ContactExchangeRequestID, userID domain.UserID) (map[domain.ContactExchangeRequestID]domain.ContactExchangeStatus, error) {
+	seen := make(map[domain.ContactExchangeRequestID]bool, len(ids))
+	unique := make([]domain.ContactExchangeRequestID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	statuses, err := s.contactExchangeRepo.FindStatusesForUser(ctx, unique, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact exchange request statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+// GetEncryptionAuditTrail returns a page of encryption audit logs and the total matching count
+func (s *ContactExchangeService) GetEncryptionAuditTrail(ctx context.Context, filters domain.EncryptionAuditFilters) ([]*domain.EncryptionAuditLog, int64, error) {
+	filters.SetDefaults()
+
+	logs, err := s.auditLogger.GetAuditTrail(filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit trail: %w", err)
+	}
+
+	total, err := s.auditLogger.CountAuditTrail(filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit trail: %w", err)
+	}
+
+	return logs, total, nil
+}
+
 // DecryptContactInfo decrypts the contact information from an approved contact exchange request
 func (s *ContactExchangeService) DecryptContactInfo(ctx context.Context, requestID domain.ContactExchangeRequestID, userID domain.UserID) (*domain.ContactInfo, error) {
 	// Find request
@@ -308,7 +500,7 @@ func (s *ContactExchangeService) DecryptContactInfo(ctx context.Context, request
 	}
 
 	// Verify authorization - only requester or owner can decrypt
-	if !request.RequesterUserID().Equals(userID) && !request.OwnerUserID().Equals(userID) {
+	if !request.CanBeViewedBy(userID) {
 		return nil, fmt.Errorf("unauthorized to decrypt contact information")
 	}
 
@@ -328,16 +520,22 @@ func (s *ContactExchangeService) DecryptContactInfo(ctx context.Context, request
 		return nil, fmt.Errorf("no contact information available")
 	}
 
+	// Audit the key the contact info was encrypted with, which may no longer be the active one
+	keyFingerprint := encryptedInfo.KeyFingerprint
+	if keyFingerprint == "" {
+		keyFingerprint = s.encryptionService.GetActiveKeyFingerprint()
+	}
+
 	// Decrypt using encryption service
 	contactInfo, err := s.encryptionService.DecryptContactInfo(encryptedInfo)
 	if err != nil {
 		// Log decryption failure
 		errorMessage := err.Error()
-		s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+		s.logAudit(ctx, &domain.EncryptionAuditLog{
 			Operation:      domain.EncryptionOperationDecrypt,
 			UserID:         userID,
 			RequestID:      &requestID,
-			KeyFingerprint: s.encryptionService.GetActiveKeyFingerprint(),
+			KeyFingerprint: keyFingerprint,
 			Success:        false,
 			ErrorMessage:   &errorMessage,
 		})
@@ -345,24 +543,37 @@ func (s *ContactExchangeService) DecryptContactInfo(ctx context.Context, request
 	}
 
 	// Log successful decryption
-	s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+	s.logAudit(ctx, &domain.EncryptionAuditLog{
 		Operation:      domain.EncryptionOperationDecrypt,
 		UserID:         userID,
 		RequestID:      &requestID,
-		KeyFingerprint: s.encryptionService.GetActiveKeyFingerprint(),
+		KeyFingerprint: keyFingerprint,
 		Success:        true,
 	})
 
 	return contactInfo, nil
 }
 
+// logAudit records an encryption operation along with the origin of the request that triggered it
+func (s *ContactExchangeService) logAudit(ctx context.Context, entry *domain.EncryptionAuditLog) {
+	if origin, ok := domain.RequestOriginFromContext(ctx); ok {
+		if origin.IPAddress != "" {
+			entry.IPAddress = &origin.IPAddress
+		}
+		if origin.UserAgent != "" {
+			entry.UserAgent = &origin.UserAgent
+		}
+	}
+	s.auditLogger.LogOperation(entry)
+}
+
 // GenerateContactToken creates a secure token for contact exchange
 func (s *ContactExchangeService) GenerateContactToken(ctx context.Context, contactInfo domain.ContactInfo, expiresAt time.Time, userID domain.UserID) (*domain.ContactToken, error) {
 	token, err := s.encryptionService.GenerateContactToken(contactInfo, expiresAt)
 	if err != nil {
 		// Log token creation failure
 		errorMessage := err.Error()
-		s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+		s.logAudit(ctx, &domain.EncryptionAuditLog{
 			Operation:      domain.EncryptionOperationTokenCreate,
 			UserID:         userID,
 			KeyFingerprint: s.encryptionService.GetActiveKeyFingerprint(),
@@ -373,7 +584,7 @@ func (s *ContactExchangeService) GenerateContactToken(ctx context.Context, conta
 	}
 
 	// Log successful token creation
-	s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+	s.logAudit(ctx, &domain.EncryptionAuditLog{
 		Operation:      domain.EncryptionOperationTokenCreate,
 		UserID:         userID,
 		KeyFingerprint: s.encryptionService.GetActiveKeyFingerprint(),
@@ -389,7 +600,7 @@ func (s *ContactExchangeService) ValidateContactToken(ctx context.Context, token
 	if err != nil {
 		// Log token validation failure
 		errorMessage := err.Error()
-		s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+		s.logAudit(ctx, &domain.EncryptionAuditLog{
 			Operation:      domain.EncryptionOperationTokenValidate,
 			UserID:         userID,
 			KeyFingerprint: token.KeyFingerprint,
@@ -400,7 +611,7 @@ func (s *ContactExchangeService) ValidateContactToken(ctx context.Context, token
 	}
 
 	// Log successful token validation
-	s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+	s.logAudit(ctx, &domain.EncryptionAuditLog{
 		Operation:      domain.EncryptionOperationTokenValidate,
 		UserID:         userID,
 		KeyFingerprint: token.KeyFingerprint,
@@ -410,45 +621,394 @@ func (s *ContactExchangeService) ValidateContactToken(ctx context.Context, token
 	return contactInfo, nil
 }
 
-func (s *ContactExchangeService) ProcessExpiredRequests(ctx context.Context) error {
-	// Find expired requests
-	expiredRequests, err := s.contactExchangeRepo.FindExpired(ctx, 100)
+// BulkResult summarizes a bulk maintenance run such as expiring or purging requests
+type BulkResult struct {
+	Processed int `json:"processed"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Batches   int `json:"batches"`
+	// BudgetExhausted is set when the run stopped on its time budget with work left over
+	BudgetExhausted bool `json:"budget_exhausted"`
+}
+
+func (r *BulkResult) add(batch BulkResult) {
+	r.Processed += batch.Processed
+	r.Succeeded += batch.Succeeded
+	r.Failed += batch.Failed
+	r.Skipped += batch.Skipped
+	r.Batches++
+}
+
+// ProcessExpiredRequests expires pending and approved requests past their expiry, batch by
+// batch, until none remain or the run's time budget is spent
+func (s *ContactExchangeService) ProcessExpiredRequests(ctx context.Context) (BulkResult, error) {
+	result, err := s.processInBatches(ctx, s.expirationBatchSize(), s.contactExchangeRepo.FindExpired, s.expireBatch)
+	if err != nil {
+		return result, fmt.Errorf("failed to find expired requests: %w", err)
+	}
+
+	return result, nil
+}
+
+// CleanupExpiredTokens securely removes encrypted contact data from expired requests
+func (s *ContactExchangeService) CleanupExpiredTokens(ctx context.Context) (BulkResult, error) {
+	result, err := s.processInBatches(ctx, s.expirationBatchSize(), s.contactExchangeRepo.FindPurgeable, s.cleanupBatch)
+	if err != nil {
+		return result, fmt.Errorf("failed to find expired requests for cleanup: %w", err)
+	}
+
+	return result, nil
+}
+
+// SendExpiryReminders publishes an expiring-soon event, once, for every pending or approved
+// request that expires within the reminder lead, so users can act before access is lost
+func (s *ContactExchangeService) SendExpiryReminders(ctx context.Context) (BulkResult, error) {
+	lead := s.reminderLead()
+	findDue := func(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error) {
+		return s.contactExchangeRepo.FindDueForExpiryReminder(ctx, lead, limit)
+	}
+
+	result, err := s.processInBatches(ctx, s.expirationBatchSize(), findDue, s.remindBatch)
+	if err != nil {
+		return result, fmt.Errorf("failed to find requests due for expiry reminder: %w", err)
+	}
+
+	return result, nil
+}
+
+// ReEncryptionResult summarizes a re-encryption run and the keys it could retire afterwards
+type ReEncryptionResult struct {
+	BulkResult
+	RetiredKeys []string `json:"retired_keys"`
+}
+
+// ReEncryptAll moves the contact info of approved, unexpired requests onto the active key,
+// batchSize requests at a time, so keys rotated out no longer have to be kept. Once every
+// request is on the active key, old keys that no stored contact info uses are retired. A
+// batch size of 0 or less uses the expiration batch size.
+func (s *ContactExchangeService) ReEncryptAll(ctx context.Context, batchSize int) (ReEncryptionResult, error) {
+	if batchSize <= 0 {
+		batchSize = s.expirationBatchSize()
+	}
+
+	activeKey := s.encryptionService.GetActiveKeyFingerprint()
+	findStale := func(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error) {
+		return s.contactExchangeRepo.FindEncryptedWithOtherKey(ctx, activeKey, limit)
+	}
+
+	var result ReEncryptionResult
+	bulk, err := s.processInBatches(ctx, batchSize, findStale, s.reEncryptBatch)
+	result.BulkResult = bulk
+	if err != nil {
+		return result, fmt.Errorf("failed to find requests to re-encrypt: %w", err)
+	}
+	if bulk.Failed > 0 || bulk.BudgetExhausted {
+		return result, nil
+	}
+
+	// Expired and denied requests may still hold contact info until it is purged
+	inUse, err := s.contactExchangeRepo.FindKeyFingerprintsInUse(domain.WithPrimaryReads(ctx))
+	if err != nil {
+		return result, fmt.Errorf("failed to find encryption keys in use: %w", err)
+	}
+	for _, fingerprint := range inUse {
+		if fingerprint == "" {
+			// Contact info from before keys were recorded could be under any of them
+			return result, nil
+		}
+	}
+
+	result.RetiredKeys, err = s.encryptionService.RetireUnusedKeys(inUse)
+	if err != nil {
+		return result, fmt.Errorf("failed to retire unused keys: %w", err)
+	}
+
+	return result, nil
+}
+
+// reEncryptBatch re-encrypts one batch of requests under the active key
+func (s *ContactExchangeService) reEncryptBatch(ctx context.Context, requests []*domain.ContactExchangeRequest) BulkResult {
+	result := BulkResult{Processed: len(requests)}
+	for _, request := range requests {
+		if err := s.reEncrypt(ctx, request); err != nil {
+			fmt.Printf("Warning: failed to re-encrypt contact info for request %s: %v\n", request.ID().String(), err)
+			result.Failed++
+			continue
+		}
+		result.Succeeded++
+	}
+
+	return result
+}
+
+// reEncrypt re-encrypts a request's contact info and records the outcome in the audit log
+func (s *ContactExchangeService) reEncrypt(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	err := s.reEncryptContactInfo(ctx, request)
+
+	requestID := request.ID()
+	entry := &domain.EncryptionAuditLog{
+		Operation:      domain.EncryptionOperationReEncrypt,
+		UserID:         request.OwnerUserID(),
+		RequestID:      &requestID,
+		KeyFingerprint: s.encryptionService.GetActiveKeyFingerprint(),
+		Success:        err == nil,
+	}
+	if err != nil {
+		errorMessage := err.Error()
+		entry.ErrorMessage = &errorMessage
+	}
+	s.logAudit(ctx, entry)
+
+	return err
+}
+
+// reEncryptContactInfo decrypts a request's contact info with the key it was encrypted with
+// and stores it encrypted with the active key
+func (s *ContactExchangeService) reEncryptContactInfo(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	contactInfo, err := s.encryptionService.DecryptContactInfo(request.EncryptedContactInfo())
+	if err != nil {
+		return fmt.Errorf("failed to decrypt contact info: %w", err)
+	}
+
+	encryptedContactInfo, err := s.encryptionService.EncryptContactInfo(*contactInfo)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt contact info: %w", err)
+	}
+
+	if err := request.ReplaceEncryptedContactInfo(encryptedContactInfo); err != nil {
+		return err
+	}
+
+	if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
+		return fmt.Errorf("failed to update contact exchange request: %w", err)
+	}
+
+	return nil
+}
+
+// processInBatches feeds batches from find to process until a short batch signals the backlog
+// is drained, a batch makes no progress, or the time budget runs out. Reads go to the primary so
+// rows updated by the previous batch are not served again from a lagging replica.
+func (s *ContactExchangeService) processInBatches(
+	ctx context.Context,
+	batchSize int,
+	find func(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error),
+	process func(ctx context.Context, requests []*domain.ContactExchangeRequest) BulkResult,
+) (BulkResult, error) {
+	ctx = domain.WithPrimaryReads(ctx)
+	deadline := time.Now().Add(s.expirationTimeBudget())
+
+	var result BulkResult
+	for {
+		requests, err := find(ctx, batchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(requests) == 0 {
+			return result, nil
+		}
+
+		batch := process(ctx, requests)
+		result.add(batch)
+
+		if len(requests) < batchSize || batch.Succeeded == 0 {
+			return result, nil
+		}
+		if ctx.Err() != nil || time.Now().After(deadline) {
+			result.BudgetExhausted = true
+			return result, nil
+		}
+	}
+}
+
+func (s *ContactExchangeService) expirationBatchSize() int {
+	if s.config.ExpirationBatchSize <= 0 {
+		return defaultExpirationBatchSize
+	}
+	return s.config.ExpirationBatchSize
+}
+
+func (s *ContactExchangeService) reminderLead() time.Duration {
+	if s.config.ReminderLeadHours <= 0 {
+		return defaultReminderLead
+	}
+	return time.Duration(s.config.ReminderLeadHours) * time.Hour
+}
+
+func (s *ContactExchangeService) maxRequestsPerPostPerHour() int {
+	if s.config.MaxRequestsPerPostPerHour <= 0 {
+		return defaultMaxRequestsPerPostPerHour
+	}
+	return s.config.MaxRequestsPerPostPerHour
+}
+
+func (s *ContactExchangeService) maxPendingRequests() int {
+	if s.config.MaxPendingRequests <= 0 {
+		return defaultMaxPendingRequests
+	}
+	return s.config.MaxPendingRequests
+}
+
+func (s *ContactExchangeService) expirationTimeBudget() time.Duration {
+	if s.config.ExpirationTimeBudgetSeconds <= 0 {
+		return defaultExpirationTimeBudget
+	}
+	return time.Duration(s.config.ExpirationTimeBudgetSeconds) * time.Second
+}
+
+// expireBatch expires one batch of requests
+func (s *ContactExchangeService) expireBatch(ctx context.Context, expiredRequests []*domain.ContactExchangeRequest) BulkResult {
+	// Load everyone involved up front instead of twice per request
+	userIDs := make([]domain.UserID, 0, 2*len(expiredRequests))
+	for _, request := range expiredRequests {
+		userIDs = append(userIDs, request.RequesterUserID(), request.OwnerUserID())
+	}
+	users, err := s.userContextRepo.GetPrivacySafeUsers(ctx, userIDs)
 	if err != nil {
-		return fmt.Errorf("failed to find expired requests: %w", err)
+		fmt.Printf("Warning: failed to batch load user contexts for expired requests: %v\n", err)
 	}
 
+	result := BulkResult{Processed: len(expiredRequests)}
 	for _, request := range expiredRequests {
-		if err := s.expireContactExchangeRequest(ctx, request); err != nil {
+		if request.Status() == domain.ContactExchangeStatusExpired {
+			result.Skipped++
+			continue
+		}
+		if err := s.expireContactExchangeRequest(ctx, request, users); err != nil {
 			// Log error but continue processing other requests
 			fmt.Printf("Warning: failed to expire contact exchange request %s: %v\n", request.ID().String(), err)
+			result.Failed++
+			continue
 		}
+		result.Succeeded++
 	}
 
-	return nil
+	return result
 }
 
-// CleanupExpiredTokens securely removes encrypted contact data from expired requests
-func (s *ContactExchangeService) CleanupExpiredTokens(ctx context.Context) error {
-	// Find expired requests that still have encrypted contact info
-	expiredRequests, err := s.contactExchangeRepo.FindExpired(ctx, 100)
+// remindBatch sends expiry reminders for one batch of requests. A request is marked as reminded
+// in the same transaction that records its event, so failed reminders are retried on the next run.
+func (s *ContactExchangeService) remindBatch(ctx context.Context, dueRequests []*domain.ContactExchangeRequest) BulkResult {
+	userIDs := make([]domain.UserID, 0, 2*len(dueRequests))
+	for _, request := range dueRequests {
+		userIDs = append(userIDs, request.RequesterUserID(), request.OwnerUserID())
+	}
+	users, err := s.userContextRepo.GetPrivacySafeUsers(ctx, userIDs)
 	if err != nil {
-		return fmt.Errorf("failed to find expired requests for cleanup: %w", err)
+		fmt.Printf("Warning: failed to batch load user contexts for expiry reminders: %v\n", err)
 	}
 
-	cleanedCount := 0
-	for _, request := range expiredRequests {
-		if request.EncryptedContactInfo() != nil && request.Status() == domain.ContactExchangeStatusExpired {
-			// Securely clear the encrypted contact information
-			if err := s.securelyCleanupContactInfo(ctx, request); err != nil {
-				fmt.Printf("Warning: failed to cleanup contact info for request %s: %v\n", request.ID().String(), err)
-				continue
+	result := BulkResult{Processed: len(dueRequests)}
+	for _, request := range dueRequests {
+		status := request.Status()
+		if request.IsExpired() || (status != domain.ContactExchangeStatusPending && status != domain.ContactExchangeStatusApproved) {
+			result.Skipped++
+			continue
+		}
+		event, err := s.expiringSoonEvent(ctx, request, users)
+		if err != nil {
+			fmt.Printf("Warning: failed to send expiry reminder for request %s: %v\n", request.ID().String(), err)
+			result.Failed++
+			continue
+		}
+		err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+			if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+				return fmt.Errorf("failed to publish ContactExchangeExpiringSoon event: %w", err)
+			}
+			if err := s.contactExchangeRepo.MarkExpiryReminderSent(ctx, request.ID(), time.Now()); err != nil {
+				return fmt.Errorf("failed to mark expiry reminder sent: %w", err)
 			}
-			cleanedCount++
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("Warning: failed to send expiry reminder for request %s: %v\n", request.ID().String(), err)
+			result.Failed++
+			continue
 		}
+		result.Succeeded++
 	}
 
-	fmt.Printf("Cleaned up encrypted contact information for %d expired requests\n", cleanedCount)
-	return nil
+	return result
+}
+
+// expiringSoonEvent reminds both parties now and schedules a last reminder shortly before expiry
+func (s *ContactExchangeService) expiringSoonEvent(ctx context.Context, request *domain.ContactExchangeRequest, users map[domain.UserID]*domain.PrivacySafeUser) (*domain.PostEvent, error) {
+	post, err := s.postRepo.FindByID(ctx, request.PostID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	requester, err := s.preloadedUser(ctx, users, request.RequesterUserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get requester user context: %w", err)
+	}
+
+	owner, err := s.preloadedUser(ctx, users, request.OwnerUserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner user context: %w", err)
+	}
+
+	remaining := time.Until(request.ExpiresAt())
+	template := expiringSoonTemplate
+
+	eventData := &domain.ContactExchangeExpiringSoonEventData{
+		ContactRequest: request.ToContactRequestData(),
+		HoursRemaining: remaining.Hours(),
+		RelatedPost:    post.ToPostData(),
+		InvolvedUsers: domain.InvolvedUsersExtended{
+			Requester: domain.ToPrivacySafeUserExtendedFromUser(requester),
+			Owner:     domain.ToPrivacySafeUserExtendedFromUser(owner),
+		},
+		NotificationRequirements: &domain.NotificationRequirements{
+			ImmediateNotification: true,
+			NotificationTemplate:  &template,
+			ReminderSchedule:      expiryReminderSchedule(remaining),
+		},
+	}
+
+	return domain.NewContactExchangeEvent(
+		domain.EventTypeContactExchangeExpiringSoon,
+		request.ID(),
+		request.OwnerUserID(),
+		post.OrganizationID(),
+		eventData,
+	), nil
+}
+
+// expiryReminderSchedule schedules a final reminder finalReminderLead before expiry, when there
+// is enough time left for it to be worth a second notification
+func expiryReminderSchedule(remaining time.Duration) []domain.NotificationReminder {
+	if remaining < 2*finalReminderLead {
+		return nil
+	}
+
+	return []domain.NotificationReminder{{
+		DelayHours: int((remaining - finalReminderLead) / time.Hour),
+		Template:   expiringFinalTemplate,
+		Condition:  reminderConditionUnexpired,
+	}}
+}
+
+// cleanupBatch purges contact information from one batch of expired requests
+func (s *ContactExchangeService) cleanupBatch(ctx context.Context, expiredRequests []*domain.ContactExchangeRequest) BulkResult {
+	result := BulkResult{Processed: len(expiredRequests)}
+	for _, request := range expiredRequests {
+		if request.EncryptedContactInfo() == nil || request.Status() != domain.ContactExchangeStatusExpired {
+			result.Skipped++
+			continue
+		}
+
+		// Securely clear the encrypted contact information
+		if err := s.securelyCleanupContactInfo(ctx, request); err != nil {
+			fmt.Printf("Warning: failed to cleanup contact info for request %s: %v\n", request.ID().String(), err)
+			result.Failed++
+			continue
+		}
+		result.Succeeded++
+	}
+
+	return result
 }
 
 // securelyCleanupContactInfo removes encrypted contact data and logs the operation
@@ -458,7 +1018,7 @@ func (s *ContactExchangeService) securelyCleanupContactInfo(ctx context.Context,
 		// Log cleanup failure
 		requestID := request.ID()
 		errorMessage := err.Error()
-		s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+		s.logAudit(ctx, &domain.EncryptionAuditLog{
 			Operation:      domain.EncryptionOperationDecrypt, // Using decrypt since we're accessing encrypted data
 			UserID:         request.OwnerUserID(),
 			RequestID:      &requestID,
@@ -469,14 +1029,24 @@ func (s *ContactExchangeService) securelyCleanupContactInfo(ctx context.Context,
 		return fmt.Errorf("failed to clear contact info: %w", err)
 	}
 
-	// Update the request in the database
-	if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
-		return fmt.Errorf("failed to update request after clearing contact info: %w", err)
+	// Update the request in the database together with the purge event
+	event := s.contactDataPurgedEvent(ctx, request)
+	err := withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
+			return fmt.Errorf("failed to update request after clearing contact info: %w", err)
+		}
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish ContactExchangeDataPurged event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Log successful cleanup operation for audit
 	requestID := request.ID()
-	s.auditLogger.LogOperation(&domain.EncryptionAuditLog{
+	s.logAudit(ctx, &domain.EncryptionAuditLog{
 		Operation:      domain.EncryptionOperationDecrypt, // Using decrypt since we're accessing encrypted data
 		UserID:         request.OwnerUserID(),
 		RequestID:      &requestID,
@@ -487,29 +1057,71 @@ func (s *ContactExchangeService) securelyCleanupContactInfo(ctx context.Context,
 	return nil
 }
 
-func (s *ContactExchangeService) expireContactExchangeRequest(ctx context.Context, request *domain.ContactExchangeRequest) error {
+// contactDataPurgedEvent tells downstream consumers to invalidate any contact references
+// they cached for the request
+func (s *ContactExchangeService) contactDataPurgedEvent(ctx context.Context, request *domain.ContactExchangeRequest) *domain.PostEvent {
+	var tenantID *domain.OrganizationID
+	if post, err := s.postRepo.FindByID(ctx, request.PostID()); err == nil {
+		tenantID = post.OrganizationID()
+	}
+
+	eventData := &domain.ContactExchangeDataPurgedEventData{
+		RequestID:   request.ID().String(),
+		PostID:      request.PostID().String(),
+		PurgeReason: "expired",
+		PurgedAt:    request.UpdatedAt(),
+		CleanupActions: domain.CleanupActions{
+			RevokeContactAccess: true,
+			PurgeContactInfo:    true,
+		},
+	}
+
+	return domain.NewContactExchangeEvent(
+		domain.EventTypeContactExchangeDataPurged,
+		request.ID(),
+		request.OwnerUserID(),
+		tenantID,
+		eventData,
+	)
+}
+
+// eventUser loads a user's privacy-safe context for an event. A failed lookup falls back to a
+// minimal context instead of holding up the change the event announces.
+func (s *ContactExchangeService) eventUser(ctx context.Context, userID domain.UserID, role string) *domain.PrivacySafeUser {
+	user, err := s.userContextRepo.GetPrivacySafeUser(ctx, userID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get %s user context, publishing with a minimal one: %v\n", role, err)
+		return minimalUserContext(userID)
+	}
+	return user
+}
+
+// preloadedUser returns a user from a batch lookup, fetching it if the batch did not include it
+func (s *ContactExchangeService) preloadedUser(ctx context.Context, users map[domain.UserID]*domain.PrivacySafeUser, userID domain.UserID) (*domain.PrivacySafeUser, error) {
+	if user, ok := users[userID]; ok && user != nil {
+		return user, nil
+	}
+	return s.userContextRepo.GetPrivacySafeUser(ctx, userID)
+}
+
+func (s *ContactExchangeService) expireContactExchangeRequest(ctx context.Context, request *domain.ContactExchangeRequest, users map[domain.UserID]*domain.PrivacySafeUser) error {
 	// Mark as expired
 	if err := request.Expire(); err != nil {
 		return err
 	}
 
-	// Update request
-	if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
-		return fmt.Errorf("failed to update expired request: %w", err)
-	}
-
 	// Get related post and user contexts for event
 	post, err := s.postRepo.FindByID(ctx, request.PostID())
 	if err != nil {
 		return fmt.Errorf("failed to find post: %w", err)
 	}
 
-	requester, err := s.userContextRepo.GetPrivacySafeUser(ctx, request.RequesterUserID())
+	requester, err := s.preloadedUser(ctx, users, request.RequesterUserID())
 	if err != nil {
 		return fmt.Errorf("failed to get requester user context: %w", err)
 	}
 
-	owner, err := s.userContextRepo.GetPrivacySafeUser(ctx, request.OwnerUserID())
+	owner, err := s.preloadedUser(ctx, users, request.OwnerUserID())
 	if err != nil {
 		return fmt.Errorf("failed to get owner user context: %w", err)
 	}
@@ -548,10 +1160,14 @@ func (s *ContactExchangeService) expireContactExchangeRequest(ctx context.Contex
 		eventData,
 	)
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to publish ContactExchangeExpired event: %v\n", err)
-	}
-
-	return nil
+	// Update request together with its event
+	return withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.contactExchangeRepo.Update(ctx, request); err != nil {
+			return fmt.Errorf("failed to update expired request: %w", err)
+		}
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish ContactExchangeExpired event: %w", err)
+		}
+		return nil
+	})
 }
\ No newline at end of file
@@ -58,10 +58,10 @@ func NewEventService(cfg config.KafkaConfig) (*EventService, error) {
 		}
 	}
 
-	// Create writer - configuration differs based on environment
+	// Create writer - configuration differs based on environment. Each message names its
+	// topic, so one writer serves the event, thin event and dead letter topics.
 	writer := &kafka.Writer{
 		Addr:                   kafka.TCP(cfg.BootstrapServers),
-		Topic:                  cfg.Topic,
 		Balancer:               &kafka.LeastBytes{},
 		BatchTimeout:           batchTimeout,
 		BatchSize:              batchSize,
@@ -122,6 +122,7 @@ func (e *EventService) PublishEvent(ctx context.Context, event *domain.PostEvent
 
 	// Create enhanced Kafka message with comprehensive headers
 	message := kafka.Message{
+		Topic: e.topic,
 		Key:   []byte(event.AggregateID), // Use aggregate ID for better partitioning
 		Value: eventData,
 		Headers: []kafka.Header{
@@ -131,7 +132,8 @@ func (e *EventService) PublishEvent(ctx context.Context, event *domain.PostEvent
 			{Key: "aggregate_id", Value: []byte(event.AggregateID)},
 			{Key: "aggregate_type", Value: []byte(event.AggregateType)},
 			{Key: "source_service", Value: []byte(event.SourceService)},
-			{Key: "timestamp", Value: []byte(event.Timestamp.Format(time.RFC3339))},
+			{Key: "source_version", Value: []byte(domain.ServiceVersion())},
+			{Key: "timestamp", Value: []byte(domain.FormatTimestamp(event.Timestamp))},
 			{Key: "content_type", Value: []byte("application/json")},
 			{Key: "schema_version", Value: []byte("1.0")},
 		},
@@ -187,6 +189,24 @@ func (e *EventService) PublishEvent(ctx context.Context, event *domain.PostEvent
 	return nil
 }
 
+// PublishMessage writes an already serialized message to topic, retrying like PublishEvent.
+// It lets the anti-corruption publisher send translated events through this writer.
+func (e *EventService) PublishMessage(topic string, key string, message []byte) error {
+	err := e.writeMessageWithRetry(context.Background(), kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: message,
+		Headers: []kafka.Header{
+			{Key: "source_version", Value: []byte(domain.ServiceVersion())},
+			{Key: "content_type", Value: []byte("application/json")},
+		},
+	}, 3)
+	if err != nil {
+		return fmt.Errorf("failed to write message to Kafka after retries: %w", err)
+	}
+	return nil
+}
+
 // validateEvent ensures the event has all required fields for fat event processing
 func (e *EventService) validateEvent(event *domain.PostEvent) error {
 	if event.ID.String() == "" {
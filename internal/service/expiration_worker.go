@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+const (
+	defaultExpirationJobInterval = 5 * time.Minute
+	defaultExpirationJobTimeout  = 2 * time.Minute
+
+	// expirationLockName is held for each pass so only one instance expires and purges at a time
+	expirationLockName = "contact-exchange-expiration"
+)
+
+// ExpirationWorker expires contact exchange requests past their expiry and then purges the
+// contact data of expired ones, on a schedule. A pass that fails is logged and tried again on
+// the next tick.
+type ExpirationWorker struct {
+	contactExchangeService *ContactExchangeService
+	locker                 domain.Locker
+	config                 config.ContactExchangeConfig
+}
+
+// NewExpirationWorker creates a worker that runs the expiration and purge jobs of
+// contactExchangeService. Passes are serialized across instances through locker; without one
+// every pass runs.
+func NewExpirationWorker(contactExchangeService *ContactExchangeService, locker domain.Locker, cfg config.ContactExchangeConfig) *ExpirationWorker {
+	return &ExpirationWorker{
+		contactExchangeService: contactExchangeService,
+		locker:                 locker,
+		config:                 cfg,
+	}
+}
+
+// Run expires and purges requests every interval until the context is cancelled
+func (w *ExpirationWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval())
+	defer ticker.Stop()
+
+	for {
+		w.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce expires overdue requests, then purges the contact data of expired ones. Each run is
+// cancelled once it takes longer than the timeout; the rest is picked up by the next pass.
+// Nothing runs while another instance is in the middle of a pass.
+func (w *ExpirationWorker) RunOnce(ctx context.Context) (expired BulkResult, purged BulkResult) {
+	pass := func(ctx context.Context) error {
+		expired = w.run(ctx, "expire requests", w.contactExchangeService.ProcessExpiredRequests)
+		purged = w.run(ctx, "purge expired contact data", w.contactExchangeService.CleanupExpiredTokens)
+		return nil
+	}
+
+	if w.locker == nil {
+		_ = pass(ctx)
+		return expired, purged
+	}
+	if _, err := w.locker.TryWithLock(ctx, expirationLockName, pass); err != nil && ctx.Err() == nil {
+		log.Printf("Warning: scheduled contact exchange jobs skipped, lock unavailable: %v", err)
+	}
+	return expired, purged
+}
+
+func (w *ExpirationWorker) run(ctx context.Context, name string, job func(ctx context.Context) (BulkResult, error)) BulkResult {
+	if ctx.Err() != nil {
+		return BulkResult{}
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, w.timeout())
+	defer cancel()
+
+	result, err := job(runCtx)
+	if err != nil && ctx.Err() == nil {
+		log.Printf("Warning: scheduled job to %s failed after %d requests: %v", name, result.Processed, err)
+		return result
+	}
+
+	if result.Processed > 0 {
+		log.Printf("Scheduled job to %s: %d processed, %d succeeded, %d failed, %d skipped in %d batches",
+			name, result.Processed, result.Succeeded, result.Failed, result.Skipped, result.Batches)
+	}
+	return result
+}
+
+func (w *ExpirationWorker) interval() time.Duration {
+	if w.config.ExpirationJobIntervalSeconds <= 0 {
+		return defaultExpirationJobInterval
+	}
+	return time.Duration(w.config.ExpirationJobIntervalSeconds) * time.Second
+}
+
+func (w *ExpirationWorker) timeout() time.Duration {
+	if w.config.ExpirationJobTimeoutSeconds <= 0 {
+		return defaultExpirationJobTimeout
+	}
+	return time.Duration(w.config.ExpirationJobTimeoutSeconds) * time.Second
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// thumbnailJPEGQuality balances size and artifacts at thumbnail sizes
+const thumbnailJPEGQuality = 85
+
+// readImageDimensions reads the pixel size from the image header and rewinds the file.
+// Unreadable headers and unknown formats report 0x0, meaning unknown; only a failure to
+// rewind is returned as an error.
+func readImageDimensions(file io.ReadSeeker, format string) (int, int, error) {
+	var cfg image.Config
+	var err error
+
+	switch strings.ToLower(format) {
+	case "jpg", "jpeg":
+		cfg, err = jpeg.DecodeConfig(file)
+	case "png":
+		cfg, err = png.DecodeConfig(file)
+	case "gif":
+		cfg, err = gif.DecodeConfig(file)
+	case "webp":
+		cfg, err = webp.DecodeConfig(file)
+	default:
+		return 0, 0, nil
+	}
+
+	if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil {
+		return 0, 0, fmt.Errorf("failed to rewind image: %w", seekErr)
+	}
+
+	if err != nil {
+		return 0, 0, nil
+	}
+
+	return cfg.Width, cfg.Height, nil
+}
+
+// decodeFirstFrame decodes a still image, or the first frame of an animated GIF
+func decodeFirstFrame(r io.Reader, format string) (image.Image, error) {
+	switch strings.ToLower(format) {
+	case "jpg", "jpeg":
+		return jpeg.Decode(r)
+	case "png":
+		return png.Decode(r)
+	case "gif":
+		// gif.Decode only returns the first frame of an animation
+		return gif.Decode(r)
+	case "webp":
+		return webp.Decode(r)
+	default:
+		return nil, fmt.Errorf("unsupported image format for decoding: %s", format)
+	}
+}
+
+// resizeToFit scales img down so neither side exceeds maxDimension, keeping its aspect ratio.
+// Images already small enough are returned as they are.
+func resizeToFit(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if maxDimension <= 0 || (width <= maxDimension && height <= maxDimension) {
+		return img
+	}
+
+	if width >= height {
+		height = max(height*maxDimension/width, 1)
+		width = maxDimension
+	} else {
+		width = max(width*maxDimension/height, 1)
+		height = maxDimension
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// thumbnailFormat is the format a thumbnail of an original in format is stored in. JPEG
+// stays JPEG; everything else becomes PNG so transparency survives, as there is no WebP
+// encoder and GIF thumbnails are a single still frame.
+func thumbnailFormat(format string) string {
+	switch strings.ToLower(format) {
+	case "jpg", "jpeg":
+		return "jpg"
+	default:
+		return "png"
+	}
+}
+
+// encodeThumbnail writes img in the given thumbnail format
+func encodeThumbnail(w io.Writer, img image.Image, format string) error {
+	if format == "jpg" {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: thumbnailJPEGQuality})
+	}
+	return png.Encode(w, img)
+}
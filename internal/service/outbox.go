@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+// OutboxEventPublisher records events in the outbox instead of sending them to Kafka. Called
+// inside a transaction, the event is only kept if the change it announces is committed; the
+// RelayWorker sends it on from there.
+type OutboxEventPublisher struct {
+	outbox domain.OutboxRepository
+}
+
+// NewOutboxEventPublisher creates a publisher that appends to the outbox
+func NewOutboxEventPublisher(outbox domain.OutboxRepository) *OutboxEventPublisher {
+	return &OutboxEventPublisher{outbox: outbox}
+}
+
+// PublishEvent appends the event to the outbox in the transaction on the context, if any
+func (p *OutboxEventPublisher) PublishEvent(ctx context.Context, event *domain.PostEvent) error {
+	if err := p.outbox.Append(ctx, event); err != nil {
+		return fmt.Errorf("failed to record event in outbox: %w", err)
+	}
+	return nil
+}
+
+// withinTransaction runs fn in a single transaction so a change and the events recorded for
+// it are committed together. Without a transaction manager fn runs on its own.
+func withinTransaction(ctx context.Context, transactions domain.TransactionManager, fn func(ctx context.Context) error) error {
+	if transactions == nil {
+		return fn(ctx)
+	}
+	return transactions.WithinTransaction(ctx, fn)
+}
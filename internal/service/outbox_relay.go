@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+)
+
+const (
+	defaultRelayInterval       = time.Second
+	defaultRelayBatchSize      = 100
+	defaultRelayInitialBackoff = time.Second
+	defaultRelayMaxBackoff     = 5 * time.Minute
+
+	// relayLockName is held for each relay pass so only one instance relays at a time
+	relayLockName = "outbox-relay"
+)
+
+// RelayWorker sends events recorded in the outbox on to Kafka, at least once each. Events of
+// one aggregate are sent in the order they were recorded and share its partition key, so a
+// failed event holds back the ones after it until a retry gets it through. Events the
+// publisher dead-letters are not retried.
+type RelayWorker struct {
+	outbox    domain.OutboxRepository
+	publisher domain.EventPublisher
+	locker    domain.Locker
+	config    config.OutboxConfig
+}
+
+// NewRelayWorker creates a relay that publishes outbox events through publisher. Passes are
+// serialized across instances through locker; without one every pass runs.
+func NewRelayWorker(outbox domain.OutboxRepository, publisher domain.EventPublisher, locker domain.Locker, cfg config.OutboxConfig) *RelayWorker {
+	return &RelayWorker{
+		outbox:    outbox,
+		publisher: publisher,
+		locker:    locker,
+		config:    cfg,
+	}
+}
+
+// Run relays pending events every interval until the context is cancelled
+func (w *RelayWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval())
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.RelayPending(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Warning: outbox relay pass failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending publishes one batch of due events. An event that fails is retried after a
+// backoff, and later events of its aggregate are skipped until it goes through. Nothing is
+// relayed while another instance is in the middle of a pass.
+func (w *RelayWorker) RelayPending(ctx context.Context) (BulkResult, error) {
+	if w.locker == nil {
+		return w.relayPending(ctx)
+	}
+
+	var result BulkResult
+	_, err := w.locker.TryWithLock(ctx, relayLockName, func(ctx context.Context) error {
+		var err error
+		result, err = w.relayPending(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (w *RelayWorker) relayPending(ctx context.Context) (BulkResult, error) {
+	pending, err := w.outbox.FindPending(ctx, time.Now(), w.batchSize())
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	result := BulkResult{Processed: len(pending), Batches: 1}
+	blocked := make(map[string]bool)
+	for _, outboxEvent := range pending {
+		event := outboxEvent.Event
+		if blocked[event.AggregateID] {
+			result.Skipped++
+			continue
+		}
+
+		if err := w.publisher.PublishEvent(ctx, event); err != nil {
+			result.Failed++
+
+			// The event is kept in the dead letter topic; retrying it would fail the same way
+			// and hold back the rest of its aggregate for good
+			if errors.Is(err, anti_corruption.ErrEventDeadLettered) {
+				log.Printf("Warning: %s event %s was dead-lettered and will not be retried: %v", event.EventType, event.ID, err)
+				if err := w.outbox.MarkDeadLettered(ctx, outboxEvent.Sequence, time.Now(), err.Error()); err != nil {
+					log.Printf("Warning: failed to record dead-lettered event %s: %v", event.ID, err)
+					blocked[event.AggregateID] = true
+				}
+				continue
+			}
+
+			blocked[event.AggregateID] = true
+
+			nextAttemptAt := time.Now().Add(w.backoff(outboxEvent.Attempts + 1))
+			log.Printf("Warning: failed to relay %s event %s (attempt %d), retrying at %s: %v",
+				event.EventType, event.ID, outboxEvent.Attempts+1, nextAttemptAt.Format(time.RFC3339), err)
+			if err := w.outbox.MarkFailed(ctx, outboxEvent.Sequence, nextAttemptAt, err.Error()); err != nil {
+				log.Printf("Warning: failed to record relay failure for event %s: %v", event.ID, err)
+			}
+			continue
+		}
+
+		// A publish that is not marked is sent again next pass; later events of the aggregate
+		// wait for it so they are not delivered ahead of it
+		if err := w.outbox.MarkPublished(ctx, outboxEvent.Sequence, time.Now()); err != nil {
+			log.Printf("Warning: failed to mark event %s relayed: %v", event.ID, err)
+			blocked[event.AggregateID] = true
+			result.Failed++
+			continue
+		}
+		result.Succeeded++
+	}
+
+	return result, nil
+}
+
+// backoff is the wait before the given attempt; it doubles after each failure up to the max
+func (w *RelayWorker) backoff(attempt int) time.Duration {
+	backoff := defaultRelayInitialBackoff
+	if w.config.RetryInitialBackoffMs > 0 {
+		backoff = time.Duration(w.config.RetryInitialBackoffMs) * time.Millisecond
+	}
+	maxBackoff := defaultRelayMaxBackoff
+	if w.config.RetryMaxBackoffMs > 0 {
+		maxBackoff = time.Duration(w.config.RetryMaxBackoffMs) * time.Millisecond
+	}
+
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+func (w *RelayWorker) interval() time.Duration {
+	if w.config.RelayIntervalMs <= 0 {
+		return defaultRelayInterval
+	}
+	return time.Duration(w.config.RelayIntervalMs) * time.Millisecond
+}
+
+func (w *RelayWorker) batchSize() int {
+	if w.config.RelayBatchSize <= 0 {
+		return defaultRelayBatchSize
+	}
+	return w.config.RelayBatchSize
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/domain"
 )
 
@@ -14,7 +17,10 @@ type PostService struct {
 	photoRepo       domain.PhotoRepository
 	userContextRepo domain.UserContextRepository
 	orgContextRepo  domain.OrganizationContextRepository
+	landmarkRepo    domain.LandmarkRepository
 	eventPublisher  domain.EventPublisher
+	transactions    domain.TransactionManager
+	features        config.FeatureConfig
 }
 
 // PostServiceConfig holds configuration for enhanced fat event publishing
@@ -29,77 +35,136 @@ func NewPostService(
 	photoRepo domain.PhotoRepository,
 	userContextRepo domain.UserContextRepository,
 	orgContextRepo domain.OrganizationContextRepository,
+	landmarkRepo domain.LandmarkRepository,
 	eventPublisher domain.EventPublisher,
+	transactions domain.TransactionManager,
+	features config.FeatureConfig,
 ) *PostService {
 	return &PostService{
 		postRepo:        postRepo,
 		photoRepo:       photoRepo,
 		userContextRepo: userContextRepo,
 		orgContextRepo:  orgContextRepo,
+		landmarkRepo:    landmarkRepo,
 		eventPublisher:  eventPublisher,
+		transactions:    transactions,
+		features:        features,
 	}
 }
 
-func (s *PostService) CreatePost(ctx context.Context, title, description string, photos []domain.Photo, location domain.Location, radiusMeters int, postType domain.PostType, createdBy domain.UserID, organizationID *domain.OrganizationID) (*domain.Post, error) {
+// CreatePost creates an active post. An empty language falls back to the creator's preferred language.
+func (s *PostService) CreatePost(ctx context.Context, title, description string, photos []domain.Photo, location domain.Location, radiusMeters int, postType domain.PostType, createdBy domain.UserID, organizationID *domain.OrganizationID, language string, tags []string) (*domain.Post, error) {
 	post, err := domain.NewPost(title, description, photos, location, radiusMeters, postType, createdBy, organizationID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid post data: %w", err)
 	}
 
-	if err := s.postRepo.Save(ctx, post); err != nil {
-		return nil, fmt.Errorf("failed to save post: %w", err)
+	if err := s.applyLanguage(ctx, post, language); err != nil {
+		return nil, fmt.Errorf("invalid post data: %w", err)
 	}
 
-	// Generate correlation ID for tracing
-	correlationID := uuid.New().String()
+	if err := post.SetTags(tags); err != nil {
+		return nil, fmt.Errorf("invalid post data: %w", err)
+	}
 
-	// Publish fat PostCreated event with complete context
-	if err := s.publishPostCreatedEvent(ctx, post, correlationID); err != nil {
-		log.Printf("Failed to publish post created event: %v", err)
-		// Don't fail the operation for event publishing errors
+	// Fat PostCreated event with complete context, traced by a fresh correlation ID
+	event := s.postCreatedEvent(ctx, post, uuid.New().String())
+
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.postRepo.Save(ctx, post); err != nil {
+			return fmt.Errorf("failed to save post: %w", err)
+		}
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish post created event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return post, nil
 }
 
-// publishPostCreatedEvent creates and publishes a complete fat event for post creation
-func (s *PostService) publishPostCreatedEvent(ctx context.Context, post *domain.Post, correlationID string) error {
-	// Get privacy-safe user context
-	userContext, err := s.userContextRepo.GetPrivacySafeUser(ctx, post.CreatedBy())
+// CreateDraftPost saves a post that only its author can see. No events are published
+// until the draft is published.
+func (s *PostService) CreateDraftPost(ctx context.Context, title, description string, photos []domain.Photo, location domain.Location, radiusMeters int, postType domain.PostType, createdBy domain.UserID, organizationID *domain.OrganizationID, language string, tags []string) (*domain.Post, error) {
+	post, err := domain.NewDraftPost(title, description, photos, location, radiusMeters, postType, createdBy, organizationID)
 	if err != nil {
-		log.Printf("Warning: failed to get user context for post creation event: %v", err)
-		// Create minimal user context
-		userContext = &domain.PrivacySafeUser{
-			UserID:      post.CreatedBy(),
-			DisplayName: "Unknown User",
-			Preferences: domain.UserPreferences{
-				Timezone:             "UTC",
-				Language:             "en",
-				NotificationChannels: []domain.NotificationChannel{},
-			},
-		}
+		return nil, fmt.Errorf("invalid post data: %w", err)
 	}
 
-	// Get organization context if applicable
-	var orgContext *domain.OrganizationData
-	if post.OrganizationID() != nil {
-		orgData, err := s.orgContextRepo.GetOrganizationData(ctx, *post.OrganizationID())
-		if err != nil {
-			log.Printf("Warning: failed to get organization context for post creation event: %v", err)
-		} else {
-			orgContext = orgData
-		}
+	if err := s.applyLanguage(ctx, post, language); err != nil {
+		return nil, fmt.Errorf("invalid post data: %w", err)
 	}
 
-	// Create fat event payload
-	eventData := &domain.PostCreatedEventData{
-		Post:         post.ToPostData(),
-		User:         *userContext,
-		Organization: orgContext,
-		AIAnalysis:   domain.CreateAIMetadataPlaceholder(),
-		Triggers:     domain.CreateEventTriggersForPostCreated(),
+	if err := post.SetTags(tags); err != nil {
+		return nil, fmt.Errorf("invalid post data: %w", err)
+	}
+
+	if err := s.postRepo.Save(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to save draft post: %w", err)
+	}
+
+	return post, nil
+}
+
+// applyLanguage sets the language a post is written in. Without one, the creator's preferred
+// language is used; a preference that is missing or unusable leaves the language unset.
+func (s *PostService) applyLanguage(ctx context.Context, post *domain.Post, language string) error {
+	if language != "" {
+		return post.SetLanguage(language)
+	}
+
+	user, err := s.userContextRepo.GetPrivacySafeUser(ctx, post.CreatedBy())
+	if err != nil {
+		log.Printf("Warning: failed to get user context for post language: %v", err)
+		return nil
+	}
+
+	if err := post.SetLanguage(user.Preferences.Language); err != nil {
+		log.Printf("Warning: ignoring preferred language %q of user %s: %v", user.Preferences.Language, post.CreatedBy(), err)
+	}
+	return nil
+}
+
+// PublishPost validates a draft and makes it active, announcing it as a newly created post
+func (s *PostService) PublishPost(ctx context.Context, id domain.PostID, userID domain.UserID) (*domain.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if !post.CreatedBy().Equals(userID) {
+		return nil, domain.ErrUnauthorizedOperation(userID, "publish_post")
+	}
+
+	if err := post.Publish(); err != nil {
+		return nil, err
+	}
+
+	event := s.postCreatedEvent(ctx, post, uuid.New().String())
+
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.postRepo.Update(ctx, post); err != nil {
+			return fmt.Errorf("failed to save published post: %w", err)
+		}
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish post created event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return post, nil
+}
+
+// postCreatedEvent builds the complete fat event for post creation
+func (s *PostService) postCreatedEvent(ctx context.Context, post *domain.Post, correlationID string) *domain.PostEvent {
+	eventData := s.buildPostCreatedEventData(ctx, post)
+
 	// Create event with correlation ID
 	event := domain.NewPostEventWithCorrelation(
 		domain.EventTypePostCreated,
@@ -113,7 +178,145 @@ func (s *PostService) publishPostCreatedEvent(ctx context.Context, post *domain.
 	// Add privacy context
 	event.Privacy = domain.CreatePrivacyContext(nil, "organization_members")
 
-	return s.eventPublisher.PublishEvent(ctx, event)
+	return event
+}
+
+// GetPostCreatedEventData rebuilds the fat post created payload for the post's current state,
+// for consumers that need it without waiting for a republish. Drafts have no such event.
+func (s *PostService) GetPostCreatedEventData(ctx context.Context, id domain.PostID) (*domain.PostCreatedEventData, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if post.IsDraft() {
+		return nil, domain.ErrPostNotFound(id)
+	}
+
+	return s.buildPostCreatedEventData(ctx, post), nil
+}
+
+// ReemitPostEvent rebuilds a post's fat event from its current state and publishes it again,
+// tagged as a replay, for consumers that missed the original. Active posts are announced as
+// created; resolved, deleted and other published posts get an event for their current status.
+// Drafts have never been announced and are reported as not found.
+func (s *PostService) ReemitPostEvent(ctx context.Context, id domain.PostID, reason string) (*domain.PostEvent, error) {
+	post, err := s.postRepo.FindByID(domain.WithPrimaryReads(ctx), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if post.IsDraft() {
+		return nil, domain.ErrPostNotFound(id)
+	}
+
+	var event *domain.PostEvent
+	if post.Status() == domain.PostStatusActive {
+		event = domain.NewPostEvent(
+			domain.EventTypePostCreated,
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			s.buildPostCreatedEventData(ctx, post),
+		)
+		event.Privacy = domain.CreatePrivacyContext(nil, "organization_members")
+	} else {
+		event = domain.NewPostEvent(
+			statusEventType(post.Status()),
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			s.buildCurrentStatusEventData(ctx, post),
+		)
+	}
+	event.MarkReplay(reason)
+
+	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to publish replayed event: %w", err)
+	}
+
+	return event, nil
+}
+
+// buildCurrentStatusEventData describes a post's current status for a replayed event. The
+// status it changed from is not stored, so both statuses are the current one.
+func (s *PostService) buildCurrentStatusEventData(ctx context.Context, post *domain.Post) *domain.PostStatusChangedEventData {
+	reason := "replay"
+	eventData := &domain.PostStatusChangedEventData{
+		Post:           post.ToPostData(),
+		Organization:   s.getOrganizationContext(ctx, post, "post replay"),
+		NewStatus:      post.Status(),
+		PreviousStatus: post.Status(),
+		Reason:         &reason,
+	}
+
+	if user, err := s.userContextRepo.GetPrivacySafeUser(ctx, post.CreatedBy()); err == nil {
+		eventData.User = *user
+	} else {
+		log.Printf("Warning: failed to get user context for post replay event: %v", err)
+		eventData.User = domain.PrivacySafeUser{UserID: post.CreatedBy(), DisplayName: "Unknown User"}
+	}
+
+	return eventData
+}
+
+// statusEventType is the event announcing a post reaching the given status
+func statusEventType(status domain.PostStatus) domain.EventType {
+	switch status {
+	case domain.PostStatusResolved:
+		return domain.EventTypePostResolved
+	case domain.PostStatusDeleted:
+		return domain.EventTypePostDeleted
+	default:
+		return domain.EventTypePostUpdated
+	}
+}
+
+// minimalUserContext stands in for a user whose context could not be loaded, so events about
+// changes that are already saved are still published
+func minimalUserContext(userID domain.UserID) *domain.PrivacySafeUser {
+	return &domain.PrivacySafeUser{
+		UserID:      userID,
+		DisplayName: "Unknown User",
+		Preferences: domain.UserPreferences{
+			Timezone:             "UTC",
+			Language:             "en",
+			NotificationChannels: []domain.NotificationChannel{},
+		},
+	}
+}
+
+// buildPostCreatedEventData gathers the user, organization and AI context for a post created event
+func (s *PostService) buildPostCreatedEventData(ctx context.Context, post *domain.Post) *domain.PostCreatedEventData {
+	// Get privacy-safe user context
+	userContext, err := s.userContextRepo.GetPrivacySafeUser(ctx, post.CreatedBy())
+	if err != nil {
+		log.Printf("Warning: failed to get user context for post creation event: %v", err)
+		userContext = minimalUserContext(post.CreatedBy())
+	}
+
+	// Create fat event payload
+	return &domain.PostCreatedEventData{
+		Post:         post.ToPostData(),
+		User:         *userContext,
+		Organization: s.getOrganizationContext(ctx, post, "post creation"),
+		AIAnalysis:   domain.CreateAIMetadataPlaceholder(),
+		Triggers:     domain.CreateEventTriggersForPostCreated(),
+	}
+}
+
+// getOrganizationContext loads the post's organization for fat events, if it has one
+func (s *PostService) getOrganizationContext(ctx context.Context, post *domain.Post, eventName string) *domain.OrganizationData {
+	if post.OrganizationID() == nil {
+		return nil
+	}
+
+	orgData, err := s.orgContextRepo.GetOrganizationData(ctx, *post.OrganizationID())
+	if err != nil {
+		log.Printf("Warning: failed to get organization context for %s event: %v", eventName, err)
+		return nil
+	}
+	return orgData
 }
 
 func (s *PostService) GetPostByID(ctx context.Context, id domain.PostID) (*domain.Post, error) {
@@ -125,8 +328,9 @@ func (s *PostService) GetPostByID(ctx context.Context, id domain.PostID) (*domai
 	return post, nil
 }
 
-func (s *PostService) GetPostsByUser(ctx context.Context, userID domain.UserID, limit, offset int) ([]*domain.Post, error) {
-	posts, err := s.postRepo.FindByUserID(ctx, userID, limit, offset)
+// GetPostsByUser lists a user's posts; drafts are included only when users view their own posts
+func (s *PostService) GetPostsByUser(ctx context.Context, userID, viewerID domain.UserID, limit, offset, maxPhotos int) ([]*domain.Post, error) {
+	posts, err := s.postRepo.FindByUserID(ctx, userID, userID.Equals(viewerID), limit, offset, maxPhotos)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find posts by user: %w", err)
 	}
@@ -134,168 +338,476 @@ func (s *PostService) GetPostsByUser(ctx context.Context, userID domain.UserID,
 	return posts, nil
 }
 
-func (s *PostService) SearchNearbyPosts(ctx context.Context, location domain.Location, radiusMeters int, postType *domain.PostType, limit, offset int) ([]*domain.Post, error) {
-	if radiusMeters <= 0 {
-		radiusMeters = 1000
+// CountPostsByUser counts what GetPostsByUser pages through for the same viewer
+func (s *PostService) CountPostsByUser(ctx context.Context, userID, viewerID domain.UserID) (int64, error) {
+	count, err := s.postRepo.CountByUserID(ctx, userID, userID.Equals(viewerID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count posts by user: %w", err)
 	}
 
-	if radiusMeters > 50000 {
-		radiusMeters = 50000
+	return count, nil
+}
+
+// SearchNearbyPosts finds posts within radiusMeters of location, nearest first, and reports
+// whether more posts follow the page. Paging stops after the maxCandidates nearest posts when
+// it is positive.
+func (s *PostService) SearchNearbyPosts(ctx context.Context, location domain.Location, radiusMeters int, postType *domain.PostType, respectPostRadius bool, maxCandidates, limit, offset, maxPhotos int) ([]*domain.Post, bool, error) {
+	if radiusMeters <= 0 {
+		return nil, false, fmt.Errorf("search radius must be positive")
 	}
 
 	radius := domain.Distance{Meters: float64(radiusMeters)}
 
-	posts, err := s.postRepo.FindNearby(ctx, location, radius, postType, limit, offset)
+	// One extra row tells whether another page exists without counting the matches
+	posts, err := s.postRepo.FindNearby(ctx, location, radius, postType, respectPostRadius, maxCandidates, limit+1, offset, maxPhotos)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search nearby posts: %w", err)
+		return nil, false, fmt.Errorf("failed to search nearby posts: %w", err)
+	}
+
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit]
+	}
+
+	return posts, hasMore, nil
+}
+
+// SearchPostsNearLandmark resolves a landmark name, fuzzily, to its location and searches
+// around it like SearchNearbyPosts. The resolved landmark is returned with the posts.
+func (s *PostService) SearchPostsNearLandmark(ctx context.Context, name string, radiusMeters int, postType *domain.PostType, respectPostRadius bool, maxCandidates, limit, offset, maxPhotos int) (*domain.Landmark, []*domain.Post, bool, error) {
+	landmark, err := s.landmarkRepo.FindByName(ctx, name)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	posts, hasMore, err := s.SearchNearbyPosts(ctx, landmark.Location, radiusMeters, postType, respectPostRadius, maxCandidates, limit, offset, maxPhotos)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return landmark, posts, hasMore, nil
+}
+
+// SearchPostsAlongRoute finds active posts within corridorMeters of a route, in route order
+func (s *PostService) SearchPostsAlongRoute(ctx context.Context, route domain.Route, corridorMeters int, postType *domain.PostType, limit, offset, maxPhotos int) ([]*domain.Post, error) {
+	if corridorMeters <= 0 {
+		return nil, fmt.Errorf("route corridor must be positive")
+	}
+
+	if err := route.Validate(); err != nil {
+		return nil, err
+	}
+
+	corridor := domain.Distance{Meters: float64(corridorMeters)}
+
+	posts, err := s.postRepo.FindAlongRoute(ctx, route, corridor, postType, limit, offset, maxPhotos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts along route: %w", err)
 	}
 
 	return posts, nil
 }
 
-func (s *PostService) UpdatePost(ctx context.Context, id domain.PostID, title, description string) (*domain.Post, error) {
+// UpdatePost changes the fields set in the update on behalf of the post's owner or an admin
+// of its organization. Fields left out of the update keep their current value.
+func (s *PostService) UpdatePost(ctx context.Context, id domain.PostID, userID domain.UserID, update domain.PostUpdate) (*domain.Post, error) {
 	post, err := s.postRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find post: %w", err)
 	}
 
-	previousData := map[string]interface{}{
-		"title":       post.Title(),
-		"description": post.Description(),
+	if err := s.authorizePostChange(ctx, post, userID, "update_post"); err != nil {
+		return nil, err
 	}
 
-	if err := post.Update(title, description); err != nil {
+	before := updatableFields(post)
+	if err := post.Update(update); err != nil {
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
+	changes, previousData := changedFields(before, updatableFields(post))
 
-	if err := s.postRepo.Update(ctx, post); err != nil {
-		return nil, fmt.Errorf("failed to save updated post: %w", err)
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.postRepo.Update(ctx, post); err != nil {
+			return fmt.Errorf("failed to save updated post: %w", err)
+		}
+
+		// Drafts are private, so edits are not announced
+		if post.IsDraft() {
+			return nil
+		}
+
+		// Publish event
+		event := domain.NewPostEvent(
+			domain.EventTypePostUpdated,
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			&domain.PostUpdatedEventData{
+				Post:     post.ToPostData(),
+				User:     domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
+					Timezone: "UTC",
+					Language: "en",
+					NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
+				}, nil),
+				Changes:  changes,
+				Previous: previousData,
+			},
+		)
+
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish post updated event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Publish event
-	changes := map[string]interface{}{
-		"title":       post.Title(),
-		"description": post.Description(),
+	return post, nil
+}
+
+// updatableFields are the values UpdatePost may change, keyed by their event field names
+func updatableFields(post *domain.Post) map[string]interface{} {
+	return map[string]interface{}{
+		"title":         post.Title(),
+		"description":   post.Description(),
+		"location":      post.Location(),
+		"radius_meters": post.RadiusMeters(),
+		"type":          post.PostType(),
+		"tags":          post.Tags(),
+	}
+}
+
+// changedFields returns the new and previous values of the fields that differ
+func changedFields(before, after map[string]interface{}) (changes, previous map[string]interface{}) {
+	changes = make(map[string]interface{})
+	previous = make(map[string]interface{})
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			changes[field] = value
+			previous[field] = before[field]
+		}
+	}
+	return changes, previous
+}
+
+// TransferPostOwnership reassigns an organization post to another member on behalf of one of
+// the organization's admins, announcing the change so both owners can be notified
+func (s *PostService) TransferPostOwnership(ctx context.Context, id domain.PostID, adminID, newOwnerID domain.UserID) (*domain.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	orgID := post.OrganizationID()
+	if orgID == nil {
+		return nil, domain.ErrInvalidOwnershipTransfer("post does not belong to an organization")
+	}
+
+	previousOwnerID := post.CreatedBy()
+	users, err := s.userContextRepo.GetPrivacySafeUsers(ctx, []domain.UserID{adminID, previousOwnerID, newOwnerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user contexts: %w", err)
+	}
+
+	if !isOrganizationMember(users[adminID], *orgID) || users[adminID].Organization.Role != domain.OrganizationRoleAdmin {
+		return nil, domain.ErrUnauthorizedOperation(adminID, "transfer_post_ownership")
+	}
+	if !isOrganizationMember(users[previousOwnerID], *orgID) {
+		return nil, domain.ErrInvalidOwnershipTransfer("current owner is not a member of the organization")
+	}
+	if !isOrganizationMember(users[newOwnerID], *orgID) {
+		return nil, domain.ErrInvalidOwnershipTransfer("new owner is not a member of the organization")
+	}
+
+	if err := post.TransferOwnership(newOwnerID); err != nil {
+		return nil, err
+	}
+
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.postRepo.Update(ctx, post); err != nil {
+			return fmt.Errorf("failed to save transferred post: %w", err)
+		}
+
+		reason := "ownership_transfer"
+		event := domain.NewPostEvent(
+			domain.EventTypePostUpdated,
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			&domain.PostUpdatedEventData{
+				Post:         post.ToPostData(),
+				User:         *users[newOwnerID],
+				Changes:      map[string]interface{}{"owner_id": newOwnerID.String()},
+				Previous:     map[string]interface{}{"owner_id": previousOwnerID.String()},
+				UpdateReason: &reason,
+				Triggers:     domain.CreateEventTriggersForPostUpdated(),
+			},
+		)
+
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish post ownership transfer event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// BumpPost moves the owner's active post back to the top of bump ordered listings and
+// announces it so search indexes can re-rank the post
+func (s *PostService) BumpPost(ctx context.Context, id domain.PostID, userID domain.UserID) (*domain.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	// Other users' drafts are not acknowledged to exist
+	if !post.IsVisibleTo(userID) {
+		return nil, domain.ErrPostNotFound(id)
+	}
+	if !post.CreatedBy().Equals(userID) {
+		return nil, domain.ErrUnauthorizedOperation(userID, "bump_post")
+	}
+
+	previousBumpedAt := post.BumpedAt()
+	if err := post.Bump(); err != nil {
+		return nil, err
 	}
 
 	event := domain.NewPostEvent(
-		domain.EventTypePostUpdated,
+		domain.EventTypePostBumped,
 		post.ID(),
 		post.CreatedBy(),
 		post.OrganizationID(),
-		&domain.PostUpdatedEventData{
-			Post:     post.ToPostData(),
-			User:     domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
-				Timezone: "UTC",
-				Language: "en",
-				NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
-			}, nil),
-			Changes:  changes,
-			Previous: previousData,
+		&domain.PostBumpedEventData{
+			Post:             post.ToPostData(),
+			Organization:     s.getOrganizationContext(ctx, post, "post bump"),
+			BumpedAt:         post.ListedAt(),
+			PreviousBumpedAt: previousBumpedAt,
 		},
 	)
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		log.Printf("Failed to publish post updated event: %v", err)
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		bumped, err := s.postRepo.Bump(ctx, post, domain.PostBumpInterval())
+		if err != nil {
+			return fmt.Errorf("failed to save bumped post: %w", err)
+		}
+		// Another bump was stored after the post was loaded
+		if !bumped {
+			return domain.ErrPostBumpTooSoon(post.ID(), post.NextBumpAt())
+		}
+
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish post bumped event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return post, nil
 }
 
-func (s *PostService) UpdatePostStatus(ctx context.Context, id domain.PostID, newStatus domain.PostStatus) (*domain.Post, error) {
+// OrganizationPostFilters restricts filters to posts of one organization and to what the
+// viewer's role there may see, whatever the request asked for. Admins see every member's
+// drafts; other members see published posts and their own drafts. Non-members are refused.
+func (s *PostService) OrganizationPostFilters(ctx context.Context, orgID domain.OrganizationID, viewerID domain.UserID, filters domain.PostFilters) (domain.PostFilters, error) {
+	user, err := s.userContextRepo.GetPrivacySafeUser(ctx, viewerID)
+	if err != nil {
+		return filters, fmt.Errorf("failed to get user context: %w", err)
+	}
+	if !isOrganizationMember(user, orgID) {
+		return filters, domain.ErrUnauthorizedOperation(viewerID, "list_organization_posts")
+	}
+
+	filters.OrganizationID = &orgID
+	filters.OrganizationIDs = nil
+	filters.ViewerID = &viewerID
+	filters.AllDrafts = user.Organization.Role == domain.OrganizationRoleAdmin
+
+	return filters, nil
+}
+
+// isOrganizationMember reports whether a user's organization context places them in orgID
+func isOrganizationMember(user *domain.PrivacySafeUser, orgID domain.OrganizationID) bool {
+	return user != nil && user.Organization != nil && user.Organization.OrganizationID.Equals(orgID)
+}
+
+// authorizePostChange allows the post's owner, and admins of the organization it belongs to,
+// to change it. Other users' drafts are reported as not found rather than forbidden.
+func (s *PostService) authorizePostChange(ctx context.Context, post *domain.Post, userID domain.UserID, operation string) error {
+	if post.CreatedBy().Equals(userID) {
+		return nil
+	}
+
+	if orgID := post.OrganizationID(); orgID != nil && s.userContextRepo != nil {
+		user, err := s.userContextRepo.GetPrivacySafeUser(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user context: %w", err)
+		}
+		if isOrganizationMember(user, *orgID) && user.Organization.Role == domain.OrganizationRoleAdmin {
+			return nil
+		}
+	}
+
+	if !post.IsVisibleTo(userID) {
+		return domain.ErrPostNotFound(post.ID())
+	}
+	return domain.ErrUnauthorizedOperation(userID, operation)
+}
+
+// UpdatePostStatus moves a post to a new status on behalf of its owner or an admin of its
+// organization. Resolving a post records how it was resolved from the optional resolution
+// details, and reopening it forgets that again.
+func (s *PostService) UpdatePostStatus(ctx context.Context, id domain.PostID, userID domain.UserID, newStatus domain.PostStatus, resolution *domain.ResolutionDetails) (*domain.Post, error) {
+	details := domain.ResolutionDetails{}
+	if resolution != nil {
+		if newStatus != domain.PostStatusResolved {
+			return nil, domain.ErrInvalidResolution("resolution details can only be given when resolving a post")
+		}
+		if err := resolution.Validate(); err != nil {
+			return nil, err
+		}
+		details = *resolution
+	}
+
 	post, err := s.postRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find post: %w", err)
 	}
 
+	if err := s.authorizePostChange(ctx, post, userID, "update_post_status"); err != nil {
+		return nil, err
+	}
+
 	previousStatus := post.Status()
 
 	if err := post.UpdateStatus(newStatus); err != nil {
 		return nil, fmt.Errorf("failed to update post status: %w", err)
 	}
 
-	if err := s.postRepo.Update(ctx, post); err != nil {
-		return nil, fmt.Errorf("failed to save updated post: %w", err)
+	var resolutionData *domain.ResolutionData
+	if newStatus == domain.PostStatusResolved {
+		if resolutionData, err = domain.NewResolutionData(post, details); err != nil {
+			return nil, err
+		}
 	}
 
-	var eventType domain.EventType
-	switch newStatus {
-	case domain.PostStatusResolved:
-		eventType = domain.EventTypePostResolved
-	case domain.PostStatusDeleted:
-		eventType = domain.EventTypePostDeleted
-	default:
-		eventType = domain.EventTypePostUpdated
-	}
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.postRepo.Update(ctx, post); err != nil {
+			return fmt.Errorf("failed to save updated post: %w", err)
+		}
 
-	event := domain.NewPostEvent(
-		eventType,
-		post.ID(),
-		post.CreatedBy(),
-		post.OrganizationID(),
-		&domain.PostStatusChangedEventData{
-			Post:           post.ToPostData(),
-			User:           domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
-				Timezone: "UTC",
-				Language: "en",
-				NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
-			}, nil),
-			NewStatus:      newStatus,
-			PreviousStatus: previousStatus,
-		},
-	)
+		if resolutionData != nil {
+			if err := s.postRepo.SaveResolution(ctx, post.ID(), resolutionData); err != nil {
+				return fmt.Errorf("failed to save post resolution: %w", err)
+			}
+		} else if previousStatus == domain.PostStatusResolved && newStatus == domain.PostStatusActive {
+			if err := s.postRepo.DeleteResolution(ctx, post.ID()); err != nil {
+				return fmt.Errorf("failed to delete post resolution: %w", err)
+			}
+		}
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		log.Printf("Failed to publish post status changed event: %v", err)
+		event := domain.NewPostEvent(
+			statusEventType(newStatus),
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			&domain.PostStatusChangedEventData{
+				Post:           post.ToPostData(),
+				User:           domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
+					Timezone: "UTC",
+					Language: "en",
+					NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
+				}, nil),
+				NewStatus:      newStatus,
+				PreviousStatus: previousStatus,
+				ResolutionData: resolutionData,
+			},
+		)
+
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish post status changed event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return post, nil
 }
 
-func (s *PostService) DeletePost(ctx context.Context, id domain.PostID) error {
+// DeletePost removes a post on behalf of its owner or an admin of its organization
+func (s *PostService) DeletePost(ctx context.Context, id domain.PostID, userID domain.UserID) error {
 	post, err := s.postRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to find post: %w", err)
 	}
 
-	if err := s.postRepo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("failed to delete post: %w", err)
+	if err := s.authorizePostChange(ctx, post, userID, "delete_post"); err != nil {
+		return err
 	}
 
-	event := domain.NewPostEvent(
-		domain.EventTypePostDeleted,
-		post.ID(),
-		post.CreatedBy(),
-		post.OrganizationID(),
-		&domain.PostStatusChangedEventData{
-			Post:           post.ToPostData(),
-			User:           domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
-				Timezone: "UTC",
-				Language: "en",
-				NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
-			}, nil),
-			NewStatus:      domain.PostStatusDeleted,
-			PreviousStatus: post.Status(),
-		},
-	)
+	return withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.postRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete post: %w", err)
+		}
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		log.Printf("Failed to publish post deleted event: %v", err)
-	}
+		if post.IsDraft() {
+			return nil
+		}
 
-	return nil
+		event := domain.NewPostEvent(
+			domain.EventTypePostDeleted,
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			&domain.PostStatusChangedEventData{
+				Post:           post.ToPostData(),
+				User:           domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
+					Timezone: "UTC",
+					Language: "en",
+					NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
+				}, nil),
+				NewStatus:      domain.PostStatusDeleted,
+				PreviousStatus: post.Status(),
+			},
+		)
+
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish post deleted event: %w", err)
+		}
+		return nil
+	})
 }
 
-func (s *PostService) AddPhotoToPost(ctx context.Context, postID domain.PostID, photoReq domain.CreatePhotoRequest) (*domain.Photo, error) {
+// AddPhotoToPost adds a photo after the post's last one on behalf of its owner or an admin of
+// its organization
+func (s *PostService) AddPhotoToPost(ctx context.Context, postID domain.PostID, userID domain.UserID, photoReq domain.CreatePhotoRequest) (*domain.Photo, error) {
 	post, err := s.postRepo.FindByID(ctx, postID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find post: %w", err)
 	}
 
-	if len(post.Photos()) >= 10 {
+	if err := s.authorizePostChange(ctx, post, userID, "add_photo"); err != nil {
+		return nil, err
+	}
+
+	if len(post.Photos()) >= domain.MaxPhotosPerPost {
 		return nil, domain.ErrInvalidPhotoCount(len(post.Photos()))
 	}
 
+	// Close any gaps first so the new photo lands right after the last one
+	if err := s.compactPhotoOrder(ctx, post); err != nil {
+		return nil, err
+	}
+
 	photoReq.PostID = postID
 	photoReq.DisplayOrder = len(post.Photos()) + 1
 
@@ -304,48 +816,129 @@ func (s *PostService) AddPhotoToPost(ctx context.Context, postID domain.PostID,
 		return nil, fmt.Errorf("invalid photo data: %w", err)
 	}
 
-	if err := s.photoRepo.Save(ctx, photo); err != nil {
-		return nil, fmt.Errorf("failed to save photo: %w", err)
-	}
+	err = withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.photoRepo.Save(ctx, photo); err != nil {
+			return fmt.Errorf("failed to save photo: %w", err)
+		}
+
+		if err := post.AddPhoto(*photo); err != nil {
+			return fmt.Errorf("failed to add photo to post: %w", err)
+		}
+
+		if err := s.postRepo.Update(ctx, post); err != nil {
+			return fmt.Errorf("failed to update post: %w", err)
+		}
+
+		if post.IsDraft() {
+			return nil
+		}
+
+		// Get privacy-safe user context for fat events
+		if _, err := s.userContextRepo.GetPrivacySafeUser(ctx, post.CreatedBy()); err != nil {
+			// Log warning but don't fail - continue with event without user context
+			log.Printf("Warning: failed to get user context for photo added event: %v", err)
+		}
+
+		postData := post.ToPostData()
+		orgContext := s.getOrganizationContext(ctx, post, "photo added")
+
+		aiProcessing := s.shouldTriggerAIProcessing(orgContext)
+		triggers := domain.CreateEventTriggersForPhotoAdded()
+		triggers.AIProcessing = aiProcessing
+
+		var priority *string
+		if aiProcessing {
+			priority = domain.StringPtr(domain.AIProcessingPriorityFor(postData))
+		}
+
+		// Publish fat PhotoAdded event with complete context
+		event := domain.NewPostEvent(
+			domain.EventTypePhotoAdded,
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			&domain.PhotoAddedEventData{
+				Post:                postData,
+				Photo:               photo.ToPhotoData(),
+				User:                domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
+					Timezone: "UTC",
+					Language: "en",
+					NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
+				}, nil),
+				Organization:        orgContext,
+				AIProcessingTrigger: aiProcessing,
+				ProcessingPriority:  priority,
+				Triggers:            triggers,
+			},
+		)
 
-	if err := post.AddPhoto(*photo); err != nil {
-		return nil, fmt.Errorf("failed to add photo to post: %w", err)
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish photo added event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.postRepo.Update(ctx, post); err != nil {
-		return nil, fmt.Errorf("failed to update post: %w", err)
+	return photo, nil
+}
+
+// PublishPhotoProcessed announces that a photo's thumbnail and dimensions are stored. Photos
+// still missing either, and photos of drafts, are not announced.
+func (s *PostService) PublishPhotoProcessed(ctx context.Context, photoID domain.PhotoID) error {
+	// Processing results were just written; a lagging replica could still miss them
+	ctx = domain.WithPrimaryReads(ctx)
+
+	photo, err := s.photoRepo.FindByID(ctx, photoID)
+	if err != nil {
+		return fmt.Errorf("failed to find photo: %w", err)
+	}
+	if !photo.IsProcessed() {
+		return nil
 	}
 
-	// Get privacy-safe user context for fat events
-	_, err = s.userContextRepo.GetPrivacySafeUser(ctx, post.CreatedBy())
+	post, err := s.postRepo.FindByID(ctx, photo.PostID())
 	if err != nil {
-		// Log warning but don't fail - continue with event without user context
-		log.Printf("Warning: failed to get user context for photo added event: %v", err)
+		return fmt.Errorf("failed to find post: %w", err)
+	}
+	if post.IsDraft() {
+		return nil
 	}
 
-	// Publish fat PhotoAdded event with complete context
 	event := domain.NewPostEvent(
-		domain.EventTypePhotoAdded,
+		domain.EventTypePhotoProcessed,
 		post.ID(),
 		post.CreatedBy(),
 		post.OrganizationID(),
-		&domain.PhotoAddedEventData{
-			Post:                post.ToPostData(),
-			Photo:               photo.ToPhotoData(),
-			User:                domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
-				Timezone: "UTC",
-				Language: "en",
-				NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
-			}, nil),
-			AIProcessingTrigger: true, // Default to trigger AI processing
+		&domain.PhotoProcessedEventData{
+			Post:         post.ToPostData(),
+			Photo:        photo.ToPhotoData(),
+			Organization: s.getOrganizationContext(ctx, post, "photo processed"),
+			Triggers:     domain.CreateEventTriggersForPhotoProcessed(),
 		},
 	)
 
 	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		log.Printf("Failed to publish photo added event: %v", err)
+		return fmt.Errorf("failed to publish photo processed event: %w", err)
 	}
 
-	return photo, nil
+	return nil
+}
+
+// shouldTriggerAIProcessing decides whether fn-media-ai should analyze a newly added photo.
+// AI processing must be enabled for the service, and organizations can opt out through their
+// AI enhancement policy.
+func (s *PostService) shouldTriggerAIProcessing(org *domain.OrganizationData) bool {
+	if !s.features.AIProcessingEnabled {
+		return false
+	}
+
+	if org != nil && org.Settings != nil && org.Settings.AIEnhancementPolicy != nil {
+		return org.Settings.AIEnhancementPolicy.AutoEnhance
+	}
+
+	return true
 }
 
 func (s *PostService) RemovePhotoFromPost(ctx context.Context, postID domain.PostID, photoID domain.PhotoID) error {
@@ -363,43 +956,143 @@ func (s *PostService) RemovePhotoFromPost(ctx context.Context, postID domain.Pos
 		return fmt.Errorf("photo does not belong to this post")
 	}
 
-	if len(post.Photos()) <= 1 {
+	if len(post.Photos()) <= 1 && !post.IsDraft() {
 		return fmt.Errorf("cannot remove last photo from post")
 	}
 
-	if err := s.photoRepo.Delete(ctx, photoID); err != nil {
-		return fmt.Errorf("failed to delete photo: %w", err)
+	return withinTransaction(ctx, s.transactions, func(ctx context.Context) error {
+		if err := s.photoRepo.Delete(ctx, photoID); err != nil {
+			return fmt.Errorf("failed to delete photo: %w", err)
+		}
+
+		if err := post.RemovePhoto(photoID); err != nil {
+			return fmt.Errorf("failed to remove photo from post: %w", err)
+		}
+
+		if err := s.compactPhotoOrder(ctx, post); err != nil {
+			return err
+		}
+
+		if err := s.postRepo.Update(ctx, post); err != nil {
+			return fmt.Errorf("failed to update post: %w", err)
+		}
+
+		if post.IsDraft() {
+			return nil
+		}
+
+		event := domain.NewPostEvent(
+			domain.EventTypePhotoRemoved,
+			post.ID(),
+			post.CreatedBy(),
+			post.OrganizationID(),
+			&domain.PhotoRemovedEventData{
+				Post:  post.ToPostData(),
+				Photo: photo.ToPhotoData(),
+				User:  domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
+					Timezone: "UTC",
+					Language: "en",
+					NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
+				}, nil),
+			},
+		)
+
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish photo removed event: %w", err)
+		}
+		return nil
+	})
+}
+
+// compactPhotoOrder keeps a post's photo display order a dense 1..N sequence
+func (s *PostService) compactPhotoOrder(ctx context.Context, post *domain.Post) error {
+	if !post.CompactPhotoOrder() {
+		return nil
+	}
+
+	if err := s.photoRepo.RenumberPhotos(ctx, post.ID(), post.Photos()); err != nil {
+		return fmt.Errorf("failed to renumber photos: %w", err)
 	}
+	return nil
+}
 
-	if err := post.RemovePhoto(photoID); err != nil {
-		return fmt.Errorf("failed to remove photo from post: %w", err)
+// GetPhoto returns a photo only if it belongs to the given post
+func (s *PostService) GetPhoto(ctx context.Context, postID domain.PostID, photoID domain.PhotoID, userID domain.UserID) (*domain.Photo, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
 	}
 
-	if err := s.postRepo.Update(ctx, post); err != nil {
-		return fmt.Errorf("failed to update post: %w", err)
+	// Photos of drafts are only shown to their owner, like the drafts themselves
+	if !post.IsVisibleTo(userID) {
+		return nil, domain.ErrPostNotFound(postID)
 	}
 
-	event := domain.NewPostEvent(
-		domain.EventTypePhotoRemoved,
-		post.ID(),
-		post.CreatedBy(),
-		post.OrganizationID(),
-		&domain.PhotoRemovedEventData{
-			Post:  post.ToPostData(),
-			Photo: photo.ToPhotoData(),
-			User:  domain.ToPrivacySafeUser(post.CreatedBy(), "User Name", domain.UserPreferences{
-				Timezone: "UTC",
-				Language: "en",
-				NotificationChannels: []domain.NotificationChannel{domain.NotificationChannelEmail},
-			}, nil),
-		},
-	)
+	photo, err := s.photoRepo.FindByID(ctx, photoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find photo: %w", err)
+	}
 
-	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil {
-		log.Printf("Failed to publish photo removed event: %v", err)
+	if !photo.PostID().Equals(postID) {
+		return nil, fmt.Errorf("photo not found in this post")
 	}
 
-	return nil
+	return photo, nil
+}
+
+// UpdatePhotoCaption edits the caption of a single photo belonging to a post, on behalf of
+// the post's owner or an admin of its organization
+func (s *PostService) UpdatePhotoCaption(ctx context.Context, postID domain.PostID, photoID domain.PhotoID, userID domain.UserID, caption string) (*domain.Photo, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if err := s.authorizePostChange(ctx, post, userID, "update_photo_caption"); err != nil {
+		return nil, err
+	}
+
+	photo, err := s.photoRepo.FindByID(ctx, photoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find photo: %w", err)
+	}
+
+	if !photo.PostID().Equals(postID) {
+		return nil, fmt.Errorf("photo not found in this post")
+	}
+
+	if err := photo.UpdateCaption(caption); err != nil {
+		return nil, fmt.Errorf("invalid caption: %w", err)
+	}
+
+	if err := s.photoRepo.Update(ctx, photo); err != nil {
+		return nil, fmt.Errorf("failed to update photo: %w", err)
+	}
+
+	return photo, nil
+}
+
+// SetCoverPhoto chooses which of the post's photos is shown on cards and capped list views,
+// on behalf of the post's owner or an admin of its organization
+func (s *PostService) SetCoverPhoto(ctx context.Context, postID domain.PostID, photoID domain.PhotoID, userID domain.UserID) (*domain.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+
+	if err := s.authorizePostChange(ctx, post, userID, "set_cover_photo"); err != nil {
+		return nil, err
+	}
+
+	if err := post.SetCoverPhoto(photoID); err != nil {
+		return nil, err
+	}
+
+	if err := s.postRepo.Update(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to save cover photo: %w", err)
+	}
+
+	return post, nil
 }
 
 func (s *PostService) ListPosts(ctx context.Context, filters domain.PostFilters) ([]*domain.Post, error) {
@@ -413,6 +1106,103 @@ func (s *PostService) ListPosts(ctx context.Context, filters domain.PostFilters)
 	return posts, nil
 }
 
+// ListPostsPage lists one page of posts and returns the cursor for the next page, or nil when
+// this is the last one. The cursor works whether the page was reached by offset or by cursor.
+func (s *PostService) ListPostsPage(ctx context.Context, filters domain.PostFilters) ([]*domain.Post, *domain.PostCursor, error) {
+	filters.SetDefaults()
+
+	posts, hasMore, err := s.postRepo.ListPage(ctx, filters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	if !hasMore || len(posts) == 0 {
+		return posts, nil, nil
+	}
+
+	next := domain.CursorAfterSorted(posts[len(posts)-1], filters.Sort)
+	return posts, &next, nil
+}
+
+// GetHeatmap aggregates post density over a bounding box
+func (s *PostService) GetHeatmap(ctx context.Context, filters domain.HeatmapFilters) ([]domain.HeatmapCell, error) {
+	filters.SetDefaults()
+
+	if err := filters.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid heatmap request: %w", err)
+	}
+
+	return s.postRepo.Heatmap(ctx, filters)
+}
+
+// OrganizationStats summarizes posting activity in an organization since a point in time
+type OrganizationStats struct {
+	OrganizationID  domain.OrganizationID
+	Since           time.Time
+	DistinctPosters int64
+}
+
+// GetOrganizationStats reports organization engagement to one of its members
+func (s *PostService) GetOrganizationStats(ctx context.Context, orgID domain.OrganizationID, userID domain.UserID, since time.Time) (*OrganizationStats, error) {
+	user, err := s.userContextRepo.GetPrivacySafeUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+	if !isOrganizationMember(user, orgID) {
+		return nil, domain.ErrUnauthorizedOperation(userID, "view_organization_stats")
+	}
+
+	posters, err := s.postRepo.CountDistinctPosters(ctx, orgID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count distinct posters: %w", err)
+	}
+
+	return &OrganizationStats{
+		OrganizationID:  orgID,
+		Since:           since,
+		DistinctPosters: posters,
+	}, nil
+}
+
+// GetPostResolution returns how a post was resolved to anyone who can see the post
+func (s *PostService) GetPostResolution(ctx context.Context, id domain.PostID, viewerID domain.UserID) (*domain.ResolutionData, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+	if !post.IsVisibleTo(viewerID) {
+		return nil, domain.ErrPostNotFound(id)
+	}
+
+	resolution, err := s.postRepo.FindResolution(ctx, id)
+	if err != nil {
+		if domain.IsPostErrorCode(err, domain.BusinessErrorNotResolved) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to find post resolution: %w", err)
+	}
+
+	return resolution, nil
+}
+
+// GetResolutionStats reports how an organization's posts were resolved to one of its members
+func (s *PostService) GetResolutionStats(ctx context.Context, filter domain.ResolutionStatsFilter, userID domain.UserID) (*domain.ResolutionStats, error) {
+	user, err := s.userContextRepo.GetPrivacySafeUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+	if !isOrganizationMember(user, filter.OrganizationID) {
+		return nil, domain.ErrUnauthorizedOperation(userID, "view_resolution_stats")
+	}
+
+	stats, err := s.postRepo.ResolutionStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute resolution stats: %w", err)
+	}
+
+	return stats, nil
+}
+
 func (s *PostService) CountPosts(ctx context.Context, filters domain.PostFilters) (int64, error) {
 	count, err := s.postRepo.Count(ctx, filters)
 	if err != nil {
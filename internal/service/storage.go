@@ -1,11 +1,13 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"mime/multipart"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -13,34 +15,35 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/google/uuid"
 	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/minio/minio-go/v7"
 	"google.golang.org/api/option"
 )
 
-// StorageService handles photo storage using Google Cloud Storage
+// StorageService handles photo storage using Google Cloud Storage, or MinIO in local environments
 type StorageService struct {
-	client *storage.Client
-	config config.StorageConfig
+	client  *storage.Client
+	minio   *minio.Client // Set instead of client when STORAGE_PROVIDER is minio or test
+	config  config.StorageConfig
+	breaker *CircuitBreaker
 }
 
 // UploadResult represents the result of a photo upload
 type UploadResult struct {
-	URL      string
-	Size     int64
-	Format   string
-	Filename string
+	URL          string
+	ThumbnailURL string // Set by PhotoProcessor.ProcessUpload; empty when no thumbnail was made
+	Size         int64
+	Format       string
+	Filename     string
+	Width        int
+	Height       int
 }
 
 // NewStorageService creates a new storage service using Google Cloud Storage
 func NewStorageService(cfg config.StorageConfig) (*StorageService, error) {
-	// For local development with MinIO, skip GCS initialization
-	storageProvider := os.Getenv("STORAGE_PROVIDER")
-	if storageProvider == "minio" || storageProvider == "test" {
-		// Return a storage service that doesn't use GCS client
-		// MinIO is already initialized by docker-compose
-		return &StorageService{
-			client: nil, // MinIO doesn't need GCS client
-			config: cfg,
-		}, nil
+	// Local development and tests store photos in MinIO instead of GCS
+	if cfg.Provider == "minio" || cfg.Provider == "test" {
+		return newMinIOStorageService(cfg)
 	}
 
 	ctx := context.Background()
@@ -63,8 +66,9 @@ func NewStorageService(cfg config.StorageConfig) (*StorageService, error) {
 	}
 
 	service := &StorageService{
-		client: client,
-		config: cfg,
+		client:  client,
+		config:  cfg,
+		breaker: newStorageCircuitBreaker(cfg),
 	}
 
 	// Initialize bucket
@@ -75,6 +79,22 @@ func NewStorageService(cfg config.StorageConfig) (*StorageService, error) {
 	return service, nil
 }
 
+func newStorageCircuitBreaker(cfg config.StorageConfig) *CircuitBreaker {
+	resetTimeout := 30 * time.Second
+	if cfg.CircuitBreakerResetTimeout != "" {
+		if duration, err := time.ParseDuration(cfg.CircuitBreakerResetTimeout); err == nil {
+			resetTimeout = duration
+		}
+	}
+
+	return NewCircuitBreaker("storage", cfg.CircuitBreakerThreshold, resetTimeout)
+}
+
+// CircuitBreakerStats returns the current state of the storage circuit breaker
+func (s *StorageService) CircuitBreakerStats() CircuitBreakerStats {
+	return s.breaker.Stats()
+}
+
 // Initialize performs any required setup for Google Cloud Storage
 func (s *StorageService) initializeBucket(ctx context.Context) error {
 	bucket := s.client.Bucket(s.config.BucketName)
@@ -112,6 +132,40 @@ func (s *StorageService) initializeBucket(ctx context.Context) error {
 	return nil
 }
 
+// defaultMaxFileSizeBytes is the upload limit used when none is configured
+const defaultMaxFileSizeBytes = 10 * 1024 * 1024
+
+// ErrFileTooLarge is returned for photos over the configured size limit
+var ErrFileTooLarge = errors.New("file too large")
+
+// MaxFileSizeBytes is the largest photo accepted for upload
+func MaxFileSizeBytes(cfg config.StorageConfig) int64 {
+	if cfg.MaxFileSizeBytes <= 0 {
+		return defaultMaxFileSizeBytes
+	}
+	return int64(cfg.MaxFileSizeBytes)
+}
+
+// ValidateFileSize rejects files over maxBytes, naming the limit so clients know the cap
+func ValidateFileSize(size, maxBytes int64) error {
+	if size > maxBytes {
+		return fmt.Errorf("%w: %d bytes (max %s)", ErrFileTooLarge, size, formatFileSize(maxBytes))
+	}
+	return nil
+}
+
+// formatFileSize renders a byte count in the largest unit that divides it evenly
+func formatFileSize(bytes int64) string {
+	switch {
+	case bytes >= 1024*1024 && bytes%(1024*1024) == 0:
+		return fmt.Sprintf("%dMB", bytes/(1024*1024))
+	case bytes >= 1024 && bytes%1024 == 0:
+		return fmt.Sprintf("%dKB", bytes/1024)
+	default:
+		return fmt.Sprintf("%d bytes", bytes)
+	}
+}
+
 // UploadPhoto uploads a photo to Google Cloud Storage
 func (s *StorageService) UploadPhoto(ctx context.Context, file multipart.File, header *multipart.FileHeader, postID uuid.UUID, organizationID *uuid.UUID) (*UploadResult, error) {
 	// Validate file format
@@ -120,65 +174,29 @@ func (s *StorageService) UploadPhoto(ctx context.Context, file multipart.File, h
 		return nil, fmt.Errorf("invalid image format: %s", format)
 	}
 
-	// Validate file size (max 10MB)
-	if header.Size > 10*1024*1024 {
-		return nil, fmt.Errorf("file too large: %d bytes (max 10MB)", header.Size)
+	if err := ValidateFileSize(header.Size, MaxFileSizeBytes(s.config)); err != nil {
+		return nil, err
 	}
 
-	// Generate unique filename
-	filename := s.generateFilename(postID, organizationID, format)
-
-	// If using MinIO (client is nil), return a mock result for now
-	if s.client == nil {
-		// For MinIO/local development, just return a mock URL
-		// In a real implementation, you would use the MinIO Go SDK
-		minioEndpoint := os.Getenv("MINIO_ENDPOINT")
-		minioBucket := os.Getenv("MINIO_BUCKET")
-		if minioEndpoint == "" {
-			minioEndpoint = "localhost:9000"
-		}
-		if minioBucket == "" {
-			minioBucket = "posts-photos-dev"
-		}
-
-		return &UploadResult{
-			URL:      fmt.Sprintf("http://%s/%s/%s", minioEndpoint, minioBucket, filename),
-			Size:     header.Size,
-			Format:   format,
-			Filename: filename,
-		}, nil
+	width, height, err := readImageDimensions(file, format)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create object writer for GCS
-	bucket := s.client.Bucket(s.config.BucketName)
-	obj := bucket.Object(filename)
-	writer := obj.NewWriter(ctx)
+	// Generate unique filename
+	filename := s.generateFilename(postID, organizationID, format)
 
-	// Set content type and metadata
-	writer.ContentType = s.getContentType(format)
-	writer.Metadata = map[string]string{
+	// Storage calls go through the circuit breaker so an unhealthy backend fails fast
+	metadata := map[string]string{
 		"post-id":       postID.String(),
 		"original-name": header.Filename,
-		"upload-time":   time.Now().Format(time.RFC3339),
-	}
-
-	// Copy file content to GCS
-	_, err := io.Copy(writer, file)
-	if err != nil {
-		writer.Close()
-		return nil, fmt.Errorf("failed to upload file: %w", err)
-	}
-
-	// Close the writer to finalize the upload
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to finalize upload: %w", err)
+		"upload-time":   domain.FormatTimestamp(time.Now()),
 	}
-
-	// Make the object publicly readable
-	acl := obj.ACL()
-	err = acl.Set(ctx, storage.AllUsers, storage.RoleReader)
+	err = s.breaker.Execute(func() error {
+		return s.writeObject(ctx, file, filename, s.getContentType(format), metadata)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to set object ACL: %w", err)
+		return nil, err
 	}
 
 	// Generate public URL
@@ -189,26 +207,61 @@ func (s *StorageService) UploadPhoto(ctx context.Context, file multipart.File, h
 		Size:     header.Size,
 		Format:   format,
 		Filename: filename,
+		Width:    width,
+		Height:   height,
 	}, nil
 }
 
 // DeletePhoto deletes a photo from Google Cloud Storage
 func (s *StorageService) DeletePhoto(ctx context.Context, filename string) error {
-	// If using MinIO (client is nil), skip deletion for now
+	if s.minio != nil {
+		return s.breaker.Execute(func() error {
+			if err := s.minio.RemoveObject(ctx, s.config.MinIO.Bucket, filename, minio.RemoveObjectOptions{}); err != nil {
+				return fmt.Errorf("failed to delete file: %w", err)
+			}
+			return nil
+		})
+	}
+
+	return s.breaker.Execute(func() error {
+		bucket := s.client.Bucket(s.config.BucketName)
+		obj := bucket.Object(filename)
+
+		if err := obj.Delete(ctx); err != nil {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ArchivePhoto moves a stored photo to the colder archive storage class. URLs outside the
+// bucket are left alone.
+func (s *StorageService) ArchivePhoto(ctx context.Context, photoURL string) error {
+	// If using MinIO (client is nil), there is no storage class to change
 	if s.client == nil {
-		// In a real implementation, you would use MinIO SDK to delete
 		return nil
 	}
 
-	bucket := s.client.Bucket(s.config.BucketName)
-	obj := bucket.Object(filename)
-
-	err := obj.Delete(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to delete file: %w", err)
+	objectName, ok := s.objectNameFromURL(photoURL)
+	if !ok {
+		return nil
 	}
 
-	return nil
+	return s.breaker.Execute(func() error {
+		obj := s.client.Bucket(s.config.BucketName).Object(objectName)
+
+		// Rewriting an object onto itself is how GCS changes its storage class
+		copier := obj.CopierFrom(obj)
+		copier.StorageClass = s.config.ArchiveStorageClass
+		// Attributes set on the copy replace the source's, so keep the object cacheable
+		copier.CacheControl = s.config.ObjectCacheControl
+		if _, err := copier.Run(ctx); err != nil {
+			return fmt.Errorf("failed to archive file: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // GetPhotoURL returns the public URL for a photo
@@ -216,22 +269,115 @@ func (s *StorageService) GetPhotoURL(filename string) string {
 	return s.generatePublicURL(filename)
 }
 
-// GenerateThumbnail generates a thumbnail for an uploaded photo
+// GenerateThumbnail creates a thumbnail of a stored photo, no larger than the configured
+// maximum dimension, next to the original
 func (s *StorageService) GenerateThumbnail(ctx context.Context, originalURL string, postID uuid.UUID, organizationID *uuid.UUID) (string, error) {
-	// This is a placeholder for thumbnail generation
-	// In a real implementation with GCS, you would:
-	// 1. Use Cloud Functions or Cloud Run to process images
-	// 2. Use Cloud Storage triggers for automatic processing
-	// 3. Use Google Cloud Vision API for image analysis
-	// 4. Store thumbnails in a separate path/bucket
+	objectName, ok := s.objectNameFromURL(originalURL)
+	if !ok {
+		return "", fmt.Errorf("photo URL does not belong to bucket %s: %s", s.config.BucketName, originalURL)
+	}
+
+	var original []byte
+	err := s.breaker.Execute(func() error {
+		reader, err := s.openObject(ctx, objectName)
+		if err != nil {
+			return fmt.Errorf("failed to read original photo: %w", err)
+		}
+		defer reader.Close()
 
-	// For now, just return the original URL
-	// TODO: Implement actual thumbnail generation with Cloud Functions
-	return originalURL, nil
+		original, err = io.ReadAll(reader)
+		if err != nil {
+			return fmt.Errorf("failed to read original photo: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.createThumbnail(ctx, bytes.NewReader(original), s.getFileExtension(objectName), objectName, postID, organizationID)
 }
 
 // Helper methods
 
+// createThumbnail decodes an original in the given format, scales it down and stores the
+// result under the thumbnail path. Animated GIFs get a still of their first frame.
+// Decoding happens outside the circuit breaker so corrupt uploads do not count as storage
+// failures.
+func (s *StorageService) createThumbnail(ctx context.Context, original io.Reader, format, sourceObject string, postID uuid.UUID, organizationID *uuid.UUID) (string, error) {
+	img, err := decodeFirstFrame(original, format)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s image: %w", format, err)
+	}
+
+	thumbFormat := thumbnailFormat(format)
+	var buf bytes.Buffer
+	if err := encodeThumbnail(&buf, resizeToFit(img, s.config.ThumbnailMaxDimension), thumbFormat); err != nil {
+		return "", fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	filename := strings.Replace(s.generateFilename(postID, organizationID, thumbFormat), "/original/", "/thumbnail/", 1)
+	metadata := map[string]string{
+		"post-id":       postID.String(),
+		"source-object": sourceObject,
+		"upload-time":   domain.FormatTimestamp(time.Now()),
+	}
+	err = s.breaker.Execute(func() error {
+		return s.writeObject(ctx, &buf, filename, s.getContentType(thumbFormat), metadata)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return s.generatePublicURL(filename), nil
+}
+
+// openObject reads a stored object
+func (s *StorageService) openObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	if s.minio != nil {
+		return s.openMinIOObject(ctx, objectName)
+	}
+	return s.client.Bucket(s.config.BucketName).Object(objectName).NewReader(ctx)
+}
+
+// writeObject streams content to GCS and makes it publicly readable
+func (s *StorageService) writeObject(ctx context.Context, content io.Reader, filename, contentType string, metadata map[string]string) error {
+	if s.minio != nil {
+		return s.writeMinIOObject(ctx, content, filename, contentType, metadata)
+	}
+
+	// Create object writer for GCS
+	bucket := s.client.Bucket(s.config.BucketName)
+	obj := bucket.Object(filename)
+	writer := obj.NewWriter(ctx)
+
+	// Set content type, caching and metadata
+	writer.ContentType = contentType
+	writer.CacheControl = s.config.ObjectCacheControl
+	writer.Metadata = metadata
+
+	// Copy file content to GCS
+	_, err := io.Copy(writer, content)
+	if err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	// Close the writer to finalize the upload
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finalize upload: %w", err)
+	}
+
+	// Make the object publicly readable
+	acl := obj.ACL()
+	err = acl.Set(ctx, storage.AllUsers, storage.RoleReader)
+	if err != nil {
+		return fmt.Errorf("failed to set object ACL: %w", err)
+	}
+
+	return nil
+}
+
 func (s *StorageService) generateFilename(postID uuid.UUID, organizationID *uuid.UUID, format string) string {
 	timestamp := time.Now().Format("20060102150405")
 	uniqueID := uuid.New().String()[:8]
@@ -261,10 +407,30 @@ func (s *StorageService) generatePublicURL(filename string) string {
 		return fmt.Sprintf("%s/%s", strings.TrimSuffix(s.config.CDNDomain, "/"), filename)
 	}
 
+	if s.minio != nil {
+		return s.minioURLPrefix() + filename
+	}
+
 	// Otherwise use the standard GCS public URL
 	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", s.config.BucketName, filename)
 }
 
+// objectNameFromURL reverses generatePublicURL
+func (s *StorageService) objectNameFromURL(url string) (string, bool) {
+	prefix := fmt.Sprintf("https://storage.googleapis.com/%s/", s.config.BucketName)
+	if s.minio != nil {
+		prefix = s.minioURLPrefix()
+	}
+	if s.config.CDNDomain != "" {
+		prefix = strings.TrimSuffix(s.config.CDNDomain, "/") + "/"
+	}
+
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, prefix), true
+}
+
 func (s *StorageService) getFileExtension(filename string) string {
 	ext := strings.ToLower(filepath.Ext(filename))
 	if ext == "" {
@@ -274,62 +440,79 @@ func (s *StorageService) getFileExtension(filename string) string {
 }
 
 func (s *StorageService) isValidImageFormat(format string) bool {
-	validFormats := map[string]bool{
-		"jpg":  true,
-		"jpeg": true,
-		"png":  true,
-		"webp": true,
-	}
-	return validFormats[strings.ToLower(format)]
+	return domain.IsAllowedPhotoFormat(format)
 }
 
 func (s *StorageService) getContentType(format string) string {
-	contentTypes := map[string]string{
-		"jpg":  "image/jpeg",
-		"jpeg": "image/jpeg",
-		"png":  "image/png",
-		"webp": "image/webp",
-	}
-
-	if contentType, exists := contentTypes[strings.ToLower(format)]; exists {
-		return contentType
-	}
-
-	return "application/octet-stream"
+	return domain.PhotoMimeType(format)
 }
 
 // PhotoProcessor handles photo processing workflows
 type PhotoProcessor struct {
-	storage *StorageService
+	storage     *StorageService
+	photoRepo   domain.PhotoRepository
+	postService *PostService
 }
 
-// NewPhotoProcessor creates a new photo processor
-func NewPhotoProcessor(storage *StorageService) *PhotoProcessor {
-	return &PhotoProcessor{storage: storage}
+// NewPhotoProcessor creates a new photo processor. Processed photos are announced through
+// postService; pass nil to store thumbnails without publishing.
+func NewPhotoProcessor(storage *StorageService, photoRepo domain.PhotoRepository, postService *PostService) *PhotoProcessor {
+	return &PhotoProcessor{storage: storage, photoRepo: photoRepo, postService: postService}
 }
 
-// ProcessUpload processes a photo upload with thumbnail generation
+// ProcessUpload uploads the original photo and a thumbnail made from the same bytes. Pass
+// the result's ThumbnailURL on to the photo record when saving it. Images that cannot be
+// decoded are still uploaded, just without a thumbnail.
 func (p *PhotoProcessor) ProcessUpload(ctx context.Context, file multipart.File, header *multipart.FileHeader, postID uuid.UUID, organizationID *uuid.UUID) (*UploadResult, error) {
-	// Upload original
 	result, err := p.storage.UploadPhoto(ctx, file, header, postID, organizationID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate thumbnail asynchronously
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		log.Printf("Skipping thumbnail for %s: failed to rewind upload: %v", result.Filename, err)
+		return result, nil
+	}
+
+	thumbnailURL, err := p.storage.createThumbnail(ctx, file, result.Format, result.Filename, postID, organizationID)
+	if err != nil {
+		log.Printf("Skipping thumbnail for %s: %v", result.Filename, err)
+		return result, nil
+	}
+	result.ThumbnailURL = thumbnailURL
+
+	return result, nil
+}
+
+// GenerateThumbnailAsync generates a thumbnail for an already saved photo in the background
+// and stores it on the photo record
+func (p *PhotoProcessor) GenerateThumbnailAsync(photo *domain.Photo, organizationID *uuid.UUID) {
 	go func() {
-		thumbnailURL, err := p.storage.GenerateThumbnail(context.Background(), result.URL, postID, organizationID)
+		ctx := context.Background()
+
+		thumbnailURL, err := p.storage.GenerateThumbnail(ctx, photo.URL(), photo.PostID().UUID(), organizationID)
 		if err != nil {
 			// Log error, but don't fail the upload
-			fmt.Printf("Failed to generate thumbnail: %v\n", err)
+			log.Printf("Failed to generate thumbnail for photo %s: %v", photo.ID(), err)
 			return
 		}
 
-		// In a real implementation, you would update the photo record with the thumbnail URL
-		fmt.Printf("Thumbnail generated: %s\n", thumbnailURL)
+		p.onThumbnailGenerated(ctx, photo.ID(), thumbnailURL, photo.Width(), photo.Height())
 	}()
+}
 
-	return result, nil
+func (p *PhotoProcessor) onThumbnailGenerated(ctx context.Context, photoID domain.PhotoID, thumbnailURL string, width, height int) {
+	if err := p.photoRepo.UpdateThumbnail(ctx, photoID, thumbnailURL, width, height); err != nil {
+		log.Printf("Failed to store thumbnail for photo %s: %v", photoID, err)
+		return
+	}
+
+	if p.postService == nil {
+		return
+	}
+	if err := p.postService.PublishPhotoProcessed(ctx, photoID); err != nil {
+		log.Printf("Failed to announce processed photo %s: %v", photoID, err)
+	}
 }
 
 // TestStorageService provides a simple storage implementation for testing
@@ -354,9 +537,8 @@ func (s *TestStorageService) UploadPhoto(ctx context.Context, file multipart.Fil
 		return nil, fmt.Errorf("invalid image format: %s", format)
 	}
 
-	// Validate file size (max 10MB)
-	if header.Size > 10*1024*1024 {
-		return nil, fmt.Errorf("file too large: %d bytes (max 10MB)", header.Size)
+	if err := ValidateFileSize(header.Size, MaxFileSizeBytes(s.config)); err != nil {
+		return nil, err
 	}
 
 	// Generate unique filename
@@ -385,6 +567,11 @@ func (s *TestStorageService) DeletePhoto(ctx context.Context, filename string) e
 	return nil
 }
 
+// ArchivePhoto is a no-op for in-memory test storage
+func (s *TestStorageService) ArchivePhoto(ctx context.Context, photoURL string) error {
+	return nil
+}
+
 // GetPhotoURL returns the public URL for a photo
 func (s *TestStorageService) GetPhotoURL(filename string) string {
 	return s.generatePublicURL(filename)
@@ -435,11 +622,5 @@ func (s *TestStorageService) getFileExtension(filename string) string {
 }
 
 func (s *TestStorageService) isValidImageFormat(format string) bool {
-	validFormats := map[string]bool{
-		"jpg":  true,
-		"jpeg": true,
-		"png":  true,
-		"webp": true,
-	}
-	return validFormats[strings.ToLower(format)]
+	return domain.IsAllowedPhotoFormat(format)
 }
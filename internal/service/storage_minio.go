@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// publicReadPolicy lets anyone fetch objects, but not list or change, in a bucket created here
+const publicReadPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`
+
+// newMinIOStorageService stores photos in an S3-compatible MinIO bucket, used in local
+// environments in place of Google Cloud Storage
+func newMinIOStorageService(cfg config.StorageConfig) (*StorageService, error) {
+	client, err := minio.New(cfg.MinIO.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.MinIO.AccessKey, cfg.MinIO.SecretKey, ""),
+		Secure: cfg.MinIO.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MinIO client: %w", err)
+	}
+
+	service := &StorageService{
+		minio:   client,
+		config:  cfg,
+		breaker: newStorageCircuitBreaker(cfg),
+	}
+
+	if err := service.initializeMinIOBucket(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to initialize bucket: %w", err)
+	}
+
+	return service, nil
+}
+
+// initializeMinIOBucket creates the bucket, publicly readable, if it does not exist yet
+func (s *StorageService) initializeMinIOBucket(ctx context.Context) error {
+	bucket := s.config.MinIO.Bucket
+
+	exists, err := s.minio.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to access bucket: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := s.minio.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+		return fmt.Errorf("failed to create bucket: %w", err)
+	}
+
+	if err := s.minio.SetBucketPolicy(ctx, bucket, fmt.Sprintf(publicReadPolicy, bucket)); err != nil {
+		return fmt.Errorf("failed to set bucket policy: %w", err)
+	}
+
+	return nil
+}
+
+// writeMinIOObject streams content into the bucket
+func (s *StorageService) writeMinIOObject(ctx context.Context, content io.Reader, filename, contentType string, metadata map[string]string) error {
+	_, err := s.minio.PutObject(ctx, s.config.MinIO.Bucket, filename, content, readerSize(content), minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: s.config.ObjectCacheControl,
+		UserMetadata: metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return nil
+}
+
+// openMinIOObject reads an object from the bucket
+func (s *StorageService) openMinIOObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	object, err := s.minio.GetObject(ctx, s.config.MinIO.Bucket, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// GetObject is lazy; Stat surfaces a missing object here rather than on the first read
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// minioURLPrefix is the base of object URLs served directly by MinIO
+func (s *StorageService) minioURLPrefix() string {
+	scheme := "http"
+	if s.config.MinIO.UseSSL {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/%s/", scheme, strings.TrimSuffix(s.config.MinIO.Endpoint, "/"), s.config.MinIO.Bucket)
+}
+
+// readerSize reports the remaining length of content when it can be known without reading
+// it, or -1. A known size lets the upload skip buffering into multipart chunks.
+func readerSize(content io.Reader) int64 {
+	switch r := content.(type) {
+	case *bytes.Buffer:
+		return int64(r.Len())
+	case io.Seeker:
+		current, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1
+		}
+		if _, err := r.Seek(current, io.SeekStart); err != nil {
+			return -1
+		}
+		return end - current
+	default:
+		return -1
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
 )
 
 // TestStorageService provides a simple storage implementation for testing
@@ -34,9 +35,8 @@ func (s *TestStorageService) UploadPhoto(ctx context.Context, file multipart.Fil
 		return nil, fmt.Errorf("invalid image format: %s", format)
 	}
 
-	// Validate file size (max 10MB)
-	if header.Size > 10*1024*1024 {
-		return nil, fmt.Errorf("file too large: %d bytes (max 10MB)", header.Size)
+	if err := ValidateFileSize(header.Size, MaxFileSizeBytes(s.config)); err != nil {
+		return nil, err
 	}
 
 	// Generate unique filename
@@ -115,11 +115,5 @@ func (s *TestStorageService) getFileExtension(filename string) string {
 }
 
 func (s *TestStorageService) isValidImageFormat(format string) bool {
-	validFormats := map[string]bool{
-		"jpg":  true,
-		"jpeg": true,
-		"png":  true,
-		"webp": true,
-	}
-	return validFormats[strings.ToLower(format)]
+	return domain.IsAllowedPhotoFormat(format)
 }
@@ -5,8 +5,10 @@ package internal
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/google/wire"
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
 	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/domain"
 	"github.com/jsarabia/fn-posts/internal/handler"
@@ -19,46 +21,74 @@ type Application struct {
 	PostHandler            *handler.PostHandler
 	PhotoHandler           *handler.PhotoHandler
 	ContactExchangeHandler *handler.ContactExchangeHandler
+	ClaimHandler           *handler.ClaimHandler
+	ImportHandler          *handler.ImportHandler
+	StorageService         *service.StorageService
+	EventPublisher         *anti_corruption.AntiCorruptionEventPublisher
+	RelayWorker            *service.RelayWorker
+	ExpirationWorker       *service.ExpirationWorker
 	Config                 *config.Config
 }
 
 // InitializeApplication sets up the complete application using Wire
-func InitializeApplication(db *sql.DB, cfg *config.Config) (*Application, error) {
+func InitializeApplication(dbs *repository.DBRouter, cfg *config.Config) (*Application, error) {
 	wire.Build(
 		// Repositories
 		repository.NewPostgresPostRepository,
 		repository.NewPostgresPhotoRepository,
 		repository.NewPostgresContactExchangeRepository,
+		repository.NewPostgresClaimRepository,
+		repository.NewPostgresLandmarkRepository,
 		repository.NewMockUserContextRepository,
 		repository.NewMockOrganizationContextRepository,
 		repository.NewPostgresEncryptionAuditLogger,
 		repository.NewPostgresKeyRepository,
+		repository.NewPostgresOutboxRepository,
 
 		// Services
 		service.NewEventService,
+		service.NewOutboxEventPublisher,
 		service.NewStorageService,
 		service.NewPostService,
 		service.NewContactExchangeService,
-		domain.NewRSAEncryptionService,
+		service.NewClaimService,
+		service.NewExpirationWorker,
+		domain.NewRSAEncryptionServiceWithRetry,
+		anti_corruption.NewEventTranslator,
+		providePostImportService,
 
 		// Handlers
 		handler.NewPostHandler,
 		handler.NewPhotoHandler,
 		handler.NewContactExchangeHandler,
+		handler.NewClaimHandler,
+		handler.NewImportHandler,
 
 		// Providers
+		providePrimaryDB,
 		provideStorageConfig,
 		provideKafkaConfig,
+		provideFeatureConfig,
+		provideContactExchangeConfig,
+		provideClaimConfig,
+		provideKeyLoadRetry,
 		provideStorageInterface,
 		providePostRepository,
 		providePhotoRepository,
 		provideContactExchangeRepository,
+		provideClaimRepository,
+		provideLandmarkRepository,
 		provideUserContextRepository,
 		provideOrganizationContextRepository,
 		provideEncryptionService,
 		provideEncryptionAuditLogger,
 		provideKeyRepository,
 		provideEventPublisher,
+		provideOutboxRepository,
+		provideTransactionManager,
+		provideLocker,
+		provideRelayPublisher,
+		provideRelayWorker,
 
 		// Application
 		wire.Struct(new(Application), "*"),
@@ -68,6 +98,10 @@ func InitializeApplication(db *sql.DB, cfg *config.Config) (*Application, error)
 
 // Providers for configuration and interfaces
 
+func providePrimaryDB(dbs *repository.DBRouter) *sql.DB {
+	return dbs.Primary()
+}
+
 func provideStorageConfig(cfg *config.Config) config.StorageConfig {
 	return cfg.StorageConfig
 }
@@ -76,6 +110,26 @@ func provideKafkaConfig(cfg *config.Config) config.KafkaConfig {
 	return cfg.KafkaConfig
 }
 
+func provideFeatureConfig(cfg *config.Config) config.FeatureConfig {
+	return cfg.Features
+}
+
+func provideContactExchangeConfig(cfg *config.Config) config.ContactExchangeConfig {
+	return cfg.ContactExchange
+}
+
+func provideClaimConfig(cfg *config.Config) config.ClaimConfig {
+	return cfg.Claims
+}
+
+func provideKeyLoadRetry(cfg *config.Config) domain.KeyLoadRetry {
+	return domain.KeyLoadRetry{
+		Attempts:       cfg.Encryption.KeyLoadAttempts,
+		InitialBackoff: time.Duration(cfg.Encryption.KeyLoadInitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(cfg.Encryption.KeyLoadMaxBackoffMs) * time.Millisecond,
+	}
+}
+
 func provideStorageInterface(storageService *service.StorageService) handler.StorageInterface {
 	return storageService
 }
@@ -88,18 +142,58 @@ func providePhotoRepository(repo *repository.PostgresPhotoRepository) domain.Pho
 	return repo
 }
 
-func provideEventPublisher(eventService *service.EventService) domain.EventPublisher {
-	return eventService
+// provideEventPublisher records service events in the outbox; the relay sends them to Kafka
+func provideEventPublisher(publisher *service.OutboxEventPublisher) domain.EventPublisher {
+	return publisher
+}
+
+func provideOutboxRepository(repo *repository.PostgresOutboxRepository) domain.OutboxRepository {
+	return repo
+}
+
+func provideTransactionManager(dbs *repository.DBRouter) domain.TransactionManager {
+	return dbs
+}
+
+// providePostImportService records the events of imported posts through the outbox, in the
+// transaction that stores them
+func providePostImportService(translator *anti_corruption.EventTranslator, postRepo domain.PostRepository, userContextRepo domain.UserContextRepository, eventPublisher domain.EventPublisher, transactions domain.TransactionManager) *anti_corruption.PostImportService {
+	return anti_corruption.NewPostImportService(translator, postRepo, userContextRepo, eventPublisher, transactions, 0)
+}
+
+func provideLocker(dbs *repository.DBRouter) domain.Locker {
+	return dbs
+}
+
+// provideRelayPublisher translates outbox post events to the external schema before they are
+// sent through the Kafka writer, dead-lettering the ones that cannot be translated
+func provideRelayPublisher(eventService *service.EventService, kafkaConfig config.KafkaConfig) *anti_corruption.AntiCorruptionEventPublisher {
+	return anti_corruption.NewRelayEventPublisher(eventService, eventService, kafkaConfig.ThinEventTypes)
+}
+
+func provideRelayWorker(outbox domain.OutboxRepository, publisher *anti_corruption.AntiCorruptionEventPublisher, locker domain.Locker, cfg *config.Config) *service.RelayWorker {
+	return service.NewRelayWorker(outbox, publisher, locker, cfg.Outbox)
 }
 
 func provideContactExchangeRepository(repo *repository.PostgresContactExchangeRepository) domain.ContactExchangeRepository {
 	return repo
 }
 
-func provideUserContextRepository(repo *repository.MockUserContextRepository) domain.UserContextRepository {
+func provideClaimRepository(repo *repository.PostgresClaimRepository) domain.ClaimRepository {
 	return repo
 }
 
+func provideLandmarkRepository(repo *repository.PostgresLandmarkRepository) domain.LandmarkRepository {
+	return repo
+}
+
+func provideUserContextRepository(repo *repository.MockUserContextRepository, cfg *config.Config) domain.UserContextRepository {
+	if cfg.UserContext.CacheTTLSeconds <= 0 {
+		return repo
+	}
+	return repository.NewCachingUserContextRepository(repo, time.Duration(cfg.UserContext.CacheTTLSeconds)*time.Second)
+}
+
 func provideOrganizationContextRepository(repo *repository.MockOrganizationContextRepository) domain.OrganizationContextRepository {
 	return repo
 }
@@ -8,57 +8,87 @@ package internal
 
 import (
 	"database/sql"
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
 	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/domain"
 	"github.com/jsarabia/fn-posts/internal/handler"
 	"github.com/jsarabia/fn-posts/internal/repository"
 	"github.com/jsarabia/fn-posts/internal/service"
+	"time"
 )
 
 // Injectors from wire.go:
 
 // InitializeApplication sets up the complete application using Wire
-func InitializeApplication(db *sql.DB, cfg *config.Config) (*Application, error) {
-	postgresPostRepository := repository.NewPostgresPostRepository(db)
+func InitializeApplication(dbs *repository.DBRouter, cfg *config.Config) (*Application, error) {
+	postgresPostRepository := repository.NewPostgresPostRepository(dbs)
 	postRepository := providePostRepository(postgresPostRepository)
-	postgresPhotoRepository := repository.NewPostgresPhotoRepository(db)
+	postgresPhotoRepository := repository.NewPostgresPhotoRepository(dbs)
 	photoRepository := providePhotoRepository(postgresPhotoRepository)
 	mockUserContextRepository := repository.NewMockUserContextRepository()
-	userContextRepository := provideUserContextRepository(mockUserContextRepository)
+	userContextRepository := provideUserContextRepository(mockUserContextRepository, cfg)
 	mockOrganizationContextRepository := repository.NewMockOrganizationContextRepository()
 	organizationContextRepository := provideOrganizationContextRepository(mockOrganizationContextRepository)
-	kafkaConfig := provideKafkaConfig(cfg)
-	eventService, err := service.NewEventService(kafkaConfig)
-	if err != nil {
-		return nil, err
-	}
-	eventPublisher := provideEventPublisher(eventService)
-	postService := service.NewPostService(postRepository, photoRepository, userContextRepository, organizationContextRepository, eventPublisher)
+	postgresOutboxRepository := repository.NewPostgresOutboxRepository(dbs)
+	outboxRepository := provideOutboxRepository(postgresOutboxRepository)
+	outboxEventPublisher := service.NewOutboxEventPublisher(outboxRepository)
+	eventPublisher := provideEventPublisher(outboxEventPublisher)
+	transactionManager := provideTransactionManager(dbs)
+	featureConfig := provideFeatureConfig(cfg)
+	postgresLandmarkRepository := repository.NewPostgresLandmarkRepository(dbs)
+	landmarkRepository := provideLandmarkRepository(postgresLandmarkRepository)
+	postService := service.NewPostService(postRepository, photoRepository, userContextRepository, organizationContextRepository, landmarkRepository, eventPublisher, transactionManager, featureConfig)
 	storageConfig := provideStorageConfig(cfg)
 	storageService, err := service.NewStorageService(storageConfig)
 	if err != nil {
 		return nil, err
 	}
 	storageInterface := provideStorageInterface(storageService)
-	postHandler := handler.NewPostHandler(postService, storageInterface)
-	photoHandler := handler.NewPhotoHandler(postService, storageInterface)
-	postgresContactExchangeRepository := repository.NewPostgresContactExchangeRepository(db)
+	postHandler := handler.NewPostHandler(postService, storageInterface, cfg)
+	photoHandler := handler.NewPhotoHandler(postService, storageInterface, cfg)
+	postgresContactExchangeRepository := repository.NewPostgresContactExchangeRepository(dbs)
 	contactExchangeRepository := provideContactExchangeRepository(postgresContactExchangeRepository)
+	db := providePrimaryDB(dbs)
 	postgresKeyRepository := repository.NewPostgresKeyRepository(db)
 	keyRepository := provideKeyRepository(postgresKeyRepository)
 	postgresEncryptionAuditLogger := repository.NewPostgresEncryptionAuditLogger(db)
 	encryptionAuditLogger := provideEncryptionAuditLogger(postgresEncryptionAuditLogger)
-	rsaEncryptionService, err := domain.NewRSAEncryptionService(keyRepository, encryptionAuditLogger)
+	keyLoadRetry := provideKeyLoadRetry(cfg)
+	rsaEncryptionService, err := domain.NewRSAEncryptionServiceWithRetry(keyRepository, encryptionAuditLogger, keyLoadRetry)
 	if err != nil {
 		return nil, err
 	}
 	encryptionService := provideEncryptionService(rsaEncryptionService)
-	contactExchangeService := service.NewContactExchangeService(contactExchangeRepository, postRepository, userContextRepository, eventPublisher, encryptionService, encryptionAuditLogger)
+	contactExchangeConfig := provideContactExchangeConfig(cfg)
+	contactExchangeService := service.NewContactExchangeService(contactExchangeRepository, postRepository, userContextRepository, eventPublisher, transactionManager, encryptionService, encryptionAuditLogger, contactExchangeConfig)
 	contactExchangeHandler := handler.NewContactExchangeHandler(contactExchangeService)
+	postgresClaimRepository := repository.NewPostgresClaimRepository(dbs)
+	claimRepository := provideClaimRepository(postgresClaimRepository)
+	claimConfig := provideClaimConfig(cfg)
+	claimService := service.NewClaimService(claimRepository, postRepository, userContextRepository, eventPublisher, contactExchangeService, claimConfig)
+	claimHandler := handler.NewClaimHandler(claimService)
+	eventTranslator := anti_corruption.NewEventTranslator()
+	postImportService := providePostImportService(eventTranslator, postRepository, userContextRepository, eventPublisher, transactionManager)
+	importHandler := handler.NewImportHandler(postImportService)
+	kafkaConfig := provideKafkaConfig(cfg)
+	eventService, err := service.NewEventService(kafkaConfig)
+	if err != nil {
+		return nil, err
+	}
+	antiCorruptionEventPublisher := provideRelayPublisher(eventService, kafkaConfig)
+	locker := provideLocker(dbs)
+	relayWorker := provideRelayWorker(outboxRepository, antiCorruptionEventPublisher, locker, cfg)
+	expirationWorker := service.NewExpirationWorker(contactExchangeService, locker, contactExchangeConfig)
 	application := &Application{
 		PostHandler:            postHandler,
 		PhotoHandler:           photoHandler,
 		ContactExchangeHandler: contactExchangeHandler,
+		ClaimHandler:           claimHandler,
+		ImportHandler:          importHandler,
+		StorageService:         storageService,
+		EventPublisher:         antiCorruptionEventPublisher,
+		RelayWorker:            relayWorker,
+		ExpirationWorker:       expirationWorker,
 		Config:                 cfg,
 	}
 	return application, nil
@@ -71,9 +101,19 @@ type Application struct {
 	PostHandler            *handler.PostHandler
 	PhotoHandler           *handler.PhotoHandler
 	ContactExchangeHandler *handler.ContactExchangeHandler
+	ClaimHandler           *handler.ClaimHandler
+	ImportHandler          *handler.ImportHandler
+	StorageService         *service.StorageService
+	EventPublisher         *anti_corruption.AntiCorruptionEventPublisher
+	RelayWorker            *service.RelayWorker
+	ExpirationWorker       *service.ExpirationWorker
 	Config                 *config.Config
 }
 
+func providePrimaryDB(dbs *repository.DBRouter) *sql.DB {
+	return dbs.Primary()
+}
+
 func provideStorageConfig(cfg *config.Config) config.StorageConfig {
 	return cfg.StorageConfig
 }
@@ -82,6 +122,26 @@ func provideKafkaConfig(cfg *config.Config) config.KafkaConfig {
 	return cfg.KafkaConfig
 }
 
+func provideFeatureConfig(cfg *config.Config) config.FeatureConfig {
+	return cfg.Features
+}
+
+func provideContactExchangeConfig(cfg *config.Config) config.ContactExchangeConfig {
+	return cfg.ContactExchange
+}
+
+func provideClaimConfig(cfg *config.Config) config.ClaimConfig {
+	return cfg.Claims
+}
+
+func provideKeyLoadRetry(cfg *config.Config) domain.KeyLoadRetry {
+	return domain.KeyLoadRetry{
+		Attempts:       cfg.Encryption.KeyLoadAttempts,
+		InitialBackoff: time.Duration(cfg.Encryption.KeyLoadInitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(cfg.Encryption.KeyLoadMaxBackoffMs) * time.Millisecond,
+	}
+}
+
 func provideStorageInterface(storageService *service.StorageService) handler.StorageInterface {
 	return storageService
 }
@@ -94,18 +154,58 @@ func providePhotoRepository(repo *repository.PostgresPhotoRepository) domain.Pho
 	return repo
 }
 
-func provideEventPublisher(eventService *service.EventService) domain.EventPublisher {
-	return eventService
+// provideEventPublisher records service events in the outbox; the relay sends them to Kafka
+func provideEventPublisher(publisher *service.OutboxEventPublisher) domain.EventPublisher {
+	return publisher
+}
+
+func provideOutboxRepository(repo *repository.PostgresOutboxRepository) domain.OutboxRepository {
+	return repo
+}
+
+func provideTransactionManager(dbs *repository.DBRouter) domain.TransactionManager {
+	return dbs
+}
+
+// providePostImportService records the events of imported posts through the outbox, in the
+// transaction that stores them
+func providePostImportService(translator *anti_corruption.EventTranslator, postRepo domain.PostRepository, userContextRepo domain.UserContextRepository, eventPublisher domain.EventPublisher, transactions domain.TransactionManager) *anti_corruption.PostImportService {
+	return anti_corruption.NewPostImportService(translator, postRepo, userContextRepo, eventPublisher, transactions, 0)
+}
+
+func provideLocker(dbs *repository.DBRouter) domain.Locker {
+	return dbs
+}
+
+// provideRelayPublisher translates outbox post events to the external schema before they are
+// sent through the Kafka writer, dead-lettering the ones that cannot be translated
+func provideRelayPublisher(eventService *service.EventService, kafkaConfig config.KafkaConfig) *anti_corruption.AntiCorruptionEventPublisher {
+	return anti_corruption.NewRelayEventPublisher(eventService, eventService, kafkaConfig.ThinEventTypes)
+}
+
+func provideRelayWorker(outbox domain.OutboxRepository, publisher *anti_corruption.AntiCorruptionEventPublisher, locker domain.Locker, cfg *config.Config) *service.RelayWorker {
+	return service.NewRelayWorker(outbox, publisher, locker, cfg.Outbox)
 }
 
 func provideContactExchangeRepository(repo *repository.PostgresContactExchangeRepository) domain.ContactExchangeRepository {
 	return repo
 }
 
-func provideUserContextRepository(repo *repository.MockUserContextRepository) domain.UserContextRepository {
+func provideClaimRepository(repo *repository.PostgresClaimRepository) domain.ClaimRepository {
+	return repo
+}
+
+func provideLandmarkRepository(repo *repository.PostgresLandmarkRepository) domain.LandmarkRepository {
 	return repo
 }
 
+func provideUserContextRepository(repo *repository.MockUserContextRepository, cfg *config.Config) domain.UserContextRepository {
+	if cfg.UserContext.CacheTTLSeconds <= 0 {
+		return repo
+	}
+	return repository.NewCachingUserContextRepository(repo, time.Duration(cfg.UserContext.CacheTTLSeconds)*time.Second)
+}
+
 func provideOrganizationContextRepository(repo *repository.MockOrganizationContextRepository) domain.OrganizationContextRepository {
 	return repo
 }
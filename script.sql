@@ -3,14 +3,17 @@
 
 -- Enable PostGIS extension
 CREATE EXTENSION IF NOT EXISTS postgis;
+-- Trigram similarity for fuzzy landmark names
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
 
 -- Create enum types for type safety
-CREATE TYPE post_status AS ENUM ('active', 'resolved', 'expired', 'deleted');
+CREATE TYPE post_status AS ENUM ('draft', 'active', 'resolved', 'expired', 'deleted', 'archived');
 CREATE TYPE post_type AS ENUM ('lost', 'found');
 CREATE TYPE contact_exchange_status AS ENUM ('pending', 'approved', 'denied', 'expired');
 CREATE TYPE contact_exchange_approval_type AS ENUM ('full_contact', 'platform_message', 'limited_contact');
 CREATE TYPE verification_method AS ENUM ('photo_proof', 'security_question', 'admin_approval');
 CREATE TYPE denial_reason AS ENUM ('not_owner', 'insufficient_verification', 'suspicious_request', 'post_resolved', 'user_preference', 'other');
+CREATE TYPE claim_status AS ENUM ('pending', 'approved', 'rejected');
 
 -- Create posts table
 CREATE TABLE posts (
@@ -38,14 +41,31 @@ CREATE TABLE post_photos (
     thumbnail_url TEXT,
     caption     TEXT,
     display_order INTEGER NOT NULL CHECK (display_order >= 1 AND display_order <= 10),
-    format      VARCHAR(10) NOT NULL CHECK (format IN ('jpg', 'jpeg', 'png', 'webp')),
+    format      VARCHAR(10) NOT NULL CHECK (format IN ('jpg', 'jpeg', 'png', 'webp', 'gif')),
     size_bytes  BIGINT NOT NULL CHECK (size_bytes > 0),
+    width       INTEGER CHECK (width > 0),
+    height      INTEGER CHECK (height > 0),
     created_at  TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 
-    -- Ensure unique display order per post
-    UNIQUE(post_id, display_order)
+    -- Ensure unique display order per post; deferrable so photos can swap positions in one transaction
+    CONSTRAINT post_photos_post_display_order_key UNIQUE (post_id, display_order) DEFERRABLE INITIALLY IMMEDIATE
 );
 
+-- Storage object name of each photo's original upload; NULL for photos stored before it was recorded
+ALTER TABLE post_photos ADD COLUMN filename TEXT;
+
+-- Optional cover photo; posts without one use their lowest display_order photo
+ALTER TABLE posts ADD COLUMN cover_photo_id UUID REFERENCES post_photos(id) ON DELETE SET NULL;
+
+-- Optional ISO 639 language of the post text, defaulted from the creator's preferences
+ALTER TABLE posts ADD COLUMN language VARCHAR(3) CHECK (language ~ '^[a-z]{2,3}$');
+
+-- Lowercase tags set by the author, at most 20 per post
+ALTER TABLE posts ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}' CHECK (cardinality(tags) <= 20);
+
+-- Last time the owner bumped the post back to the top of listings; created_at keeps the true creation time
+ALTER TABLE posts ADD COLUMN bumped_at TIMESTAMP WITH TIME ZONE;
+
 -- Create indexes for performance
 
 -- Primary geospatial index for location-based queries
@@ -57,12 +77,25 @@ CREATE INDEX idx_posts_status_type ON posts (status, type);
 -- Index for multi-tenant queries
 CREATE INDEX idx_posts_org_status ON posts (organization_id, status) WHERE organization_id IS NOT NULL;
 
--- Index for temporal queries (recent posts first)
-CREATE INDEX idx_posts_created_at ON posts (created_at DESC);
+-- Index for distinct poster counts on organization dashboards (index-only scans)
+CREATE INDEX idx_posts_org_posters ON posts (organization_id, created_at) INCLUDE (user_id)
+    WHERE organization_id IS NOT NULL AND status NOT IN ('draft', 'deleted');
+
+-- Index for temporal queries (recent posts first); id keeps cursor pagination on the index
+CREATE INDEX idx_posts_created_at ON posts (created_at DESC, id DESC);
+
+-- Index for listings ordered by bump time, which falls back to creation for posts never bumped
+CREATE INDEX idx_posts_listed_at ON posts ((COALESCE(bumped_at, created_at)) DESC, id DESC);
 
 -- Index for user posts lookup
 CREATE INDEX idx_posts_user_id ON posts (user_id);
 
+-- Index for language filtering
+CREATE INDEX idx_posts_language ON posts (language) WHERE language IS NOT NULL;
+
+-- Index for tag containment filtering
+CREATE INDEX idx_posts_tags ON posts USING GIN (tags);
+
 -- Index for post photos ordering
 CREATE INDEX idx_post_photos_post_display ON post_photos (post_id, display_order);
 
@@ -72,7 +105,12 @@ CREATE INDEX idx_contact_exchange_requester ON contact_exchange_requests (reques
 CREATE INDEX idx_contact_exchange_owner ON contact_exchange_requests (owner_user_id, status);
 CREATE INDEX idx_contact_exchange_status ON contact_exchange_requests (status);
 CREATE INDEX idx_contact_exchange_expires ON contact_exchange_requests (expires_at) WHERE status IN ('pending', 'approved');
+CREATE INDEX idx_contact_exchange_reminder_due ON contact_exchange_requests (expires_at)
+    WHERE status IN ('pending', 'approved') AND expiry_reminder_sent_at IS NULL;
 CREATE INDEX idx_contact_exchange_created ON contact_exchange_requests (created_at DESC);
+-- A requester has at most one pending request per post
+CREATE UNIQUE INDEX idx_contact_exchange_one_pending ON contact_exchange_requests (post_id, requester_user_id)
+    WHERE status = 'pending';
 
 -- Create updated_at trigger function
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -83,6 +121,39 @@ BEGIN
 END;
 $$ language 'plpgsql';
 
+-- Text search configuration for a post language; languages without a stemmer use 'simple'.
+-- Search queries should use the same function so documents and queries are stemmed alike,
+-- e.g. to_tsvector(post_text_search_config(language), title) @@ plainto_tsquery(post_text_search_config($1), $2)
+CREATE OR REPLACE FUNCTION post_text_search_config(lang VARCHAR)
+RETURNS regconfig AS $$
+    SELECT CASE lang
+        WHEN 'ar' THEN 'arabic'
+        WHEN 'da' THEN 'danish'
+        WHEN 'de' THEN 'german'
+        WHEN 'el' THEN 'greek'
+        WHEN 'en' THEN 'english'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'fi' THEN 'finnish'
+        WHEN 'fr' THEN 'french'
+        WHEN 'hu' THEN 'hungarian'
+        WHEN 'id' THEN 'indonesian'
+        WHEN 'it' THEN 'italian'
+        WHEN 'nl' THEN 'dutch'
+        WHEN 'no' THEN 'norwegian'
+        WHEN 'pt' THEN 'portuguese'
+        WHEN 'ro' THEN 'romanian'
+        WHEN 'ru' THEN 'russian'
+        WHEN 'sv' THEN 'swedish'
+        WHEN 'tr' THEN 'turkish'
+        ELSE 'simple'
+    END::regconfig
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Full-text index over post text, stemmed in each post's own language
+CREATE INDEX idx_posts_text_search ON posts USING GIN (
+    to_tsvector(post_text_search_config(language), title || ' ' || COALESCE(description, ''))
+);
+
 -- Create trigger for posts table
 CREATE TRIGGER update_posts_updated_at
     BEFORE UPDATE ON posts
@@ -107,11 +178,14 @@ CREATE TABLE contact_exchange_requests (
     verification_method verification_method,
     verification_question TEXT,
     verification_requirements JSONB,
+    verification_completed BOOLEAN NOT NULL DEFAULT false,
+    verification_submission JSONB,  -- The requester's answer or photo proof
     approval_type   contact_exchange_approval_type,
     denial_reason   denial_reason,
     denial_message  TEXT,
     encrypted_contact_info JSONB,
     expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    expiry_reminder_sent_at TIMESTAMP WITH TIME ZONE,
     created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 
@@ -124,11 +198,121 @@ CREATE TABLE contact_exchange_requests (
 COMMENT ON TABLE posts IS 'Lost and found posts with geospatial location data';
 COMMENT ON COLUMN posts.location IS 'PostGIS point geometry in WGS84 (SRID 4326) coordinate system';
 COMMENT ON COLUMN posts.radius_meters IS 'Search radius in meters for this post (100m to 50km)';
+COMMENT ON COLUMN posts.language IS 'ISO 639 language code of the post text, NULL when unknown';
+COMMENT ON COLUMN posts.tags IS 'Lowercase author-set tags, filtered with array containment';
+COMMENT ON COLUMN posts.bumped_at IS 'Last owner bump, NULL if never bumped; bump ordered listings sort by COALESCE(bumped_at, created_at)';
 COMMENT ON TABLE post_photos IS 'Photos associated with posts, supports 1-10 photos per post';
 COMMENT ON COLUMN post_photos.display_order IS 'Display order of photos (1-10), unique per post';
 COMMENT ON TABLE contact_exchange_requests IS 'Secure contact exchange requests between post owners and interested users';
 COMMENT ON COLUMN contact_exchange_requests.encrypted_contact_info IS 'Encrypted contact information (email/phone) when approved';
 COMMENT ON COLUMN contact_exchange_requests.verification_requirements IS 'JSON array of verification requirements';
+COMMENT ON COLUMN contact_exchange_requests.expiry_reminder_sent_at IS 'When the expiring-soon reminder was published, so it is sent once';
+
+-- Create post_claims table for ownership claims on found posts
+CREATE TABLE post_claims (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id         UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    claimant_user_id UUID NOT NULL,
+    owner_user_id   UUID NOT NULL,
+    status          claim_status DEFAULT 'pending',
+    proof_text      TEXT NOT NULL DEFAULT '',
+    proof_photo_urls TEXT[] NOT NULL DEFAULT '{}',
+    contact_exchange_request_id UUID REFERENCES contact_exchange_requests(id) ON DELETE SET NULL,
+    reviewed_at     TIMESTAMP WITH TIME ZONE,
+    retain_until    TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    -- Constraints
+    CONSTRAINT post_claims_different_users CHECK (claimant_user_id != owner_user_id),
+    CONSTRAINT post_claims_proof_photos_count CHECK (cardinality(proof_photo_urls) <= 5)
+);
+
+-- Indexes for post claims
+CREATE INDEX idx_post_claims_post_created ON post_claims (post_id, created_at DESC);
+CREATE INDEX idx_post_claims_claimant ON post_claims (claimant_user_id, status);
+CREATE INDEX idx_post_claims_retain_until ON post_claims (retain_until);
+
+-- Create trigger for post_claims table
+CREATE TRIGGER update_post_claims_updated_at
+    BEFORE UPDATE ON post_claims
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE post_claims IS 'Proof of ownership submitted by claimants on found posts, reviewed by the post owner';
+COMMENT ON COLUMN post_claims.retain_until IS 'Claims are deleted once this passes';
+
+-- Create resolutions table recording how resolved posts were resolved
+CREATE TABLE resolutions (
+    post_id         UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    resolution_type VARCHAR(20) NOT NULL,
+    match_id        VARCHAR(255),
+    time_to_resolution_hours INTEGER NOT NULL,
+    match_accuracy  DOUBLE PRECISION,
+    user_satisfaction_score INTEGER,
+    resolved_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Constraints
+    CONSTRAINT resolutions_type_valid CHECK (resolution_type IN ('matched', 'self_found', 'other')),
+    CONSTRAINT resolutions_time_not_negative CHECK (time_to_resolution_hours >= 0),
+    CONSTRAINT resolutions_satisfaction_range CHECK (user_satisfaction_score BETWEEN 1 AND 5)
+);
+
+-- Stats filter resolutions by when they happened
+CREATE INDEX idx_resolutions_resolved_at ON resolutions (resolved_at);
+
+COMMENT ON TABLE resolutions IS 'How each resolved post was resolved; removed when the post is reopened';
+COMMENT ON COLUMN resolutions.time_to_resolution_hours IS 'Whole hours from the post being created to it being resolved';
+
+-- Create landmarks table of well known places posts can be searched around by name
+CREATE TABLE landmarks (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name            VARCHAR(200) NOT NULL,
+    -- Lowercased with whitespace collapsed, as search terms are before matching
+    normalized_name VARCHAR(200) GENERATED ALWAYS AS (lower(regexp_replace(btrim(name), '\s+', ' ', 'g'))) STORED,
+    location        GEOMETRY(POINT, 4326) NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT landmarks_name_unique UNIQUE (normalized_name)
+);
+
+CREATE INDEX idx_landmarks_normalized_name_trgm ON landmarks USING GIN (normalized_name gin_trgm_ops);
+
+INSERT INTO landmarks (name, location) VALUES
+    ('Central Park', ST_SetSRID(ST_MakePoint(-73.9665, 40.7831), 4326)),
+    ('Times Square', ST_SetSRID(ST_MakePoint(-73.9934, 40.7505), 4326)),
+    ('Brooklyn Bridge', ST_SetSRID(ST_MakePoint(-73.9442, 40.6782), 4326)),
+    ('Empire State Building', ST_SetSRID(ST_MakePoint(-73.9857, 40.7484), 4326));
+
+-- Create outbox_events table; events are written in the same transaction as the change they
+-- announce and relayed to Kafka from here
+CREATE TABLE outbox_events (
+    sequence        BIGSERIAL PRIMARY KEY,
+    event_id        UUID UNIQUE NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    aggregate_type  VARCHAR(100) NOT NULL,
+    aggregate_id    VARCHAR(255) NOT NULL,
+    post_id         UUID NOT NULL,
+    user_id         UUID NOT NULL,
+    tenant_id       UUID,
+    payload_type    VARCHAR(100) NOT NULL,
+    event           JSONB NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    published_at    TIMESTAMP WITH TIME ZONE,
+    dead_lettered_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Indexes for the outbox relay
+CREATE INDEX idx_outbox_events_pending ON outbox_events (sequence) WHERE published_at IS NULL AND dead_lettered_at IS NULL;
+CREATE INDEX idx_outbox_events_aggregate_pending ON outbox_events (aggregate_id, sequence) WHERE published_at IS NULL AND dead_lettered_at IS NULL;
+
+COMMENT ON TABLE outbox_events IS 'Domain events waiting to be relayed to Kafka, in the order they were recorded';
+COMMENT ON COLUMN outbox_events.payload_type IS 'Go type of the event payload, so the relay can decode it again';
+COMMENT ON COLUMN outbox_events.next_attempt_at IS 'Failed events are retried from this time, with backoff';
+COMMENT ON COLUMN outbox_events.dead_lettered_at IS 'Set instead of published_at when the event could not be translated and went to the dead letter topic; it is not retried';
 
 -- Create encryption_keys table for RSA-4096 key management
 CREATE TABLE encryption_keys (
@@ -139,6 +323,7 @@ CREATE TABLE encryption_keys (
     is_active       BOOLEAN DEFAULT false,
     created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     expires_at      TIMESTAMP WITH TIME ZONE,
+    retired_at      TIMESTAMP WITH TIME ZONE,  -- Set once no contact info is encrypted with the key
 
     -- Only one active key at a time
     CONSTRAINT one_active_key EXCLUDE (is_active WITH =) WHERE (is_active = true)
@@ -147,7 +332,7 @@ CREATE TABLE encryption_keys (
 -- Create encryption_audit_logs table for compliance tracking
 CREATE TABLE encryption_audit_logs (
     id              VARCHAR(255) PRIMARY KEY,
-    operation       VARCHAR(50) NOT NULL CHECK (operation IN ('encrypt', 'decrypt', 'token_create', 'token_validate', 'key_rotation')),
+    operation       VARCHAR(50) NOT NULL CHECK (operation IN ('encrypt', 'decrypt', 'token_create', 'token_validate', 'key_rotation', 're_encrypt')),
     user_id         UUID NOT NULL,
     request_id      UUID,  -- Optional reference to contact exchange request
     key_fingerprint VARCHAR(255) NOT NULL,
@@ -0,0 +1,88 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAIProcessingPriority(t *testing.T) {
+	rewardOffered := true
+	noReward := false
+	urgent := "Urgent"
+	low := "low"
+
+	testCases := []struct {
+		name     string
+		metadata *domain.PostMetadata
+		expected string
+	}{
+		{"no metadata", nil, domain.AIProcessingPriorityNormal},
+		{"reward offered", &domain.PostMetadata{RewardOffered: &rewardOffered}, domain.AIProcessingPriorityHigh},
+		{"no reward", &domain.PostMetadata{RewardOffered: &noReward}, domain.AIProcessingPriorityNormal},
+		{"urgent", &domain.PostMetadata{Urgency: &urgent}, domain.AIProcessingPriorityHigh},
+		{"low urgency", &domain.PostMetadata{Urgency: &low}, domain.AIProcessingPriorityNormal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			post := domain.PostData{Metadata: tc.metadata}
+			require.Equal(t, tc.expected, domain.AIProcessingPriorityFor(post))
+		})
+	}
+}
+
+func TestPhotoAddedProcessingPriority(t *testing.T) {
+	addPhoto := func(t *testing.T, tags ...string) *domain.PhotoAddedEventData {
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost dog", "Brown beagle",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost, domain.NewUserID(), nil, time.Now(), time.Now(), nil)
+		require.NoError(t, post.SetTags(tags))
+		publisher := &recordingEventPublisher{}
+		postService := service.NewPostService(&updatablePostRepository{singlePostRepository: singlePostRepository{post: post}},
+			&savingPhotoRepository{}, repository.NewMockUserContextRepository(), nil, nil, publisher, nil, config.FeatureConfig{AIProcessingEnabled: true})
+
+		_, err := postService.AddPhotoToPost(context.Background(), post.ID(), post.CreatedBy(), domain.CreatePhotoRequest{
+			URL: "https://example.com/dog.jpg", Format: "jpg", SizeBytes: 2048,
+		})
+		require.NoError(t, err)
+		require.Len(t, publisher.events, 1)
+
+		data, ok := publisher.events[0].Payload.(*domain.PhotoAddedEventData)
+		require.True(t, ok)
+		require.True(t, data.AIProcessingTrigger)
+		require.NotNil(t, data.ProcessingPriority)
+		return data
+	}
+
+	t.Run("should analyze photos of posts offering a reward first", func(t *testing.T) {
+		data := addPhoto(t, "dog", domain.TagReward)
+		require.Equal(t, domain.AIProcessingPriorityHigh, *data.ProcessingPriority)
+		require.True(t, *data.Post.Metadata.RewardOffered)
+	})
+
+	t.Run("should analyze photos of urgent posts first", func(t *testing.T) {
+		data := addPhoto(t, domain.TagUrgent)
+		require.Equal(t, domain.AIProcessingPriorityHigh, *data.ProcessingPriority)
+	})
+
+	t.Run("should queue other photos normally", func(t *testing.T) {
+		data := addPhoto(t, "dog")
+		require.Equal(t, domain.AIProcessingPriorityNormal, *data.ProcessingPriority)
+		require.Nil(t, data.Post.Metadata)
+	})
+}
+
+// savingPhotoRepository accepts every new photo
+type savingPhotoRepository struct {
+	domain.PhotoRepository
+}
+
+func (r *savingPhotoRepository) Save(ctx context.Context, photo *domain.Photo) error {
+	return nil
+}
@@ -0,0 +1,144 @@
+package e2e
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const secret = "test-secret-key-for-auth-tests-0123"
+	cfg := config.AuthConfig{JWTAlgorithm: "HS256", JWTSecret: secret, JWTIssuer: "findly-auth"}
+	verifier, err := handler.NewTokenVerifier(cfg)
+	require.NoError(t, err)
+
+	newRouter := func(devMode bool) *gin.Engine {
+		router := gin.New()
+		posts := router.Group("/posts", handler.AuthMiddleware(verifier, devMode))
+		posts.GET("", func(c *gin.Context) {
+			c.String(http.StatusOK, handler.AuthenticatedUserID(c).String())
+		})
+		return router
+	}
+
+	serve := func(router *gin.Engine, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	sign := func(t *testing.T, key []byte, claims jwt.Claims) string {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: key}, nil)
+		require.NoError(t, err)
+		token, err := jwt.Signed(signer).Claims(claims).Serialize()
+		require.NoError(t, err)
+		return token
+	}
+
+	userID := domain.NewUserID()
+	validClaims := func() jwt.Claims {
+		return jwt.Claims{
+			Subject: userID.String(),
+			Issuer:  "findly-auth",
+			Expiry:  jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}
+	}
+
+	t.Run("should put the token's subject in the context", func(t *testing.T) {
+		w := serve(newRouter(false), map[string]string{"Authorization": "Bearer " + sign(t, []byte(secret), validClaims())})
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, userID.String(), w.Body.String())
+	})
+
+	t.Run("should refuse tokens that are expired, badly signed or from another issuer", func(t *testing.T) {
+		expired := validClaims()
+		expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+		otherIssuer := validClaims()
+		otherIssuer.Issuer = "someone-else"
+		noExpiry := validClaims()
+		noExpiry.Expiry = nil
+		notAUser := validClaims()
+		notAUser.Subject = "admin"
+
+		tokens := map[string]string{
+			"expired":      sign(t, []byte(secret), expired),
+			"wrong key":    sign(t, []byte("another-secret-key-for-auth-tests"), validClaims()),
+			"other issuer": sign(t, []byte(secret), otherIssuer),
+			"no expiry":    sign(t, []byte(secret), noExpiry),
+			"bad subject":  sign(t, []byte(secret), notAUser),
+			"malformed":    "not-a-jwt",
+		}
+		for name, token := range tokens {
+			w := serve(newRouter(false), map[string]string{"Authorization": "Bearer " + token})
+			require.Equal(t, http.StatusUnauthorized, w.Code, name)
+		}
+	})
+
+	t.Run("should require a token unless in dev mode", func(t *testing.T) {
+		header := map[string]string{handler.DevUserIDHeader: userID.String()}
+		require.Equal(t, http.StatusUnauthorized, serve(newRouter(false), nil).Code)
+		require.Equal(t, http.StatusUnauthorized, serve(newRouter(false), header).Code)
+
+		w := serve(newRouter(true), header)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, userID.String(), w.Body.String())
+
+		require.Equal(t, http.StatusUnauthorized, serve(newRouter(true), nil).Code)
+		require.Equal(t, http.StatusUnauthorized, serve(newRouter(true), map[string]string{
+			handler.DevUserIDHeader: userID.String(),
+			"Authorization":         "Bearer not-a-jwt",
+		}).Code)
+	})
+
+	t.Run("should verify RS256 tokens with the configured public key", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+		publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+		rsaVerifier, err := handler.NewTokenVerifier(config.AuthConfig{JWTAlgorithm: "RS256", JWTPublicKey: publicKey})
+		require.NoError(t, err)
+
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+		require.NoError(t, err)
+		token, err := jwt.Signed(signer).Claims(validClaims()).Serialize()
+		require.NoError(t, err)
+
+		got, err := rsaVerifier.UserID(token)
+		require.NoError(t, err)
+		require.True(t, got.Equals(userID))
+
+		// An HS256 token signed with the public key must not pass as RS256
+		_, err = rsaVerifier.UserID(sign(t, []byte(publicKey), validClaims()))
+		require.ErrorIs(t, err, handler.ErrInvalidToken)
+	})
+
+	t.Run("should refuse a configuration without a key", func(t *testing.T) {
+		_, err := handler.NewTokenVerifier(config.AuthConfig{JWTAlgorithm: "HS256"})
+		require.Error(t, err)
+		_, err = handler.NewTokenVerifier(config.AuthConfig{JWTAlgorithm: "HS256", JWTSecret: "too-short"})
+		require.Error(t, err)
+		_, err = handler.NewTokenVerifier(config.AuthConfig{JWTAlgorithm: "none", JWTSecret: secret})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,113 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errBackend := errors.New("backend unavailable")
+	fail := func() error { return errBackend }
+	succeed := func() error { return nil }
+
+	// newBreaker trips after three failures and probes again after a minute on a clock the
+	// test moves by hand
+	newBreaker := func() (*service.CircuitBreaker, *time.Time) {
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		breaker := service.NewCircuitBreaker("test", 3, time.Minute)
+		breaker.SetClock(func() time.Time { return now })
+		return breaker, &now
+	}
+	trip := func(t *testing.T, breaker *service.CircuitBreaker) {
+		for i := 0; i < 3; i++ {
+			require.ErrorIs(t, breaker.Execute(fail), errBackend)
+		}
+		require.Equal(t, service.CircuitOpen, breaker.Stats().State)
+	}
+
+	t.Run("should stay closed below the failure threshold", func(t *testing.T) {
+		breaker, _ := newBreaker()
+
+		require.ErrorIs(t, breaker.Execute(fail), errBackend)
+		require.ErrorIs(t, breaker.Execute(fail), errBackend)
+		require.NoError(t, breaker.Execute(succeed))
+		require.ErrorIs(t, breaker.Execute(fail), errBackend)
+		require.ErrorIs(t, breaker.Execute(fail), errBackend)
+
+		stats := breaker.Stats()
+		require.Equal(t, service.CircuitClosed, stats.State, "a success resets the consecutive failures")
+		require.Equal(t, 2, stats.ConsecutiveFailures)
+	})
+
+	t.Run("should trip after the threshold and fail fast while open", func(t *testing.T) {
+		breaker, now := newBreaker()
+		trip(t, breaker)
+
+		called := false
+		*now = now.Add(59 * time.Second)
+		err := breaker.Execute(func() error { called = true; return nil })
+		require.ErrorIs(t, err, service.ErrCircuitOpen)
+		require.False(t, called, "calls are rejected without reaching the backend")
+
+		stats := breaker.Stats()
+		require.EqualValues(t, 1, stats.TotalRejected)
+		require.EqualValues(t, 1, stats.TimesOpened)
+		require.NotNil(t, stats.OpenedAt)
+	})
+
+	t.Run("should close again when the half-open probe succeeds", func(t *testing.T) {
+		breaker, now := newBreaker()
+		trip(t, breaker)
+
+		*now = now.Add(time.Minute)
+		require.Equal(t, service.CircuitHalfOpen, breaker.Stats().State)
+		require.NoError(t, breaker.Execute(succeed))
+
+		stats := breaker.Stats()
+		require.Equal(t, service.CircuitClosed, stats.State)
+		require.Zero(t, stats.ConsecutiveFailures)
+		require.Nil(t, stats.OpenedAt)
+	})
+
+	t.Run("should re-trip when the half-open probe fails", func(t *testing.T) {
+		breaker, now := newBreaker()
+		trip(t, breaker)
+
+		*now = now.Add(time.Minute)
+		require.ErrorIs(t, breaker.Execute(fail), errBackend)
+
+		stats := breaker.Stats()
+		require.Equal(t, service.CircuitOpen, stats.State, "a single failed probe opens the breaker again")
+		require.EqualValues(t, 2, stats.TimesOpened)
+		require.True(t, stats.OpenedAt.Equal(*now), "the reset timeout starts over from the failed probe")
+		require.ErrorIs(t, breaker.Execute(succeed), service.ErrCircuitOpen)
+	})
+
+	t.Run("should let only one probe through while half-open", func(t *testing.T) {
+		breaker, now := newBreaker()
+		trip(t, breaker)
+		*now = now.Add(time.Minute)
+
+		err := breaker.Execute(func() error {
+			require.ErrorIs(t, breaker.Execute(succeed), service.ErrCircuitOpen, "a second call waits for the probe")
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, service.CircuitClosed, breaker.Stats().State)
+	})
+
+	t.Run("should not count cancelled calls against the backend", func(t *testing.T) {
+		breaker, _ := newBreaker()
+
+		for i := 0; i < 5; i++ {
+			require.ErrorIs(t, breaker.Execute(func() error { return context.Canceled }), context.Canceled)
+		}
+		require.Equal(t, service.CircuitClosed, breaker.Stats().State)
+		require.Zero(t, breaker.Stats().TotalFailures)
+	})
+}
@@ -0,0 +1,283 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimFlow(t *testing.T) {
+	ctx := context.Background()
+	owner := domain.NewUserID()
+	claimant := domain.NewUserID()
+
+	newPost := func(postType domain.PostType) *domain.Post {
+		return domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather wallet",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, postType, owner, nil,
+			time.Now(), time.Now(), nil)
+	}
+
+	type fixture struct {
+		claimService *service.ClaimService
+		claims       *memoryClaimRepository
+		exchanges    *savingContactExchangeRepository
+		publisher    *recordingEventPublisher
+	}
+
+	newFixture := func(post *domain.Post) fixture {
+		f := fixture{
+			claims:    &memoryClaimRepository{claims: map[domain.ClaimID]*domain.Claim{}},
+			exchanges: &savingContactExchangeRepository{},
+			publisher: &recordingEventPublisher{},
+		}
+		postRepo := &singlePostRepository{post: post}
+		userContextRepo := repository.NewMockUserContextRepository()
+		contactService := service.NewContactExchangeService(
+			f.exchanges, postRepo, userContextRepo, f.publisher, nil, nil, &discardAuditLogger{}, config.ContactExchangeConfig{})
+		f.claimService = service.NewClaimService(
+			f.claims, postRepo, userContextRepo, f.publisher, contactService, config.ClaimConfig{RetentionDays: 30})
+		return f
+	}
+
+	createClaim := func(t *testing.T, f fixture, post *domain.Post) *domain.Claim {
+		claim, err := f.claimService.CreateClaim(ctx, service.CreateClaimCommand{
+			PostID:         post.ID(),
+			ClaimantUserID: claimant,
+			ProofText:      "It has a library card for J. Doe in the front pocket",
+			ProofPhotoURLs: []string{"https://example.com/receipt.jpg"},
+		})
+		require.NoError(t, err)
+		return claim
+	}
+
+	t.Run("should record a pending claim and announce it without the proof", func(t *testing.T) {
+		post := newPost(domain.PostTypeFound)
+		f := newFixture(post)
+
+		claim := createClaim(t, f, post)
+		require.Equal(t, domain.ClaimStatusPending, claim.Status())
+		require.True(t, claim.IsOwner(owner))
+		require.WithinDuration(t, time.Now().Add(30*24*time.Hour), claim.RetainUntil(), time.Minute)
+
+		require.Len(t, f.publisher.events, 1)
+		event := f.publisher.events[0]
+		require.Equal(t, domain.EventTypeClaimCreated, event.EventType)
+		require.Equal(t, "Claim", event.AggregateType)
+		require.Equal(t, post.ID(), event.PostID)
+
+		data, ok := event.Payload.(*domain.ClaimCreatedEventData)
+		require.True(t, ok)
+		require.True(t, data.Claim.HasProofText)
+		require.Equal(t, 1, data.Claim.ProofPhotoCount)
+	})
+
+	t.Run("should only accept claims from others on active found posts", func(t *testing.T) {
+		post := newPost(domain.PostTypeFound)
+		f := newFixture(post)
+
+		_, err := f.claimService.CreateClaim(ctx, service.CreateClaimCommand{
+			PostID: post.ID(), ClaimantUserID: owner, ProofText: "mine",
+		})
+		require.True(t, domain.IsPostErrorCode(err, domain.ClaimErrorCannotClaimOwn))
+
+		_, err = f.claimService.CreateClaim(ctx, service.CreateClaimCommand{
+			PostID: post.ID(), ClaimantUserID: claimant,
+		})
+		require.True(t, domain.IsPostErrorCode(err, domain.ClaimErrorInvalidProof))
+
+		lost := newPost(domain.PostTypeLost)
+		_, err = newFixture(lost).claimService.CreateClaim(ctx, service.CreateClaimCommand{
+			PostID: lost.ID(), ClaimantUserID: claimant, ProofText: "mine",
+		})
+		require.True(t, domain.IsPostErrorCode(err, domain.ClaimErrorPostNotClaimable))
+	})
+
+	t.Run("should start a pre-filled contact exchange when approved", func(t *testing.T) {
+		post := newPost(domain.PostTypeFound)
+		f := newFixture(post)
+		claim := createClaim(t, f, post)
+
+		approved, request, err := f.claimService.ApproveClaim(ctx, service.ReviewClaimCommand{
+			PostID: post.ID(), ClaimID: claim.ID(), OwnerUserID: owner, StartContactExchange: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, domain.ClaimStatusApproved, approved.Status())
+		require.NotNil(t, approved.ReviewedAt())
+
+		require.NotNil(t, request)
+		require.Len(t, f.exchanges.saved, 1)
+		require.True(t, request.IsRequester(claimant))
+		require.Equal(t, claim.ProofText(), *request.Message())
+		require.Equal(t, request.ID(), *approved.ContactExchangeRequestID())
+
+		var eventTypes []domain.EventType
+		for _, event := range f.publisher.events {
+			eventTypes = append(eventTypes, event.EventType)
+		}
+		require.Equal(t, []domain.EventType{
+			domain.EventTypeClaimCreated,
+			domain.EventTypeContactExchangeRequested,
+			domain.EventTypeClaimApproved,
+		}, eventTypes)
+	})
+
+	t.Run("should link the claimant's pending contact exchange when approved", func(t *testing.T) {
+		post := newPost(domain.PostTypeFound)
+		f := newFixture(post)
+		claim := createClaim(t, f, post)
+		pending := domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), post.ID(), claimant, owner,
+			domain.ContactExchangeStatusPending, nil, false, nil, nil, nil, nil, nil,
+			time.Now().Add(time.Hour), time.Now(), time.Now(),
+		)
+		f.exchanges.saved = append(f.exchanges.saved, pending)
+
+		approved, request, err := f.claimService.ApproveClaim(ctx, service.ReviewClaimCommand{
+			PostID: post.ID(), ClaimID: claim.ID(), OwnerUserID: owner, StartContactExchange: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, pending, request)
+		require.Equal(t, pending.ID(), *approved.ContactExchangeRequestID())
+		require.Len(t, f.exchanges.saved, 1)
+	})
+
+	t.Run("should hide claims from everyone but the post owner", func(t *testing.T) {
+		post := newPost(domain.PostTypeFound)
+		f := newFixture(post)
+		claim := createClaim(t, f, post)
+
+		_, err := f.claimService.RejectClaim(ctx, service.ReviewClaimCommand{
+			PostID: post.ID(), ClaimID: claim.ID(), OwnerUserID: claimant,
+		})
+		require.True(t, domain.IsPostErrorCode(err, domain.ClaimErrorNotFound))
+
+		_, err = f.claimService.ListClaims(ctx, post.ID(), claimant, 20, 0)
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+
+		claims, err := f.claimService.ListClaims(ctx, post.ID(), owner, 20, 0)
+		require.NoError(t, err)
+		require.Len(t, claims, 1)
+	})
+
+	t.Run("should not review a claim twice", func(t *testing.T) {
+		post := newPost(domain.PostTypeFound)
+		f := newFixture(post)
+		claim := createClaim(t, f, post)
+		cmd := service.ReviewClaimCommand{PostID: post.ID(), ClaimID: claim.ID(), OwnerUserID: owner}
+
+		_, err := f.claimService.RejectClaim(ctx, cmd)
+		require.NoError(t, err)
+
+		_, _, err = f.claimService.ApproveClaim(ctx, cmd)
+		require.True(t, domain.IsPostErrorCode(err, domain.ClaimErrorInvalidStatus))
+		require.Empty(t, f.exchanges.saved)
+	})
+
+	t.Run("should purge claims past their retention", func(t *testing.T) {
+		post := newPost(domain.PostTypeFound)
+		f := newFixture(post)
+		kept := createClaim(t, f, post)
+
+		expired := domain.ReconstructClaim(domain.NewClaimID(), post.ID(), claimant, owner,
+			domain.ClaimStatusRejected, "not mine after all", nil, nil, nil,
+			time.Now().Add(-time.Hour), time.Now().Add(-31*24*time.Hour), time.Now().Add(-31*24*time.Hour))
+		f.claims.claims[expired.ID()] = expired
+
+		purged, err := f.claimService.PurgeExpiredClaims(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), purged)
+		require.Contains(t, f.claims.claims, kept.ID())
+		require.NotContains(t, f.claims.claims, expired.ID())
+	})
+}
+
+// memoryClaimRepository keeps claims in a map
+type memoryClaimRepository struct {
+	claims map[domain.ClaimID]*domain.Claim
+}
+
+func (r *memoryClaimRepository) Save(ctx context.Context, claim *domain.Claim) error {
+	r.claims[claim.ID()] = claim
+	return nil
+}
+
+func (r *memoryClaimRepository) FindByID(ctx context.Context, id domain.ClaimID) (*domain.Claim, error) {
+	claim, ok := r.claims[id]
+	if !ok {
+		return nil, domain.ErrClaimNotFound(id)
+	}
+	return claim, nil
+}
+
+func (r *memoryClaimRepository) FindByPostID(ctx context.Context, postID domain.PostID, limit, offset int) ([]*domain.Claim, error) {
+	var claims []*domain.Claim
+	for _, claim := range r.claims {
+		if claim.PostID() == postID {
+			claims = append(claims, claim)
+		}
+	}
+	return claims, nil
+}
+
+func (r *memoryClaimRepository) Update(ctx context.Context, claim *domain.Claim) error {
+	r.claims[claim.ID()] = claim
+	return nil
+}
+
+func (r *memoryClaimRepository) DeletePastRetention(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var deleted int64
+	for id, claim := range r.claims {
+		if deleted < int64(limit) && claim.RetainUntil().Before(before) {
+			delete(r.claims, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// savingContactExchangeRepository records the requests it is asked to save and counts them the
+// way the Postgres repository does
+type savingContactExchangeRepository struct {
+	domain.ContactExchangeRepository
+	saved []*domain.ContactExchangeRequest
+}
+
+func (r *savingContactExchangeRepository) Save(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	r.saved = append(r.saved, request)
+	return nil
+}
+
+func (r *savingContactExchangeRepository) Update(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	return nil
+}
+
+func (r *savingContactExchangeRepository) FindPendingByRequester(ctx context.Context, postID domain.PostID, requesterID domain.UserID) (*domain.ContactExchangeRequest, error) {
+	for _, request := range r.saved {
+		if request.PostID() == postID && request.RequesterUserID() == requesterID && request.Status() == domain.ContactExchangeStatusPending {
+			return request, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *savingContactExchangeRepository) CountByRequester(ctx context.Context, requesterID domain.UserID, postID domain.PostID, since time.Time) (domain.RequesterRequestCounts, error) {
+	var counts domain.RequesterRequestCounts
+	for _, request := range r.saved {
+		if request.RequesterUserID() != requesterID {
+			continue
+		}
+		if request.PostID() == postID && !request.CreatedAt().Before(since) {
+			counts.ForPostSince++
+		}
+		if request.Status() == domain.ContactExchangeStatusPending && !request.IsExpired() {
+			counts.Pending++
+		}
+	}
+	return counts, nil
+}
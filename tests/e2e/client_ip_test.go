@@ -0,0 +1,76 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestOrigin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var origin domain.RequestOrigin
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies([]string{"10.0.0.0/8"}))
+	router.GET("/origin", handler.RequestOriginMiddleware(), func(c *gin.Context) {
+		origin, _ = domain.RequestOriginFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	originFrom := func(remoteAddr, forwardedFor string) domain.RequestOrigin {
+		req := httptest.NewRequest(http.MethodGet, "/origin", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("User-Agent", "findly-ios/3.1")
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		origin = domain.RequestOrigin{}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		return origin
+	}
+
+	t.Run("should take the client IP forwarded by a trusted proxy", func(t *testing.T) {
+		got := originFrom("10.1.2.3:40000", "203.0.113.7")
+		require.Equal(t, "203.0.113.7", got.IPAddress)
+		require.Equal(t, "findly-ios/3.1", got.UserAgent)
+	})
+
+	t.Run("should ignore X-Forwarded-For from untrusted peers", func(t *testing.T) {
+		got := originFrom("198.51.100.2:40000", "203.0.113.7")
+		require.Equal(t, "198.51.100.2", got.IPAddress)
+	})
+
+	t.Run("should record the origin on encryption audit logs", func(t *testing.T) {
+		auditLogger := &recordingAuditLogger{}
+		contactService := service.NewContactExchangeService(nil, nil, nil, nil, nil,
+			newFakeEncryptionService(), auditLogger, config.ContactExchangeConfig{})
+
+		ctx := domain.WithRequestOrigin(context.Background(), domain.RequestOrigin{IPAddress: "203.0.113.7", UserAgent: "findly-ios/3.1"})
+		_, err := contactService.GenerateContactToken(ctx, domain.ContactInfo{PreferredMethod: "email"}, time.Now().Add(time.Hour), domain.NewUserID())
+		require.NoError(t, err)
+
+		require.Len(t, auditLogger.logs, 1)
+		require.Equal(t, "203.0.113.7", *auditLogger.logs[0].IPAddress)
+		require.Equal(t, "findly-ios/3.1", *auditLogger.logs[0].UserAgent)
+	})
+}
+
+// recordingAuditLogger keeps every audit log entry it is given
+type recordingAuditLogger struct {
+	discardAuditLogger
+	logs []*domain.EncryptionAuditLog
+}
+
+func (l *recordingAuditLogger) LogOperation(log *domain.EncryptionAuditLog) error {
+	l.logs = append(l.logs, log)
+	return nil
+}
@@ -0,0 +1,185 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalUpdates(t *testing.T) {
+	getETag := func(t *testing.T, postID string) string {
+		resp := makeRequest(t, "GET", fmt.Sprintf("/posts/%s", postID), nil)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		etag := resp.Header.Get("ETag")
+		require.NotEmpty(t, etag)
+		require.NotEmpty(t, resp.Header.Get("Last-Modified"))
+		return etag
+	}
+
+	t.Run("should apply an update when If-Match is current", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		etag := getETag(t, post.ID)
+		updateReq := UpdatePostRequest{Title: "Updated Title", Description: "Updated Description"}
+
+		resp := makeRequestWithHeaders(t, "PUT", fmt.Sprintf("/posts/%s", post.ID), updateReq,
+			map[string]string{"If-Match": etag})
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+		// The returned tag matches the stored version
+		require.Equal(t, resp.Header.Get("ETag"), getETag(t, post.ID))
+	})
+
+	t.Run("should reject writes with a stale If-Match", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		staleETag := getETag(t, post.ID)
+
+		resp := makeRequest(t, "PUT", fmt.Sprintf("/posts/%s", post.ID),
+			UpdatePostRequest{Title: "Concurrent edit", Description: "Someone else got here first"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+
+		headers := map[string]string{"If-Match": staleETag}
+
+		resp = makeRequestWithHeaders(t, "PUT", fmt.Sprintf("/posts/%s", post.ID),
+			UpdatePostRequest{Title: "Lost update", Description: "Should not be saved"}, headers)
+		require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = makeRequestWithHeaders(t, "PATCH", fmt.Sprintf("/posts/%s/status", post.ID),
+			UpdatePostStatusRequest{Status: "resolved"}, headers)
+		require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = makeRequestWithHeaders(t, "DELETE", fmt.Sprintf("/posts/%s", post.ID), nil, headers)
+		require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = makeRequest(t, "GET", fmt.Sprintf("/posts/%s", post.ID), nil)
+		var current PostResponse
+		parseResponse(t, resp, &current)
+		require.Equal(t, "Concurrent edit", current.Title)
+		require.Equal(t, "active", current.Status)
+	})
+
+	t.Run("should honour If-Unmodified-Since", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+		resp := makeRequestWithHeaders(t, "PATCH", fmt.Sprintf("/posts/%s/status", post.ID),
+			UpdatePostStatusRequest{Status: "resolved"}, map[string]string{"If-Unmodified-Since": past})
+		require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+		resp.Body.Close()
+
+		future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		resp = makeRequestWithHeaders(t, "PATCH", fmt.Sprintf("/posts/%s/status", post.ID),
+			UpdatePostStatusRequest{Status: "resolved"}, map[string]string{"If-Unmodified-Since": future})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
+
+func TestConditionalUpdateRace(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	owner := domain.NewUserID()
+
+	newFixture := func() (*versionedPostRepository, *gin.Engine) {
+		updatedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost scarf", "Red wool",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost, owner, nil, updatedAt, updatedAt, nil)
+		posts := &versionedPostRepository{updatablePostRepository: updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}, committedAt: updatedAt}
+		router := newProductionRouter(service.NewPostService(posts, nil, nil, nil, nil, &recordingEventPublisher{}, nil, config.FeatureConfig{}), nil)
+		return posts, router
+	}
+	update := func(router *gin.Engine, posts *versionedPostRepository, headers map[string]string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(UpdatePostRequest{Title: "Lost red scarf", Description: "Red wool"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/api/posts/"+posts.post.ID().String(), bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(handler.DevUserIDHeader, owner.String())
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	etag := func(posts *versionedPostRepository) string {
+		return `"` + strconv.FormatInt(posts.committedAt.UnixMicro(), 36) + `"`
+	}
+
+	t.Run("should reject a write when the post changes after its preconditions were checked", func(t *testing.T) {
+		posts, router := newFixture()
+		headers := map[string]string{"If-Match": etag(posts)}
+		posts.interleave = func(r *versionedPostRepository) { r.committedAt = r.committedAt.Add(time.Second) }
+
+		w := update(router, posts, headers)
+		require.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+		require.Zero(t, posts.updates, "the newer version is not overwritten")
+	})
+
+	t.Run("should apply a write when the checked version is still current", func(t *testing.T) {
+		posts, router := newFixture()
+
+		w := update(router, posts, map[string]string{"If-Match": etag(posts)})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, 1, posts.updates)
+	})
+
+	t.Run("should not pin a version without preconditions", func(t *testing.T) {
+		posts, router := newFixture()
+		posts.interleave = func(r *versionedPostRepository) { r.committedAt = r.committedAt.Add(time.Second) }
+
+		w := update(router, posts, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, 1, posts.updates)
+	})
+}
+
+// versionedPostRepository applies conditional writes like the database does: a write that
+// expects another version than the last committed one fails. interleave runs after every
+// read, standing in for writes other requests commit in the meantime.
+type versionedPostRepository struct {
+	updatablePostRepository
+	committedAt time.Time
+	updates     int
+	interleave  func(r *versionedPostRepository)
+}
+
+func (r *versionedPostRepository) FindByID(ctx context.Context, id domain.PostID) (*domain.Post, error) {
+	post, err := r.updatablePostRepository.FindByID(ctx, id)
+	if err == nil && r.interleave != nil {
+		r.interleave(r)
+	}
+	return post, err
+}
+
+func (r *versionedPostRepository) Update(ctx context.Context, post *domain.Post) error {
+	if expected, ok := domain.ExpectedVersion(ctx); ok && !expected.Equal(r.committedAt) {
+		return domain.ErrPostModified(post.ID())
+	}
+	r.committedAt = post.UpdatedAt()
+	r.updates++
+	return nil
+}
@@ -0,0 +1,116 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactDataPurgedEvent(t *testing.T) {
+	ctx := context.Background()
+
+	request := domain.ReconstructContactExchangeRequest(
+		domain.NewContactExchangeRequestID(), domain.NewPostID(), domain.NewUserID(), domain.NewUserID(),
+		domain.ContactExchangeStatusExpired, nil, false, nil, nil, nil, nil,
+		&domain.EncryptedContactInfo{PreferredMethod: "email"},
+		time.Now().Add(-time.Hour), time.Now().Add(-73*time.Hour), time.Now().Add(-time.Hour),
+	)
+
+	publisher := &recordingEventPublisher{}
+	contactService := service.NewContactExchangeService(
+		&expiredContactExchangeRepository{requests: []*domain.ContactExchangeRequest{request}},
+		&missingPostRepository{},
+		nil,
+		publisher,
+		nil,
+		nil,
+		&discardAuditLogger{},
+		config.ContactExchangeConfig{},
+	)
+
+	result, err := contactService.CleanupExpiredTokens(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Succeeded)
+	require.Nil(t, request.EncryptedContactInfo())
+
+	require.Len(t, publisher.events, 1)
+	event := publisher.events[0]
+	require.Equal(t, domain.EventTypeContactExchangeDataPurged, event.EventType)
+	require.Equal(t, request.ID().String(), event.AggregateID)
+
+	data, ok := event.Payload.(*domain.ContactExchangeDataPurgedEventData)
+	require.True(t, ok)
+	require.Equal(t, request.ID().String(), data.RequestID)
+	require.True(t, data.CleanupActions.PurgeContactInfo)
+	require.True(t, data.CleanupActions.RevokeContactAccess)
+	require.Nil(t, event.TenantID, "a missing post must not block the purge event")
+}
+
+// expiredContactExchangeRepository holds requests in memory and answers the expiry queries the
+// way the Postgres repository does
+type expiredContactExchangeRepository struct {
+	domain.ContactExchangeRepository
+	requests []*domain.ContactExchangeRequest
+}
+
+func (r *expiredContactExchangeRepository) FindExpired(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error) {
+	return r.find(limit, func(request *domain.ContactExchangeRequest) bool {
+		status := request.Status()
+		return request.IsExpired() && (status == domain.ContactExchangeStatusPending || status == domain.ContactExchangeStatusApproved)
+	}), nil
+}
+
+func (r *expiredContactExchangeRepository) FindPurgeable(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error) {
+	return r.find(limit, func(request *domain.ContactExchangeRequest) bool {
+		return request.Status() == domain.ContactExchangeStatusExpired && request.EncryptedContactInfo() != nil
+	}), nil
+}
+
+func (r *expiredContactExchangeRepository) find(limit int, match func(*domain.ContactExchangeRequest) bool) []*domain.ContactExchangeRequest {
+	var found []*domain.ContactExchangeRequest
+	for _, request := range r.requests {
+		if len(found) < limit && match(request) {
+			found = append(found, request)
+		}
+	}
+	return found
+}
+
+func (r *expiredContactExchangeRepository) Update(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	return nil
+}
+
+// missingPostRepository behaves as if every post was already deleted
+type missingPostRepository struct {
+	domain.PostRepository
+}
+
+func (r *missingPostRepository) FindByID(ctx context.Context, id domain.PostID) (*domain.Post, error) {
+	return nil, domain.ErrPostNotFound(id)
+}
+
+type recordingEventPublisher struct {
+	events []*domain.PostEvent
+}
+
+func (p *recordingEventPublisher) PublishEvent(ctx context.Context, event *domain.PostEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+type discardAuditLogger struct{}
+
+func (discardAuditLogger) LogOperation(log *domain.EncryptionAuditLog) error { return nil }
+
+func (discardAuditLogger) GetAuditTrail(filters domain.EncryptionAuditFilters) ([]*domain.EncryptionAuditLog, error) {
+	return nil, nil
+}
+
+func (discardAuditLogger) CountAuditTrail(filters domain.EncryptionAuditFilters) (int64, error) {
+	return 0, nil
+}
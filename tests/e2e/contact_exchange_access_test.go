@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeRequestAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	requester, owner := domain.NewUserID(), domain.NewUserID()
+	request := domain.ReconstructContactExchangeRequest(
+		domain.NewContactExchangeRequestID(), domain.NewPostID(), requester, owner,
+		domain.ContactExchangeStatusPending, nil, false, nil, nil, nil, nil, nil,
+		time.Now().Add(72*time.Hour), time.Now(), time.Now(),
+	)
+	contactService := service.NewContactExchangeService(&singleContactExchangeRepository{request: request},
+		nil, nil, nil, nil, newFakeEncryptionService(), &discardAuditLogger{}, config.ContactExchangeConfig{})
+	router := newProductionRouter(service.NewPostService(nil, nil, nil, nil, nil, nil, nil, config.FeatureConfig{}), contactService)
+
+	get := func(requestID domain.ContactExchangeRequestID, userID domain.UserID) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts/exchange/"+requestID.String(), nil)
+		req.Header.Set(handler.DevUserIDHeader, userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should show the request to both participants", func(t *testing.T) {
+		for _, participant := range []domain.UserID{requester, owner} {
+			require.Equal(t, http.StatusOK, get(request.ID(), participant).Code)
+		}
+	})
+
+	t.Run("should answer strangers as if the request did not exist", func(t *testing.T) {
+		stranger := get(request.ID(), domain.NewUserID())
+		missing := get(domain.NewContactExchangeRequestID(), domain.NewUserID())
+
+		require.Equal(t, http.StatusNotFound, stranger.Code)
+		require.Equal(t, http.StatusNotFound, missing.Code)
+		require.JSONEq(t, missing.Body.String(), stranger.Body.String())
+	})
+}
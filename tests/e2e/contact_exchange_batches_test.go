@@ -0,0 +1,101 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeExpirationBatches(t *testing.T) {
+	ctx := context.Background()
+
+	post, err := domain.NewDraftPost("Lost keys", "Blue keychain", nil, TestLocations.CentralPark, 1000, domain.PostTypeLost, domain.NewUserID(), nil)
+	require.NoError(t, err)
+
+	newExpiredRequests := func(count int) []*domain.ContactExchangeRequest {
+		requests := make([]*domain.ContactExchangeRequest, count)
+		for i := range requests {
+			requests[i] = domain.ReconstructContactExchangeRequest(
+				domain.NewContactExchangeRequestID(), post.ID(), domain.NewUserID(), post.CreatedBy(),
+				domain.ContactExchangeStatusApproved, nil, false, nil, nil, nil, nil,
+				&domain.EncryptedContactInfo{PreferredMethod: "email"},
+				time.Now().Add(-time.Hour), time.Now().Add(-73*time.Hour), time.Now().Add(-time.Hour),
+			)
+		}
+		return requests
+	}
+
+	newService := func(repo domain.ContactExchangeRepository, cfg config.ContactExchangeConfig) *service.ContactExchangeService {
+		return service.NewContactExchangeService(
+			repo,
+			&singlePostRepository{post: post},
+			repository.NewMockUserContextRepository(),
+			&recordingEventPublisher{},
+			nil,
+			nil,
+			&discardAuditLogger{},
+			cfg,
+		)
+	}
+
+	t.Run("should keep fetching batches until the backlog is drained", func(t *testing.T) {
+		repo := &expiredContactExchangeRepository{requests: newExpiredRequests(5)}
+		contactService := newService(repo, config.ContactExchangeConfig{ExpirationBatchSize: 2})
+
+		result, err := contactService.ProcessExpiredRequests(ctx)
+		require.NoError(t, err)
+		for _, request := range repo.requests {
+			require.Equal(t, domain.ContactExchangeStatusExpired, request.Status())
+		}
+		require.Equal(t, service.BulkResult{Processed: 5, Succeeded: 5, Batches: 3}, result, "two full batches and a short one")
+
+		result, err = contactService.CleanupExpiredTokens(ctx)
+		require.NoError(t, err)
+		for _, request := range repo.requests {
+			require.Nil(t, request.EncryptedContactInfo())
+		}
+		require.Equal(t, service.BulkResult{Processed: 5, Succeeded: 5, Batches: 3}, result)
+	})
+
+	t.Run("should stop when a batch makes no progress", func(t *testing.T) {
+		requests := newExpiredRequests(4)
+		for _, request := range requests {
+			require.NoError(t, request.Expire())
+		}
+		// Every purge fails to persist, so the run gives up after the first batch
+		repo := &failingUpdateContactExchangeRepository{expiredContactExchangeRepository{requests: requests}}
+		contactService := newService(repo, config.ContactExchangeConfig{ExpirationBatchSize: 2})
+
+		result, err := contactService.CleanupExpiredTokens(ctx)
+		require.NoError(t, err)
+		require.Equal(t, service.BulkResult{Processed: 2, Failed: 2, Batches: 1}, result)
+	})
+}
+
+// singlePostRepository knows about exactly one post
+type singlePostRepository struct {
+	domain.PostRepository
+	post *domain.Post
+}
+
+func (r *singlePostRepository) FindByID(ctx context.Context, id domain.PostID) (*domain.Post, error) {
+	if id != r.post.ID() {
+		return nil, domain.ErrPostNotFound(id)
+	}
+	return r.post, nil
+}
+
+// failingUpdateContactExchangeRepository rejects every write
+type failingUpdateContactExchangeRepository struct {
+	expiredContactExchangeRepository
+}
+
+func (r *failingUpdateContactExchangeRepository) Update(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	return domain.ErrRepositoryConnection("update contact exchange request")
+}
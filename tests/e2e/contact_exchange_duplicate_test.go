@@ -0,0 +1,122 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicatePendingContactExchange(t *testing.T) {
+	ctx := context.Background()
+	gin.SetMode(gin.TestMode)
+
+	post := domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil,
+		time.Now(), time.Now(), nil)
+	newService := func() (*service.ContactExchangeService, *savingContactExchangeRepository, *recordingEventPublisher) {
+		exchanges := &savingContactExchangeRepository{}
+		events := &recordingEventPublisher{}
+		return service.NewContactExchangeService(exchanges, &singlePostRepository{post: post}, repository.NewMockUserContextRepository(),
+			events, nil, nil, &discardAuditLogger{}, config.ContactExchangeConfig{}), exchanges, events
+	}
+	create := func(contactService *service.ContactExchangeService, requester domain.UserID) (*domain.ContactExchangeRequest, error) {
+		return contactService.CreateContactExchangeRequest(ctx, service.CreateContactExchangeCommand{
+			PostID:          post.ID(),
+			RequesterUserID: requester,
+			ExpirationHours: 24,
+		})
+	}
+
+	t.Run("should return the pending request instead of opening another", func(t *testing.T) {
+		contactService, exchanges, _ := newService()
+		requester := domain.NewUserID()
+
+		first, err := create(contactService, requester)
+		require.NoError(t, err)
+
+		existing, err := create(contactService, requester)
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorAlreadyPending))
+		require.Equal(t, first.ID().String(), err.(domain.PostError).Details["request_id"])
+		require.Equal(t, first, existing)
+		require.Len(t, exchanges.saved, 1)
+
+		_, err = create(contactService, domain.NewUserID())
+		require.NoError(t, err, "other requesters may ask for the same post")
+	})
+
+	t.Run("should allow a new request once the previous one is no longer pending", func(t *testing.T) {
+		contactService, exchanges, _ := newService()
+		requester := domain.NewUserID()
+
+		first, err := create(contactService, requester)
+		require.NoError(t, err)
+		require.NoError(t, first.Deny(domain.DenialReasonUserPreference, nil))
+
+		_, err = create(contactService, requester)
+		require.NoError(t, err)
+		require.Len(t, exchanges.saved, 2)
+	})
+
+	t.Run("should expire a stale pending request and replace it", func(t *testing.T) {
+		contactService, exchanges, events := newService()
+		requester := domain.NewUserID()
+		createdAt := time.Now().Add(-25 * time.Hour)
+		stale := domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), post.ID(), requester, post.CreatedBy(),
+			domain.ContactExchangeStatusPending, nil, false, nil, nil, nil, nil, nil,
+			createdAt.Add(24*time.Hour), createdAt, createdAt,
+		)
+		exchanges.saved = append(exchanges.saved, stale)
+
+		request, err := create(contactService, requester)
+		require.NoError(t, err)
+		require.NotEqual(t, stale.ID(), request.ID())
+		require.Equal(t, domain.ContactExchangeStatusExpired, stale.Status())
+
+		require.Len(t, events.events, 2)
+		require.Equal(t, domain.EventTypeContactExchangeExpired, events.events[0].EventType)
+		require.Equal(t, domain.EventTypeContactExchangeRequested, events.events[1].EventType)
+	})
+
+	t.Run("should answer duplicates with 409 and the pending request", func(t *testing.T) {
+		contactService, _, _ := newService()
+		router := gin.New()
+		handler.NewContactExchangeHandler(contactService).RegisterRoutes(router.Group("", handler.AuthMiddleware(nil, true)))
+		requester := domain.NewUserID()
+		send := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/contacts/exchange", strings.NewReader(`{"post_id": "`+post.ID().String()+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(handler.DevUserIDHeader, requester.String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := send()
+		require.Equal(t, http.StatusCreated, w.Code)
+		var created handler.ContactExchangeResponseDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+		w = send()
+		require.Equal(t, http.StatusConflict, w.Code)
+		var conflict struct {
+			Code    string                             `json:"code"`
+			Request handler.ContactExchangeResponseDTO `json:"request"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &conflict))
+		require.Equal(t, string(domain.ContactExchangeErrorAlreadyPending), conflict.Code)
+		require.Equal(t, created.ID, conflict.Request.ID)
+	})
+}
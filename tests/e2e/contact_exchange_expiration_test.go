@@ -0,0 +1,45 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeExpirationBounds(t *testing.T) {
+	newRequest := func(expirationHours int) (*domain.ContactExchangeRequest, error) {
+		return domain.NewContactExchangeRequest(
+			domain.NewPostID(), domain.NewUserID(), domain.NewUserID(), nil, false, nil, expirationHours)
+	}
+
+	t.Run("should default and accept expirations up to the max", func(t *testing.T) {
+		request, err := newRequest(0)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(72*time.Hour), request.ExpiresAt(), time.Minute)
+
+		_, err = newRequest(domain.MaxContactExchangeExpirationHours())
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject expirations beyond the max", func(t *testing.T) {
+		_, err := newRequest(100000)
+		require.Error(t, err)
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidExpiration))
+	})
+
+	t.Run("should honor a configured max", func(t *testing.T) {
+		require.NoError(t, domain.SetMaxContactExchangeExpirationHours(96))
+		t.Cleanup(func() {
+			require.NoError(t, domain.SetMaxContactExchangeExpirationHours(0))
+		})
+
+		_, err := newRequest(96)
+		require.NoError(t, err)
+		_, err = newRequest(97)
+		require.Error(t, err)
+
+		require.Error(t, domain.SetMaxContactExchangeExpirationHours(24), "max below the default")
+	})
+}
@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeMessageLength(t *testing.T) {
+	newRequest := func(message string) (*domain.ContactExchangeRequest, error) {
+		return domain.NewContactExchangeRequest(
+			domain.NewPostID(), domain.NewUserID(), domain.NewUserID(), &message, false, nil, 0)
+	}
+
+	t.Run("should accept messages up to the max in characters", func(t *testing.T) {
+		_, err := newRequest(strings.Repeat("é", domain.MaxContactExchangeMessageLength()))
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject request and denial messages beyond the max", func(t *testing.T) {
+		tooLong := strings.Repeat("a", domain.MaxContactExchangeMessageLength()+1)
+
+		_, err := newRequest(tooLong)
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorMessageTooLong))
+
+		request, err := newRequest("hello")
+		require.NoError(t, err)
+		err = request.Deny(domain.DenialReasonOther, &tooLong)
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorMessageTooLong))
+		require.Equal(t, domain.ContactExchangeStatusPending, request.Status())
+	})
+
+	t.Run("should honor a configured max and truncate event data to it", func(t *testing.T) {
+		request, err := newRequest("twelve chars")
+		require.NoError(t, err)
+
+		require.NoError(t, domain.SetMaxContactExchangeMessageLength(5))
+		t.Cleanup(func() {
+			require.NoError(t, domain.SetMaxContactExchangeMessageLength(0))
+		})
+
+		data := request.ToContactRequestData()
+		require.NotNil(t, data.Message)
+		require.Equal(t, "twelv", *data.Message)
+
+		_, err = newRequest("twelve chars")
+		require.Error(t, err)
+		require.Error(t, domain.SetMaxContactExchangeMessageLength(-1))
+	})
+}
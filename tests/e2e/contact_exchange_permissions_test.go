@@ -0,0 +1,46 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangePermissions(t *testing.T) {
+	requesterID, ownerID, strangerID := domain.NewUserID(), domain.NewUserID(), domain.NewUserID()
+	newRequest := func(t *testing.T) *domain.ContactExchangeRequest {
+		request, err := domain.NewContactExchangeRequest(domain.NewPostID(), requesterID, ownerID, nil, false, nil, 0)
+		require.NoError(t, err)
+		return request
+	}
+
+	t.Run("should let only participants view a request", func(t *testing.T) {
+		request := newRequest(t)
+		require.True(t, request.CanBeViewedBy(requesterID))
+		require.True(t, request.CanBeViewedBy(ownerID))
+		require.False(t, request.CanBeViewedBy(strangerID))
+	})
+
+	t.Run("should let the owner approve or deny and the requester cancel a pending request", func(t *testing.T) {
+		request := newRequest(t)
+		require.True(t, request.CanBeApprovedBy(ownerID))
+		require.True(t, request.CanBeDeniedBy(ownerID))
+		require.True(t, request.CanBeCancelledBy(requesterID))
+
+		require.False(t, request.CanBeApprovedBy(requesterID))
+		require.False(t, request.CanBeDeniedBy(requesterID))
+		require.False(t, request.CanBeCancelledBy(ownerID))
+		require.False(t, request.CanBeApprovedBy(strangerID))
+	})
+
+	t.Run("should refuse every action once the request is decided", func(t *testing.T) {
+		request := newRequest(t)
+		require.NoError(t, request.Deny(domain.DenialReasonOther, nil))
+
+		require.False(t, request.CanBeApprovedBy(ownerID))
+		require.False(t, request.CanBeDeniedBy(ownerID))
+		require.False(t, request.CanBeCancelledBy(requesterID))
+		require.True(t, request.CanBeViewedBy(requesterID))
+	})
+}
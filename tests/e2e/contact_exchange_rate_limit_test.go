@@ -0,0 +1,120 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeRateLimit(t *testing.T) {
+	ctx := context.Background()
+	gin.SetMode(gin.TestMode)
+
+	post := domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil,
+		time.Now(), time.Now(), nil)
+	newService := func(cfg config.ContactExchangeConfig) (*service.ContactExchangeService, *savingContactExchangeRepository) {
+		exchanges := &savingContactExchangeRepository{}
+		return service.NewContactExchangeService(exchanges, &singlePostRepository{post: post}, repository.NewMockUserContextRepository(),
+			&recordingEventPublisher{}, nil, nil, &discardAuditLogger{}, cfg), exchanges
+	}
+	create := func(contactService *service.ContactExchangeService, requester domain.UserID) error {
+		_, err := contactService.CreateContactExchangeRequest(ctx, service.CreateContactExchangeCommand{
+			PostID:          post.ID(),
+			RequesterUserID: requester,
+			ExpirationHours: 24,
+		})
+		return err
+	}
+	// Only one request per post may be pending, so each is denied before the next is made
+	denyLast := func(exchanges *savingContactExchangeRepository) {
+		require.NoError(t, exchanges.saved[len(exchanges.saved)-1].Deny(domain.DenialReasonUserPreference, nil))
+	}
+	pastRequest := func(postID domain.PostID, requester domain.UserID, status domain.ContactExchangeStatus, createdAgo time.Duration) *domain.ContactExchangeRequest {
+		createdAt := time.Now().Add(-createdAgo)
+		return domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), postID, requester, post.CreatedBy(),
+			status, nil, false, nil, nil, nil, nil, nil,
+			createdAt.Add(24*time.Hour), createdAt, createdAt,
+		)
+	}
+
+	t.Run("should allow exactly the configured requests per post in an hour", func(t *testing.T) {
+		contactService, exchanges := newService(config.ContactExchangeConfig{MaxRequestsPerPostPerHour: 2, MaxPendingRequests: 100})
+		requester := domain.NewUserID()
+		exchanges.saved = append(exchanges.saved, pastRequest(post.ID(), requester, domain.ContactExchangeStatusDenied, 61*time.Minute))
+
+		require.NoError(t, create(contactService, requester))
+		denyLast(exchanges)
+		require.NoError(t, create(contactService, requester))
+		denyLast(exchanges)
+
+		err := create(contactService, requester)
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorRateLimited))
+		require.Len(t, exchanges.saved, 3)
+
+		require.NoError(t, create(contactService, domain.NewUserID()), "other requesters are counted separately")
+	})
+
+	t.Run("should allow exactly the configured pending requests", func(t *testing.T) {
+		contactService, exchanges := newService(config.ContactExchangeConfig{MaxRequestsPerPostPerHour: 100, MaxPendingRequests: 3})
+		requester := domain.NewUserID()
+		exchanges.saved = append(exchanges.saved,
+			pastRequest(domain.NewPostID(), requester, domain.ContactExchangeStatusPending, 2*time.Hour),
+			pastRequest(domain.NewPostID(), requester, domain.ContactExchangeStatusPending, 2*time.Hour),
+			pastRequest(domain.NewPostID(), requester, domain.ContactExchangeStatusApproved, 2*time.Hour),
+			pastRequest(domain.NewPostID(), requester, domain.ContactExchangeStatusPending, 25*time.Hour), // already expired
+		)
+
+		require.NoError(t, create(contactService, requester))
+		denyLast(exchanges)
+		exchanges.saved = append(exchanges.saved, pastRequest(domain.NewPostID(), requester, domain.ContactExchangeStatusPending, time.Hour))
+
+		err := create(contactService, requester)
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorRateLimited))
+	})
+
+	t.Run("should fall back to the default limits", func(t *testing.T) {
+		contactService, exchanges := newService(config.ContactExchangeConfig{})
+		requester := domain.NewUserID()
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, create(contactService, requester))
+			denyLast(exchanges)
+		}
+		require.True(t, domain.IsPostErrorCode(create(contactService, requester), domain.ContactExchangeErrorRateLimited))
+	})
+
+	t.Run("should answer limited requests with 429", func(t *testing.T) {
+		contactService, exchanges := newService(config.ContactExchangeConfig{MaxRequestsPerPostPerHour: 1})
+		router := gin.New()
+		handler.NewContactExchangeHandler(contactService).RegisterRoutes(router.Group("", handler.AuthMiddleware(nil, true)))
+		requester := domain.NewUserID()
+		send := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/contacts/exchange", strings.NewReader(`{"post_id": "`+post.ID().String()+`"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(handler.DevUserIDHeader, requester.String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		require.Equal(t, http.StatusCreated, send().Code)
+		denyLast(exchanges)
+
+		w := send()
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		require.Contains(t, w.Body.String(), string(domain.ContactExchangeErrorRateLimited))
+	})
+}
@@ -0,0 +1,127 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactInfoReEncryption(t *testing.T) {
+	ctx := context.Background()
+	email := "owner@example.com"
+
+	newFixture := func(t *testing.T) (*domain.RSAEncryptionService, *memoryKeyRepository, *reEncryptionContactExchangeRepository, *recordingAuditLogger, *service.ContactExchangeService) {
+		keys := &memoryKeyRepository{}
+		encryption, err := domain.NewRSAEncryptionService(keys, &discardAuditLogger{})
+		require.NoError(t, err)
+
+		repo := &reEncryptionContactExchangeRepository{}
+		audit := &recordingAuditLogger{}
+		contactService := service.NewContactExchangeService(repo, nil, repository.NewMockUserContextRepository(),
+			nil, nil, encryption, audit, config.ContactExchangeConfig{})
+		return encryption, keys, repo, audit, contactService
+	}
+	newRequest := func(t *testing.T, encryption domain.EncryptionService, status domain.ContactExchangeStatus, expiresIn time.Duration) *domain.ContactExchangeRequest {
+		encrypted, err := encryption.EncryptContactInfo(domain.ContactInfo{Email: &email, PreferredMethod: "email"})
+		require.NoError(t, err)
+		return domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), domain.NewPostID(), domain.NewUserID(), domain.NewUserID(),
+			status, nil, false, nil, nil, nil, nil, encrypted,
+			time.Now().Add(expiresIn), time.Now().Add(-time.Hour), time.Now().Add(-time.Hour),
+		)
+	}
+
+	t.Run("should move approved requests onto the new key and retire the old one", func(t *testing.T) {
+		encryption, keys, repo, audit, contactService := newFixture(t)
+		oldKey := encryption.GetActiveKeyFingerprint()
+		for i := 0; i < 3; i++ {
+			repo.requests = append(repo.requests, newRequest(t, encryption, domain.ContactExchangeStatusApproved, time.Hour))
+		}
+		require.NoError(t, encryption.RotateKeys())
+		newKey := encryption.GetActiveKeyFingerprint()
+
+		result, err := contactService.ReEncryptAll(ctx, 2)
+		require.NoError(t, err)
+		require.Equal(t, 3, result.Succeeded)
+		require.Equal(t, 2, result.Batches)
+		require.Equal(t, []string{oldKey}, result.RetiredKeys)
+
+		for _, request := range repo.requests {
+			require.Equal(t, newKey, request.EncryptedContactInfo().KeyFingerprint)
+			decrypted, err := encryption.DecryptContactInfo(request.EncryptedContactInfo())
+			require.NoError(t, err)
+			require.Equal(t, email, *decrypted.Email)
+		}
+
+		require.Len(t, audit.logs, 3)
+		for _, entry := range audit.logs {
+			require.Equal(t, domain.EncryptionOperationReEncrypt, entry.Operation)
+			require.True(t, entry.Success)
+			require.Equal(t, newKey, entry.KeyFingerprint)
+		}
+
+		retired, err := keys.GetKeyByFingerprint(oldKey)
+		require.NoError(t, err)
+		require.NotNil(t, retired.RetiredAt)
+	})
+
+	t.Run("should keep old keys that expired requests still use", func(t *testing.T) {
+		encryption, keys, repo, audit, contactService := newFixture(t)
+		oldKey := encryption.GetActiveKeyFingerprint()
+		expired := newRequest(t, encryption, domain.ContactExchangeStatusApproved, -time.Hour)
+		repo.requests = append(repo.requests, expired)
+		require.NoError(t, encryption.RotateKeys())
+
+		result, err := contactService.ReEncryptAll(ctx, 0)
+		require.NoError(t, err)
+		require.Zero(t, result.Processed)
+		require.Empty(t, result.RetiredKeys)
+		require.Empty(t, audit.logs)
+		require.Equal(t, oldKey, expired.EncryptedContactInfo().KeyFingerprint)
+
+		kept, err := keys.GetKeyByFingerprint(oldKey)
+		require.NoError(t, err)
+		require.Nil(t, kept.RetiredAt)
+	})
+}
+
+// reEncryptionContactExchangeRepository answers the re-encryption queries the way the Postgres
+// repository does
+type reEncryptionContactExchangeRepository struct {
+	domain.ContactExchangeRepository
+	requests []*domain.ContactExchangeRequest
+}
+
+func (r *reEncryptionContactExchangeRepository) FindEncryptedWithOtherKey(ctx context.Context, keyFingerprint string, limit int) ([]*domain.ContactExchangeRequest, error) {
+	var found []*domain.ContactExchangeRequest
+	for _, request := range r.requests {
+		info := request.EncryptedContactInfo()
+		if len(found) < limit && request.Status() == domain.ContactExchangeStatusApproved && !request.IsExpired() &&
+			info != nil && info.KeyFingerprint != keyFingerprint {
+			found = append(found, request)
+		}
+	}
+	return found, nil
+}
+
+func (r *reEncryptionContactExchangeRepository) FindKeyFingerprintsInUse(ctx context.Context) ([]string, error) {
+	seen := map[string]bool{}
+	var fingerprints []string
+	for _, request := range r.requests {
+		if info := request.EncryptedContactInfo(); info != nil && !seen[info.KeyFingerprint] {
+			seen[info.KeyFingerprint] = true
+			fingerprints = append(fingerprints, info.KeyFingerprint)
+		}
+	}
+	return fingerprints, nil
+}
+
+func (r *reEncryptionContactExchangeRepository) Update(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	return nil
+}
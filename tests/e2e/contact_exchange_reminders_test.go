@@ -0,0 +1,142 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeExpiryReminders(t *testing.T) {
+	ctx := context.Background()
+	owner := domain.NewUserID()
+	requester := domain.NewUserID()
+
+	post := domain.ReconstructPost(domain.NewPostID(), "Lost keys", "Three keys on a red ring",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost, owner, nil,
+		time.Now(), time.Now(), nil)
+
+	newRequest := func(status domain.ContactExchangeStatus, expiresIn time.Duration) *domain.ContactExchangeRequest {
+		return domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), post.ID(), requester, owner,
+			status, nil, false, nil, nil, nil, nil, nil,
+			time.Now().Add(expiresIn), time.Now().Add(-time.Hour), time.Now().Add(-time.Hour),
+		)
+	}
+
+	newService := func(repo *reminderContactExchangeRepository, publisher *recordingEventPublisher) *service.ContactExchangeService {
+		return service.NewContactExchangeService(
+			repo,
+			&singlePostRepository{post: post},
+			repository.NewMockUserContextRepository(),
+			publisher,
+			nil,
+			nil,
+			&discardAuditLogger{},
+			config.ContactExchangeConfig{ReminderLeadHours: 24},
+		)
+	}
+
+	t.Run("should remind once for requests expiring within the lead", func(t *testing.T) {
+		soon := newRequest(domain.ContactExchangeStatusApproved, 6*time.Hour)
+		later := newRequest(domain.ContactExchangeStatusApproved, 72*time.Hour)
+		denied := newRequest(domain.ContactExchangeStatusDenied, 6*time.Hour)
+		repo := &reminderContactExchangeRepository{
+			requests: []*domain.ContactExchangeRequest{soon, later, denied},
+			reminded: map[domain.ContactExchangeRequestID]time.Time{},
+		}
+		publisher := &recordingEventPublisher{}
+		contactService := newService(repo, publisher)
+
+		result, err := contactService.SendExpiryReminders(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Succeeded)
+		require.Contains(t, repo.reminded, soon.ID())
+
+		require.Len(t, publisher.events, 1)
+		event := publisher.events[0]
+		require.Equal(t, domain.EventTypeContactExchangeExpiringSoon, event.EventType)
+		require.Equal(t, soon.ID().String(), event.AggregateID)
+
+		data, ok := event.Payload.(*domain.ContactExchangeExpiringSoonEventData)
+		require.True(t, ok)
+		require.InDelta(t, 6, data.HoursRemaining, 0.1)
+		require.True(t, data.NotificationRequirements.ImmediateNotification)
+		require.Len(t, data.NotificationRequirements.ReminderSchedule, 1)
+		require.Equal(t, 4, data.NotificationRequirements.ReminderSchedule[0].DelayHours)
+
+		// A second run has nothing left to remind
+		result, err = contactService.SendExpiryReminders(ctx)
+		require.NoError(t, err)
+		require.Zero(t, result.Processed)
+		require.Len(t, publisher.events, 1)
+	})
+
+	t.Run("should list requests expiring for either participant", func(t *testing.T) {
+		soon := newRequest(domain.ContactExchangeStatusPending, 2*time.Hour)
+		later := newRequest(domain.ContactExchangeStatusApproved, 48*time.Hour)
+		repo := &reminderContactExchangeRepository{requests: []*domain.ContactExchangeRequest{soon, later}}
+		contactService := newService(repo, &recordingEventPublisher{})
+
+		for _, userID := range []domain.UserID{owner, requester} {
+			requests, err := contactService.FindExpiringRequests(ctx, userID, 0)
+			require.NoError(t, err)
+			require.Len(t, requests, 1)
+			require.Equal(t, soon.ID(), requests[0].ID())
+		}
+
+		requests, err := contactService.FindExpiringRequests(ctx, requester, 72*time.Hour)
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+
+		requests, err = contactService.FindExpiringRequests(ctx, domain.NewUserID(), 72*time.Hour)
+		require.NoError(t, err)
+		require.Empty(t, requests)
+	})
+}
+
+// reminderContactExchangeRepository answers the expiry reminder queries the way the Postgres
+// repository does
+type reminderContactExchangeRepository struct {
+	domain.ContactExchangeRepository
+	requests []*domain.ContactExchangeRequest
+	reminded map[domain.ContactExchangeRequestID]time.Time
+}
+
+func (r *reminderContactExchangeRepository) FindExpiringForUser(ctx context.Context, userID domain.UserID, within time.Duration) ([]*domain.ContactExchangeRequest, error) {
+	var found []*domain.ContactExchangeRequest
+	for _, request := range r.requests {
+		if request.CanBeViewedBy(userID) && r.expiresWithin(request, within) {
+			found = append(found, request)
+		}
+	}
+	return found, nil
+}
+
+func (r *reminderContactExchangeRepository) FindDueForExpiryReminder(ctx context.Context, within time.Duration, limit int) ([]*domain.ContactExchangeRequest, error) {
+	var found []*domain.ContactExchangeRequest
+	for _, request := range r.requests {
+		if _, sent := r.reminded[request.ID()]; !sent && len(found) < limit && r.expiresWithin(request, within) {
+			found = append(found, request)
+		}
+	}
+	return found, nil
+}
+
+func (r *reminderContactExchangeRepository) MarkExpiryReminderSent(ctx context.Context, id domain.ContactExchangeRequestID, sentAt time.Time) error {
+	r.reminded[id] = sentAt
+	return nil
+}
+
+func (r *reminderContactExchangeRepository) expiresWithin(request *domain.ContactExchangeRequest, within time.Duration) bool {
+	status := request.Status()
+	if status != domain.ContactExchangeStatusPending && status != domain.ContactExchangeStatusApproved {
+		return false
+	}
+	return !request.IsExpired() && request.ExpiresAt().Before(time.Now().Add(within))
+}
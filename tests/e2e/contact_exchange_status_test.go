@@ -0,0 +1,117 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeStatusLookup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	owner := domain.NewUserID()
+	requester := domain.NewUserID()
+	newRequest := func(requester, owner domain.UserID, status domain.ContactExchangeStatus) *domain.ContactExchangeRequest {
+		return domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), domain.NewPostID(), requester, owner,
+			status, nil, false, nil, nil, nil, nil, nil,
+			time.Now().Add(24*time.Hour), time.Now(), time.Now(),
+		)
+	}
+
+	pending := newRequest(requester, owner, domain.ContactExchangeStatusPending)
+	approved := newRequest(requester, owner, domain.ContactExchangeStatusApproved)
+	someoneElses := newRequest(domain.NewUserID(), domain.NewUserID(), domain.ContactExchangeStatusDenied)
+	repo := &statusContactExchangeRepository{requests: []*domain.ContactExchangeRequest{pending, approved, someoneElses}}
+
+	contactService := service.NewContactExchangeService(repo, nil, repository.NewMockUserContextRepository(),
+		nil, nil, nil, &discardAuditLogger{}, config.ContactExchangeConfig{})
+	router := gin.New()
+	handler.NewContactExchangeHandler(contactService).RegisterRoutes(router.Group("", handler.AuthMiddleware(nil, true)))
+
+	lookup := func(userID domain.UserID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/contacts/exchange/status", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(handler.DevUserIDHeader, userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	statuses := func(t *testing.T, w *httptest.ResponseRecorder) map[string]string {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Statuses map[string]string `json:"statuses"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Statuses
+	}
+	idList := func(ids ...string) string {
+		encoded, _ := json.Marshal(map[string][]string{"request_ids": ids})
+		return string(encoded)
+	}
+
+	t.Run("should report only the requests the caller takes part in", func(t *testing.T) {
+		body := idList(pending.ID().String(), approved.ID().String(), someoneElses.ID().String(),
+			domain.NewContactExchangeRequestID().String(), pending.ID().String())
+
+		for _, userID := range []domain.UserID{requester, owner} {
+			require.Equal(t, map[string]string{
+				pending.ID().String():  "pending",
+				approved.ID().String(): "approved",
+			}, statuses(t, lookup(userID, body)))
+		}
+		// One query per lookup, with the repeated ID asked about once
+		require.Equal(t, 2, repo.queries)
+		require.Len(t, repo.lastIDs, 4)
+
+		require.Empty(t, statuses(t, lookup(domain.NewUserID(), body)))
+	})
+
+	t.Run("should reject empty, oversized and malformed lookups", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, lookup(requester, `{"request_ids": []}`).Code)
+		require.Equal(t, http.StatusBadRequest, lookup(requester, `{}`).Code)
+		require.Equal(t, http.StatusBadRequest, lookup(requester, idList(pending.ID().String(), "not-a-uuid")).Code)
+
+		tooMany := make([]string, 101)
+		for i := range tooMany {
+			tooMany[i] = domain.NewContactExchangeRequestID().String()
+		}
+		require.Equal(t, http.StatusBadRequest, lookup(requester, idList(tooMany...)).Code)
+	})
+}
+
+// statusContactExchangeRepository answers status lookups the way the Postgres repository does
+// and records each query it receives
+type statusContactExchangeRepository struct {
+	domain.ContactExchangeRepository
+	requests []*domain.ContactExchangeRequest
+	queries  int
+	lastIDs  []domain.ContactExchangeRequestID
+}
+
+func (r *statusContactExchangeRepository) FindStatusesForUser(ctx context.Context, ids []domain.ContactExchangeRequestID, userID domain.UserID) (map[domain.ContactExchangeRequestID]domain.ContactExchangeStatus, error) {
+	r.queries++
+	r.lastIDs = ids
+
+	statuses := make(map[domain.ContactExchangeRequestID]domain.ContactExchangeStatus)
+	for _, id := range ids {
+		for _, request := range r.requests {
+			if request.ID() == id && request.CanBeViewedBy(userID) {
+				statuses[id] = request.Status()
+			}
+		}
+	}
+	return statuses, nil
+}
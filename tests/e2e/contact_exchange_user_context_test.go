@@ -0,0 +1,54 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeUserContextFallback(t *testing.T) {
+	ctx := context.Background()
+
+	post := domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil,
+		time.Now(), time.Now(), nil)
+	exchanges := &savingContactExchangeRepository{}
+	publisher := &recordingEventPublisher{}
+	contactService := service.NewContactExchangeService(exchanges, &singlePostRepository{post: post},
+		&unavailableUserContextRepository{}, publisher, nil, nil, &discardAuditLogger{}, config.ContactExchangeConfig{})
+
+	t.Run("should still announce a saved request when user context is unavailable", func(t *testing.T) {
+		requesterID := domain.NewUserID()
+		request, err := contactService.CreateContactExchangeRequest(ctx, service.CreateContactExchangeCommand{
+			PostID:          post.ID(),
+			RequesterUserID: requesterID,
+			ExpirationHours: 24,
+		})
+		require.NoError(t, err)
+		require.Len(t, exchanges.saved, 1)
+		require.Equal(t, request.ID(), exchanges.saved[0].ID())
+
+		require.Len(t, publisher.events, 1)
+		data, ok := publisher.events[0].Payload.(*domain.ContactExchangeRequestedEventData)
+		require.True(t, ok)
+		require.Equal(t, requesterID.String(), data.Requester.UserID)
+		require.Equal(t, "Unknown User", data.Requester.DisplayName)
+		require.Equal(t, post.CreatedBy().String(), data.Owner.UserID)
+		require.Equal(t, "Unknown User", data.Owner.DisplayName)
+	})
+}
+
+// unavailableUserContextRepository fails every user context lookup
+type unavailableUserContextRepository struct {
+	domain.UserContextRepository
+}
+
+func (r *unavailableUserContextRepository) GetPrivacySafeUser(ctx context.Context, userID domain.UserID) (*domain.PrivacySafeUser, error) {
+	return nil, errors.New("user service unavailable")
+}
@@ -0,0 +1,174 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactExchangeVerification(t *testing.T) {
+	ctx := context.Background()
+	gin.SetMode(gin.TestMode)
+
+	owner := domain.NewUserID()
+	requester := domain.NewUserID()
+	orgID := domain.NewOrganizationID()
+	admin := domain.NewUserID()
+	staff := domain.NewUserID()
+	post := domain.ReconstructPost(domain.NewPostID(), "Found keys", "Three keys on a red ring",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, owner, &orgID, time.Now(), time.Now(), nil)
+
+	users := repository.NewMockUserContextRepository()
+	users.SetMockUser(admin, &domain.PrivacySafeUser{
+		UserID:       admin,
+		Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleAdmin},
+	})
+	users.SetMockUser(staff, &domain.PrivacySafeUser{
+		UserID:       staff,
+		Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleStaff},
+	})
+
+	question := "What is on the key ring?"
+	newFixture := func(details *domain.VerificationDetails) (*domain.ContactExchangeRequest, *service.ContactExchangeService) {
+		request := domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), post.ID(), requester, owner,
+			domain.ContactExchangeStatusPending, nil, details != nil, details, nil, nil, nil, nil,
+			time.Now().Add(time.Hour), time.Now(), time.Now(),
+		)
+		contactService := service.NewContactExchangeService(&singleContactExchangeRepository{request: request}, &singlePostRepository{post: post},
+			users, &recordingEventPublisher{}, nil, newFakeEncryptionService(), &discardAuditLogger{}, config.ContactExchangeConfig{})
+		return request, contactService
+	}
+	submit := func(contactService *service.ContactExchangeService, request *domain.ContactExchangeRequest, userID domain.UserID, submission domain.VerificationSubmission) error {
+		_, err := contactService.SubmitVerification(ctx, service.SubmitVerificationCommand{RequestID: request.ID(), UserID: userID, Submission: submission})
+		return err
+	}
+	approve := func(contactService *service.ContactExchangeService, request *domain.ContactExchangeRequest) error {
+		email := "owner@example.com"
+		_, err := contactService.ApproveContactExchange(ctx, service.ApproveContactExchangeCommand{
+			RequestID:    request.ID(),
+			ApprovalType: domain.ContactExchangeApprovalTypeFull,
+			ContactInfo:  &domain.ContactInfo{Email: &email, PreferredMethod: domain.ContactMethodEmail},
+		})
+		return err
+	}
+	answer := func(text string) *string { return &text }
+
+	t.Run("should require a usable verification method on creation", func(t *testing.T) {
+		for _, details := range []*domain.VerificationDetails{
+			nil,
+			{Method: "fingerprint"},
+			{Method: domain.VerificationMethodSecurityQuestion, Question: answer(" ")},
+		} {
+			_, err := domain.NewContactExchangeRequest(post.ID(), requester, owner, nil, true, details, 0)
+			require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidVerification))
+		}
+
+		_, err := domain.NewContactExchangeRequest(post.ID(), requester, owner, nil, true,
+			&domain.VerificationDetails{Method: domain.VerificationMethodSecurityQuestion, Question: &question}, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("should approve security question requests only once answered", func(t *testing.T) {
+		request, contactService := newFixture(&domain.VerificationDetails{Method: domain.VerificationMethodSecurityQuestion, Question: &question})
+
+		require.True(t, domain.IsPostErrorCode(approve(contactService, request), domain.ContactExchangeErrorNotVerified))
+		require.Equal(t, domain.ContactExchangeStatusPending, request.Status())
+
+		err := submit(contactService, request, requester, domain.VerificationSubmission{PhotoURLs: []string{"https://example.com/keys.jpg"}})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidVerification))
+		require.False(t, request.VerificationCompleted())
+
+		require.NoError(t, submit(contactService, request, requester, domain.VerificationSubmission{Answer: answer("  A bottle opener ")}))
+		require.True(t, request.VerificationCompleted())
+		require.Equal(t, "A bottle opener", *request.VerificationSubmission().Answer)
+
+		err = submit(contactService, request, requester, domain.VerificationSubmission{Answer: answer("A whistle")})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidVerification))
+
+		require.NoError(t, approve(contactService, request))
+		require.Equal(t, domain.ContactExchangeStatusApproved, request.Status())
+	})
+
+	t.Run("should approve photo proof requests only once photos are sent", func(t *testing.T) {
+		request, contactService := newFixture(&domain.VerificationDetails{Method: domain.VerificationMethodPhotoProof})
+
+		err := submit(contactService, request, requester, domain.VerificationSubmission{Answer: answer("They are mine")})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidVerification))
+
+		err = submit(contactService, request, requester, domain.VerificationSubmission{PhotoURLs: []string{"ftp://example.com/keys.jpg"}})
+		require.True(t, domain.IsPostErrorCode(err, domain.PhotoErrorInvalidURL))
+
+		require.NoError(t, submit(contactService, request, requester, domain.VerificationSubmission{PhotoURLs: []string{"https://example.com/keys.jpg"}}))
+		require.True(t, request.VerificationCompleted())
+		require.NoError(t, approve(contactService, request))
+	})
+
+	t.Run("should approve admin approval requests only once an organization admin verifies them", func(t *testing.T) {
+		request, contactService := newFixture(&domain.VerificationDetails{Method: domain.VerificationMethodAdminApproval})
+
+		require.NoError(t, submit(contactService, request, requester, domain.VerificationSubmission{PhotoURLs: []string{"https://example.com/receipt.jpg"}}))
+		require.False(t, request.VerificationCompleted())
+		require.NotNil(t, request.VerificationSubmission())
+		require.True(t, domain.IsPostErrorCode(approve(contactService, request), domain.ContactExchangeErrorNotVerified))
+
+		for _, userID := range []domain.UserID{staff, owner, domain.NewUserID()} {
+			require.True(t, domain.IsPostErrorCode(submit(contactService, request, userID, domain.VerificationSubmission{}), domain.BusinessErrorUnauthorized))
+		}
+
+		require.NoError(t, submit(contactService, request, admin, domain.VerificationSubmission{}))
+		require.True(t, request.VerificationCompleted())
+		require.NoError(t, approve(contactService, request))
+	})
+
+	t.Run("should refuse verification for requests that do not need it", func(t *testing.T) {
+		request, contactService := newFixture(nil)
+
+		err := submit(contactService, request, requester, domain.VerificationSubmission{Answer: answer("Mine")})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidVerification))
+		require.NoError(t, approve(contactService, request))
+	})
+
+	t.Run("should verify over HTTP and answer unverified approvals with 409", func(t *testing.T) {
+		request, contactService := newFixture(&domain.VerificationDetails{Method: domain.VerificationMethodSecurityQuestion, Question: &question})
+		router := gin.New()
+		handler.NewContactExchangeHandler(contactService).RegisterRoutes(router.Group("", handler.AuthMiddleware(nil, true)))
+		send := func(action string, userID domain.UserID, body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/contacts/exchange/"+request.ID().String()+"/"+action, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(handler.DevUserIDHeader, userID.String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+		approval := `{"approval_type": "full_contact", "contact_info": {"email": "owner@example.com", "preferred_method": "email"}}`
+
+		w := send("approve", owner, approval)
+		require.Equal(t, http.StatusConflict, w.Code)
+		require.Contains(t, w.Body.String(), string(domain.ContactExchangeErrorNotVerified))
+
+		require.Equal(t, http.StatusUnprocessableEntity, send("verify", requester, `{"answer": " "}`).Code)
+		require.Equal(t, http.StatusForbidden, send("verify", domain.NewUserID(), `{"answer": "A whistle"}`).Code)
+
+		w = send("verify", requester, `{"answer": "A bottle opener"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var verified handler.ContactExchangeResponseDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &verified))
+		require.True(t, verified.VerificationCompleted)
+		require.Equal(t, "A bottle opener", *verified.VerificationProof.Answer)
+
+		require.Equal(t, http.StatusOK, send("approve", owner, approval).Code)
+	})
+}
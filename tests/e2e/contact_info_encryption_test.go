@@ -0,0 +1,195 @@
+package e2e
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactInfoEnvelopeEncryption(t *testing.T) {
+	keys := &memoryKeyRepository{}
+	encryption, err := domain.NewRSAEncryptionService(keys, &discardAuditLogger{})
+	require.NoError(t, err)
+
+	email := "owner@example.com"
+
+	t.Run("should encrypt and decrypt a 5KB message", func(t *testing.T) {
+		message := strings.Repeat("Left my keys at the front desk. ", 160)
+		require.Greater(t, len(message), 5000)
+
+		encrypted, err := encryption.EncryptContactInfo(domain.ContactInfo{
+			Email:           &email,
+			PreferredMethod: "email",
+			Message:         &message,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, encrypted.EncryptedKey)
+		require.NotNil(t, encrypted.Nonce)
+		require.NotContains(t, *encrypted.Ciphertext, email)
+
+		decrypted, err := encryption.DecryptContactInfo(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, email, *decrypted.Email)
+		require.Equal(t, message, *decrypted.Message)
+	})
+
+	t.Run("should leave no plaintext contact details outside the ciphertext", func(t *testing.T) {
+		phone := "+15551234567"
+		message := "Call after 6pm, ask for the front desk"
+		encrypted, err := encryption.EncryptContactInfo(domain.ContactInfo{
+			Email:           &email,
+			Phone:           &phone,
+			PreferredMethod: "phone",
+			Message:         &message,
+		})
+		require.NoError(t, err)
+		require.Nil(t, encrypted.Email)
+		require.Nil(t, encrypted.Phone)
+		require.Nil(t, encrypted.Message)
+		require.Equal(t, "phone", encrypted.PreferredMethod)
+
+		// As stored in Postgres and published in events
+		stored, err := json.Marshal(encrypted)
+		require.NoError(t, err)
+		for _, secret := range []string{email, phone, message} {
+			require.NotContains(t, string(stored), secret)
+		}
+
+		decrypted, err := encryption.DecryptContactInfo(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, phone, *decrypted.Phone)
+		require.Equal(t, message, *decrypted.Message)
+	})
+
+	t.Run("should refuse a tampered ciphertext", func(t *testing.T) {
+		encrypted, err := encryption.EncryptContactInfo(domain.ContactInfo{Email: &email, PreferredMethod: "email"})
+		require.NoError(t, err)
+
+		ciphertext, err := base64.StdEncoding.DecodeString(*encrypted.Ciphertext)
+		require.NoError(t, err)
+		ciphertext[0] ^= 0xff
+		tampered := base64.StdEncoding.EncodeToString(ciphertext)
+		encrypted.Ciphertext = &tampered
+
+		_, err = encryption.DecryptContactInfo(encrypted)
+		require.Error(t, err)
+	})
+
+	t.Run("should still decrypt contact info encrypted with RSA alone", func(t *testing.T) {
+		key, err := keys.GetActiveKey()
+		require.NoError(t, err)
+		block, _ := pem.Decode([]byte(key.PublicKey))
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+
+		payload, err := json.Marshal(domain.ContactInfo{Email: &email, PreferredMethod: "email"})
+		require.NoError(t, err)
+		ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey.(*rsa.PublicKey), payload, nil)
+		require.NoError(t, err)
+		legacy := base64.StdEncoding.EncodeToString(ciphertext)
+
+		// Older rows kept the ciphertext in Email next to the plaintext phone
+		phone := "+15551234567"
+		stored := &domain.EncryptedContactInfo{Email: &legacy, Phone: &phone, PreferredMethod: "email"}
+		decrypted, err := encryption.DecryptContactInfo(stored)
+		require.NoError(t, err)
+		require.Equal(t, email, *decrypted.Email)
+
+		stored.UpgradeLegacyLayout()
+		require.Equal(t, legacy, *stored.Ciphertext)
+		require.Nil(t, stored.Email)
+		require.Nil(t, stored.Phone)
+
+		decrypted, err = encryption.DecryptContactInfo(stored)
+		require.NoError(t, err)
+		require.Equal(t, email, *decrypted.Email)
+	})
+
+	t.Run("should decrypt contact info encrypted before a key rotation", func(t *testing.T) {
+		before := encryption.GetActiveKeyFingerprint()
+		encrypted, err := encryption.EncryptContactInfo(domain.ContactInfo{Email: &email, PreferredMethod: "email"})
+		require.NoError(t, err)
+		require.Equal(t, before, encrypted.KeyFingerprint)
+
+		require.NoError(t, encryption.RotateKeys())
+		require.NotEqual(t, before, encryption.GetActiveKeyFingerprint())
+
+		decrypted, err := encryption.DecryptContactInfo(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, email, *decrypted.Email)
+
+		encrypted.KeyFingerprint = "unknown-key"
+		_, err = encryption.DecryptContactInfo(encrypted)
+		require.Error(t, err)
+	})
+}
+
+// memoryKeyRepository keeps encryption keys in memory
+type memoryKeyRepository struct {
+	keys []*domain.EncryptionKey
+}
+
+func (r *memoryKeyRepository) SaveKey(key *domain.EncryptionKey) error {
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *memoryKeyRepository) GetActiveKey() (*domain.EncryptionKey, error) {
+	for _, key := range r.keys {
+		if key.IsActive {
+			return key, nil
+		}
+	}
+	return nil, domain.ErrNoActiveKey
+}
+
+func (r *memoryKeyRepository) GetKeyByFingerprint(fingerprint string) (*domain.EncryptionKey, error) {
+	for _, key := range r.keys {
+		if key.Fingerprint == fingerprint {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("key %s not found", fingerprint)
+}
+
+func (r *memoryKeyRepository) ListKeys() ([]*domain.EncryptionKey, error) {
+	return r.keys, nil
+}
+
+func (r *memoryKeyRepository) MarkKeyInactive(fingerprint string) error {
+	for _, key := range r.keys {
+		if key.Fingerprint == fingerprint {
+			key.IsActive = false
+		}
+	}
+	return nil
+}
+
+func (r *memoryKeyRepository) RetireKey(fingerprint string) error {
+	for _, key := range r.keys {
+		if key.Fingerprint == fingerprint && !key.IsActive && key.RetiredAt == nil {
+			retiredAt := time.Now()
+			key.RetiredAt = &retiredAt
+			return nil
+		}
+	}
+	return fmt.Errorf("no inactive, unretired key found with fingerprint %s", fingerprint)
+}
+
+func (r *memoryKeyRepository) SetActiveKey(fingerprint string) error {
+	for _, key := range r.keys {
+		key.IsActive = key.Fingerprint == fingerprint
+	}
+	return nil
+}
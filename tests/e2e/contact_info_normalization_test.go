@@ -0,0 +1,170 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactInfoNormalization(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should lowercase and trim emails", func(t *testing.T) {
+		email, err := domain.NormalizeEmail("  Jane.Doe+Keys@Example.COM ")
+		require.NoError(t, err)
+		require.Equal(t, "jane.doe+keys@example.com", email)
+
+		for _, invalid := range []string{"jane", "jane@example", "jane doe@example.com", "@example.com"} {
+			_, err := domain.NormalizeEmail(invalid)
+			require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidContact), invalid)
+		}
+	})
+
+	t.Run("should format phones as E.164", func(t *testing.T) {
+		for input, expected := range map[string]string{
+			"+1 (415) 555-0123": "+14155550123",
+			"0044 20 7946 0958": "+442079460958",
+			" +49.30.1234567 ":  "+49301234567",
+		} {
+			phone, err := domain.NormalizePhone(input)
+			require.NoError(t, err, input)
+			require.Equal(t, expected, phone)
+		}
+
+		for _, invalid := range []string{"415-555-0123", "+0 123 456", "+1 415 555 0123 4567 89", "call me"} {
+			_, err := domain.NormalizePhone(invalid)
+			require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidContact), invalid)
+		}
+	})
+
+	t.Run("should drop blank details and keep the rest", func(t *testing.T) {
+		email := "owner@example.com"
+		blank := "  "
+		message := "Ask for the front desk"
+		info, err := domain.NormalizeContactInfo(domain.ContactInfo{Email: &email, Phone: &blank, Message: &message, PreferredMethod: "email"})
+		require.NoError(t, err)
+		require.Equal(t, &email, info.Email)
+		require.Nil(t, info.Phone)
+		require.Equal(t, &message, info.Message)
+	})
+
+	t.Run("should require the preferred method's contact detail", func(t *testing.T) {
+		email := "owner@example.com"
+		phone := "+14155550123"
+		blank := " "
+
+		_, err := domain.NormalizeContactInfo(domain.ContactInfo{Email: &email, PreferredMethod: domain.ContactMethodPhone})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidContact))
+		require.Equal(t, "phone", err.(domain.PostError).Details["field"])
+
+		_, err = domain.NormalizeContactInfo(domain.ContactInfo{Phone: &phone, Email: &blank, PreferredMethod: domain.ContactMethodEmail})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidContact))
+		require.Equal(t, "email", err.(domain.PostError).Details["field"])
+
+		_, err = domain.NormalizeContactInfo(domain.ContactInfo{Email: &email, PreferredMethod: "carrier_pigeon"})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidContact))
+		require.Equal(t, "preferred_method", err.(domain.PostError).Details["field"])
+
+		_, err = domain.NormalizeContactInfo(domain.ContactInfo{Email: &blank, PreferredMethod: domain.ContactMethodEmail})
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorNoContactChannel))
+
+		_, err = domain.NormalizeContactInfo(domain.ContactInfo{Email: &email, Phone: &phone, PreferredMethod: domain.ContactMethodPhone})
+		require.NoError(t, err)
+	})
+
+	t.Run("should encrypt normalized contact info on approval and reject invalid details", func(t *testing.T) {
+		owner := domain.NewUserID()
+		post := domain.ReconstructPost(domain.NewPostID(), "Found keys", "Three keys on a red ring",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, owner, nil, time.Now(), time.Now(), nil)
+		newRequest := func() *domain.ContactExchangeRequest {
+			return domain.ReconstructContactExchangeRequest(
+				domain.NewContactExchangeRequestID(), post.ID(), domain.NewUserID(), owner,
+				domain.ContactExchangeStatusPending, nil, false, nil, nil, nil, nil, nil,
+				time.Now().Add(time.Hour), time.Now(), time.Now(),
+			)
+		}
+		contacts := &singleContactExchangeRepository{request: newRequest()}
+		encryption := newFakeEncryptionService()
+		contactService := service.NewContactExchangeService(contacts, &singlePostRepository{post: post}, repository.NewMockUserContextRepository(),
+			&recordingEventPublisher{}, nil, encryption, &discardAuditLogger{}, config.ContactExchangeConfig{})
+		approve := func(email, phone string) (*domain.ContactExchangeRequest, error) {
+			return contactService.ApproveContactExchange(ctx, service.ApproveContactExchangeCommand{
+				RequestID:    contacts.request.ID(),
+				ApprovalType: domain.ContactExchangeApprovalTypeFull,
+				ContactInfo:  &domain.ContactInfo{Email: &email, Phone: &phone, PreferredMethod: "phone"},
+			})
+		}
+
+		_, err := approve("owner@example.com", "555 0123")
+		require.True(t, domain.IsPostErrorCode(err, domain.ContactExchangeErrorInvalidContact))
+		require.Equal(t, domain.ContactExchangeStatusPending, contacts.request.Status())
+
+		approved, err := approve(" Owner@Example.com", "+1 415-555-0123")
+		require.NoError(t, err)
+
+		decrypted, err := encryption.DecryptContactInfo(approved.EncryptedContactInfo())
+		require.NoError(t, err)
+		require.Equal(t, "owner@example.com", *decrypted.Email)
+		require.Equal(t, "+14155550123", *decrypted.Phone)
+	})
+
+	t.Run("should answer approvals without usable contact info with 422 before approving", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		owner := domain.NewUserID()
+		request := domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), domain.NewPostID(), domain.NewUserID(), owner,
+			domain.ContactExchangeStatusPending, nil, false, nil, nil, nil, nil, nil,
+			time.Now().Add(time.Hour), time.Now(), time.Now(),
+		)
+		contactService := service.NewContactExchangeService(&singleContactExchangeRepository{request: request}, nil,
+			repository.NewMockUserContextRepository(), nil, nil, nil, &discardAuditLogger{}, config.ContactExchangeConfig{})
+		router := gin.New()
+		handler.NewContactExchangeHandler(contactService).RegisterRoutes(router.Group("", handler.AuthMiddleware(nil, true)))
+		approve := func(body string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/contacts/exchange/"+request.ID().String()+"/approve", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(handler.DevUserIDHeader, owner.String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := approve(`{"approval_type": "full_contact", "contact_info": {"email": "owner@example.com", "preferred_method": "phone"}}`)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Contains(t, w.Body.String(), "Contact phone is required when it is the preferred method")
+
+		w = approve(`{"approval_type": "full_contact"}`)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Contains(t, w.Body.String(), string(domain.ContactExchangeErrorNoContactChannel))
+
+		require.Equal(t, domain.ContactExchangeStatusPending, request.Status())
+	})
+}
+
+// singleContactExchangeRepository knows about exactly one request and accepts updates to it
+type singleContactExchangeRepository struct {
+	domain.ContactExchangeRepository
+	request *domain.ContactExchangeRequest
+}
+
+func (r *singleContactExchangeRepository) FindByID(ctx context.Context, id domain.ContactExchangeRequestID) (*domain.ContactExchangeRequest, error) {
+	if id != r.request.ID() {
+		return nil, domain.ErrContactExchangeNotFound(id)
+	}
+	return r.request, nil
+}
+
+func (r *singleContactExchangeRepository) Update(ctx context.Context, request *domain.ContactExchangeRequest) error {
+	return nil
+}
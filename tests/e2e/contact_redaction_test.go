@@ -0,0 +1,45 @@
+package e2e
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactInfoRedaction(t *testing.T) {
+	email := "owner@example.com"
+	phone := "+1-555-0100"
+	message := "call after 6pm"
+
+	contact := domain.ContactInfo{Email: &email, Phone: &phone, Message: &message, PreferredMethod: "email"}
+	encrypted := domain.EncryptedContactInfo{Email: &email, Phone: &phone, PreferredMethod: "phone"}
+
+	t.Run("should redact formatted output", func(t *testing.T) {
+		for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+			for _, value := range []interface{}{contact, &contact, encrypted, &encrypted} {
+				out := fmt.Sprintf(format, value)
+				require.NotContains(t, out, email, format)
+				require.NotContains(t, out, phone, format)
+				require.NotContains(t, out, message, format)
+				require.Contains(t, out, "[REDACTED]", format)
+			}
+		}
+
+		require.Contains(t, fmt.Sprint(encrypted), "message:<none>")
+	})
+
+	t.Run("should redact structured logs", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		logger.Info("contact exchange approved", "contact", contact, "encrypted", &encrypted)
+
+		require.NotContains(t, buf.String(), email)
+		require.NotContains(t, buf.String(), phone)
+		require.NotContains(t, buf.String(), message)
+		require.Contains(t, buf.String(), `"preferred_method":"email"`)
+	})
+}
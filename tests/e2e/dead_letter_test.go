@@ -0,0 +1,90 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDeadLettering(t *testing.T) {
+	ctx := context.Background()
+	post := domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil,
+		time.Now(), time.Now(), nil)
+	translatable := func() *domain.PostEvent {
+		return domain.NewPostEvent(domain.EventTypePostCreated, post.ID(), post.CreatedBy(), nil,
+			&domain.PostCreatedEventData{Post: post.ToPostData()})
+	}
+
+	deadLetterOf := func(t *testing.T, message recordedKafkaMessage) anti_corruption.DeadLetterEvent {
+		require.Equal(t, anti_corruption.DeadLetterTopic, message.topic)
+		require.Equal(t, post.ID().String(), message.key)
+		var deadLetter anti_corruption.DeadLetterEvent
+		require.NoError(t, json.Unmarshal(message.value, &deadLetter))
+		return deadLetter
+	}
+
+	t.Run("should publish translated events", func(t *testing.T) {
+		kafka := &recordingKafkaPublisher{}
+		publisher := anti_corruption.NewAntiCorruptionEventPublisher(anti_corruption.NewOutboundEventTranslator(), kafka)
+
+		require.NoError(t, publisher.PublishEvent(ctx, translatable()))
+		require.Equal(t, []string{"posts.events"}, kafka.topics())
+		require.Equal(t, anti_corruption.PublisherStats{Published: 1}, publisher.Stats())
+	})
+
+	t.Run("should dead-letter events that cannot be translated", func(t *testing.T) {
+		kafka := &recordingKafkaPublisher{}
+		publisher := anti_corruption.NewAntiCorruptionEventPublisher(anti_corruption.NewOutboundEventTranslator(), kafka)
+		event := domain.NewPostEvent(domain.EventTypePostResolved, post.ID(), post.CreatedBy(), nil,
+			&domain.PostCreatedEventData{Post: post.ToPostData()})
+
+		err := publisher.PublishEvent(ctx, event)
+		require.ErrorIs(t, err, anti_corruption.ErrEventDeadLettered)
+		require.Len(t, kafka.messages, 1)
+
+		deadLetter := deadLetterOf(t, kafka.messages[0])
+		require.Equal(t, anti_corruption.DeadLetterStageTranslation, deadLetter.Stage)
+		require.Equal(t, event.ID.String(), deadLetter.EventID)
+		require.Equal(t, string(domain.EventTypePostResolved), deadLetter.EventType)
+		require.NotEmpty(t, deadLetter.Error)
+		require.NotEmpty(t, deadLetter.Payload, "the raw event is kept for replay")
+		require.Equal(t, anti_corruption.PublisherStats{TranslationFailures: 1, DeadLettered: 1}, publisher.Stats())
+	})
+
+	t.Run("should dead-letter events that cannot be serialized", func(t *testing.T) {
+		kafka := &recordingKafkaPublisher{}
+		publisher := anti_corruption.NewAntiCorruptionEventPublisher(unserializableTranslator{anti_corruption.NewOutboundEventTranslator()}, kafka)
+
+		err := publisher.PublishEvent(ctx, translatable())
+		require.ErrorIs(t, err, anti_corruption.ErrEventDeadLettered)
+		require.Len(t, kafka.messages, 1)
+		require.Equal(t, anti_corruption.DeadLetterStageSerialization, deadLetterOf(t, kafka.messages[0]).Stage)
+		require.Equal(t, anti_corruption.PublisherStats{SerializationFailures: 1, DeadLettered: 1}, publisher.Stats())
+	})
+
+	t.Run("should report events that could not be dead-lettered for retry", func(t *testing.T) {
+		kafka := &recordingKafkaPublisher{err: errors.New("broker unavailable")}
+		publisher := anti_corruption.NewAntiCorruptionEventPublisher(unserializableTranslator{anti_corruption.NewOutboundEventTranslator()}, kafka)
+
+		err := publisher.PublishEvent(ctx, translatable())
+		require.Error(t, err)
+		require.NotErrorIs(t, err, anti_corruption.ErrEventDeadLettered)
+		require.Equal(t, anti_corruption.PublisherStats{SerializationFailures: 1, DeadLetterFailures: 1}, publisher.Stats())
+	})
+}
+
+// unserializableTranslator translates events but fails to serialize them
+type unserializableTranslator struct {
+	*anti_corruption.OutboundEventTranslator
+}
+
+func (unserializableTranslator) ToJSON(event *anti_corruption.KafkaEvent) ([]byte, error) {
+	return nil, errors.New("unsupported value")
+}
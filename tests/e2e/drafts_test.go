@@ -0,0 +1,158 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostDrafts(t *testing.T) {
+	createDraft := func(t *testing.T) PostResponse {
+		fields := map[string]string{
+			"title":         "Draft " + uuid.New().String()[:8],
+			"type":          "lost",
+			"latitude":      fmt.Sprintf("%f", TestLocations.CentralPark.Latitude),
+			"longitude":     fmt.Sprintf("%f", TestLocations.CentralPark.Longitude),
+			"radius_meters": "1000",
+			"draft":         "true",
+		}
+
+		resp := makeMultipartRequest(t, "/posts", fields, map[string]string{})
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var post PostResponse
+		parseResponse(t, resp, &post)
+		return post
+	}
+
+	t.Run("should save a draft without photos", func(t *testing.T) {
+		draft := createDraft(t)
+		defer CleanupPost(t, draft.ID)
+
+		require.Equal(t, "draft", draft.Status)
+		require.Empty(t, draft.Photos)
+
+		// The author can still read it
+		resp := makeRequest(t, "GET", fmt.Sprintf("/posts/%s", draft.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should hide drafts from other users", func(t *testing.T) {
+		draft := createDraft(t)
+		defer CleanupPost(t, draft.ID)
+
+		req, err := http.NewRequest("GET", fmt.Sprintf("%s/posts/%s", BaseURL, draft.ID), nil)
+		require.NoError(t, err)
+		req.Header.Set("X-User-ID", uuid.New().String())
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		// Drafts never show up in nearby search
+		endpoint := fmt.Sprintf("/posts/nearby?lat=%f&lng=%f&radius=1000",
+			TestLocations.CentralPark.Latitude,
+			TestLocations.CentralPark.Longitude)
+		resp = makeRequest(t, "GET", endpoint, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var searchResp struct {
+			Posts []PostResponse `json:"posts"`
+		}
+		parseResponse(t, resp, &searchResp)
+		for _, post := range searchResp.Posts {
+			require.NotEqual(t, draft.ID, post.ID)
+		}
+	})
+
+	t.Run("should publish a complete draft", func(t *testing.T) {
+		draft := createDraft(t)
+		defer CleanupPost(t, draft.ID)
+
+		// Publishing without photos is rejected
+		resp := makeRequest(t, "POST", fmt.Sprintf("/posts/%s/publish", draft.ID), nil)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		resp.Body.Close()
+
+		UploadTestPhoto(t, draft.ID)
+
+		resp = makeRequest(t, "POST", fmt.Sprintf("/posts/%s/publish", draft.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var published PostResponse
+		parseResponse(t, resp, &published)
+		require.Equal(t, "active", published.Status)
+		require.Len(t, published.Photos, 1)
+
+		// Publishing twice is not allowed
+		resp = makeRequest(t, "POST", fmt.Sprintf("/posts/%s/publish", draft.ID), nil)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
+
+func TestCreatePostPhotoCount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newProductionRouter(service.NewPostService(nil, nil, nil, nil, nil, nil, nil, config.FeatureConfig{}), nil)
+
+	// create posts a lost item at Central Park with the given number of photos
+	create := func(t *testing.T, draft bool, photos int) (int, map[string]string) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		fields := map[string]string{
+			"title":         "Lost keys",
+			"type":          "lost",
+			"latitude":      fmt.Sprintf("%f", TestLocations.CentralPark.Latitude),
+			"longitude":     fmt.Sprintf("%f", TestLocations.CentralPark.Longitude),
+			"radius_meters": "1000",
+			"draft":         fmt.Sprintf("%t", draft),
+		}
+		for name, value := range fields {
+			require.NoError(t, form.WriteField(name, value))
+		}
+		for i := 0; i < photos; i++ {
+			file, err := form.CreateFormFile("photos", fmt.Sprintf("keys_%d.jpg", i))
+			require.NoError(t, err)
+			_, err = file.Write([]byte("jpeg"))
+			require.NoError(t, err)
+		}
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/posts", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set(handler.DevUserIDHeader, domain.NewUserID().String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return w.Code, response
+	}
+
+	t.Run("should require a photo for published posts", func(t *testing.T) {
+		code, response := create(t, false, 0)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, fmt.Sprintf("Must upload between 1 and %d photos", domain.MaxPhotosPerPost), response["error"])
+	})
+
+	t.Run("should name the range drafts allow", func(t *testing.T) {
+		code, response := create(t, true, domain.MaxPhotosPerPost+1)
+		require.Equal(t, http.StatusBadRequest, code)
+		require.Equal(t, fmt.Sprintf("Must upload between 0 and %d photos", domain.MaxPhotosPerPost), response["error"])
+	})
+}
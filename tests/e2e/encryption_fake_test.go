@@ -0,0 +1,186 @@
+package e2e
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeEncryptionService(t *testing.T) {
+	encryptionService := newFakeEncryptionService()
+	contactInfo := domain.ContactInfo{
+		Email:           &[]string{"fake@example.com"}[0],
+		Phone:           &[]string{"+1234567890"}[0],
+		PreferredMethod: "email",
+	}
+
+	t.Run("should round-trip contact information", func(t *testing.T) {
+		encryptedInfo, err := encryptionService.EncryptContactInfo(contactInfo)
+		require.NoError(t, err)
+		assert.Nil(t, encryptedInfo.Email)
+		assert.Nil(t, encryptedInfo.Phone)
+
+		again, err := encryptionService.EncryptContactInfo(contactInfo)
+		require.NoError(t, err)
+		assert.Equal(t, *encryptedInfo.Ciphertext, *again.Ciphertext, "encryption is deterministic")
+
+		decryptedInfo, err := encryptionService.DecryptContactInfo(encryptedInfo)
+		require.NoError(t, err)
+		assert.Equal(t, *contactInfo.Email, *decryptedInfo.Email)
+		assert.Equal(t, *contactInfo.Phone, *decryptedInfo.Phone)
+	})
+
+	t.Run("should reject expired and tampered tokens", func(t *testing.T) {
+		token, err := encryptionService.GenerateContactToken(contactInfo, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		decryptedInfo, err := encryptionService.ValidateContactToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, *contactInfo.Email, *decryptedInfo.Email)
+
+		tampered := *token
+		tampered.IntegrityHash = "corrupted_hash"
+		_, err = encryptionService.ValidateContactToken(&tampered)
+		assert.ErrorContains(t, err, "integrity")
+
+		expired, err := encryptionService.GenerateContactToken(contactInfo, time.Now().Add(-time.Second))
+		require.NoError(t, err)
+		_, err = encryptionService.ValidateContactToken(expired)
+		assert.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("should still decrypt data from before a key rotation", func(t *testing.T) {
+		encryptedInfo, err := encryptionService.EncryptContactInfo(contactInfo)
+		require.NoError(t, err)
+		before := encryptionService.GetActiveKeyFingerprint()
+
+		require.NoError(t, encryptionService.RotateKeys())
+		assert.NotEqual(t, before, encryptionService.GetActiveKeyFingerprint())
+
+		decryptedInfo, err := encryptionService.DecryptContactInfo(encryptedInfo)
+		require.NoError(t, err)
+		assert.Equal(t, *contactInfo.Email, *decryptedInfo.Email)
+	})
+}
+
+// fakeEncryptionService stands in for the RSA-4096 service in tests that only need contact
+// information to round-trip. Ciphertext is the key fingerprint and the base64 encoded JSON, so
+// results are deterministic and no key is ever generated. It must never be used outside tests.
+type fakeEncryptionService struct {
+	generation int
+	keys       map[string]bool
+}
+
+func newFakeEncryptionService() *fakeEncryptionService {
+	s := &fakeEncryptionService{keys: map[string]bool{}}
+	s.rotate()
+	return s
+}
+
+func (s *fakeEncryptionService) EncryptContactInfo(contactInfo domain.ContactInfo) (*domain.EncryptedContactInfo, error) {
+	sealed, err := s.seal(contactInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt contact info: %w", err)
+	}
+
+	return &domain.EncryptedContactInfo{
+		PreferredMethod:     contactInfo.PreferredMethod,
+		SharingRestrictions: contactInfo.Restrictions,
+		Ciphertext:          &sealed,
+	}, nil
+}
+
+func (s *fakeEncryptionService) DecryptContactInfo(encryptedInfo *domain.EncryptedContactInfo) (*domain.ContactInfo, error) {
+	if encryptedInfo.Ciphertext == nil {
+		return nil, fmt.Errorf("no encrypted data found")
+	}
+
+	var contactInfo domain.ContactInfo
+	if err := s.open(*encryptedInfo.Ciphertext, &contactInfo); err != nil {
+		return nil, fmt.Errorf("failed to decrypt contact info: %w", err)
+	}
+	return &contactInfo, nil
+}
+
+func (s *fakeEncryptionService) GenerateContactToken(contactInfo domain.ContactInfo, expiresAt time.Time) (*domain.ContactToken, error) {
+	sealed, err := s.seal(contactInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt token payload: %w", err)
+	}
+
+	return &domain.ContactToken{
+		Token:          sealed,
+		KeyFingerprint: s.GetActiveKeyFingerprint(),
+		ExpiresAt:      expiresAt,
+		CreatedAt:      time.Now(),
+		IntegrityHash:  fakeIntegrityHash(sealed),
+	}, nil
+}
+
+func (s *fakeEncryptionService) ValidateContactToken(token *domain.ContactToken) (*domain.ContactInfo, error) {
+	if time.Now().After(token.ExpiresAt) {
+		return nil, fmt.Errorf("contact token has expired")
+	}
+	if fakeIntegrityHash(token.Token) != token.IntegrityHash {
+		return nil, fmt.Errorf("token integrity check failed")
+	}
+
+	var contactInfo domain.ContactInfo
+	if err := s.open(token.Token, &contactInfo); err != nil {
+		return nil, fmt.Errorf("failed to decrypt token: %w", err)
+	}
+	return &contactInfo, nil
+}
+
+func (s *fakeEncryptionService) RotateKeys() error {
+	s.rotate()
+	return nil
+}
+
+func (s *fakeEncryptionService) GetActiveKeyFingerprint() string {
+	return fmt.Sprintf("fake-key-%d", s.generation)
+}
+
+func (s *fakeEncryptionService) RetireUnusedKeys(inUse []string) ([]string, error) {
+	return nil, nil
+}
+
+func (s *fakeEncryptionService) rotate() {
+	s.generation++
+	s.keys[s.GetActiveKeyFingerprint()] = true
+}
+
+func (s *fakeEncryptionService) seal(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return s.GetActiveKeyFingerprint() + "." + base64.StdEncoding.EncodeToString(data), nil
+}
+
+func (s *fakeEncryptionService) open(sealed string, target interface{}) error {
+	fingerprint, encoded, ok := strings.Cut(sealed, ".")
+	if !ok || !s.keys[fingerprint] {
+		return fmt.Errorf("unknown encryption key")
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func fakeIntegrityHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+var _ domain.EncryptionService = (*fakeEncryptionService)(nil)
@@ -0,0 +1,82 @@
+package e2e
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptionServiceInitialization(t *testing.T) {
+	retry := domain.KeyLoadRetry{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	errConnRefused := errors.New("dial tcp 127.0.0.1:5432: connect: connection refused")
+
+	t.Run("should wait for a key store that comes up during startup", func(t *testing.T) {
+		keys := &flakyKeyRepository{
+			failures: 2,
+			err:      errConnRefused,
+			active:   &domain.EncryptionKey{Fingerprint: "existing", IsActive: true},
+		}
+
+		encryptionService, err := domain.NewRSAEncryptionServiceWithRetry(keys, discardAuditLogger{}, retry)
+		require.NoError(t, err)
+		require.Equal(t, "existing", encryptionService.GetActiveKeyFingerprint())
+		require.Equal(t, 3, keys.loads)
+	})
+
+	t.Run("should give up on an unreachable key store with a typed error", func(t *testing.T) {
+		keys := &flakyKeyRepository{failures: 10, err: errConnRefused}
+
+		_, err := domain.NewRSAEncryptionServiceWithRetry(keys, discardAuditLogger{}, retry)
+
+		var initErr *domain.EncryptionInitError
+		require.ErrorAs(t, err, &initErr)
+		require.Equal(t, domain.EncryptionInitKeyStoreUnavailable, initErr.Failure)
+		require.Equal(t, 3, initErr.Attempts)
+		require.ErrorIs(t, err, errConnRefused)
+		require.Equal(t, 3, keys.loads)
+	})
+
+	t.Run("should not retry when there is no key and one cannot be saved", func(t *testing.T) {
+		keys := &flakyKeyRepository{saveErr: errors.New("permission denied for table encryption_keys")}
+
+		_, err := domain.NewRSAEncryptionServiceWithRetry(keys, discardAuditLogger{}, retry)
+
+		var initErr *domain.EncryptionInitError
+		require.ErrorAs(t, err, &initErr)
+		require.Equal(t, domain.EncryptionInitKeyGenerationFailed, initErr.Failure)
+		require.False(t, initErr.Retryable())
+		require.Equal(t, 1, keys.loads)
+	})
+}
+
+// flakyKeyRepository fails to load the active key a set number of times before answering
+type flakyKeyRepository struct {
+	domain.KeyRepository
+	failures int
+	err      error
+	saveErr  error
+	active   *domain.EncryptionKey
+	loads    int
+}
+
+func (r *flakyKeyRepository) GetActiveKey() (*domain.EncryptionKey, error) {
+	r.loads++
+	if r.loads <= r.failures {
+		return nil, r.err
+	}
+	if r.active == nil {
+		return nil, domain.ErrNoActiveKey
+	}
+	return r.active, nil
+}
+
+func (r *flakyKeyRepository) SaveKey(key *domain.EncryptionKey) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+	r.active = key
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jsarabia/fn-posts/internal/config"
 	"github.com/jsarabia/fn-posts/internal/domain"
 	"github.com/jsarabia/fn-posts/internal/repository"
 	"github.com/jsarabia/fn-posts/internal/service"
@@ -45,9 +46,9 @@ func TestRSAEncryptionService_E2E(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, encryptedInfo)
 
-		// Verify that the data is encrypted (email field should contain encrypted data)
-		assert.NotNil(t, encryptedInfo.Email)
-		assert.NotEqual(t, *originalContactInfo.Email, *encryptedInfo.Email)
+		// Verify that the data is encrypted and no plaintext email is kept
+		assert.NotNil(t, encryptedInfo.Ciphertext)
+		assert.Nil(t, encryptedInfo.Email)
 
 		// Decrypt contact information
 		decryptedInfo, err := encryptionService.DecryptContactInfo(encryptedInfo)
@@ -172,11 +173,10 @@ func TestContactExchangeService_EncryptionIntegration_E2E(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
 
-	// Setup all repositories and services
-	keyRepo := repository.NewPostgresKeyRepository(db)
+	// Setup all repositories and services. The workflow only needs contact information to
+	// round-trip, so the fake service spares it the RSA-4096 key generation.
 	auditLogger := repository.NewPostgresEncryptionAuditLogger(db)
-	encryptionService, err := domain.NewRSAEncryptionService(keyRepo, auditLogger)
-	require.NoError(t, err)
+	encryptionService := newFakeEncryptionService()
 
 	// Create mock repositories for other dependencies
 	contactExchangeRepo := &mockContactExchangeRepository{requests: make(map[string]*domain.ContactExchangeRequest)}
@@ -190,8 +190,10 @@ func TestContactExchangeService_EncryptionIntegration_E2E(t *testing.T) {
 		postRepo,
 		userContextRepo,
 		eventPublisher,
+		nil,
 		encryptionService,
 		auditLogger,
+		config.ContactExchangeConfig{},
 	)
 
 	t.Run("Complete Contact Exchange Workflow with Encryption", func(t *testing.T) {
@@ -253,7 +255,8 @@ func TestContactExchangeService_EncryptionIntegration_E2E(t *testing.T) {
 		encryptedInfo := approvedRequest.EncryptedContactInfo()
 		require.NotNil(t, encryptedInfo)
 		// The contact info should be encrypted (not plaintext)
-		assert.NotEqual(t, *contactInfo.Email, *encryptedInfo.Email)
+		assert.Nil(t, encryptedInfo.Email)
+		assert.NotContains(t, *encryptedInfo.Ciphertext, *contactInfo.Email)
 
 		// Step 3: Decrypt contact information (requester access)
 		decryptedInfo, err := contactService.DecryptContactInfo(ctx, request.ID(), requesterUserID)
@@ -267,7 +270,12 @@ func TestContactExchangeService_EncryptionIntegration_E2E(t *testing.T) {
 		assert.Equal(t, *contactInfo.Message, *decryptedInfo.Message)
 
 		// Step 4: Verify audit logs were created
-		auditLogs, err := auditLogger.GetAuditTrail(ownerUserID, &request.ID(), 10)
+		requestID := request.ID()
+		auditLogs, err := auditLogger.GetAuditTrail(domain.EncryptionAuditFilters{
+			UserID:    &ownerUserID,
+			RequestID: &requestID,
+			Limit:     10,
+		})
 		require.NoError(t, err)
 		assert.Greater(t, len(auditLogs), 0)
 
@@ -380,6 +388,42 @@ func (m *mockContactExchangeRepository) FindExpired(ctx context.Context, limit i
 	return nil, nil
 }
 
+func (m *mockContactExchangeRepository) FindPurgeable(ctx context.Context, limit int) ([]*domain.ContactExchangeRequest, error) {
+	return nil, nil
+}
+
+func (m *mockContactExchangeRepository) FindExpiringForUser(ctx context.Context, userID domain.UserID, within time.Duration) ([]*domain.ContactExchangeRequest, error) {
+	return nil, nil
+}
+
+func (m *mockContactExchangeRepository) FindPendingByRequester(ctx context.Context, postID domain.PostID, requesterID domain.UserID) (*domain.ContactExchangeRequest, error) {
+	return nil, nil
+}
+
+func (m *mockContactExchangeRepository) CountByRequester(ctx context.Context, requesterID domain.UserID, postID domain.PostID, since time.Time) (domain.RequesterRequestCounts, error) {
+	return domain.RequesterRequestCounts{}, nil
+}
+
+func (m *mockContactExchangeRepository) FindEncryptedWithOtherKey(ctx context.Context, keyFingerprint string, limit int) ([]*domain.ContactExchangeRequest, error) {
+	return nil, nil
+}
+
+func (m *mockContactExchangeRepository) FindKeyFingerprintsInUse(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockContactExchangeRepository) FindStatusesForUser(ctx context.Context, ids []domain.ContactExchangeRequestID, userID domain.UserID) (map[domain.ContactExchangeRequestID]domain.ContactExchangeStatus, error) {
+	return map[domain.ContactExchangeRequestID]domain.ContactExchangeStatus{}, nil
+}
+
+func (m *mockContactExchangeRepository) FindDueForExpiryReminder(ctx context.Context, within time.Duration, limit int) ([]*domain.ContactExchangeRequest, error) {
+	return nil, nil
+}
+
+func (m *mockContactExchangeRepository) MarkExpiryReminderSent(ctx context.Context, id domain.ContactExchangeRequestID, sentAt time.Time) error {
+	return nil
+}
+
 func (m *mockContactExchangeRepository) Delete(ctx context.Context, id domain.ContactExchangeRequestID) error {
 	delete(m.requests, id.String())
 	return nil
@@ -402,6 +446,13 @@ func (m *mockPostRepository) Save(ctx context.Context, post *domain.Post) error
 	return nil
 }
 
+func (m *mockPostRepository) SaveBatch(ctx context.Context, posts []*domain.Post) error {
+	for _, post := range posts {
+		m.posts[post.ID().String()] = post
+	}
+	return nil
+}
+
 func (m *mockPostRepository) FindByID(ctx context.Context, id domain.PostID) (*domain.Post, error) {
 	if post, exists := m.posts[id.String()]; exists {
 		return post, nil
@@ -409,11 +460,19 @@ func (m *mockPostRepository) FindByID(ctx context.Context, id domain.PostID) (*d
 	return nil, domain.NewPostError(domain.BusinessErrorPostNotFound, "not found")
 }
 
-func (m *mockPostRepository) FindByUserID(ctx context.Context, userID domain.UserID, limit, offset int) ([]*domain.Post, error) {
+func (m *mockPostRepository) FindByUserID(ctx context.Context, userID domain.UserID, includeDrafts bool, limit, offset, maxPhotos int) ([]*domain.Post, error) {
+	return nil, nil
+}
+
+func (m *mockPostRepository) CountByUserID(ctx context.Context, userID domain.UserID, includeDrafts bool) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockPostRepository) FindNearby(ctx context.Context, location domain.Location, radius domain.Distance, postType *domain.PostType, respectPostRadius bool, maxCandidates, limit, offset, maxPhotos int) ([]*domain.Post, error) {
 	return nil, nil
 }
 
-func (m *mockPostRepository) FindNearby(ctx context.Context, location domain.Location, radius domain.Distance, postType *domain.PostType, limit, offset int) ([]*domain.Post, error) {
+func (m *mockPostRepository) FindAlongRoute(ctx context.Context, route domain.Route, corridor domain.Distance, postType *domain.PostType, limit, offset, maxPhotos int) ([]*domain.Post, error) {
 	return nil, nil
 }
 
@@ -422,6 +481,11 @@ func (m *mockPostRepository) Update(ctx context.Context, post *domain.Post) erro
 	return nil
 }
 
+func (m *mockPostRepository) Bump(ctx context.Context, post *domain.Post, minInterval time.Duration) (bool, error) {
+	m.posts[post.ID().String()] = post
+	return true, nil
+}
+
 func (m *mockPostRepository) Delete(ctx context.Context, id domain.PostID) error {
 	delete(m.posts, id.String())
 	return nil
@@ -431,10 +495,38 @@ func (m *mockPostRepository) List(ctx context.Context, filters domain.PostFilter
 	return nil, nil
 }
 
+func (m *mockPostRepository) ListPage(ctx context.Context, filters domain.PostFilters) ([]*domain.Post, bool, error) {
+	return nil, false, nil
+}
+
 func (m *mockPostRepository) Count(ctx context.Context, filters domain.PostFilters) (int64, error) {
 	return 0, nil
 }
 
+func (m *mockPostRepository) Heatmap(ctx context.Context, filters domain.HeatmapFilters) ([]domain.HeatmapCell, error) {
+	return nil, nil
+}
+
+func (m *mockPostRepository) CountDistinctPosters(ctx context.Context, orgID domain.OrganizationID, since time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockPostRepository) SaveResolution(ctx context.Context, postID domain.PostID, resolution *domain.ResolutionData) error {
+	return nil
+}
+
+func (m *mockPostRepository) DeleteResolution(ctx context.Context, postID domain.PostID) error {
+	return nil
+}
+
+func (m *mockPostRepository) FindResolution(ctx context.Context, postID domain.PostID) (*domain.ResolutionData, error) {
+	return nil, domain.ErrResolutionNotFound(postID)
+}
+
+func (m *mockPostRepository) ResolutionStats(ctx context.Context, filter domain.ResolutionStatsFilter) (*domain.ResolutionStats, error) {
+	return domain.NewResolutionStats(nil), nil
+}
+
 type mockUserContextRepository struct {
 	users map[string]*domain.PrivacySafeUser
 }
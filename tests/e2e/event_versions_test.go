@@ -0,0 +1,46 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventVersions(t *testing.T) {
+	t.Run("should stamp events with the configured version per type", func(t *testing.T) {
+		overrides, err := domain.ParseEventVersions([]string{"post.created=2", " post.updated = 3 "})
+		require.NoError(t, err)
+		require.NoError(t, domain.SetEventVersions(overrides))
+		t.Cleanup(func() {
+			require.NoError(t, domain.SetEventVersions(nil))
+		})
+
+		postID := domain.NewPostID()
+		userID := domain.NewUserID()
+
+		created := domain.NewPostEvent(domain.EventTypePostCreated, postID, userID, nil, nil)
+		require.Equal(t, 2, created.EventVersion)
+
+		updated := domain.NewPostEventWithCorrelation(domain.EventTypePostUpdated, postID, userID, nil, nil, "corr-1")
+		require.Equal(t, 3, updated.EventVersion)
+
+		// Types without an override keep the default
+		deleted := domain.NewPostEvent(domain.EventTypePostDeleted, postID, userID, nil, nil)
+		require.Equal(t, domain.DefaultEventVersion, deleted.EventVersion)
+	})
+
+	t.Run("should reject invalid overrides", func(t *testing.T) {
+		_, err := domain.ParseEventVersions([]string{"post.created"})
+		require.Error(t, err)
+
+		_, err = domain.ParseEventVersions([]string{"post.created=two"})
+		require.Error(t, err)
+
+		require.Error(t, domain.SetEventVersions(map[domain.EventType]int{"post.unknown": 2}))
+		require.Error(t, domain.SetEventVersions(map[domain.EventType]int{domain.EventTypePostCreated: 0}))
+
+		// A rejected override leaves the registry untouched
+		require.Equal(t, domain.DefaultEventVersion, domain.EventVersionFor(domain.EventTypePostCreated))
+	})
+}
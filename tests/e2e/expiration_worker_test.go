@@ -0,0 +1,85 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpirationWorker(t *testing.T) {
+	post, err := domain.NewDraftPost("Lost wallet", "Brown leather", nil, TestLocations.CentralPark, 1000, domain.PostTypeLost, domain.NewUserID(), nil)
+	require.NoError(t, err)
+
+	newFixture := func() (*domain.ContactExchangeRequest, *recordingEventPublisher, *service.ExpirationWorker) {
+		request := domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), post.ID(), domain.NewUserID(), post.CreatedBy(),
+			domain.ContactExchangeStatusPending, nil, false, nil, nil, nil, nil, nil,
+			time.Now().Add(-time.Minute), time.Now().Add(-73*time.Hour), time.Now().Add(-73*time.Hour),
+		)
+		publisher := &recordingEventPublisher{}
+		cfg := config.ContactExchangeConfig{ExpirationJobIntervalSeconds: 1}
+		contactService := service.NewContactExchangeService(&expiredContactExchangeRepository{requests: []*domain.ContactExchangeRequest{request}},
+			&singlePostRepository{post: post}, repository.NewMockUserContextRepository(), publisher, nil, nil, &discardAuditLogger{}, cfg)
+		return request, publisher, service.NewExpirationWorker(contactService, nil, cfg)
+	}
+
+	t.Run("should expire overdue pending requests and publish the expiration", func(t *testing.T) {
+		request, publisher, worker := newFixture()
+
+		expired, purged := worker.RunOnce(context.Background())
+		require.Equal(t, service.BulkResult{Processed: 1, Succeeded: 1, Batches: 1}, expired)
+		require.Zero(t, purged.Processed, "pending requests hold no contact data to purge")
+		require.Equal(t, domain.ContactExchangeStatusExpired, request.Status())
+		require.Len(t, publisher.events, 1)
+		require.Equal(t, domain.EventTypeContactExchangeExpired, publisher.events[0].EventType)
+		require.Equal(t, request.ID().String(), publisher.events[0].AggregateID)
+
+		expired, _ = worker.RunOnce(context.Background())
+		require.Zero(t, expired.Processed, "expired requests are not expired again")
+		require.Len(t, publisher.events, 1)
+	})
+
+	t.Run("should skip the pass while another instance runs it", func(t *testing.T) {
+		request, publisher, _ := newFixture()
+		contactService := service.NewContactExchangeService(&expiredContactExchangeRepository{requests: []*domain.ContactExchangeRequest{request}},
+			&singlePostRepository{post: post}, repository.NewMockUserContextRepository(), publisher, nil, nil, &discardAuditLogger{}, config.ContactExchangeConfig{})
+		locker := &heldLocker{held: true}
+		worker := service.NewExpirationWorker(contactService, locker, config.ContactExchangeConfig{})
+
+		expired, _ := worker.RunOnce(context.Background())
+		require.Zero(t, expired.Processed)
+		require.Equal(t, domain.ContactExchangeStatusPending, request.Status())
+
+		locker.held = false
+		expired, _ = worker.RunOnce(context.Background())
+		require.Equal(t, 1, expired.Succeeded)
+		require.Equal(t, domain.ContactExchangeStatusExpired, request.Status())
+		require.Equal(t, []string{"contact-exchange-expiration", "contact-exchange-expiration"}, locker.names)
+	})
+
+	t.Run("should stop without running once cancelled", func(t *testing.T) {
+		request, publisher, worker := newFixture()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		done := make(chan struct{})
+		go func() {
+			worker.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("worker kept running after its context was cancelled")
+		}
+		require.Equal(t, domain.ContactExchangeStatusPending, request.Status())
+		require.Empty(t, publisher.events)
+	})
+}
@@ -0,0 +1,90 @@
+package e2e
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDScanning(t *testing.T) {
+	t.Run("should scan and store IDs as uuid columns", func(t *testing.T) {
+		id := uuid.New()
+
+		var postID domain.PostID
+		require.NoError(t, postID.Scan(id.String()))
+		require.Equal(t, id, postID.UUID())
+
+		var photoID domain.PhotoID
+		require.NoError(t, photoID.Scan([]byte(id.String())))
+		require.Equal(t, id, photoID.UUID())
+
+		value, err := domain.UserIDFromUUID(id).Value()
+		require.NoError(t, err)
+		require.Equal(t, id.String(), value)
+
+		var userID domain.UserID
+		require.Error(t, userID.Scan("not-a-uuid"))
+	})
+
+	t.Run("should scan NULL as the zero ID", func(t *testing.T) {
+		orgID := domain.NewOrganizationID()
+		require.NoError(t, orgID.Scan(nil))
+		require.True(t, orgID.IsZero())
+	})
+}
+
+func TestPostRepositoryNullOrganization(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	posts := repository.NewPostgresPostRepository(repository.NewDBRouter(db, nil))
+
+	save := func(t *testing.T, orgID *domain.OrganizationID) *domain.Post {
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost umbrella", "Black, folding",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost,
+			domain.NewUserID(), orgID, time.Now(), time.Now(), nil)
+		require.NoError(t, posts.Save(ctx, post))
+		t.Cleanup(func() {
+			_, _ = db.Exec("DELETE FROM posts WHERE id = $1", post.ID())
+		})
+		return post
+	}
+
+	t.Run("should store and load posts without an organization", func(t *testing.T) {
+		post := save(t, nil)
+
+		var stored sql.NullString
+		require.NoError(t, db.QueryRow("SELECT organization_id FROM posts WHERE id = $1", post.ID()).Scan(&stored))
+		require.False(t, stored.Valid)
+
+		found, err := posts.FindByID(ctx, post.ID())
+		require.NoError(t, err)
+		require.Nil(t, found.OrganizationID())
+		require.Nil(t, found.CoverPhotoID())
+		require.True(t, found.ID().Equals(post.ID()))
+		require.True(t, found.CreatedBy().Equals(post.CreatedBy()))
+	})
+
+	t.Run("should list posts with and without an organization together", func(t *testing.T) {
+		orgID := domain.NewOrganizationID()
+		withOrg := save(t, &orgID)
+		withoutOrg := save(t, nil)
+
+		userIDs := []domain.UserID{withOrg.CreatedBy(), withoutOrg.CreatedBy()}
+		for i, expected := range []*domain.OrganizationID{&orgID, nil} {
+			listed, err := posts.List(ctx, domain.PostFilters{UserID: &userIDs[i]})
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			if expected == nil {
+				require.Nil(t, listed[0].OrganizationID())
+			} else {
+				require.True(t, listed[0].OrganizationID().Equals(*expected))
+			}
+		}
+	})
+}
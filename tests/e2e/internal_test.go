@@ -0,0 +1,53 @@
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalPostEvent(t *testing.T) {
+	t.Run("should return the fat event payload for a post", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		resp := makeRequestWithHeaders(t, "GET", fmt.Sprintf("/internal/posts/%s/event", post.ID), nil,
+			map[string]string{"X-Internal-Token": TestInternalToken})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var eventData map[string]interface{}
+		parseResponse(t, resp, &eventData)
+
+		require.Contains(t, eventData, "post")
+		require.Contains(t, eventData, "user")
+		require.Contains(t, eventData, "ai_analysis")
+		require.Contains(t, eventData, "triggers")
+
+		postData := eventData["post"].(map[string]interface{})
+		require.Equal(t, post.ID, postData["id"])
+		require.Equal(t, post.Title, postData["title"])
+	})
+
+	t.Run("should reject callers without the internal token", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		resp := makeRequest(t, "GET", fmt.Sprintf("/internal/posts/%s/event", post.ID), nil)
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = makeRequestWithHeaders(t, "GET", fmt.Sprintf("/internal/posts/%s/event", post.ID), nil,
+			map[string]string{"X-Internal-Token": "wrong-token"})
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp.Body.Close()
+	})
+
+	t.Run("should return 404 for non-existent post", func(t *testing.T) {
+		resp := makeRequestWithHeaders(t, "GET", "/internal/posts/550e8400-e29b-41d4-a716-446655440404/event", nil,
+			map[string]string{"X-Internal-Token": TestInternalToken})
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
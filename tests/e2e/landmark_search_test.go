@@ -0,0 +1,114 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPostsNearLandmark(t *testing.T) {
+	ctx := context.Background()
+	gin.SetMode(gin.TestMode)
+
+	posts := &locatingNearbyPostRepository{}
+	posts.posts = append(posts.posts, domain.ReconstructPost(domain.NewPostID(), "Lost scarf", "Green wool",
+		TestLocations.TimesSquare, 1000, domain.PostStatusActive, domain.PostTypeLost,
+		domain.NewUserID(), nil, time.Now(), time.Now(), nil))
+	landmarks := &memoryLandmarkRepository{landmarks: []domain.Landmark{
+		{Name: "Central Park", Location: TestLocations.CentralPark},
+		{Name: "Times Square", Location: TestLocations.TimesSquare},
+	}}
+	postService := service.NewPostService(posts, nil, nil, nil, landmarks, nil, nil, config.FeatureConfig{})
+
+	t.Run("should normalize landmark names before matching", func(t *testing.T) {
+		require.Equal(t, "central park", domain.NormalizeLandmarkName("  Central \t PARK "))
+	})
+
+	t.Run("should search around the resolved landmark", func(t *testing.T) {
+		landmark, found, hasMore, err := postService.SearchPostsNearLandmark(ctx, "times  SQUARE", 2000, nil, false, 0, 20, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, "Times Square", landmark.Name)
+		require.Equal(t, TestLocations.TimesSquare, posts.searchedAround)
+		require.Len(t, found, 1)
+		require.False(t, hasMore)
+	})
+
+	t.Run("should report unknown landmarks", func(t *testing.T) {
+		_, _, _, err := postService.SearchPostsNearLandmark(ctx, "Golden Gate", 2000, nil, false, 0, 20, 0, 0)
+		require.True(t, domain.IsPostErrorCode(err, domain.LocationErrorUnknownLandmark))
+	})
+
+	t.Run("should return the resolved center and answer unknown landmarks with 404", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/posts/near-landmark/:name", handler.AuthMiddleware(nil, true),
+			handler.NewPostHandler(postService, nil, &config.Config{Search: config.SearchConfig{DefaultRadiusMeters: 1000, MaxRadiusMeters: 5000}}).SearchPostsNearLandmark)
+		get := func(path string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set(handler.DevUserIDHeader, domain.NewUserID().String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		w := get("/posts/near-landmark/central%20park?radius=10000")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Landmark     handler.LandmarkResponse `json:"landmark"`
+			RadiusMeters int                      `json:"radius_meters"`
+			Count        int                      `json:"count"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, handler.LandmarkResponse{
+			Name:      "Central Park",
+			Latitude:  TestLocations.CentralPark.Latitude,
+			Longitude: TestLocations.CentralPark.Longitude,
+		}, response.Landmark)
+		require.Equal(t, 5000, response.RadiusMeters)
+		require.Equal(t, 1, response.Count)
+
+		w = get("/posts/near-landmark/atlantis")
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Contains(t, w.Body.String(), string(domain.LocationErrorUnknownLandmark))
+	})
+}
+
+// memoryLandmarkRepository resolves names contained in a landmark's normalized name, standing
+// in for the trigram matching done in Postgres
+type memoryLandmarkRepository struct {
+	landmarks []domain.Landmark
+}
+
+func (r *memoryLandmarkRepository) FindByName(ctx context.Context, name string) (*domain.Landmark, error) {
+	term := domain.NormalizeLandmarkName(name)
+	for _, landmark := range r.landmarks {
+		if term != "" && strings.Contains(domain.NormalizeLandmarkName(landmark.Name), term) {
+			return &landmark, nil
+		}
+	}
+	return nil, domain.ErrUnknownLandmark(name)
+}
+
+// locatingNearbyPostRepository pages nearby posts like pagedNearbyPostRepository and records
+// the point searched around and whether post radii were respected
+type locatingNearbyPostRepository struct {
+	pagedNearbyPostRepository
+	searchedAround      domain.Location
+	respectedPostRadius bool
+}
+
+func (r *locatingNearbyPostRepository) FindNearby(ctx context.Context, location domain.Location, radius domain.Distance, postType *domain.PostType, respectPostRadius bool, maxCandidates, limit, offset, maxPhotos int) ([]*domain.Post, error) {
+	r.searchedAround = location
+	r.respectedPostRadius = respectPostRadius
+	return r.pagedNearbyPostRepository.FindNearby(ctx, location, radius, postType, respectPostRadius, maxCandidates, limit, offset, maxPhotos)
+}
@@ -0,0 +1,180 @@
+package e2e
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationEquality(t *testing.T) {
+	t.Run("should compare locations by distance", func(t *testing.T) {
+		park := TestLocations.CentralPark
+		// About 11m north of the test location
+		nearby := domain.Location{Latitude: park.Latitude + 0.0001, Longitude: park.Longitude}
+
+		require.True(t, park.EqualsWithin(park, 0))
+		require.True(t, park.EqualsWithin(nearby, 15))
+		require.False(t, park.EqualsWithin(nearby, 5))
+		require.False(t, park.EqualsWithin(TestLocations.TimesSquare, 1000))
+		require.False(t, park.EqualsWithin(park, -1))
+
+		AssertLocationNear(t, park, nearby, 15)
+	})
+
+	t.Run("should produce the same key for equivalent coordinates", func(t *testing.T) {
+		a := domain.Location{Latitude: 40.783100001, Longitude: -73.966499999}
+		b := domain.Location{Latitude: 40.7831, Longitude: -73.9665}
+		require.Equal(t, a.Key(), b.Key())
+		require.Equal(t, "40.78310,-73.96650", b.Key())
+
+		// Antimeridian and poles have several spellings of the same point
+		require.Equal(t,
+			domain.Location{Latitude: 10, Longitude: 180}.Key(),
+			domain.Location{Latitude: 10, Longitude: -180}.Key())
+		require.Equal(t,
+			domain.Location{Latitude: 90, Longitude: 45}.Key(),
+			domain.Location{Latitude: 90, Longitude: -120}.Key())
+		require.Equal(t,
+			domain.Location{Latitude: 0, Longitude: -0.000001}.Key(),
+			domain.Location{Latitude: 0, Longitude: 0}.Key())
+
+		require.NotEqual(t, TestLocations.CentralPark.Key(), TestLocations.TimesSquare.Key())
+	})
+}
+
+func TestRoute(t *testing.T) {
+	t.Run("should require between two and the max number of valid points", func(t *testing.T) {
+		_, err := domain.NewRoute([]domain.Location{TestLocations.CentralPark})
+		require.Error(t, err)
+
+		_, err = domain.NewRoute([]domain.Location{TestLocations.CentralPark, {Latitude: 91}})
+		require.Error(t, err)
+
+		tooLong := make([]domain.Location, domain.MaxRoutePoints+1)
+		_, err = domain.NewRoute(tooLong)
+		require.Error(t, err)
+
+		_, err = domain.NewRoute([]domain.Location{TestLocations.CentralPark, TestLocations.TimesSquare})
+		require.NoError(t, err)
+	})
+
+	t.Run("should measure the length along every leg", func(t *testing.T) {
+		route, err := domain.NewRoute([]domain.Location{
+			TestLocations.CentralPark, TestLocations.TimesSquare, TestLocations.EmpireState,
+		})
+		require.NoError(t, err)
+
+		legs := TestLocations.CentralPark.DistanceTo(TestLocations.TimesSquare).Meters +
+			TestLocations.TimesSquare.DistanceTo(TestLocations.EmpireState).Meters
+		require.InDelta(t, legs, route.Length().Meters, 0.001)
+		require.Greater(t, route.Length().Meters, TestLocations.CentralPark.DistanceTo(TestLocations.EmpireState).Meters)
+	})
+}
+
+func TestLocationPlausibility(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, domain.SetLocationPlausibility(domain.DefaultLocationPlausibility))
+	})
+
+	t.Run("should reject null island by default", func(t *testing.T) {
+		err := domain.Location{Latitude: 0, Longitude: 0}.CheckPlausibility()
+		require.True(t, domain.IsPostErrorCode(err, domain.LocationErrorImplausible))
+
+		err = domain.Location{Latitude: 0.00001, Longitude: -0.00002}.CheckPlausibility()
+		require.True(t, domain.IsPostErrorCode(err, domain.LocationErrorImplausible))
+
+		// Points on the equator or prime meridian are real places
+		require.NoError(t, domain.Location{Latitude: 0, Longitude: 32.58}.CheckPlausibility())
+		require.NoError(t, domain.Location{Latitude: 51.4779, Longitude: 0}.CheckPlausibility())
+		require.NoError(t, TestLocations.CentralPark.CheckPlausibility())
+	})
+
+	t.Run("should reject coordinates more precise than configured", func(t *testing.T) {
+		require.NoError(t, domain.SetLocationPlausibility(domain.LocationPlausibility{MaxDecimals: 8}))
+
+		require.NoError(t, domain.Location{Latitude: 40.78310001, Longitude: -73.9665}.CheckPlausibility())
+		err := domain.Location{Latitude: 40.783100012345, Longitude: -73.9665}.CheckPlausibility()
+		require.True(t, domain.IsPostErrorCode(err, domain.LocationErrorImplausible))
+
+		// Null island is allowed once that check is turned off
+		require.NoError(t, domain.Location{Latitude: 0, Longitude: 0}.CheckPlausibility())
+	})
+
+	t.Run("should refuse a negative precision limit", func(t *testing.T) {
+		require.Error(t, domain.SetLocationPlausibility(domain.LocationPlausibility{MaxDecimals: -1}))
+	})
+}
+
+func TestLocationObfuscation(t *testing.T) {
+	t.Run("should snap to the center of the grid cell", func(t *testing.T) {
+		snapped := TestLocations.CentralPark.Obfuscate(0.01)
+		require.InDelta(t, 40.785, snapped.Latitude, 1e-9)
+		require.InDelta(t, -73.965, snapped.Longitude, 1e-9)
+
+		// Any point in the same cell gives the same answer, so the exact point cannot be recovered
+		sameCell := domain.Location{Latitude: 40.7899, Longitude: -73.9601}
+		require.Equal(t, snapped, sameCell.Obfuscate(0.01))
+		require.NotEqual(t, snapped, TestLocations.TimesSquare.Obfuscate(0.01))
+	})
+
+	t.Run("should keep snapped points within coordinate range", func(t *testing.T) {
+		for _, location := range []domain.Location{
+			{Latitude: 90, Longitude: 180},
+			{Latitude: -90, Longitude: -180},
+		} {
+			snapped := location.Obfuscate(0.01)
+			require.LessOrEqual(t, math.Abs(snapped.Latitude), 90.0)
+			require.LessOrEqual(t, math.Abs(snapped.Longitude), 180.0)
+			require.True(t, location.EqualsWithin(snapped, 1000))
+		}
+	})
+
+	t.Run("should refuse a precision that would leave locations exact", func(t *testing.T) {
+		for _, precision := range []float64{0, -0.01, math.NaN()} {
+			require.Error(t, config.LocationPrivacyConfig{ObfuscationEnabled: true, PrecisionDegrees: precision}.Validate(), precision)
+		}
+		require.NoError(t, config.LocationPrivacyConfig{ObfuscationEnabled: true, PrecisionDegrees: 0.01}.Validate())
+		require.NoError(t, config.LocationPrivacyConfig{PrecisionDegrees: 0}.Validate(), "the precision is unused while obfuscation is off")
+	})
+
+	t.Run("should show the exact location only to the owner", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		owner := domain.NewUserID()
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost keys", "Three keys on a red ring",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost, owner, nil, time.Now(), time.Now(), nil)
+		postService := service.NewPostService(&singlePostRepository{post: post}, nil, nil, nil, nil, nil, nil, config.FeatureConfig{})
+		cfg := &config.Config{LocationPrivacy: config.LocationPrivacyConfig{ObfuscationEnabled: true, PrecisionDegrees: 0.01}}
+		router := gin.New()
+		router.GET("/posts/:id", handler.AuthMiddleware(nil, true), handler.NewPostHandler(postService, nil, cfg).GetPost)
+
+		get := func(t *testing.T, viewer domain.UserID) handler.PostResponse {
+			req := httptest.NewRequest(http.MethodGet, "/posts/"+post.ID().String(), nil)
+			req.Header.Set(handler.DevUserIDHeader, viewer.String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var response handler.PostResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			return response
+		}
+
+		response := get(t, owner)
+		require.Equal(t, TestLocations.CentralPark, response.Location)
+		require.False(t, response.LocationApproximate)
+
+		response = get(t, domain.NewUserID())
+		require.Equal(t, TestLocations.CentralPark.Obfuscate(0.01), response.Location)
+		require.True(t, response.LocationApproximate)
+	})
+}
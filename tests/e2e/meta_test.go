@@ -0,0 +1,35 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEnums(t *testing.T) {
+	t.Run("should list the valid values of every enum", func(t *testing.T) {
+		resp := makeRequest(t, "GET", "/meta/enums", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var enums map[string][]string
+		parseResponse(t, resp, &enums)
+
+		require.ElementsMatch(t, []string{"lost", "found"}, enums["post_types"])
+		require.Contains(t, enums["post_statuses"], "archived")
+		require.Contains(t, enums["contact_exchange_approval_types"], "platform_message")
+		require.Contains(t, enums["denial_reasons"], "not_owner")
+		require.Contains(t, enums["verification_methods"], "security_question")
+	})
+
+	t.Run("should only list values the domain accepts", func(t *testing.T) {
+		for _, postType := range domain.PostTypes() {
+			require.True(t, postType.IsValid(), postType)
+		}
+		for _, status := range domain.PostStatuses() {
+			_, err := domain.PostStatusFromString(string(status))
+			require.NoError(t, err)
+		}
+	})
+}
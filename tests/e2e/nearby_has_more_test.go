@@ -0,0 +1,109 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchNearbyPostsHasMore(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &pagedNearbyPostRepository{}
+	for i := 0; i < 3; i++ {
+		repo.posts = append(repo.posts, domain.ReconstructPost(domain.NewPostID(), "Lost cat", "",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost,
+			domain.NewUserID(), nil, time.Now(), time.Now(), nil))
+	}
+	postService := service.NewPostService(repo, nil, nil, nil, nil, nil, nil, config.FeatureConfig{})
+
+	for _, tc := range []struct {
+		name          string
+		maxCandidates int
+		limit, offset int
+		count         int
+		hasMore       bool
+	}{
+		{"should report more posts after a full page", 0, 2, 0, 2, true},
+		{"should report no more posts on the last page", 0, 2, 2, 1, false},
+		{"should report no more posts when the page is exactly full", 0, 3, 0, 3, false},
+		{"should stop paging at the candidate cap", 2, 2, 0, 2, false},
+		{"should return nothing past the candidate cap", 2, 2, 2, 0, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			posts, hasMore, err := postService.SearchNearbyPosts(ctx, TestLocations.CentralPark, 5000, nil, false, tc.maxCandidates, tc.limit, tc.offset, 0)
+			require.NoError(t, err)
+			require.Len(t, posts, tc.count)
+			require.Equal(t, tc.hasMore, hasMore)
+		})
+	}
+}
+
+func TestSearchNearbyPostsRespectPostRadius(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// search asks for nearby posts with the given query, with post radii respected or not by default
+	search := func(t *testing.T, respectByDefault bool, query string) (int, *locatingNearbyPostRepository) {
+		posts := &locatingNearbyPostRepository{}
+		postService := service.NewPostService(posts, nil, nil, nil, nil, nil, nil, config.FeatureConfig{})
+		cfg := &config.Config{Search: config.SearchConfig{DefaultRadiusMeters: 1000, MaxRadiusMeters: 5000, RespectPostRadius: respectByDefault}}
+		router := gin.New()
+		router.GET("/posts/nearby", handler.AuthMiddleware(nil, true), handler.NewPostHandler(postService, nil, cfg).SearchNearbyPosts)
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/nearby?lat=%f&lng=%f%s",
+			TestLocations.CentralPark.Latitude, TestLocations.CentralPark.Longitude, query), nil)
+		req.Header.Set(handler.DevUserIDHeader, domain.NewUserID().String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code, posts
+	}
+
+	t.Run("should follow the configured default", func(t *testing.T) {
+		for _, respect := range []bool{true, false} {
+			code, posts := search(t, respect, "")
+			require.Equal(t, http.StatusOK, code)
+			require.Equal(t, respect, posts.respectedPostRadius)
+		}
+	})
+
+	t.Run("should let a request turn post radii on and off", func(t *testing.T) {
+		code, posts := search(t, true, "&respect_post_radius=false")
+		require.Equal(t, http.StatusOK, code)
+		require.False(t, posts.respectedPostRadius)
+
+		code, posts = search(t, false, "&respect_post_radius=true")
+		require.Equal(t, http.StatusOK, code)
+		require.True(t, posts.respectedPostRadius)
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		code, _ := search(t, true, "&respect_post_radius=sometimes")
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+// pagedNearbyPostRepository pages through a fixed list of nearby posts, nearest first
+type pagedNearbyPostRepository struct {
+	domain.PostRepository
+	posts []*domain.Post
+}
+
+func (r *pagedNearbyPostRepository) FindNearby(ctx context.Context, location domain.Location, radius domain.Distance, postType *domain.PostType, respectPostRadius bool, maxCandidates, limit, offset, maxPhotos int) ([]*domain.Post, error) {
+	candidates := r.posts
+	if maxCandidates > 0 {
+		candidates = candidates[:min(maxCandidates, len(candidates))]
+	}
+	start := min(offset, len(candidates))
+	end := min(start+limit, len(candidates))
+	return candidates[start:end], nil
+}
@@ -0,0 +1,57 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationPostFilters(t *testing.T) {
+	ctx := context.Background()
+	orgID := domain.NewOrganizationID()
+
+	newService := func(role domain.OrganizationRole, memberOf domain.OrganizationID) (*service.PostService, domain.UserID) {
+		viewer := domain.NewUserID()
+		users := repository.NewMockUserContextRepository()
+		users.SetMockUser(viewer, &domain.PrivacySafeUser{
+			UserID:       viewer,
+			Organization: &domain.OrganizationContext{OrganizationID: memberOf, Role: role},
+		})
+		return service.NewPostService(nil, nil, users, nil, nil, nil, nil, config.FeatureConfig{}), viewer
+	}
+
+	t.Run("should let admins see every draft in their organization", func(t *testing.T) {
+		postService, viewer := newService(domain.OrganizationRoleAdmin, orgID)
+		otherOrg := domain.NewOrganizationID()
+
+		filters, err := postService.OrganizationPostFilters(ctx, orgID, viewer, domain.PostFilters{
+			OrganizationID:  &otherOrg,
+			OrganizationIDs: []domain.OrganizationID{otherOrg},
+		})
+		require.NoError(t, err)
+		require.True(t, filters.AllDrafts)
+		require.Equal(t, orgID, *filters.OrganizationID)
+		require.Empty(t, filters.OrganizationIDs)
+	})
+
+	t.Run("should limit members to their own drafts", func(t *testing.T) {
+		postService, viewer := newService(domain.OrganizationRoleStaff, orgID)
+
+		filters, err := postService.OrganizationPostFilters(ctx, orgID, viewer, domain.PostFilters{})
+		require.NoError(t, err)
+		require.False(t, filters.AllDrafts)
+		require.Equal(t, viewer, *filters.ViewerID)
+	})
+
+	t.Run("should reject users outside the organization", func(t *testing.T) {
+		postService, viewer := newService(domain.OrganizationRoleAdmin, domain.NewOrganizationID())
+
+		_, err := postService.OrganizationPostFilters(ctx, orgID, viewer, domain.PostFilters{})
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+	})
+}
@@ -0,0 +1,55 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationStats(t *testing.T) {
+	ctx := context.Background()
+	orgID := domain.NewOrganizationID()
+	member := domain.NewUserID()
+	since := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	users := repository.NewMockUserContextRepository()
+	users.SetMockUser(member, &domain.PrivacySafeUser{
+		UserID:       member,
+		Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleStaff},
+	})
+
+	posts := &posterCountingPostRepository{posters: 7}
+	postService := service.NewPostService(posts, nil, users, nil, nil, nil, nil, config.FeatureConfig{})
+
+	t.Run("should count distinct posters for members", func(t *testing.T) {
+		stats, err := postService.GetOrganizationStats(ctx, orgID, member, since)
+		require.NoError(t, err)
+		require.Equal(t, int64(7), stats.DistinctPosters)
+		require.Equal(t, orgID, posts.orgID)
+		require.Equal(t, since, posts.since)
+	})
+
+	t.Run("should not report stats to users outside the organization", func(t *testing.T) {
+		_, err := postService.GetOrganizationStats(ctx, orgID, domain.NewUserID(), since)
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+	})
+}
+
+// posterCountingPostRepository answers distinct poster counts and records what it was asked
+type posterCountingPostRepository struct {
+	domain.PostRepository
+	posters int64
+	orgID   domain.OrganizationID
+	since   time.Time
+}
+
+func (r *posterCountingPostRepository) CountDistinctPosters(ctx context.Context, orgID domain.OrganizationID, since time.Time) (int64, error) {
+	r.orgID, r.since = orgID, since
+	return r.posters, nil
+}
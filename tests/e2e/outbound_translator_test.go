@@ -0,0 +1,116 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboundEventTranslator(t *testing.T) {
+	translator := anti_corruption.NewOutboundEventTranslator()
+
+	orgID := domain.NewOrganizationID()
+	postID := domain.NewPostID()
+	photo := domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/1.jpg",
+		"https://example.com/1_thumb.jpg", "Front", 1, "jpg", 2048, time.Now())
+	post := domain.ReconstructPost(postID, "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusResolved, domain.PostTypeFound, domain.NewUserID(), &orgID,
+		time.Now(), time.Now(), []domain.Photo{*photo})
+	require.NoError(t, post.SetTags([]string{"wallet"}))
+	postData := post.ToPostData()
+	photoData := photo.ToPhotoData()
+
+	translate := func(t *testing.T, eventType domain.EventType, payload interface{}) *anti_corruption.KafkaEvent {
+		event := domain.NewPostEvent(eventType, postID, post.CreatedBy(), &orgID, payload)
+		kafkaEvent, err := translator.TranslatePostEvent(event)
+		require.NoError(t, err)
+		require.Equal(t, string(eventType), kafkaEvent.EventType)
+		require.Equal(t, event.ID.String(), kafkaEvent.EventID)
+		return kafkaEvent
+	}
+
+	t.Run("should translate created posts from their event data", func(t *testing.T) {
+		kafkaEvent := translate(t, domain.EventTypePostCreated, &domain.PostCreatedEventData{Post: postData})
+
+		data, ok := kafkaEvent.Data.(anti_corruption.PostCreatedEventData)
+		require.True(t, ok)
+		require.Equal(t, postID.String(), data.Post.PostID)
+		require.Equal(t, "Brown leather", data.Post.Description)
+		require.Equal(t, orgID.String(), *data.Post.OrganizationID)
+		require.Equal(t, TestLocations.CentralPark.Latitude, data.Post.Location.Latitude)
+		require.Equal(t, []string{"wallet"}, data.Post.Tags)
+		require.Len(t, data.Post.Photos, 1)
+		require.Equal(t, "https://example.com/1_thumb.jpg", data.Post.Photos[0].ThumbnailURL)
+	})
+
+	t.Run("should translate updates with their changes", func(t *testing.T) {
+		kafkaEvent := translate(t, domain.EventTypePostUpdated, &domain.PostUpdatedEventData{
+			Post:     postData,
+			Changes:  map[string]interface{}{"title": "Found wallet"},
+			Previous: map[string]interface{}{"title": "Wallet"},
+		})
+
+		data, ok := kafkaEvent.Data.(anti_corruption.PostUpdatedEventData)
+		require.True(t, ok)
+		require.Equal(t, postID.String(), data.Post.PostID)
+		require.Equal(t, "Wallet", data.Previous["title"])
+	})
+
+	t.Run("should translate status changes announced as updates", func(t *testing.T) {
+		kafkaEvent := translate(t, domain.EventTypePostUpdated, &domain.PostStatusChangedEventData{
+			Post:           postData,
+			NewStatus:      domain.PostStatusExpired,
+			PreviousStatus: domain.PostStatusActive,
+		})
+
+		data, ok := kafkaEvent.Data.(anti_corruption.PostUpdatedEventData)
+		require.True(t, ok)
+		require.Equal(t, "expired", data.Changes["status"])
+		require.Equal(t, "active", data.Previous["status"])
+	})
+
+	t.Run("should translate resolved and deleted posts from the embedded post", func(t *testing.T) {
+		for _, eventType := range []domain.EventType{domain.EventTypePostResolved, domain.EventTypePostDeleted} {
+			kafkaEvent := translate(t, eventType, &domain.PostStatusChangedEventData{
+				Post:           postData,
+				NewStatus:      domain.PostStatusResolved,
+				PreviousStatus: domain.PostStatusActive,
+			})
+
+			data, ok := kafkaEvent.Data.(anti_corruption.PostStatusChangedEventData)
+			require.True(t, ok, eventType)
+			require.Equal(t, postID.String(), data.PostID, eventType)
+			require.Equal(t, "resolved", data.NewStatus, eventType)
+			require.Equal(t, "active", data.PreviousStatus, eventType)
+		}
+	})
+
+	t.Run("should translate photo events from the embedded photo", func(t *testing.T) {
+		payloads := map[domain.EventType]interface{}{
+			domain.EventTypePhotoAdded:     &domain.PhotoAddedEventData{Post: postData, Photo: photoData},
+			domain.EventTypePhotoRemoved:   &domain.PhotoRemovedEventData{Post: postData, Photo: photoData},
+			domain.EventTypePhotoProcessed: &domain.PhotoProcessedEventData{Post: postData, Photo: photoData},
+		}
+		for eventType, payload := range payloads {
+			kafkaEvent := translate(t, eventType, payload)
+
+			data, ok := kafkaEvent.Data.(anti_corruption.PhotoEventData)
+			require.True(t, ok, eventType)
+			require.Equal(t, postID.String(), data.PostID, eventType)
+			require.Equal(t, photo.ID().String(), data.Photo.PhotoID, eventType)
+			require.Equal(t, "https://example.com/1.jpg", data.Photo.URL, eventType)
+			require.Equal(t, "jpg", data.Photo.Format, eventType)
+			require.Equal(t, int64(2048), data.Photo.SizeBytes, eventType)
+			require.Equal(t, 1, data.Photo.DisplayOrder, eventType)
+		}
+	})
+
+	t.Run("should reject payloads that do not match the event type", func(t *testing.T) {
+		event := domain.NewPostEvent(domain.EventTypePostResolved, postID, post.CreatedBy(), nil, &domain.PostCreatedEventData{Post: postData})
+		_, err := translator.TranslatePostEvent(event)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,405 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	dbs := repository.NewDBRouter(db, nil)
+	outbox := repository.NewPostgresOutboxRepository(dbs)
+
+	orgID := domain.NewOrganizationID()
+	post := domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), &orgID,
+		time.Now(), time.Now(), nil)
+	newEvent := func(eventType domain.EventType) *domain.PostEvent {
+		return domain.NewPostEvent(eventType, post.ID(), post.CreatedBy(), &orgID,
+			&domain.PostCreatedEventData{Post: post.ToPostData()})
+	}
+	t.Cleanup(func() {
+		_, _ = db.Exec("DELETE FROM outbox_events WHERE aggregate_id = $1", post.ID().String())
+	})
+
+	pending := func(t *testing.T, at time.Time) []*domain.OutboxEvent {
+		events, err := outbox.FindPending(ctx, at, 1000)
+		require.NoError(t, err)
+
+		var ours []*domain.OutboxEvent
+		for _, event := range events {
+			if event.Event.AggregateID == post.ID().String() {
+				ours = append(ours, event)
+			}
+		}
+		return ours
+	}
+
+	t.Run("should drop events appended in a rolled back transaction", func(t *testing.T) {
+		err := dbs.WithinTransaction(ctx, func(ctx context.Context) error {
+			require.NoError(t, outbox.Append(ctx, newEvent(domain.EventTypePostCreated)))
+			return errors.New("save failed")
+		})
+		require.Error(t, err)
+		require.Empty(t, pending(t, time.Now()))
+	})
+
+	first, second := newEvent(domain.EventTypePostCreated), newEvent(domain.EventTypePostUpdated)
+	require.NoError(t, dbs.WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, outbox.Append(ctx, first))
+		return outbox.Append(ctx, second)
+	}))
+
+	t.Run("should return committed events in order with their payload type and IDs", func(t *testing.T) {
+		events := pending(t, time.Now())
+		require.Len(t, events, 2)
+		require.Equal(t, first.ID, events[0].Event.ID)
+		require.Equal(t, second.ID, events[1].Event.ID)
+		require.Less(t, events[0].Sequence, events[1].Sequence)
+
+		event := events[0].Event
+		require.True(t, event.PostID.Equals(post.ID()))
+		require.True(t, event.UserID.Equals(post.CreatedBy()))
+		require.True(t, event.TenantID.Equals(orgID))
+		data, ok := event.Payload.(*domain.PostCreatedEventData)
+		require.True(t, ok)
+		require.Equal(t, post.ID().String(), data.Post.ID)
+	})
+
+	t.Run("should hold back an aggregate's later events while an earlier one waits to retry", func(t *testing.T) {
+		events := pending(t, time.Now())
+		require.NoError(t, outbox.MarkFailed(ctx, events[0].Sequence, time.Now().Add(time.Hour), "broker unavailable"))
+		require.Empty(t, pending(t, time.Now()))
+
+		retried := pending(t, time.Now().Add(2*time.Hour))
+		require.Len(t, retried, 2)
+		require.Equal(t, 1, retried[0].Attempts)
+		require.Equal(t, "broker unavailable", *retried[0].LastError)
+	})
+
+	t.Run("should stop returning published events", func(t *testing.T) {
+		events := pending(t, time.Now().Add(2*time.Hour))
+		for _, event := range events {
+			require.NoError(t, outbox.MarkPublished(ctx, event.Sequence, time.Now()))
+		}
+		require.Empty(t, pending(t, time.Now().Add(2*time.Hour)))
+	})
+
+	t.Run("should stop returning dead-lettered events without holding back the aggregate", func(t *testing.T) {
+		deadLettered, next := newEvent(domain.EventTypePostUpdated), newEvent(domain.EventTypePostUpdated)
+		require.NoError(t, outbox.Append(ctx, deadLettered))
+		require.NoError(t, outbox.Append(ctx, next))
+
+		events := pending(t, time.Now())
+		require.Len(t, events, 2)
+		require.NoError(t, outbox.MarkDeadLettered(ctx, events[0].Sequence, time.Now(), "translation failed"))
+
+		events = pending(t, time.Now())
+		require.Len(t, events, 1)
+		require.Equal(t, next.ID, events[0].Event.ID)
+	})
+
+	t.Run("should let one instance at a time hold a lock", func(t *testing.T) {
+		ran, err := dbs.TryWithLock(ctx, "outbox-test", func(ctx context.Context) error {
+			other, err := dbs.TryWithLock(ctx, "outbox-test", func(ctx context.Context) error {
+				t.Fatal("the lock was taken twice")
+				return nil
+			})
+			require.NoError(t, err)
+			require.False(t, other)
+			return nil
+		})
+		require.NoError(t, err)
+		require.True(t, ran)
+
+		ran, err = dbs.TryWithLock(ctx, "outbox-test", func(ctx context.Context) error { return nil })
+		require.NoError(t, err)
+		require.True(t, ran, "the lock is released after the work")
+	})
+}
+
+func TestOutboxEventPayloads(t *testing.T) {
+	t.Run("should decode known payloads into their own type", func(t *testing.T) {
+		data, err := json.Marshal(&domain.PostStatusChangedEventData{NewStatus: domain.PostStatusResolved})
+		require.NoError(t, err)
+
+		payloadType := domain.EventPayloadType(&domain.PostStatusChangedEventData{})
+		require.Equal(t, "PostStatusChangedEventData", payloadType)
+
+		payload, err := domain.DecodeEventPayload(payloadType, data)
+		require.NoError(t, err)
+		statusChange, ok := payload.(*domain.PostStatusChangedEventData)
+		require.True(t, ok)
+		require.Equal(t, domain.PostStatusResolved, statusChange.NewStatus)
+	})
+
+	t.Run("should keep other payloads as raw JSON", func(t *testing.T) {
+		payload, err := domain.DecodeEventPayload("map", json.RawMessage(`{"reason":"test"}`))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"reason":"test"}`, string(payload.(json.RawMessage)))
+	})
+}
+
+func TestOutboxRelayWorker(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.OutboxConfig{RetryInitialBackoffMs: 1, RetryMaxBackoffMs: 1}
+
+	newEvent := func(postID domain.PostID) *domain.PostEvent {
+		return domain.NewPostEvent(domain.EventTypePostUpdated, postID, domain.NewUserID(), nil, &domain.PostUpdatedEventData{})
+	}
+
+	t.Run("should retry a failed event before the rest of its aggregate", func(t *testing.T) {
+		postA, postB := domain.NewPostID(), domain.NewPostID()
+		outbox := &memoryOutboxRepository{}
+		a1, a2, b1 := newEvent(postA), newEvent(postA), newEvent(postB)
+		for _, event := range []*domain.PostEvent{a1, a2, b1} {
+			require.NoError(t, outbox.Append(ctx, event))
+		}
+		publisher := &flakyEventPublisher{failures: map[string]int{a1.ID.String(): 1}}
+		relay := service.NewRelayWorker(outbox, publisher, nil, cfg)
+
+		result, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Succeeded)
+		require.Equal(t, 1, result.Failed)
+		require.Equal(t, 1, result.Skipped)
+		require.Equal(t, []string{b1.ID.String()}, publisher.published)
+		require.Equal(t, 1, outbox.events[0].Attempts)
+
+		time.Sleep(5 * time.Millisecond)
+		result, err = relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Succeeded)
+		require.Equal(t, []string{b1.ID.String(), a1.ID.String(), a2.ID.String()}, publisher.published)
+
+		result, err = relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Zero(t, result.Processed)
+	})
+
+	t.Run("should not retry dead-lettered events or hold back their aggregate", func(t *testing.T) {
+		postID := domain.NewPostID()
+		outbox := &memoryOutboxRepository{}
+		untranslatable := domain.NewPostEvent(domain.EventTypePostUpdated, postID, domain.NewUserID(), nil, nil)
+		next := newEvent(postID)
+		for _, event := range []*domain.PostEvent{untranslatable, next} {
+			require.NoError(t, outbox.Append(ctx, event))
+		}
+		kafka := &recordingKafkaPublisher{}
+		relay := service.NewRelayWorker(outbox, anti_corruption.NewAntiCorruptionEventPublisher(anti_corruption.NewOutboundEventTranslator(), kafka), nil, cfg)
+
+		result, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Failed)
+		require.Equal(t, 1, result.Succeeded)
+		require.Equal(t, []string{anti_corruption.DeadLetterTopic, "posts.events"}, kafka.topics())
+		require.True(t, outbox.deadLettered[1])
+		require.Equal(t, 1, outbox.events[0].Attempts)
+
+		result, err = relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Zero(t, result.Processed, "dead-lettered events are not relayed again")
+	})
+
+	t.Run("should retry events whose dead-lettering failed", func(t *testing.T) {
+		outbox := &memoryOutboxRepository{}
+		require.NoError(t, outbox.Append(ctx, domain.NewPostEvent(domain.EventTypePostUpdated, domain.NewPostID(), domain.NewUserID(), nil, nil)))
+		kafka := &recordingKafkaPublisher{err: errors.New("broker unavailable")}
+		relay := service.NewRelayWorker(outbox, anti_corruption.NewAntiCorruptionEventPublisher(anti_corruption.NewOutboundEventTranslator(), kafka), nil, cfg)
+
+		result, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Failed)
+		require.Empty(t, outbox.deadLettered)
+		require.NotNil(t, outbox.events[0].LastError, "the event is retried after a backoff")
+	})
+
+	t.Run("should skip the pass while another instance relays", func(t *testing.T) {
+		outbox := &memoryOutboxRepository{}
+		require.NoError(t, outbox.Append(ctx, newEvent(domain.NewPostID())))
+		publisher := &flakyEventPublisher{failures: map[string]int{}}
+		locker := &heldLocker{held: true}
+		relay := service.NewRelayWorker(outbox, publisher, locker, cfg)
+
+		result, err := relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Zero(t, result.Processed)
+		require.Empty(t, publisher.published)
+
+		locker.held = false
+		result, err = relay.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Succeeded)
+		require.Equal(t, []string{"outbox-relay", "outbox-relay"}, locker.names)
+	})
+
+	t.Run("should send events of other aggregates through unchanged", func(t *testing.T) {
+		outbox := &memoryOutboxRepository{}
+		event := domain.NewContactExchangeEvent(domain.EventTypeContactExchangeExpired, domain.NewContactExchangeRequestID(), domain.NewUserID(), nil, nil)
+		require.NoError(t, outbox.Append(ctx, event))
+		kafka := &recordingKafkaPublisher{}
+		passthrough := &flakyEventPublisher{failures: map[string]int{}}
+		publisher := anti_corruption.NewAntiCorruptionEventPublisher(anti_corruption.NewOutboundEventTranslator(), kafka)
+		publisher.SetPassthrough(passthrough)
+
+		result, err := service.NewRelayWorker(outbox, publisher, nil, cfg).RelayPending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Succeeded)
+		require.Equal(t, []string{event.ID.String()}, passthrough.published)
+		require.Empty(t, kafka.messages)
+	})
+
+	t.Run("should fail the change when its event cannot be recorded", func(t *testing.T) {
+		post := domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil,
+			time.Now(), time.Now(), nil)
+		transactions := &recordingTransactionManager{}
+		publisher := &flakyEventPublisher{failures: map[string]int{}, failAll: true}
+		postService := service.NewPostService(&updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}, nil, nil, nil, nil,
+			publisher, transactions, config.FeatureConfig{})
+
+		_, err := postService.UpdatePostStatus(ctx, post.ID(), post.CreatedBy(), domain.PostStatusResolved, nil)
+		require.Error(t, err)
+		require.Equal(t, 1, transactions.rolledBack)
+	})
+}
+
+// memoryOutboxRepository keeps outbox events in memory, in the order they were appended
+type memoryOutboxRepository struct {
+	events       []*domain.OutboxEvent
+	done         map[int64]bool
+	deadLettered map[int64]bool
+}
+
+func (r *memoryOutboxRepository) Append(ctx context.Context, event *domain.PostEvent) error {
+	r.events = append(r.events, &domain.OutboxEvent{Sequence: int64(len(r.events) + 1), Event: event, CreatedAt: time.Now()})
+	return nil
+}
+
+func (r *memoryOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxEvent, error) {
+	var pending []*domain.OutboxEvent
+	waiting := make(map[string]bool)
+	for _, event := range r.events {
+		if r.done[event.Sequence] {
+			continue
+		}
+		if waiting[event.Event.AggregateID] || event.NextAttemptAt.After(now) {
+			waiting[event.Event.AggregateID] = true
+			continue
+		}
+		pending = append(pending, event)
+	}
+	return pending, nil
+}
+
+func (r *memoryOutboxRepository) MarkPublished(ctx context.Context, sequence int64, publishedAt time.Time) error {
+	if r.done == nil {
+		r.done = make(map[int64]bool)
+	}
+	r.done[sequence] = true
+	return nil
+}
+
+func (r *memoryOutboxRepository) MarkFailed(ctx context.Context, sequence int64, nextAttemptAt time.Time, lastError string) error {
+	event := r.events[sequence-1]
+	event.Attempts++
+	event.NextAttemptAt = nextAttemptAt
+	event.LastError = &lastError
+	return nil
+}
+
+func (r *memoryOutboxRepository) MarkDeadLettered(ctx context.Context, sequence int64, deadLetteredAt time.Time, lastError string) error {
+	if r.deadLettered == nil {
+		r.deadLettered = make(map[int64]bool)
+	}
+	r.deadLettered[sequence] = true
+	event := r.events[sequence-1]
+	event.Attempts++
+	event.LastError = &lastError
+	return r.MarkPublished(ctx, sequence, deadLetteredAt)
+}
+
+// flakyEventPublisher fails each event the given number of times before publishing it
+type flakyEventPublisher struct {
+	failures  map[string]int
+	failAll   bool
+	published []string
+}
+
+func (p *flakyEventPublisher) PublishEvent(ctx context.Context, event *domain.PostEvent) error {
+	id := event.ID.String()
+	if p.failAll || p.failures[id] > 0 {
+		p.failures[id]--
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, id)
+	return nil
+}
+
+// recordingKafkaPublisher keeps the messages written to each topic, or fails them with err:
+// all of them, or only those to failTopic when it is set
+type recordingKafkaPublisher struct {
+	err       error
+	failTopic string
+	messages  []recordedKafkaMessage
+}
+
+type recordedKafkaMessage struct {
+	topic string
+	key   string
+	value []byte
+}
+
+func (p *recordingKafkaPublisher) PublishMessage(topic string, key string, message []byte) error {
+	if p.err != nil && (p.failTopic == "" || p.failTopic == topic) {
+		return p.err
+	}
+	p.messages = append(p.messages, recordedKafkaMessage{topic: topic, key: key, value: message})
+	return nil
+}
+
+func (p *recordingKafkaPublisher) topics() []string {
+	var topics []string
+	for _, message := range p.messages {
+		topics = append(topics, message.topic)
+	}
+	return topics
+}
+
+// heldLocker runs work only while no other instance is said to hold the lock
+type heldLocker struct {
+	held  bool
+	names []string
+}
+
+func (l *heldLocker) TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	l.names = append(l.names, name)
+	if l.held {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+// recordingTransactionManager runs units of work directly and counts the ones that failed
+type recordingTransactionManager struct {
+	rolledBack int
+}
+
+func (m *recordingTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if err != nil {
+		m.rolledBack++
+	}
+	return err
+}
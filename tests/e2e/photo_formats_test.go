@@ -0,0 +1,52 @@
+package e2e
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowedPhotoFormats(t *testing.T) {
+	t.Run("should reject a format removed from the allowlist everywhere", func(t *testing.T) {
+		require.NoError(t, domain.SetAllowedPhotoFormats([]string{"jpg", "jpeg", "png"}))
+		t.Cleanup(func() {
+			require.NoError(t, domain.SetAllowedPhotoFormats(nil))
+		})
+
+		require.False(t, domain.IsAllowedPhotoFormat("webp"))
+		require.False(t, domain.IsAllowedPhotoFormat(".webp"))
+		require.True(t, domain.IsAllowedPhotoFormat("JPG"))
+
+		// Domain validation
+		_, err := domain.NewPhoto(domain.CreatePhotoRequest{
+			PostID:       domain.NewPostID(),
+			URL:          "https://example.com/photo.webp",
+			DisplayOrder: 1,
+			Format:       "webp",
+			SizeBytes:    1024,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jpg, jpeg, png")
+
+		// Storage validation
+		storage := service.NewTestStorageService(config.StorageConfig{BucketName: "posts-test-bucket"})
+		header := &multipart.FileHeader{Filename: "photo.webp", Size: 1024}
+		_, err = storage.UploadPhoto(context.Background(), nil, header, uuid.New(), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid image format")
+	})
+
+	t.Run("should refuse to allow formats the service cannot serve", func(t *testing.T) {
+		err := domain.SetAllowedPhotoFormats([]string{"jpg", "tiff"})
+		require.Error(t, err)
+
+		// The previous allowlist stays in effect
+		require.True(t, domain.IsAllowedPhotoFormat("webp"))
+	})
+}
@@ -0,0 +1,40 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactPhotoOrder(t *testing.T) {
+	postID := domain.NewPostID()
+	photoAt := func(order int) domain.Photo {
+		return *domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/photo.jpg", "", "", order, "jpg", 1024, time.Now())
+	}
+
+	t.Run("should close gaps while keeping relative order", func(t *testing.T) {
+		third, first, second := photoAt(7), photoAt(2), photoAt(4)
+		post := domain.ReconstructPost(postID, "Lost keys", "", TestLocations.CentralPark, 1000,
+			domain.PostStatusActive, domain.PostTypeLost, domain.NewUserID(), nil,
+			time.Now(), time.Now(), []domain.Photo{third, first, second})
+
+		require.True(t, post.CompactPhotoOrder())
+
+		photos := post.Photos()
+		require.Equal(t, []domain.PhotoID{first.ID(), second.ID(), third.ID()},
+			[]domain.PhotoID{photos[0].ID(), photos[1].ID(), photos[2].ID()})
+		for i, photo := range photos {
+			require.Equal(t, i+1, photo.DisplayOrder())
+		}
+	})
+
+	t.Run("should report no change for a dense sequence", func(t *testing.T) {
+		post := domain.ReconstructPost(postID, "Lost keys", "", TestLocations.CentralPark, 1000,
+			domain.PostStatusActive, domain.PostTypeLost, domain.NewUserID(), nil,
+			time.Now(), time.Now(), []domain.Photo{photoAt(1), photoAt(2)})
+
+		require.False(t, post.CompactPhotoOrder())
+	})
+}
@@ -0,0 +1,70 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishPhotoProcessed(t *testing.T) {
+	ctx := context.Background()
+
+	publish := func(t *testing.T, status domain.PostStatus, thumbnailURL string) *recordingEventPublisher {
+		postID := domain.NewPostID()
+		photo := domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/1.jpg",
+			thumbnailURL, "", 1, "jpg", 2048, time.Now())
+		photo.SetDimensions(800, 600)
+		post := domain.ReconstructPost(postID, "Found wallet", "Brown leather",
+			TestLocations.CentralPark, 1000, status, domain.PostTypeFound, domain.NewUserID(), nil,
+			time.Now(), time.Now(), []domain.Photo{*photo})
+
+		publisher := &recordingEventPublisher{}
+		postService := service.NewPostService(&singlePostRepository{post: post}, &singlePhotoRepository{photo: photo},
+			repository.NewMockUserContextRepository(), nil, nil, publisher, nil, config.FeatureConfig{})
+
+		require.NoError(t, postService.PublishPhotoProcessed(ctx, photo.ID()))
+		return publisher
+	}
+
+	t.Run("should announce photos with their thumbnail and dimensions", func(t *testing.T) {
+		publisher := publish(t, domain.PostStatusActive, "https://example.com/1_thumb.jpg")
+		require.Len(t, publisher.events, 1)
+
+		event := publisher.events[0]
+		require.Equal(t, domain.EventTypePhotoProcessed, event.EventType)
+		data, ok := event.Payload.(*domain.PhotoProcessedEventData)
+		require.True(t, ok)
+		require.NotNil(t, data.Photo.ThumbnailURL)
+		require.Equal(t, "https://example.com/1_thumb.jpg", *data.Photo.ThumbnailURL)
+		require.Equal(t, 800, data.Photo.Width)
+		require.Equal(t, 600, data.Photo.Height)
+		require.True(t, data.Triggers.Reindexing)
+	})
+
+	t.Run("should not announce photos still being processed", func(t *testing.T) {
+		require.Empty(t, publish(t, domain.PostStatusActive, "").events)
+	})
+
+	t.Run("should not announce photos of drafts", func(t *testing.T) {
+		require.Empty(t, publish(t, domain.PostStatusDraft, "https://example.com/1_thumb.jpg").events)
+	})
+}
+
+// singlePhotoRepository serves one photo by ID
+type singlePhotoRepository struct {
+	domain.PhotoRepository
+	photo *domain.Photo
+}
+
+func (r *singlePhotoRepository) FindByID(ctx context.Context, id domain.PhotoID) (*domain.Photo, error) {
+	if id != r.photo.ID() {
+		return nil, domain.ErrPhotoNotFound(id)
+	}
+	return r.photo, nil
+}
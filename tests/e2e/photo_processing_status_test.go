@@ -0,0 +1,77 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPhotosProcessingStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	postID := domain.NewPostID()
+	ready := domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/1.jpg",
+		"https://example.com/1_thumb.jpg", "", 1, "jpg", 2048, time.Now())
+	ready.SetDimensions(800, 600)
+	inFlight := domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/2.jpg",
+		"", "", 2, "jpg", 2048, time.Now())
+	post := domain.ReconstructPost(postID, "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil,
+		time.Now(), time.Now(), []domain.Photo{*ready, *inFlight})
+
+	postService := service.NewPostService(&singlePostRepository{post: post}, nil, nil, nil, nil, nil, nil, config.FeatureConfig{})
+	photoHandler := handler.NewPhotoHandler(postService, nil, &config.Config{})
+
+	router := gin.New()
+	router.GET("/posts/:id/photos", photoHandler.ListPhotos)
+
+	list := func(t *testing.T, query string) (int, handler.ListPhotosResponse) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/"+postID.String()+"/photos"+query, nil))
+
+		var response handler.ListPhotosResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+		}
+		return w.Code, response
+	}
+
+	t.Run("should report each photo's processing status", func(t *testing.T) {
+		status, response := list(t, "")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, 1, response.Processing)
+		require.Len(t, response.Photos, 2)
+		require.Equal(t, handler.PhotoProcessingStatusReady, response.Photos[0].ProcessingStatus)
+		require.Equal(t, handler.PhotoProcessingStatusProcessing, response.Photos[1].ProcessingStatus)
+	})
+
+	t.Run("should keep only photos still processing", func(t *testing.T) {
+		status, response := list(t, "?processing=true")
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, response.Photos, 1)
+		require.Equal(t, inFlight.ID().UUID(), response.Photos[0].ID)
+	})
+
+	t.Run("should keep only finished photos", func(t *testing.T) {
+		status, response := list(t, "?processing=false")
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, response.Photos, 1)
+		require.Equal(t, ready.ID().UUID(), response.Photos[0].ID)
+		require.Equal(t, 1, response.Processing)
+	})
+
+	t.Run("should reject an invalid filter", func(t *testing.T) {
+		status, _ := list(t, "?processing=maybe")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
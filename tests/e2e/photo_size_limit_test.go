@@ -0,0 +1,40 @@
+package e2e
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhotoSizeLimit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reject photos over the configured limit and name it", func(t *testing.T) {
+		storage := service.NewTestStorageService(config.StorageConfig{BucketName: "posts-test-bucket", MaxFileSizeBytes: 2 * 1024 * 1024})
+
+		_, err := storage.UploadPhoto(ctx, nil, &multipart.FileHeader{Filename: "photo.jpg", Size: 2*1024*1024 + 1}, uuid.New(), nil)
+		require.ErrorIs(t, err, service.ErrFileTooLarge)
+		require.Contains(t, err.Error(), "max 2MB")
+
+		_, err = storage.UploadPhoto(ctx, nil, &multipart.FileHeader{Filename: "photo.jpg", Size: 2 * 1024 * 1024}, uuid.New(), nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("should fall back to 10MB when no limit is configured", func(t *testing.T) {
+		require.Equal(t, int64(10*1024*1024), service.MaxFileSizeBytes(config.StorageConfig{}))
+
+		err := service.ValidateFileSize(11*1024*1024, service.MaxFileSizeBytes(config.StorageConfig{}))
+		require.ErrorIs(t, err, service.ErrFileTooLarge)
+		require.Contains(t, err.Error(), "max 10MB")
+	})
+
+	t.Run("should describe limits that are not whole megabytes", func(t *testing.T) {
+		require.Contains(t, service.ValidateFileSize(600*1024, 512*1024).Error(), "max 512KB")
+		require.Contains(t, service.ValidateFileSize(1000, 999).Error(), "max 999 bytes")
+	})
+}
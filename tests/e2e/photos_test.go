@@ -9,6 +9,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/jsarabia/fn-posts/internal/domain"
 	"github.com/stretchr/testify/require"
 )
 
@@ -126,11 +127,12 @@ func TestPhotoUpload(t *testing.T) {
 		require.Equal(t, 3, successCount)
 		require.Equal(t, 3, totalCount)
 
-		// Verify display order
+		// Uploads run concurrently, but display order and captions follow the request order
 		for i, photoInterface := range uploadedPhotos {
 			photo := photoInterface.(map[string]interface{})
 			photoDetails := photo["photo"].(map[string]interface{})
 			require.Equal(t, float64(i+1), photoDetails["display_order"])
+			require.Equal(t, fmt.Sprintf("Caption for photo %d", i+1), photoDetails["caption"])
 		}
 	})
 
@@ -139,12 +141,11 @@ func TestPhotoUpload(t *testing.T) {
 		post := CreateTestPostWithDefaults(t)
 		defer CleanupPost(t, post.ID)
 
-		// Try to upload 11 photos (exceeds limit of 10)
+		// Try to upload one photo more than the limit
 		var body bytes.Buffer
 		writer := multipart.NewWriter(&body)
 
-		// Create 11 fake files
-		for i := 0; i < 11; i++ {
+		for i := 0; i <= domain.MaxPhotosPerPost; i++ {
 			testImageData := []byte(fmt.Sprintf("fake-image-data-%d", i))
 			part, err := writer.CreateFormFile("photos", fmt.Sprintf("test-%d.jpg", i))
 			require.NoError(t, err)
@@ -169,7 +170,7 @@ func TestPhotoUpload(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 
 		errorMsg := parseErrorResponse(t, resp)
-		require.Contains(t, errorMsg, "Maximum 10 photos allowed")
+		require.Contains(t, errorMsg, fmt.Sprintf("Maximum %d photos allowed", domain.MaxPhotosPerPost))
 	})
 
 	t.Run("should validate file formats", func(t *testing.T) {
@@ -345,3 +346,127 @@ func TestPhotoDelete(t *testing.T) {
 		}
 	})
 }
+
+func TestGetPhoto(t *testing.T) {
+	t.Run("should return a single photo's metadata", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		UploadTestPhoto(t, post.ID)
+
+		resp := makeRequest(t, "GET", fmt.Sprintf("/posts/%s", post.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var postResp PostResponse
+		parseResponse(t, resp, &postResp)
+		require.Len(t, postResp.Photos, 1)
+		expected := postResp.Photos[0]
+
+		resp = makeRequest(t, "GET", fmt.Sprintf("/posts/%s/photos/%s", post.ID, expected.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NotEmpty(t, resp.Header.Get("Cache-Control"), "photo metadata should carry cache headers")
+
+		var photo PhotoResponse
+		parseResponse(t, resp, &photo)
+		require.Equal(t, expected.ID, photo.ID)
+		require.Equal(t, expected.URL, photo.URL)
+		require.Equal(t, "Test photo caption", photo.Caption)
+		require.Equal(t, "jpg", photo.Format)
+	})
+
+	t.Run("should return 404 for a photo of another post", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+		otherPost := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, otherPost.ID)
+
+		UploadTestPhoto(t, otherPost.ID)
+
+		resp := makeRequest(t, "GET", fmt.Sprintf("/posts/%s", otherPost.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var postResp PostResponse
+		parseResponse(t, resp, &postResp)
+		require.Len(t, postResp.Photos, 1)
+
+		resp = makeRequest(t, "GET", fmt.Sprintf("/posts/%s/photos/%s", post.ID, postResp.Photos[0].ID), nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("should return 404 for non-existent photo", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		resp := makeRequest(t, "GET", fmt.Sprintf("/posts/%s/photos/550e8400-e29b-41d4-a716-446655440404", post.ID), nil)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCoverPhoto(t *testing.T) {
+	t.Run("should default to the first photo and follow the chosen cover", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		require.Nil(t, post.CoverPhotoID, "a post without photos has no cover")
+
+		UploadTestPhoto(t, post.ID)
+		UploadTestPhoto(t, post.ID)
+
+		resp := makeRequest(t, "GET", fmt.Sprintf("/posts/%s", post.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var postResp PostResponse
+		parseResponse(t, resp, &postResp)
+		require.Len(t, postResp.Photos, 2)
+		require.NotNil(t, postResp.CoverPhotoID)
+		require.Equal(t, postResp.Photos[0].ID, *postResp.CoverPhotoID)
+
+		coverID := postResp.Photos[1].ID
+		resp = makeRequest(t, "PUT", fmt.Sprintf("/posts/%s/cover", post.ID), map[string]string{"photo_id": coverID})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		parseResponse(t, resp, &postResp)
+		require.NotNil(t, postResp.CoverPhotoID)
+		require.Equal(t, coverID, *postResp.CoverPhotoID)
+
+		resp = makeRequest(t, "GET", fmt.Sprintf("/posts/%s", post.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		parseResponse(t, resp, &postResp)
+		require.Equal(t, coverID, *postResp.CoverPhotoID)
+
+		// Capped list views keep the cover rather than the first photo
+		resp = makeRequest(t, "GET", fmt.Sprintf("/users/%s/posts?max_photos=1", TestUserID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var listResp struct {
+			Posts []PostResponse `json:"posts"`
+		}
+		parseResponse(t, resp, &listResp)
+
+		found := false
+		for _, listed := range listResp.Posts {
+			if listed.ID == post.ID {
+				found = true
+				require.Len(t, listed.Photos, 1)
+				require.Equal(t, coverID, listed.Photos[0].ID)
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("should return 404 for a photo from another post", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		resp := makeRequest(t, "PUT", fmt.Sprintf("/posts/%s/cover", post.ID),
+			map[string]string{"photo_id": "550e8400-e29b-41d4-a716-446655440404"})
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		resp.Body.Close()
+	})
+
+	t.Run("should validate the photo ID", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		resp := makeRequest(t, "PUT", fmt.Sprintf("/posts/%s/cover", post.ID), map[string]string{"photo_id": "invalid"})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
@@ -0,0 +1,347 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostChangeAuthorization(t *testing.T) {
+	ctx := context.Background()
+	orgID := domain.NewOrganizationID()
+	owner := domain.NewUserID()
+
+	newPost := func(status domain.PostStatus) *domain.Post {
+		return domain.ReconstructPost(domain.NewPostID(), "Found keys", "Three keys on a red ring",
+			TestLocations.CentralPark, 1000, status, domain.PostTypeFound, owner, &orgID, time.Now(), time.Now(), nil)
+	}
+	newService := func(post *domain.Post) (*service.PostService, *deletablePostRepository, *repository.MockUserContextRepository) {
+		posts := &deletablePostRepository{updatablePostRepository: updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}}
+		users := repository.NewMockUserContextRepository()
+		return service.NewPostService(posts, nil, users, nil, nil, &recordingEventPublisher{}, nil, config.FeatureConfig{}), posts, users
+	}
+	retitle := func(title string) domain.PostUpdate {
+		return domain.PostUpdate{Title: &title}
+	}
+	member := func(users *repository.MockUserContextRepository, role domain.OrganizationRole) domain.UserID {
+		userID := domain.NewUserID()
+		users.SetMockUser(userID, &domain.PrivacySafeUser{
+			UserID:       userID,
+			Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: role},
+		})
+		return userID
+	}
+
+	t.Run("should let the owner change their post", func(t *testing.T) {
+		post := newPost(domain.PostStatusActive)
+		postService, posts, _ := newService(post)
+
+		updated, err := postService.UpdatePost(ctx, post.ID(), owner, retitle("Found car keys"))
+		require.NoError(t, err)
+		require.Equal(t, "Found car keys", updated.Title())
+
+		_, err = postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusResolved, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, postService.DeletePost(ctx, post.ID(), owner))
+		require.True(t, posts.deleted)
+	})
+
+	t.Run("should forbid other users from changing a post", func(t *testing.T) {
+		post := newPost(domain.PostStatusActive)
+		postService, posts, users := newService(post)
+
+		for _, stranger := range []domain.UserID{domain.NewUserID(), member(users, domain.OrganizationRoleStaff)} {
+			_, err := postService.UpdatePost(ctx, post.ID(), stranger, retitle("Mine now"))
+			require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+
+			_, err = postService.UpdatePostStatus(ctx, post.ID(), stranger, domain.PostStatusResolved, nil)
+			require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+
+			err = postService.DeletePost(ctx, post.ID(), stranger)
+			require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+		}
+
+		require.Equal(t, "Found keys", post.Title())
+		require.Equal(t, domain.PostStatusActive, post.Status())
+		require.False(t, posts.deleted)
+	})
+
+	t.Run("should not acknowledge other users' drafts", func(t *testing.T) {
+		post := newPost(domain.PostStatusDraft)
+		postService, _, _ := newService(post)
+
+		err := postService.DeletePost(ctx, post.ID(), domain.NewUserID())
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorPostNotFound))
+	})
+
+	t.Run("should let admins of the post's organization change it", func(t *testing.T) {
+		post := newPost(domain.PostStatusActive)
+		postService, posts, users := newService(post)
+		admin := member(users, domain.OrganizationRoleAdmin)
+
+		_, err := postService.UpdatePost(ctx, post.ID(), admin, retitle("Found car keys"))
+		require.NoError(t, err)
+
+		_, err = postService.UpdatePostStatus(ctx, post.ID(), admin, domain.PostStatusResolved, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, postService.DeletePost(ctx, post.ID(), admin))
+		require.True(t, posts.deleted)
+	})
+
+	t.Run("should not let admins of another organization change it", func(t *testing.T) {
+		post := newPost(domain.PostStatusActive)
+		postService, _, users := newService(post)
+		outsider := domain.NewUserID()
+		users.SetMockUser(outsider, &domain.PrivacySafeUser{
+			UserID:       outsider,
+			Organization: &domain.OrganizationContext{OrganizationID: domain.NewOrganizationID(), Role: domain.OrganizationRoleAdmin},
+		})
+
+		err := postService.DeletePost(ctx, post.ID(), outsider)
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+	})
+}
+
+// deletablePostRepository knows about exactly one post and accepts updates to it and its
+// deletion
+type deletablePostRepository struct {
+	updatablePostRepository
+	deleted bool
+}
+
+func (r *deletablePostRepository) Delete(ctx context.Context, id domain.PostID) error {
+	r.deleted = true
+	return nil
+}
+
+func TestPhotoChangeAuthorization(t *testing.T) {
+	ctx := context.Background()
+	orgID := domain.NewOrganizationID()
+	owner := domain.NewUserID()
+
+	newFixture := func(status domain.PostStatus) (*domain.Post, *domain.Photo, *service.PostService, *repository.MockUserContextRepository) {
+		postID := domain.NewPostID()
+		photo := domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/keys.jpg", "", "Keys", 1, "jpg", 2048, time.Now())
+		post := domain.ReconstructPost(postID, "Found keys", "Three keys on a red ring",
+			TestLocations.CentralPark, 1000, status, domain.PostTypeFound, owner, &orgID, time.Now(), time.Now(), []domain.Photo{*photo})
+		users := repository.NewMockUserContextRepository()
+		postService := service.NewPostService(&updatablePostRepository{singlePostRepository: singlePostRepository{post: post}},
+			&updatablePhotoRepository{singlePhotoRepository: singlePhotoRepository{photo: photo}}, users, nil, nil, &recordingEventPublisher{}, nil, config.FeatureConfig{})
+		return post, photo, postService, users
+	}
+
+	t.Run("should let only the owner and organization admins edit captions", func(t *testing.T) {
+		post, photo, postService, users := newFixture(domain.PostStatusActive)
+		staff, admin := domain.NewUserID(), domain.NewUserID()
+		users.SetMockUser(staff, &domain.PrivacySafeUser{UserID: staff, Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleStaff}})
+		users.SetMockUser(admin, &domain.PrivacySafeUser{UserID: admin, Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleAdmin}})
+
+		for _, stranger := range []domain.UserID{domain.NewUserID(), staff} {
+			_, err := postService.UpdatePhotoCaption(ctx, post.ID(), photo.ID(), stranger, "Mine now")
+			require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+		}
+		require.Equal(t, "Keys", photo.Caption())
+
+		_, err := postService.UpdatePhotoCaption(ctx, post.ID(), photo.ID(), owner, "Car keys")
+		require.NoError(t, err)
+		require.Equal(t, "Car keys", photo.Caption())
+
+		_, err = postService.UpdatePhotoCaption(ctx, post.ID(), photo.ID(), admin, "Three car keys")
+		require.NoError(t, err)
+		require.Equal(t, "Three car keys", photo.Caption())
+	})
+
+	t.Run("should not acknowledge photos of other users' drafts", func(t *testing.T) {
+		post, photo, postService, _ := newFixture(domain.PostStatusDraft)
+
+		_, err := postService.UpdatePhotoCaption(ctx, post.ID(), photo.ID(), domain.NewUserID(), "Mine now")
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorPostNotFound))
+	})
+
+	t.Run("should let only the owner and organization admins choose the cover photo", func(t *testing.T) {
+		post, photo, postService, users := newFixture(domain.PostStatusActive)
+		staff, admin := domain.NewUserID(), domain.NewUserID()
+		users.SetMockUser(staff, &domain.PrivacySafeUser{UserID: staff, Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleStaff}})
+		users.SetMockUser(admin, &domain.PrivacySafeUser{UserID: admin, Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleAdmin}})
+
+		for _, stranger := range []domain.UserID{domain.NewUserID(), staff} {
+			_, err := postService.SetCoverPhoto(ctx, post.ID(), photo.ID(), stranger)
+			require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+		}
+		require.Nil(t, post.ChosenCoverPhotoID())
+
+		for _, editor := range []domain.UserID{owner, admin} {
+			updated, err := postService.SetCoverPhoto(ctx, post.ID(), photo.ID(), editor)
+			require.NoError(t, err)
+			require.True(t, updated.ChosenCoverPhotoID().Equals(photo.ID()))
+		}
+
+		_, err := postService.SetCoverPhoto(ctx, post.ID(), domain.NewPhotoID(), owner)
+		require.True(t, domain.IsPostErrorCode(err, domain.PhotoErrorNotFound))
+	})
+
+	t.Run("should show photos of drafts only to their owner", func(t *testing.T) {
+		post, photo, postService, _ := newFixture(domain.PostStatusDraft)
+
+		_, err := postService.GetPhoto(ctx, post.ID(), photo.ID(), domain.NewUserID())
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorPostNotFound))
+
+		found, err := postService.GetPhoto(ctx, post.ID(), photo.ID(), owner)
+		require.NoError(t, err)
+		require.Equal(t, photo.ID(), found.ID())
+	})
+}
+
+func TestPhotoUploadAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	orgID := domain.NewOrganizationID()
+	owner, staff, admin := domain.NewUserID(), domain.NewUserID(), domain.NewUserID()
+
+	upload := func(t *testing.T, status domain.PostStatus, caller domain.UserID) (*httptest.ResponseRecorder, *domain.Post, *recordingEventPublisher, *memoryPhotoStorage) {
+		post := domain.ReconstructPost(domain.NewPostID(), "Found keys", "Three keys on a red ring",
+			TestLocations.CentralPark, 1000, status, domain.PostTypeFound, owner, &orgID, time.Now(), time.Now(), nil)
+		users := repository.NewMockUserContextRepository()
+		users.SetMockUser(staff, &domain.PrivacySafeUser{UserID: staff, Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleStaff}})
+		users.SetMockUser(admin, &domain.PrivacySafeUser{UserID: admin, Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleAdmin}})
+		publisher := &recordingEventPublisher{}
+		postService := service.NewPostService(&updatablePostRepository{singlePostRepository: singlePostRepository{post: post}},
+			&savingPhotoRepository{}, users, repository.NewMockOrganizationContextRepository(), nil, publisher, nil, config.FeatureConfig{})
+		storage := &memoryPhotoStorage{}
+		router := newProductionRouterWithStorage(postService, nil, storage)
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("photos", "keys.jpg")
+		require.NoError(t, err)
+		_, err = file.Write([]byte("jpeg"))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/posts/"+post.ID().String()+"/photos", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set(handler.DevUserIDHeader, caller.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w, post, publisher, storage
+	}
+
+	t.Run("should forbid strangers and organization staff from adding photos", func(t *testing.T) {
+		for _, stranger := range []domain.UserID{domain.NewUserID(), staff} {
+			w, post, publisher, storage := upload(t, domain.PostStatusActive, stranger)
+
+			require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+			require.Empty(t, post.Photos())
+			require.Empty(t, publisher.events)
+			require.Equal(t, storage.uploaded, storage.deleted, "the uploaded file is removed again")
+		}
+	})
+
+	t.Run("should not acknowledge other users' drafts", func(t *testing.T) {
+		w, post, _, storage := upload(t, domain.PostStatusDraft, domain.NewUserID())
+
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+		require.Empty(t, post.Photos())
+		require.Equal(t, storage.uploaded, storage.deleted)
+	})
+
+	t.Run("should let the owner and organization admins add photos", func(t *testing.T) {
+		for _, editor := range []domain.UserID{owner, admin} {
+			w, post, publisher, storage := upload(t, domain.PostStatusActive, editor)
+
+			require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+			require.Len(t, post.Photos(), 1)
+			require.Len(t, publisher.events, 1)
+			require.Equal(t, domain.EventTypePhotoAdded, publisher.events[0].EventType)
+			require.Empty(t, storage.deleted)
+		}
+	})
+}
+
+// memoryPhotoStorage accepts every upload and remembers which files were stored and deleted
+type memoryPhotoStorage struct {
+	handler.StorageInterface
+	mu       sync.Mutex
+	uploaded []string
+	deleted  []string
+}
+
+func (s *memoryPhotoStorage) UploadPhoto(ctx context.Context, file multipart.File, header *multipart.FileHeader, postID uuid.UUID, organizationID *uuid.UUID) (*service.UploadResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	filename := postID.String() + "/" + header.Filename
+	s.uploaded = append(s.uploaded, filename)
+	return &service.UploadResult{URL: "https://example.com/" + filename, Size: header.Size, Format: "jpg", Filename: filename}, nil
+}
+
+func (s *memoryPhotoStorage) DeletePhoto(ctx context.Context, filename string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleted = append(s.deleted, filename)
+	return nil
+}
+
+// updatablePhotoRepository serves one photo by ID and accepts updates to it
+type updatablePhotoRepository struct {
+	singlePhotoRepository
+}
+
+func (r *updatablePhotoRepository) Update(ctx context.Context, photo *domain.Photo) error {
+	return nil
+}
+
+func TestSetCoverPhotoResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	owner := domain.NewUserID()
+	postID := domain.NewPostID()
+	photo := domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/keys.jpg", "", "Keys", 1, "jpg", 2048, time.Now())
+	post := domain.ReconstructPost(postID, "Found keys", "Three keys on a red ring",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, owner, nil, time.Now(), time.Now(), []domain.Photo{*photo})
+
+	setCover := func(posts domain.PostRepository, postID domain.PostID, photoID domain.PhotoID, userID domain.UserID) int {
+		router := newProductionRouter(service.NewPostService(posts, nil, repository.NewMockUserContextRepository(), nil, nil, &recordingEventPublisher{}, nil, config.FeatureConfig{}), nil)
+		body, err := json.Marshal(map[string]string{"photo_id": photoID.String()})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/api/posts/"+postID.String()+"/cover", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(handler.DevUserIDHeader, userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	posts := &updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}
+
+	require.Equal(t, http.StatusOK, setCover(posts, postID, photo.ID(), owner))
+	require.Equal(t, http.StatusForbidden, setCover(posts, postID, photo.ID(), domain.NewUserID()))
+	require.Equal(t, http.StatusNotFound, setCover(posts, postID, domain.NewPhotoID(), owner), "unknown photo")
+	require.Equal(t, http.StatusNotFound, setCover(posts, domain.NewPostID(), photo.ID(), owner), "unknown post")
+	require.Equal(t, http.StatusInternalServerError,
+		setCover(&failingUpdatePostRepository{updatablePostRepository: *posts}, postID, photo.ID(), owner),
+		"storage failures are not reported as a missing photo")
+}
+
+// failingUpdatePostRepository finds its post but cannot store changes to it
+type failingUpdatePostRepository struct {
+	updatablePostRepository
+}
+
+func (r *failingUpdatePostRepository) Update(ctx context.Context, post *domain.Post) error {
+	return errors.New("connection reset by peer")
+}
@@ -0,0 +1,179 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostBump(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, domain.SetPostBumpIntervalHours(24))
+	t.Cleanup(func() { _ = domain.SetPostBumpIntervalHours(0) })
+
+	owner := domain.NewUserID()
+	newPost := func(status domain.PostStatus, createdAt time.Time) *domain.Post {
+		return domain.ReconstructPost(domain.NewPostID(), "Lost umbrella", "Black, folding",
+			TestLocations.CentralPark, 1000, status, domain.PostTypeLost, owner, nil, createdAt, createdAt, nil)
+	}
+	newService := func(post *domain.Post) (*service.PostService, *bumpingPostRepository, *recordingEventPublisher) {
+		posts := &bumpingPostRepository{updatablePostRepository: updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}}
+		events := &recordingEventPublisher{}
+		return service.NewPostService(posts, nil, nil, nil, nil, events, nil, config.FeatureConfig{}), posts, events
+	}
+
+	t.Run("should bump an old post once per interval and announce it", func(t *testing.T) {
+		createdAt := time.Now().Add(-72 * time.Hour).Truncate(time.Second)
+		post := newPost(domain.PostStatusActive, createdAt)
+		postService, posts, events := newService(post)
+
+		bumped, err := postService.BumpPost(ctx, post.ID(), owner)
+		require.NoError(t, err)
+		require.NotNil(t, bumped.BumpedAt())
+		require.True(t, bumped.CreatedAt().Equal(createdAt), "bumping keeps the creation time")
+		require.Equal(t, 1, posts.bumps)
+
+		require.Len(t, events.events, 1)
+		require.Equal(t, domain.EventTypePostBumped, events.events[0].EventType)
+		data, ok := events.events[0].Payload.(*domain.PostBumpedEventData)
+		require.True(t, ok)
+		require.True(t, data.BumpedAt.Equal(*bumped.BumpedAt()))
+		require.Nil(t, data.PreviousBumpedAt)
+
+		_, err = postService.BumpPost(ctx, post.ID(), owner)
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorBumpTooSoon))
+		require.Equal(t, 1, posts.bumps)
+		require.Len(t, events.events, 1)
+	})
+
+	t.Run("should not bump a post created within the interval", func(t *testing.T) {
+		post := newPost(domain.PostStatusActive, time.Now().Add(-time.Hour))
+		postService, _, events := newService(post)
+
+		_, err := postService.BumpPost(ctx, post.ID(), owner)
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorBumpTooSoon))
+		require.Empty(t, events.events)
+	})
+
+	t.Run("should only let the owner bump an active post", func(t *testing.T) {
+		old := time.Now().Add(-72 * time.Hour)
+
+		active := newPost(domain.PostStatusActive, old)
+		postService, _, _ := newService(active)
+		_, err := postService.BumpPost(ctx, active.ID(), domain.NewUserID())
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+
+		draft := newPost(domain.PostStatusDraft, old)
+		postService, _, _ = newService(draft)
+		_, err = postService.BumpPost(ctx, draft.ID(), domain.NewUserID())
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorPostNotFound))
+
+		resolved := newPost(domain.PostStatusResolved, old)
+		postService, _, _ = newService(resolved)
+		_, err = postService.BumpPost(ctx, resolved.ID(), owner)
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorNotBumpable))
+	})
+
+	t.Run("should refuse a bump that lost the race to a concurrent one", func(t *testing.T) {
+		post := newPost(domain.PostStatusActive, time.Now().Add(-72*time.Hour))
+		postService, posts, events := newService(post)
+		posts.refuse = true
+
+		_, err := postService.BumpPost(ctx, post.ID(), owner)
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorBumpTooSoon))
+		require.Empty(t, events.events)
+	})
+}
+
+func TestPostBumpRepository(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	posts := repository.NewPostgresPostRepository(repository.NewDBRouter(db, nil))
+	author := domain.NewUserID()
+
+	save := func(t *testing.T, createdAt time.Time) *domain.Post {
+		post := domain.ReconstructPost(domain.NewPostID(), "Found scarf", "", TestLocations.CentralPark,
+			1000, domain.PostStatusActive, domain.PostTypeFound, author, nil, createdAt, createdAt, nil)
+		require.NoError(t, posts.Save(ctx, post))
+		t.Cleanup(func() {
+			_, _ = db.Exec("DELETE FROM posts WHERE id = $1", post.ID())
+		})
+		return post
+	}
+
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	oldest := save(t, base.Add(-72*time.Hour))
+	middle := save(t, base.Add(-48*time.Hour))
+	newest := save(t, base.Add(-time.Hour))
+
+	t.Run("should store only one of two concurrent bumps", func(t *testing.T) {
+		first, err := posts.FindByID(ctx, oldest.ID())
+		require.NoError(t, err)
+		second, err := posts.FindByID(ctx, oldest.ID())
+		require.NoError(t, err)
+
+		require.NoError(t, first.Bump())
+		bumped, err := posts.Bump(ctx, first, 24*time.Hour)
+		require.NoError(t, err)
+		require.True(t, bumped)
+
+		require.NoError(t, second.Bump())
+		bumped, err = posts.Bump(ctx, second, 24*time.Hour)
+		require.NoError(t, err)
+		require.False(t, bumped)
+
+		stored, err := posts.FindByID(ctx, oldest.ID())
+		require.NoError(t, err)
+		require.WithinDuration(t, *first.BumpedAt(), *stored.BumpedAt(), time.Microsecond)
+		require.True(t, stored.CreatedAt().Equal(oldest.CreatedAt()))
+	})
+
+	ids := func(listed []*domain.Post) []domain.PostID {
+		var ids []domain.PostID
+		for _, post := range listed {
+			ids = append(ids, post.ID())
+		}
+		return ids
+	}
+
+	t.Run("should list bumped posts first when sorted by bump time", func(t *testing.T) {
+		listed, err := posts.List(ctx, domain.PostFilters{UserID: &author})
+		require.NoError(t, err)
+		require.Equal(t, []domain.PostID{newest.ID(), middle.ID(), oldest.ID()}, ids(listed))
+
+		filters := domain.PostFilters{UserID: &author, Sort: domain.PostSortBumpedAt, Limit: 2}
+		page, hasMore, err := posts.ListPage(ctx, filters)
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Equal(t, []domain.PostID{oldest.ID(), newest.ID()}, ids(page))
+
+		cursor := domain.CursorAfterSorted(page[len(page)-1], domain.PostSortBumpedAt)
+		filters.Cursor = &cursor
+		page, hasMore, err = posts.ListPage(ctx, filters)
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Equal(t, []domain.PostID{middle.ID()}, ids(page))
+	})
+}
+
+// bumpingPostRepository knows about exactly one post and stores its bumps, or refuses them
+// as if a concurrent bump had been stored first
+type bumpingPostRepository struct {
+	updatablePostRepository
+	refuse bool
+	bumps  int
+}
+
+func (r *bumpingPostRepository) Bump(ctx context.Context, post *domain.Post, minInterval time.Duration) (bool, error) {
+	if r.refuse {
+		return false, nil
+	}
+	r.bumps++
+	return true, nil
+}
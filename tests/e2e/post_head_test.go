@@ -0,0 +1,74 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadPost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	owner := domain.NewUserID()
+	updatedAt := time.Date(2025, 3, 14, 9, 26, 53, 589793000, time.UTC)
+	newRouter := func(post *domain.Post) *gin.Engine {
+		postService := service.NewPostService(&singlePostRepository{post: post}, nil, nil, nil, nil, nil, nil, config.FeatureConfig{})
+		postHandler := handler.NewPostHandler(postService, nil, &config.Config{})
+
+		router := gin.New()
+		posts := router.Group("/posts", handler.AuthMiddleware(nil, true))
+		posts.GET("/:id", postHandler.GetPost)
+		posts.HEAD("/:id", postHandler.HeadPost)
+		return router
+	}
+	newPost := func(status domain.PostStatus) *domain.Post {
+		return domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+			TestLocations.CentralPark, 1000, status, domain.PostTypeFound, owner, nil, updatedAt, updatedAt, nil)
+	}
+	serve := func(router *gin.Engine, method, path string, viewer domain.UserID) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(handler.DevUserIDHeader, viewer.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should report an existing post's validators without a body", func(t *testing.T) {
+		post := newPost(domain.PostStatusActive)
+		router := newRouter(post)
+
+		head := serve(router, http.MethodHead, "/posts/"+post.ID().String(), domain.NewUserID())
+		require.Equal(t, http.StatusOK, head.Code)
+		require.Empty(t, head.Body.String())
+		require.Equal(t, "Fri, 14 Mar 2025 09:26:53 GMT", head.Header().Get("Last-Modified"))
+
+		get := serve(router, http.MethodGet, "/posts/"+post.ID().String(), domain.NewUserID())
+		require.Equal(t, http.StatusOK, get.Code)
+		require.NotEmpty(t, head.Header().Get("ETag"))
+		require.Equal(t, get.Header().Get("ETag"), head.Header().Get("ETag"))
+	})
+
+	t.Run("should report missing and hidden posts as not found", func(t *testing.T) {
+		draft := newPost(domain.PostStatusDraft)
+		router := newRouter(draft)
+
+		missing := serve(router, http.MethodHead, "/posts/"+domain.NewPostID().String(), owner)
+		require.Equal(t, http.StatusNotFound, missing.Code)
+		require.Empty(t, missing.Body.String())
+
+		hidden := serve(router, http.MethodHead, "/posts/"+draft.ID().String(), domain.NewUserID())
+		require.Equal(t, http.StatusNotFound, hidden.Code)
+		require.Empty(t, hidden.Header().Get("ETag"))
+
+		require.Equal(t, http.StatusOK, serve(router, http.MethodHead, "/posts/"+draft.ID().String(), owner).Code)
+		require.Equal(t, http.StatusBadRequest, serve(router, http.MethodHead, "/posts/not-a-uuid", owner).Code)
+	})
+}
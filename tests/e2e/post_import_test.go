@@ -0,0 +1,119 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostImport(t *testing.T) {
+	ctx := context.Background()
+
+	newEvent := func(title string) anti_corruption.ExternalPostCreatedEvent {
+		return anti_corruption.ExternalPostCreatedEvent{
+			EventID:   domain.NewPostID().String(),
+			EventType: "post.created",
+			Source:    "legacy",
+			Timestamp: time.Now(),
+			Data: anti_corruption.ExternalPostEventData{
+				PostID:    domain.NewPostID().String(),
+				Title:     title,
+				Location:  anti_corruption.ExternalLocation{Latitude: TestLocations.CentralPark.Latitude, Longitude: TestLocations.CentralPark.Longitude},
+				Type:      "lost",
+				UserID:    domain.NewUserID().String(),
+				CreatedAt: time.Now().Add(-time.Hour),
+				UpdatedAt: time.Now().Add(-time.Hour),
+				Photos: []anti_corruption.ExternalPhotoData{{
+					PhotoID: domain.NewPhotoID().String(), URL: "https://example.com/legacy.jpg", DisplayOrder: 1, Format: "jpg", SizeBytes: 2048,
+				}},
+			},
+		}
+	}
+	newService := func(posts *importingPostRepository) (*anti_corruption.PostImportService, *memoryOutboxRepository, *recordingTransactionManager) {
+		outbox := &memoryOutboxRepository{}
+		transactions := &recordingTransactionManager{}
+		importService := anti_corruption.NewPostImportService(anti_corruption.NewEventTranslator(), posts,
+			repository.NewMockUserContextRepository(), service.NewOutboxEventPublisher(outbox), transactions, 2)
+		return importService, outbox, transactions
+	}
+
+	t.Run("should store batches and record their created events in the outbox", func(t *testing.T) {
+		posts := &importingPostRepository{}
+		importService, outbox, transactions := newService(posts)
+
+		report, err := importService.ImportPosts(ctx, []anti_corruption.ExternalPostCreatedEvent{
+			newEvent("Lost keys"), newEvent("Lost wallet"), newEvent("Lost scarf"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, &anti_corruption.ImportReport{Total: 3, Imported: 3}, report)
+		require.Equal(t, 2, posts.batches, "two batches of at most two posts")
+		require.Len(t, posts.saved, 3)
+		require.Zero(t, transactions.rolledBack)
+
+		require.Len(t, outbox.events, 3)
+		for i, outboxEvent := range outbox.events {
+			require.Equal(t, domain.EventTypePostCreated, outboxEvent.Event.EventType)
+			require.Equal(t, posts.saved[i].ID().String(), outboxEvent.Event.AggregateID)
+		}
+	})
+
+	t.Run("should report bad records without stopping the rest", func(t *testing.T) {
+		posts := &importingPostRepository{reject: "Rejected by the database"}
+		importService, outbox, transactions := newService(posts)
+
+		untranslatable := newEvent("Lost umbrella")
+		untranslatable.Data.Type = "stolen"
+		rejected := newEvent("Rejected by the database")
+		report, err := importService.ImportPosts(ctx, []anti_corruption.ExternalPostCreatedEvent{
+			untranslatable, newEvent("Lost keys"), rejected,
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 3, report.Total)
+		require.Equal(t, 1, report.Imported)
+		require.Equal(t, 2, report.Failed)
+		require.Equal(t, 0, report.Errors[0].Index)
+		require.Equal(t, untranslatable.Data.PostID, report.Errors[0].ExternalPostID)
+		require.Equal(t, 2, report.Errors[1].Index)
+		require.Equal(t, rejected.Data.PostID, report.Errors[1].ExternalPostID)
+
+		require.Equal(t, 2, transactions.rolledBack, "the batch and the rejected post are rolled back")
+		require.Len(t, posts.saved, 1)
+		require.Len(t, outbox.events, 1, "only the imported post is announced")
+		require.Equal(t, posts.saved[0].ID().String(), outbox.events[0].Event.AggregateID)
+	})
+}
+
+// importingPostRepository keeps saved posts in memory and refuses posts titled reject,
+// failing the whole batch they are in as a database would
+type importingPostRepository struct {
+	domain.PostRepository
+	reject  string
+	saved   []*domain.Post
+	batches int
+}
+
+func (r *importingPostRepository) Save(ctx context.Context, post *domain.Post) error {
+	if post.Title() == r.reject {
+		return domain.ErrRepositoryConnection("save post")
+	}
+	r.saved = append(r.saved, post)
+	return nil
+}
+
+func (r *importingPostRepository) SaveBatch(ctx context.Context, posts []*domain.Post) error {
+	for _, post := range posts {
+		if post.Title() == r.reject {
+			return domain.ErrRepositoryConnection("save posts")
+		}
+	}
+	r.batches++
+	r.saved = append(r.saved, posts...)
+	return nil
+}
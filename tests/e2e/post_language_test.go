@@ -0,0 +1,88 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostLanguage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should normalize language tags to their ISO 639 code", func(t *testing.T) {
+		for tag, want := range map[string]string{"es-MX": "es", "PT_br": "pt", " fr ": "fr", "fil": "fil", "": ""} {
+			got, err := domain.ParseLanguage(tag)
+			require.NoError(t, err, tag)
+			require.Equal(t, want, got, tag)
+		}
+
+		for _, tag := range []string{"e", "english", "e5", "-es"} {
+			_, err := domain.ParseLanguage(tag)
+			require.True(t, domain.IsPostErrorCode(err, domain.PostErrorInvalidLanguage), tag)
+		}
+	})
+
+	newService := func(preferred string) (*service.PostService, *savingPostRepository, domain.UserID) {
+		creator := domain.NewUserID()
+		users := repository.NewMockUserContextRepository()
+		users.SetMockUser(creator, &domain.PrivacySafeUser{
+			UserID:      creator,
+			Preferences: domain.UserPreferences{Language: preferred},
+		})
+		posts := &savingPostRepository{}
+		return service.NewPostService(posts, nil, users, nil, nil, nil, nil, config.FeatureConfig{}), posts, creator
+	}
+
+	createDraft := func(t *testing.T, postService *service.PostService, creator domain.UserID, language string) (*domain.Post, error) {
+		return postService.CreateDraftPost(ctx, "Cartera perdida", "", nil, TestLocations.CentralPark,
+			1000, domain.PostTypeLost, creator, nil, language, nil)
+	}
+
+	t.Run("should default to the creator's preferred language", func(t *testing.T) {
+		postService, posts, creator := newService("es-MX")
+
+		post, err := createDraft(t, postService, creator, "")
+		require.NoError(t, err)
+		require.Equal(t, "es", post.Language())
+		require.Len(t, posts.saved, 1)
+		require.Equal(t, "es", *post.ToPostData().Language)
+	})
+
+	t.Run("should prefer an explicit language over the preference", func(t *testing.T) {
+		postService, _, creator := newService("es")
+
+		post, err := createDraft(t, postService, creator, "de")
+		require.NoError(t, err)
+		require.Equal(t, "de", post.Language())
+
+		_, err = createDraft(t, postService, creator, "deutsch")
+		var postErr domain.PostError
+		require.ErrorAs(t, err, &postErr)
+		require.Equal(t, domain.PostErrorInvalidLanguage, postErr.Code)
+	})
+
+	t.Run("should leave the language unset for an unusable preference", func(t *testing.T) {
+		postService, _, creator := newService("not a language")
+
+		post, err := createDraft(t, postService, creator, "")
+		require.NoError(t, err)
+		require.Empty(t, post.Language())
+		require.Nil(t, post.ToPostData().Language)
+	})
+}
+
+// savingPostRepository records the posts it is asked to save
+type savingPostRepository struct {
+	domain.PostRepository
+	saved []*domain.Post
+}
+
+func (r *savingPostRepository) Save(ctx context.Context, post *domain.Post) error {
+	r.saved = append(r.saved, post)
+	return nil
+}
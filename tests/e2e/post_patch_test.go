@@ -0,0 +1,114 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchPost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	owner := domain.NewUserID()
+	newFixture := func() (*domain.Post, *gin.Engine, *recordingEventPublisher) {
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost backpack", "Blue, with a laptop inside",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost, owner, nil,
+			time.Now(), time.Now(), nil)
+		require.NoError(t, post.SetTags([]string{"backpack"}))
+
+		events := &recordingEventPublisher{}
+		postService := service.NewPostService(&updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}, nil, nil, nil, nil,
+			events, nil, config.FeatureConfig{})
+		postHandler := handler.NewPostHandler(postService, nil, &config.Config{})
+
+		router := gin.New()
+		router.PATCH("/posts/:id", handler.AuthMiddleware(nil, true), postHandler.PatchPost)
+		return post, router, events
+	}
+	patch := func(router *gin.Engine, post *domain.Post, userID domain.UserID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/posts/"+post.ID().String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(handler.DevUserIDHeader, userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	updatedEvent := func(t *testing.T, events *recordingEventPublisher) *domain.PostUpdatedEventData {
+		require.Len(t, events.events, 1)
+		require.Equal(t, domain.EventTypePostUpdated, events.events[0].EventType)
+		data, ok := events.events[0].Payload.(*domain.PostUpdatedEventData)
+		require.True(t, ok)
+		return data
+	}
+
+	t.Run("should change only the radius and keep every other field", func(t *testing.T) {
+		post, router, events := newFixture()
+
+		w := patch(router, post, owner, `{"radius_meters": 2500}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response handler.PostResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, 2500, response.RadiusMeters)
+		require.Equal(t, "Lost backpack", response.Title)
+		require.Equal(t, "Blue, with a laptop inside", response.Description)
+		require.Equal(t, domain.PostTypeLost, response.Type)
+		require.Equal(t, []string{"backpack"}, response.Tags)
+		require.Equal(t, TestLocations.CentralPark, post.Location())
+
+		data := updatedEvent(t, events)
+		require.Equal(t, map[string]interface{}{"radius_meters": 2500}, data.Changes)
+		require.Equal(t, map[string]interface{}{"radius_meters": 1000}, data.Previous)
+	})
+
+	t.Run("should move the post and change its type", func(t *testing.T) {
+		post, router, events := newFixture()
+
+		w := patch(router, post, owner, `{"location": {"latitude": 40.7580, "longitude": -73.9855}, "type": "found", "title": "Lost backpack"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, domain.Location{Latitude: 40.7580, Longitude: -73.9855}, post.Location())
+		require.Equal(t, domain.PostTypeFound, post.PostType())
+
+		// The unchanged title is not reported as a change
+		data := updatedEvent(t, events)
+		require.Len(t, data.Changes, 2)
+		require.Equal(t, domain.PostTypeFound, data.Changes["type"])
+		require.Equal(t, domain.PostTypeLost, data.Previous["type"])
+		require.Equal(t, TestLocations.CentralPark, data.Previous["location"])
+	})
+
+	t.Run("should reject out of bounds values and leave the post unchanged", func(t *testing.T) {
+		post, router, events := newFixture()
+
+		w := patch(router, post, owner, `{"title": "Found backpack", "radius_meters": 50}`)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Contains(t, w.Body.String(), string(domain.PostErrorInvalidRadius))
+		require.Equal(t, "Lost backpack", post.Title())
+		require.Equal(t, 1000, post.RadiusMeters())
+
+		w = patch(router, post, owner, `{"location": {"latitude": 91, "longitude": 0}}`)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Contains(t, w.Body.String(), "INVALID_LOCATION")
+
+		require.Equal(t, http.StatusBadRequest, patch(router, post, owner, `{"type": "stolen"}`).Code)
+		require.Equal(t, http.StatusBadRequest, patch(router, post, owner, `{}`).Code)
+		require.Empty(t, events.events)
+	})
+
+	t.Run("should forbid other users", func(t *testing.T) {
+		post, router, _ := newFixture()
+
+		require.Equal(t, http.StatusForbidden, patch(router, post, domain.NewUserID(), `{"radius_meters": 2500}`).Code)
+		require.Equal(t, 1000, post.RadiusMeters())
+	})
+}
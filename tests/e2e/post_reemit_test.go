@@ -0,0 +1,61 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReemitPostEvent(t *testing.T) {
+	ctx := context.Background()
+	owner := domain.NewUserID()
+
+	reemit := func(t *testing.T, status domain.PostStatus) (*domain.PostEvent, *recordingEventPublisher, error) {
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost keys", "Three keys on a red ring",
+			TestLocations.CentralPark, 1000, status, domain.PostTypeLost, owner, nil,
+			time.Now(), time.Now(), nil)
+		publisher := &recordingEventPublisher{}
+		postService := service.NewPostService(&singlePostRepository{post: post}, nil,
+			repository.NewMockUserContextRepository(), nil, nil, publisher, nil, config.FeatureConfig{})
+
+		event, err := postService.ReemitPostEvent(ctx, post.ID(), "consumer missed it")
+		return event, publisher, err
+	}
+
+	t.Run("should replay active posts as created", func(t *testing.T) {
+		event, publisher, err := reemit(t, domain.PostStatusActive)
+		require.NoError(t, err)
+		require.Len(t, publisher.events, 1)
+		require.Same(t, event, publisher.events[0])
+
+		require.Equal(t, domain.EventTypePostCreated, event.EventType)
+		require.IsType(t, &domain.PostCreatedEventData{}, event.Payload)
+		require.NotNil(t, event.Metadata)
+		require.True(t, event.Metadata.Replay)
+		require.Equal(t, "consumer missed it", event.Metadata.ReplayReason)
+		require.NotNil(t, event.Metadata.ReplayedAt)
+	})
+
+	t.Run("should replay other posts with their current status", func(t *testing.T) {
+		event, _, err := reemit(t, domain.PostStatusResolved)
+		require.NoError(t, err)
+		require.Equal(t, domain.EventTypePostResolved, event.EventType)
+		require.True(t, event.Metadata.Replay)
+
+		data, ok := event.Payload.(*domain.PostStatusChangedEventData)
+		require.True(t, ok)
+		require.Equal(t, domain.PostStatusResolved, data.NewStatus)
+	})
+
+	t.Run("should not announce drafts", func(t *testing.T) {
+		_, publisher, err := reemit(t, domain.PostStatusDraft)
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorPostNotFound))
+		require.Empty(t, publisher.events)
+	})
+}
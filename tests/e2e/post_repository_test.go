@@ -0,0 +1,362 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostRepositoryRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	posts := repository.NewPostgresPostRepository(repository.NewDBRouter(db, nil))
+
+	orgID := domain.NewOrganizationID()
+	postID := domain.NewPostID()
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	photos := []domain.Photo{
+		*domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/1.jpg", "", "Front", 1, "jpg", 2048, createdAt),
+		*domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/2.png", "", "", 2, "png", 4096, createdAt),
+	}
+	post := domain.ReconstructPost(postID, "Found backpack", "Blue, with a laptop sleeve",
+		TestLocations.TimesSquare, 2000, domain.PostStatusActive, domain.PostTypeFound,
+		domain.NewUserID(), &orgID, createdAt, createdAt, photos)
+	require.NoError(t, post.SetLanguage("en"))
+	require.NoError(t, post.SetTags([]string{"backpack", "laptop"}))
+
+	require.NoError(t, posts.Save(ctx, post))
+	t.Cleanup(func() {
+		_, _ = db.Exec("DELETE FROM posts WHERE id = $1", postID)
+	})
+
+	t.Run("should reload every field that was saved", func(t *testing.T) {
+		found, err := posts.FindByID(ctx, postID)
+		require.NoError(t, err)
+
+		require.True(t, found.ID().Equals(postID))
+		require.Equal(t, post.Title(), found.Title())
+		require.Equal(t, post.Description(), found.Description())
+		AssertLocationNear(t, post.Location(), found.Location(), 1)
+		require.Equal(t, post.RadiusMeters(), found.RadiusMeters())
+		require.Equal(t, post.Status(), found.Status())
+		require.Equal(t, post.PostType(), found.PostType())
+		require.True(t, found.CreatedBy().Equals(post.CreatedBy()))
+		require.True(t, found.OrganizationID().Equals(orgID))
+		require.Equal(t, "en", found.Language())
+		require.Equal(t, []string{"backpack", "laptop"}, found.Tags())
+		require.True(t, found.CreatedAt().Equal(createdAt))
+
+		require.Len(t, found.Photos(), 2)
+		for i, photo := range found.Photos() {
+			require.True(t, photo.ID().Equals(photos[i].ID()))
+			require.True(t, photo.PostID().Equals(postID))
+			require.Equal(t, photos[i].URL(), photo.URL())
+		}
+	})
+
+	t.Run("should bind IDs on update", func(t *testing.T) {
+		found, err := posts.FindByID(ctx, postID)
+		require.NoError(t, err)
+		require.NoError(t, found.SetCoverPhoto(photos[1].ID()))
+		title, radius, postType := "Found blue backpack", 3000, domain.PostTypeLost
+		require.NoError(t, found.Update(domain.PostUpdate{Title: &title, RadiusMeters: &radius, Type: &postType}))
+		require.NoError(t, posts.Update(ctx, found))
+
+		updated, err := posts.FindByID(ctx, postID)
+		require.NoError(t, err)
+		require.Equal(t, "Found blue backpack", updated.Title())
+		require.Equal(t, 3000, updated.RadiusMeters())
+		require.Equal(t, domain.PostTypeLost, updated.PostType())
+		require.Equal(t, "Blue, with a laptop sleeve", updated.Description())
+		require.NotNil(t, updated.ChosenCoverPhotoID())
+		require.True(t, updated.ChosenCoverPhotoID().Equals(photos[1].ID()))
+		require.True(t, updated.CreatedBy().Equals(post.CreatedBy()))
+	})
+
+	t.Run("should only apply writes expecting the current version", func(t *testing.T) {
+		found, err := posts.FindByID(ctx, postID)
+		require.NoError(t, err)
+		stale := domain.WithExpectedVersion(ctx, found.UpdatedAt().Add(-time.Second))
+		current := domain.WithExpectedVersion(ctx, found.UpdatedAt())
+
+		title := "Found navy backpack"
+		require.NoError(t, found.Update(domain.PostUpdate{Title: &title}))
+		require.True(t, domain.IsPostErrorCode(posts.Update(stale, found), domain.BusinessErrorPostModified))
+		require.True(t, domain.IsPostErrorCode(posts.Delete(stale, postID), domain.BusinessErrorPostModified))
+
+		require.NoError(t, posts.Update(current, found))
+		require.True(t, domain.IsPostErrorCode(posts.Update(current, found), domain.BusinessErrorPostModified),
+			"the first write moved the post to a newer version")
+
+		updated, err := posts.FindByID(ctx, postID)
+		require.NoError(t, err)
+		require.Equal(t, "Found navy backpack", updated.Title())
+		require.Equal(t, domain.PostStatusActive, updated.Status())
+	})
+
+	t.Run("should filter by every requested tag", func(t *testing.T) {
+		listed, err := posts.List(ctx, domain.PostFilters{OrganizationID: &orgID, Tags: []string{"laptop", "backpack"}, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+
+		listed, err = posts.List(ctx, domain.PostFilters{OrganizationID: &orgID, Tags: []string{"laptop", "wallet"}, Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, listed)
+
+		count, err := posts.Count(ctx, domain.PostFilters{OrganizationID: &orgID, Tags: []string{"backpack"}})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+	})
+}
+
+func TestPostRepositorySaveRollsBackOnPhotoFailure(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	posts := repository.NewPostgresPostRepository(repository.NewDBRouter(db, nil))
+
+	postID := domain.NewPostID()
+	createdAt := time.Now().UTC()
+	first := *domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/1.jpg", "", "", 1, "jpg", 2048, createdAt)
+	second := *domain.ReconstructPhoto(domain.NewPhotoID(), postID, "https://example.com/2.jpg", "", "", 2, "jpg", 2048, createdAt)
+	// The third photo reuses the first one's ID, so its insert fails after two photos went in
+	duplicate := *domain.ReconstructPhoto(first.ID(), postID, "https://example.com/3.jpg", "", "", 3, "jpg", 2048, createdAt)
+	post := domain.ReconstructPost(postID, "Found umbrella", "Black, folding",
+		TestLocations.BrooklynBridge, 1000, domain.PostStatusActive, domain.PostTypeFound,
+		domain.NewUserID(), nil, createdAt, createdAt, []domain.Photo{first, second, duplicate})
+	t.Cleanup(func() {
+		_, _ = db.Exec("DELETE FROM posts WHERE id = $1", postID)
+	})
+
+	require.Error(t, posts.Save(ctx, post))
+
+	_, err := posts.FindByID(ctx, postID)
+	require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorPostNotFound), "the post is rolled back with its photos")
+
+	var photoCount int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM post_photos WHERE post_id = $1", postID).Scan(&photoCount))
+	require.Zero(t, photoCount)
+}
+
+func TestPostRepositoryLocationAxes(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	posts := repository.NewPostgresPostRepository(repository.NewDBRouter(db, nil))
+
+	save := func(t *testing.T, location domain.Location) *domain.Post {
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost scarf", "Green wool",
+			location, 50000, domain.PostStatusActive, domain.PostTypeLost,
+			domain.NewUserID(), nil, time.Now(), time.Now(), nil)
+		require.NoError(t, posts.Save(ctx, post))
+		t.Cleanup(func() {
+			_, _ = db.Exec("DELETE FROM posts WHERE id = $1", post.ID())
+		})
+		return post
+	}
+
+	// nearbyIDsWithin searches in meters and keeps only the given posts, in result order
+	nearbyIDsWithin := func(t *testing.T, respectPostRadius bool, from domain.Location, meters float64, saved ...*domain.Post) []domain.PostID {
+		found, err := posts.FindNearby(ctx, from, domain.Distance{Meters: meters}, nil, respectPostRadius, 0, 100, 0, 0)
+		require.NoError(t, err)
+
+		var ids []domain.PostID
+		for _, post := range found {
+			for _, s := range saved {
+				if post.ID().Equals(s.ID()) {
+					ids = append(ids, post.ID())
+				}
+			}
+		}
+		return ids
+	}
+	nearbyIDs := func(t *testing.T, from domain.Location, meters float64, saved ...*domain.Post) []domain.PostID {
+		return nearbyIDsWithin(t, true, from, meters, saved...)
+	}
+
+	t.Run("should reload the saved coordinate", func(t *testing.T) {
+		// Latitude and longitude differ in sign and magnitude so a swap cannot go unnoticed
+		for _, location := range []domain.Location{
+			TestLocations.CentralPark,
+			{Latitude: -33.8568, Longitude: 151.2153},
+			{Latitude: 64.1466, Longitude: -21.9426},
+		} {
+			post := save(t, location)
+
+			found, err := posts.FindByID(ctx, post.ID())
+			require.NoError(t, err)
+			AssertLocationNear(t, location, found.Location(), 0.5)
+		}
+	})
+
+	t.Run("should order nearby posts by distance", func(t *testing.T) {
+		timesSquare := save(t, TestLocations.TimesSquare)
+		brooklyn := save(t, TestLocations.BrooklynBridge)
+		centralPark := save(t, TestLocations.CentralPark)
+
+		ids := nearbyIDs(t, TestLocations.EmpireState, 20000, brooklyn, centralPark, timesSquare)
+		require.Equal(t, []domain.PostID{timesSquare.ID(), centralPark.ID(), brooklyn.ID()}, ids)
+	})
+
+	t.Run("should measure the search radius in meters whether or not post radii are respected", func(t *testing.T) {
+		// Every post reaches 50km, further than the search radius, so only the search radius bounds them
+		timesSquare := save(t, TestLocations.TimesSquare)
+		brooklyn := save(t, TestLocations.BrooklynBridge)
+		centralPark := save(t, TestLocations.CentralPark)
+
+		for _, meters := range []float64{1000, 5000, 20000} {
+			respecting := nearbyIDsWithin(t, true, TestLocations.EmpireState, meters, brooklyn, centralPark, timesSquare)
+			ignoring := nearbyIDsWithin(t, false, TestLocations.EmpireState, meters, brooklyn, centralPark, timesSquare)
+			require.Equal(t, respecting, ignoring, "within %.0fm", meters)
+		}
+		require.Equal(t, []domain.PostID{timesSquare.ID()}, nearbyIDsWithin(t, false, TestLocations.EmpireState, 1000, brooklyn, centralPark, timesSquare))
+	})
+
+	t.Run("should not find posts at the swapped coordinate", func(t *testing.T) {
+		post := save(t, TestLocations.CentralPark)
+		swapped := domain.Location{
+			Latitude:  TestLocations.CentralPark.Longitude,
+			Longitude: TestLocations.CentralPark.Latitude,
+		}
+
+		require.Empty(t, nearbyIDs(t, swapped, 10000, post))
+		require.Len(t, nearbyIDs(t, TestLocations.CentralPark, 10000, post), 1)
+	})
+
+	t.Run("should page through only the nearest candidates", func(t *testing.T) {
+		reykjavik := domain.Location{Latitude: 64.1466, Longitude: -21.9426}
+		var saved []*domain.Post
+		for i := 1; i <= 3; i++ {
+			saved = append(saved, save(t, domain.Location{Latitude: reykjavik.Latitude + 0.001*float64(i), Longitude: reykjavik.Longitude}))
+		}
+
+		found, err := posts.FindNearby(ctx, reykjavik, domain.Distance{Meters: 10000}, nil, true, 2, 100, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		require.True(t, found[0].ID().Equals(saved[0].ID()))
+		require.True(t, found[1].ID().Equals(saved[1].ID()))
+
+		found, err = posts.FindNearby(ctx, reykjavik, domain.Distance{Meters: 10000}, nil, true, 2, 100, 1, 0)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.True(t, found[0].ID().Equals(saved[1].ID()))
+	})
+}
+
+func TestPostCursorPagination(t *testing.T) {
+	t.Run("should round-trip a cursor through its token", func(t *testing.T) {
+		cursor := domain.PostCursor{
+			CreatedAt: time.Date(2026, time.March, 4, 5, 6, 7, 891011000, time.UTC),
+			ID:        domain.NewPostID(),
+		}
+
+		decoded, err := domain.DecodePostCursor(cursor.Encode())
+		require.NoError(t, err)
+		require.True(t, decoded.CreatedAt.Equal(cursor.CreatedAt))
+		require.True(t, decoded.ID.Equals(cursor.ID))
+
+		for _, token := range []string{"", "not base64!", "bm8tc2VwYXJhdG9y", cursor.Encode()[:10]} {
+			_, err := domain.DecodePostCursor(token)
+			require.ErrorIs(t, err, domain.ErrInvalidCursor, token)
+		}
+	})
+
+	db := openTestDB(t)
+	ctx := context.Background()
+	posts := repository.NewPostgresPostRepository(repository.NewDBRouter(db, nil))
+	author := domain.NewUserID()
+
+	save := func(t *testing.T, createdAt time.Time) *domain.Post {
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost glove", "", TestLocations.CentralPark,
+			1000, domain.PostStatusActive, domain.PostTypeLost, author, nil, createdAt, createdAt, nil)
+		require.NoError(t, posts.Save(ctx, post))
+		t.Cleanup(func() {
+			_, _ = db.Exec("DELETE FROM posts WHERE id = $1", post.ID())
+		})
+		return post
+	}
+
+	t.Run("should visit every post once while new posts arrive", func(t *testing.T) {
+		base := time.Now().UTC().Truncate(time.Microsecond).Add(-time.Hour)
+		var expected []domain.PostID
+		for i := 0; i < 5; i++ {
+			// Two posts share each timestamp so the id tie-break is exercised
+			createdAt := base.Add(-time.Duration(i/2) * time.Minute)
+			expected = append(expected, save(t, createdAt).ID())
+		}
+
+		filters := domain.PostFilters{UserID: &author, Limit: 2}
+		var seen []domain.PostID
+		for page := 0; ; page++ {
+			require.Less(t, page, 5, "pagination did not terminate")
+
+			listed, hasMore, err := posts.ListPage(ctx, filters)
+			require.NoError(t, err)
+			for _, post := range listed {
+				seen = append(seen, post.ID())
+			}
+
+			// A newer post created mid-iteration must not shift later pages
+			save(t, time.Now().UTC())
+
+			if !hasMore {
+				break
+			}
+			cursor := domain.CursorAfter(listed[len(listed)-1])
+			filters.Cursor = &cursor
+		}
+
+		require.ElementsMatch(t, expected, seen)
+		require.Len(t, seen, len(expected))
+	})
+}
+
+func TestPhotoRepositoryRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	router := repository.NewDBRouter(db, nil)
+	posts := repository.NewPostgresPostRepository(router)
+	photos := repository.NewPostgresPhotoRepository(router)
+
+	post := domain.ReconstructPost(domain.NewPostID(), "Found earring", "Silver hoop",
+		TestLocations.CentralPark, 500, domain.PostStatusActive, domain.PostTypeFound,
+		domain.NewUserID(), nil, time.Now(), time.Now(), nil)
+	require.NoError(t, posts.Save(ctx, post))
+	t.Cleanup(func() {
+		_, _ = db.Exec("DELETE FROM posts WHERE id = $1", post.ID())
+	})
+
+	photo, err := domain.NewPhoto(domain.CreatePhotoRequest{
+		PostID:       post.ID(),
+		URL:          "https://example.com/public/earring.webp",
+		Filename:     "public/earring.webp",
+		DisplayOrder: 1,
+		Format:       "webp",
+		SizeBytes:    2048,
+		Width:        1200,
+		Height:       900,
+	})
+	require.NoError(t, err)
+	require.NoError(t, photos.Save(ctx, photo))
+
+	t.Run("should reload dimensions and filename into event data", func(t *testing.T) {
+		found, err := photos.FindByID(ctx, photo.ID())
+		require.NoError(t, err)
+
+		data := found.ToPhotoData()
+		require.Equal(t, 1200, data.Width)
+		require.Equal(t, 900, data.Height)
+		require.Equal(t, "public/earring.webp", data.Filename)
+		require.Equal(t, "image/webp", data.MimeType)
+	})
+
+	t.Run("should load the filename with the post's photos", func(t *testing.T) {
+		found, err := posts.FindByID(ctx, post.ID())
+		require.NoError(t, err)
+		require.Len(t, found.Photos(), 1)
+		require.Equal(t, "public/earring.webp", found.Photos()[0].Filename())
+		require.Equal(t, 1200, found.Photos()[0].Width())
+	})
+}
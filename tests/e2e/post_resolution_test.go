@@ -0,0 +1,165 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostResolution(t *testing.T) {
+	ctx := context.Background()
+	gin.SetMode(gin.TestMode)
+
+	owner := domain.NewUserID()
+	newFixture := func() (*domain.Post, *updatablePostRepository, *recordingEventPublisher, *service.PostService) {
+		createdAt := time.Now().Add(-50 * time.Hour)
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost keys", "Three keys on a red ring",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost, owner, nil, createdAt, createdAt, nil)
+		posts := &updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}
+		events := &recordingEventPublisher{}
+		return post, posts, events, service.NewPostService(posts, nil, nil, nil, nil, events, nil, config.FeatureConfig{})
+	}
+
+	t.Run("should record how a post was resolved and announce it", func(t *testing.T) {
+		post, posts, events, postService := newFixture()
+		matchID := "match-42"
+		score := 5
+
+		_, err := postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusResolved, &domain.ResolutionDetails{
+			Type: domain.ResolutionTypeMatched, MatchID: &matchID, UserSatisfactionScore: &score,
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, posts.resolution)
+		require.Equal(t, domain.ResolutionTypeMatched, posts.resolution.ResolutionType)
+		require.Equal(t, &matchID, posts.resolution.MatchID)
+		require.Equal(t, 50, posts.resolution.SuccessMetrics.TimeToResolution)
+		require.Equal(t, &score, posts.resolution.SuccessMetrics.UserSatisfactionScore)
+		require.Equal(t, post.UpdatedAt(), posts.resolution.ResolvedAt)
+
+		require.Len(t, events.events, 1)
+		data, ok := events.events[0].Payload.(*domain.PostStatusChangedEventData)
+		require.True(t, ok)
+		require.Equal(t, posts.resolution, data.ResolutionData)
+	})
+
+	t.Run("should record resolutions without details as other and forget them on reopening", func(t *testing.T) {
+		post, posts, _, postService := newFixture()
+
+		_, err := postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusResolved, nil)
+		require.NoError(t, err)
+		require.Equal(t, domain.ResolutionTypeOther, posts.resolution.ResolutionType)
+
+		_, err = postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusActive, nil)
+		require.NoError(t, err)
+		require.Nil(t, posts.resolution)
+	})
+
+	t.Run("should reject invalid resolution details", func(t *testing.T) {
+		post, posts, events, postService := newFixture()
+		score := 6
+
+		_, err := postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusResolved, &domain.ResolutionDetails{Type: "lucky"})
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorInvalidResolution))
+
+		_, err = postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusResolved, &domain.ResolutionDetails{
+			Type: domain.ResolutionTypeSelfFound, UserSatisfactionScore: &score,
+		})
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorInvalidResolution))
+
+		_, err = postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusExpired, &domain.ResolutionDetails{Type: domain.ResolutionTypeSelfFound})
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorInvalidResolution))
+
+		require.Nil(t, posts.resolution)
+		require.Empty(t, events.events)
+	})
+
+	t.Run("should serve a post's resolution", func(t *testing.T) {
+		post, _, _, postService := newFixture()
+		router := gin.New()
+		router.GET("/posts/:id/resolution", handler.AuthMiddleware(nil, true), handler.NewPostHandler(postService, nil, &config.Config{}).GetPostResolution)
+		get := func() *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/posts/"+post.ID().String()+"/resolution", nil)
+			req.Header.Set(handler.DevUserIDHeader, domain.NewUserID().String())
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			return w
+		}
+
+		require.Equal(t, http.StatusNotFound, get().Code)
+
+		_, err := postService.UpdatePostStatus(ctx, post.ID(), owner, domain.PostStatusResolved, &domain.ResolutionDetails{Type: domain.ResolutionTypeSelfFound})
+		require.NoError(t, err)
+
+		w := get()
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resolution domain.ResolutionData
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resolution))
+		require.Equal(t, domain.ResolutionTypeSelfFound, resolution.ResolutionType)
+		require.Equal(t, 50, resolution.SuccessMetrics.TimeToResolution)
+	})
+}
+
+func TestResolutionStats(t *testing.T) {
+	ctx := context.Background()
+	orgID := domain.NewOrganizationID()
+	member := domain.NewUserID()
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+	users := repository.NewMockUserContextRepository()
+	users.SetMockUser(member, &domain.PrivacySafeUser{
+		UserID:       member,
+		Organization: &domain.OrganizationContext{OrganizationID: orgID, Role: domain.OrganizationRoleStaff},
+	})
+	posts := &resolutionStatsPostRepository{byType: map[string]domain.ResolutionTypeStats{
+		domain.ResolutionTypeMatched:   {Count: 3, AverageTimeToResolutionHours: 10},
+		domain.ResolutionTypeSelfFound: {Count: 1, AverageTimeToResolutionHours: 30},
+	}}
+	postService := service.NewPostService(posts, nil, users, nil, nil, nil, nil, config.FeatureConfig{})
+
+	t.Run("should weight the overall average by each type's count", func(t *testing.T) {
+		filter := domain.ResolutionStatsFilter{OrganizationID: orgID, From: &from}
+		stats, err := postService.GetResolutionStats(ctx, filter, member)
+		require.NoError(t, err)
+		require.Equal(t, int64(4), stats.Total)
+		require.InDelta(t, 15, stats.AverageTimeToResolutionHours, 0.0001)
+		require.Equal(t, int64(3), stats.ByType[domain.ResolutionTypeMatched].Count)
+		require.Equal(t, filter, posts.filter)
+	})
+
+	t.Run("should report no resolutions as empty stats", func(t *testing.T) {
+		stats := domain.NewResolutionStats(nil)
+		require.Zero(t, stats.Total)
+		require.Zero(t, stats.AverageTimeToResolutionHours)
+		require.NotNil(t, stats.ByType)
+	})
+
+	t.Run("should not report stats to users outside the organization", func(t *testing.T) {
+		_, err := postService.GetResolutionStats(ctx, domain.ResolutionStatsFilter{OrganizationID: orgID}, domain.NewUserID())
+		require.True(t, domain.IsPostErrorCode(err, domain.BusinessErrorUnauthorized))
+	})
+}
+
+// resolutionStatsPostRepository answers resolution stats per type and records the filter it
+// was asked with
+type resolutionStatsPostRepository struct {
+	domain.PostRepository
+	byType map[string]domain.ResolutionTypeStats
+	filter domain.ResolutionStatsFilter
+}
+
+func (r *resolutionStatsPostRepository) ResolutionStats(ctx context.Context, filter domain.ResolutionStatsFilter) (*domain.ResolutionStats, error) {
+	r.filter = filter
+	return domain.NewResolutionStats(r.byType), nil
+}
@@ -0,0 +1,95 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostTags(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should lowercase tags and drop blanks and duplicates", func(t *testing.T) {
+		tags, err := domain.NormalizeTags([]string{" Wallet", "LEATHER", "", "wallet", "brown "})
+		require.NoError(t, err)
+		require.Equal(t, []string{"wallet", "leather", "brown"}, tags)
+	})
+
+	t.Run("should cap the number and length of tags", func(t *testing.T) {
+		tooMany := make([]string, domain.MaxTagsPerPost+1)
+		for i := range tooMany {
+			tooMany[i] = fmt.Sprintf("tag%d", i)
+		}
+		_, err := domain.NormalizeTags(tooMany)
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorInvalidTags))
+
+		_, err = domain.NormalizeTags([]string{string(make([]rune, domain.MaxTagLength+1))})
+		require.True(t, domain.IsPostErrorCode(err, domain.PostErrorInvalidTags))
+
+		// Duplicates collapse before the cap is applied
+		_, err = domain.NormalizeTags(append(tooMany[:domain.MaxTagsPerPost], "TAG0"))
+		require.NoError(t, err)
+	})
+
+	t.Run("should carry stored tags in event data", func(t *testing.T) {
+		post, err := domain.NewDraftPost("Lost wallet", "", nil, TestLocations.CentralPark, 1000, domain.PostTypeLost, domain.NewUserID(), nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{}, post.ToPostData().Tags)
+
+		require.NoError(t, post.SetTags([]string{"Wallet", "Leather"}))
+		require.Equal(t, []string{"wallet", "leather"}, post.ToPostData().Tags)
+	})
+
+	t.Run("should keep tags on updates that omit them and clear them on empty tags", func(t *testing.T) {
+		post, err := domain.NewDraftPost("Lost wallet", "", nil, TestLocations.CentralPark, 1000, domain.PostTypeLost, domain.NewUserID(), nil)
+		require.NoError(t, err)
+		require.NoError(t, post.SetTags([]string{"wallet"}))
+		postService := service.NewPostService(&updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}, nil, nil, nil, nil, nil, nil, config.FeatureConfig{})
+
+		title := "Lost brown wallet"
+		updated, err := postService.UpdatePost(ctx, post.ID(), post.CreatedBy(), domain.PostUpdate{Title: &title})
+		require.NoError(t, err)
+		require.Equal(t, []string{"wallet"}, updated.Tags())
+
+		updated, err = postService.UpdatePost(ctx, post.ID(), post.CreatedBy(), domain.PostUpdate{Title: &title, Tags: []string{"Brown", "wallet"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"brown", "wallet"}, updated.Tags())
+
+		updated, err = postService.UpdatePost(ctx, post.ID(), post.CreatedBy(), domain.PostUpdate{Title: &title, Tags: []string{}})
+		require.NoError(t, err)
+		require.Empty(t, updated.Tags())
+	})
+}
+
+// updatablePostRepository knows about exactly one post and accepts updates to it and its
+// resolution
+type updatablePostRepository struct {
+	singlePostRepository
+	resolution *domain.ResolutionData
+}
+
+func (r *updatablePostRepository) Update(ctx context.Context, post *domain.Post) error {
+	return nil
+}
+
+func (r *updatablePostRepository) SaveResolution(ctx context.Context, postID domain.PostID, resolution *domain.ResolutionData) error {
+	r.resolution = resolution
+	return nil
+}
+
+func (r *updatablePostRepository) DeleteResolution(ctx context.Context, postID domain.PostID) error {
+	r.resolution = nil
+	return nil
+}
+
+func (r *updatablePostRepository) FindResolution(ctx context.Context, postID domain.PostID) (*domain.ResolutionData, error) {
+	if r.resolution == nil {
+		return nil, domain.ErrResolutionNotFound(postID)
+	}
+	return r.resolution, nil
+}
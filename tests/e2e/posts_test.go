@@ -1,10 +1,14 @@
 package e2e
 
 import (
+	"bytes"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/jsarabia/fn-posts/internal/domain"
 	"github.com/stretchr/testify/require"
 )
@@ -94,6 +98,58 @@ func TestCreatePost(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("should reject out-of-range coordinates with field errors", func(t *testing.T) {
+		fields := map[string]string{
+			"title":         "Valid title",
+			"type":          "lost",
+			"latitude":      "91",
+			"longitude":     "-200",
+			"radius_meters": "1000",
+		}
+
+		resp := makeMultipartRequest(t, "/posts", fields, map[string]string{})
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		var errResp struct {
+			Code   string            `json:"code"`
+			Fields map[string]string `json:"fields"`
+		}
+		parseResponse(t, resp, &errResp)
+
+		require.Equal(t, "INVALID_LOCATION", errResp.Code)
+		require.Contains(t, errResp.Fields, "latitude")
+		require.Contains(t, errResp.Fields, "longitude")
+	})
+
+	t.Run("should require an authenticated user", func(t *testing.T) {
+		for _, userID := range []string{"", "not-a-uuid", "00000000-0000-0000-0000-000000000000"} {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			for key, value := range map[string]string{
+				"title":         "Ownerless post",
+				"type":          "lost",
+				"latitude":      "40.7829",
+				"longitude":     "-73.9654",
+				"radius_meters": "1000",
+			} {
+				require.NoError(t, writer.WriteField(key, value))
+			}
+			require.NoError(t, writer.Close())
+
+			req, err := http.NewRequest("POST", BaseURL+"/posts", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			if userID != "" {
+				req.Header.Set("X-User-ID", userID)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode, "X-User-ID %q", userID)
+			resp.Body.Close()
+		}
+	})
 }
 
 func TestGetPost(t *testing.T) {
@@ -184,6 +240,44 @@ func TestUpdatePostStatus(t *testing.T) {
 		require.Equal(t, "resolved", updatedPost.Status)
 	})
 
+	t.Run("should archive resolved posts and hide them from the default list", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		// Active posts must be resolved before they can be archived
+		resp := makeRequest(t, "PATCH", fmt.Sprintf("/posts/%s/status", post.ID), UpdatePostStatusRequest{Status: "archived"})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp = makeRequest(t, "PATCH", fmt.Sprintf("/posts/%s/status", post.ID), UpdatePostStatusRequest{Status: "resolved"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp = makeRequest(t, "PATCH", fmt.Sprintf("/posts/%s/status", post.ID), UpdatePostStatusRequest{Status: "archived"})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var archived PostResponse
+		parseResponse(t, resp, &archived)
+		require.Equal(t, "archived", archived.Status)
+
+		resp = makeRequest(t, "GET", "/posts?limit=100", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var listResp ListPostsResponse
+		parseResponse(t, resp, &listResp)
+		for _, listed := range listResp.Posts {
+			require.NotEqual(t, post.ID, listed.ID)
+		}
+
+		resp = makeRequest(t, "GET", "/posts?status=archived&limit=100", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		parseResponse(t, resp, &listResp)
+		found := false
+		for _, listed := range listResp.Posts {
+			found = found || listed.ID == post.ID
+		}
+		require.True(t, found)
+	})
+
 	t.Run("should fail with invalid status", func(t *testing.T) {
 		post := CreateTestPostWithDefaults(t)
 		defer CleanupPost(t, post.ID)
@@ -269,6 +363,41 @@ func TestListPosts(t *testing.T) {
 		}
 	})
 
+	t.Run("should reject an unknown post type", func(t *testing.T) {
+		endpoints := []string{
+			"/posts?type=invalid",
+			"/posts/nearby?lat=40.7829&lng=-73.9654&type=invalid",
+			"/posts/heatmap?bbox=-74.05,40.68,-73.90,40.82&type=invalid",
+		}
+
+		for _, endpoint := range endpoints {
+			resp := makeRequest(t, "GET", endpoint, nil)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, endpoint)
+			require.Contains(t, parseErrorResponse(t, resp), "Invalid post type", endpoint)
+		}
+	})
+
+	t.Run("should reject an unknown status", func(t *testing.T) {
+		endpoints := []string{
+			"/posts?status=bogus",
+			"/posts/heatmap?bbox=-74.05,40.68,-73.90,40.82&status=bogus",
+			"/contacts/exchange?role=requester&status=bogus",
+		}
+
+		for _, endpoint := range endpoints {
+			resp := makeRequest(t, "GET", endpoint, nil)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, endpoint)
+			require.Contains(t, parseErrorResponse(t, resp), "Invalid status", endpoint)
+		}
+
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		resp := makeRequest(t, "PATCH", fmt.Sprintf("/posts/%s/status", post.ID), UpdatePostStatusRequest{Status: "bogus"})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Contains(t, parseErrorResponse(t, resp), "Invalid status")
+	})
+
 	t.Run("should filter posts by status", func(t *testing.T) {
 		// Create a post and mark it as resolved
 		post := CreateTestPostWithDefaults(t)
@@ -290,6 +419,64 @@ func TestListPosts(t *testing.T) {
 			require.Equal(t, "resolved", p.Status)
 		}
 	})
+
+	t.Run("should filter posts by one or several organizations", func(t *testing.T) {
+		orgIDs := []string{uuid.New().String(), uuid.New().String(), uuid.New().String()}
+		postIDs := make([]string, len(orgIDs))
+		for i := range orgIDs {
+			post := CreateTestPost(t, CreatePostRequest{
+				Title:          fmt.Sprintf("Organization post %d", i+1),
+				Location:       TestLocations.CentralPark,
+				RadiusMeters:   1000,
+				Type:           "found",
+				OrganizationID: &orgIDs[i],
+			})
+			defer CleanupPost(t, post.ID)
+			postIDs[i] = post.ID
+		}
+
+		listIDs := func(query string) []string {
+			resp := makeRequest(t, "GET", "/posts?limit=100&"+query, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var listResp ListPostsResponse
+			parseResponse(t, resp, &listResp)
+			require.Equal(t, int64(len(listResp.Posts)), listResp.Total)
+
+			ids := make([]string, len(listResp.Posts))
+			for i, p := range listResp.Posts {
+				ids[i] = p.ID
+			}
+			return ids
+		}
+
+		require.ElementsMatch(t, postIDs[:1], listIDs("organization_id="+orgIDs[0]))
+		require.ElementsMatch(t, postIDs[:2],
+			listIDs(fmt.Sprintf("organization_id=%s&organization_id=%s", orgIDs[0], orgIDs[1])))
+	})
+
+	t.Run("should reject out-of-range pagination consistently", func(t *testing.T) {
+		endpoints := []string{
+			"/posts",
+			fmt.Sprintf("/posts/nearby?lat=%f&lng=%f&radius=1000&",
+				TestLocations.CentralPark.Latitude,
+				TestLocations.CentralPark.Longitude),
+			fmt.Sprintf("/users/%s/posts", TestUserID),
+		}
+
+		for _, endpoint := range endpoints {
+			sep := "?"
+			if strings.HasSuffix(endpoint, "&") {
+				sep = ""
+			}
+
+			for _, query := range []string{"limit=0", "limit=101", "limit=abc", "offset=-1"} {
+				resp := makeRequest(t, "GET", endpoint+sep+query, nil)
+				require.Equal(t, http.StatusBadRequest, resp.StatusCode, "%s%s%s", endpoint, sep, query)
+				resp.Body.Close()
+			}
+		}
+	})
 }
 
 func TestGetUserPosts(t *testing.T) {
@@ -331,5 +518,67 @@ func TestGetUserPosts(t *testing.T) {
 
 		postsList := userPostsResp["posts"].([]interface{})
 		require.Len(t, postsList, 0)
+		require.Equal(t, float64(0), userPostsResp["total"])
+	})
+
+	t.Run("should report the total beyond the page and leave out deleted posts", func(t *testing.T) {
+		userID := uuid.New().String()
+		headers := map[string]string{"X-User-ID": userID}
+
+		postIDs := make([]string, 3)
+		for i := range postIDs {
+			req := CreatePostRequest{
+				Title:        fmt.Sprintf("Counted post %d", i+1),
+				Location:     TestLocations.CentralPark,
+				RadiusMeters: 1000,
+				Type:         "lost",
+			}
+			resp := makeRequestWithHeaders(t, "POST", "/posts", req, headers)
+			require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+			var post PostResponse
+			parseResponse(t, resp, &post)
+			defer CleanupPost(t, post.ID)
+			postIDs[i] = post.ID
+		}
+
+		resp := makeRequestWithHeaders(t, "DELETE", fmt.Sprintf("/posts/%s", postIDs[0]), nil, headers)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		resp.Body.Close()
+
+		resp = makeRequest(t, "GET", fmt.Sprintf("/users/%s/posts?limit=1", userID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var userPostsResp map[string]interface{}
+		parseResponse(t, resp, &userPostsResp)
+
+		require.Len(t, userPostsResp["posts"].([]interface{}), 1)
+		require.Equal(t, float64(2), userPostsResp["total"])
+	})
+}
+
+func TestTransferPost(t *testing.T) {
+	t.Run("should reject posts outside an organization", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		transferReq := map[string]string{"new_owner_id": "550e8400-e29b-41d4-a716-446655440001"}
+		resp := makeRequest(t, "POST", fmt.Sprintf("/posts/%s/transfer", post.ID), transferReq)
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("should fail with an invalid new owner", func(t *testing.T) {
+		post := CreateTestPostWithDefaults(t)
+		defer CleanupPost(t, post.ID)
+
+		transferReq := map[string]string{"new_owner_id": "not-a-uuid"}
+		resp := makeRequest(t, "POST", fmt.Sprintf("/posts/%s/transfer", post.ID), transferReq)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should return 404 for non-existent post", func(t *testing.T) {
+		transferReq := map[string]string{"new_owner_id": "550e8400-e29b-41d4-a716-446655440001"}
+		resp := makeRequest(t, "POST", "/posts/550e8400-e29b-41d4-a716-446655440404/transfer", transferReq)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
 }
@@ -0,0 +1,65 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	readOnly := handler.NewReadOnlyMode(config.MaintenanceConfig{RetryAfterSeconds: 90})
+	maintenance := handler.NewMaintenanceHandler(readOnly)
+
+	router := gin.New()
+	router.PUT("/internal/maintenance/read-only", maintenance.SetReadOnly)
+	public := router.Group("", handler.ReadOnlyMiddleware(readOnly))
+	public.GET("/posts", func(c *gin.Context) { c.Status(http.StatusOK) })
+	public.POST("/posts", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	public.DELETE("/posts/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should accept writes by default", func(t *testing.T) {
+		require.Equal(t, http.StatusCreated, serve(http.MethodPost, "/posts", "").Code)
+	})
+
+	t.Run("should refuse writes and keep serving reads while read-only", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve(http.MethodPut, "/internal/maintenance/read-only", `{"enabled": true}`).Code)
+		require.True(t, readOnly.Enabled())
+
+		for _, method := range []string{http.MethodPost, http.MethodDelete} {
+			path := "/posts"
+			if method == http.MethodDelete {
+				path = "/posts/1"
+			}
+			w := serve(method, path, "")
+			require.Equal(t, http.StatusServiceUnavailable, w.Code, method)
+			require.Equal(t, "90", w.Header().Get("Retry-After"), method)
+		}
+
+		require.Equal(t, http.StatusOK, serve(http.MethodGet, "/posts", "").Code)
+	})
+
+	t.Run("should accept writes again once switched off", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve(http.MethodPut, "/internal/maintenance/read-only", `{"enabled": false}`).Code)
+		require.Equal(t, http.StatusCreated, serve(http.MethodPost, "/posts", "").Code)
+	})
+
+	t.Run("should require the enabled flag", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "/internal/maintenance/read-only", `{}`).Code)
+	})
+}
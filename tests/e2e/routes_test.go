@@ -0,0 +1,97 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should register every route without conflicts", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			newProductionRouter(service.NewPostService(nil, nil, nil, nil, nil, nil, nil, config.FeatureConfig{}), nil)
+		})
+	})
+
+	t.Run("should bump a post through the production route", func(t *testing.T) {
+		require.NoError(t, domain.SetPostBumpIntervalHours(24))
+		t.Cleanup(func() { _ = domain.SetPostBumpIntervalHours(0) })
+
+		owner := domain.NewUserID()
+		createdAt := time.Now().Add(-72 * time.Hour)
+		post := domain.ReconstructPost(domain.NewPostID(), "Lost umbrella", "Black, folding",
+			TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeLost, owner, nil, createdAt, createdAt, nil)
+		posts := &bumpingPostRepository{updatablePostRepository: updatablePostRepository{singlePostRepository: singlePostRepository{post: post}}}
+		router := newProductionRouter(service.NewPostService(posts, nil, nil, nil, nil, &recordingEventPublisher{}, nil, config.FeatureConfig{}), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/posts/"+post.ID().String()+"/bump", nil)
+		req.Header.Set(handler.DevUserIDHeader, owner.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, 1, posts.bumps)
+	})
+}
+
+// newProductionRouter serves the routes the server registers, under /api, with callers named
+// by the dev mode user header
+func newProductionRouter(postService *service.PostService, contactService *service.ContactExchangeService) *gin.Engine {
+	return newProductionRouterWithStorage(postService, contactService, nil)
+}
+
+// newProductionRouterWithStorage is newProductionRouter with photos stored in storage
+func newProductionRouterWithStorage(postService *service.PostService, contactService *service.ContactExchangeService, storage handler.StorageInterface) *gin.Engine {
+	cfg := &config.Config{InternalAPIToken: TestInternalToken}
+	router := gin.New()
+	handler.SetupRoutes(router.Group("/api"), handler.Handlers{
+		Post:            handler.NewPostHandler(postService, storage, cfg),
+		Photo:           handler.NewPhotoHandler(postService, storage, cfg),
+		ContactExchange: handler.NewContactExchangeHandler(contactService),
+		Claim:           handler.NewClaimHandler(nil),
+		Import:          handler.NewImportHandler(nil),
+	}, handler.AuthMiddleware(nil, true), handler.NewReadOnlyMode(cfg.Maintenance), cfg)
+	return router
+}
+
+func TestEncryptionAuditTrailAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	contactService := service.NewContactExchangeService(nil, nil, nil, nil, nil, newFakeEncryptionService(), &discardAuditLogger{}, config.ContactExchangeConfig{})
+	router := newProductionRouter(service.NewPostService(nil, nil, nil, nil, nil, nil, nil, config.FeatureConfig{}), contactService)
+	get := func(path string, headers map[string]string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	user := map[string]string{handler.DevUserIDHeader: domain.NewUserID().String()}
+
+	t.Run("should not serve the audit trail to signed-in users", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, get("/api/admin/audit/encryption", user))
+		require.Equal(t, http.StatusUnauthorized, get("/api/internal/audit/encryption", user))
+	})
+
+	t.Run("should serve the audit trail to internal callers", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, get("/api/internal/audit/encryption", map[string]string{"X-Internal-Token": TestInternalToken}))
+	})
+
+	t.Run("should reject unknown operations", func(t *testing.T) {
+		internal := map[string]string{"X-Internal-Token": TestInternalToken}
+		require.Equal(t, http.StatusBadRequest, get("/api/internal/audit/encryption?operation=decrpyt", internal))
+		require.Equal(t, http.StatusOK, get("/api/internal/audit/encryption?operation=decrypt", internal))
+	})
+}
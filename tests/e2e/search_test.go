@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/domain"
 	"github.com/stretchr/testify/require"
 )
 
@@ -245,4 +247,256 @@ func TestSearchNearbyPosts(t *testing.T) {
 		posts := searchResp["posts"].([]interface{})
 		require.GreaterOrEqual(t, len(posts), 1, "Should find at least one post with default radius")
 	})
+
+	t.Run("should report the effective radius and clamp it to the configured max", func(t *testing.T) {
+		testCases := []struct {
+			query    string
+			expected float64
+		}{
+			{"", 1000},
+			{"&radius=2500", 2500},
+			{"&radius=10000000", 50000},
+		}
+
+		for _, tc := range testCases {
+			endpoint := fmt.Sprintf("/posts/nearby?lat=%f&lng=%f%s",
+				TestLocations.CentralPark.Latitude,
+				TestLocations.CentralPark.Longitude,
+				tc.query)
+
+			resp := makeRequest(t, "GET", endpoint, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode, endpoint)
+
+			var searchResp map[string]interface{}
+			parseResponse(t, resp, &searchResp)
+			require.Equal(t, tc.expected, searchResp["radius_meters"], endpoint)
+		}
+	})
+	t.Run("should hide tight-radius posts from distant searchers", func(t *testing.T) {
+		// Post only meant to be visible within 100m of Central Park
+		req := CreatePostRequest{
+			Title:        "Tight Radius Post " + uuid.New().String()[:8],
+			Description:  "Only visible to searchers close by",
+			Location:     TestLocations.CentralPark,
+			RadiusMeters: 100,
+			Type:         "lost",
+		}
+		post := CreateTestPost(t, req)
+		defer CleanupPost(t, post.ID)
+
+		findTitle := func(lat, lng float64) bool {
+			endpoint := fmt.Sprintf("/posts/nearby?lat=%f&lng=%f&radius=10000&respect_post_radius=true&limit=100", lat, lng)
+
+			resp := makeRequest(t, "GET", endpoint, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var searchResp map[string]interface{}
+			parseResponse(t, resp, &searchResp)
+
+			for _, p := range searchResp["posts"].([]interface{}) {
+				if p.(map[string]interface{})["title"].(string) == req.Title {
+					return true
+				}
+			}
+			return false
+		}
+
+		// Searching from the post's own location should find it
+		require.True(t, findTitle(TestLocations.CentralPark.Latitude, TestLocations.CentralPark.Longitude),
+			"Should find tight-radius post from inside its radius")
+
+		// Times Square is ~4km away: inside the 10km search radius but outside the post's 100m radius
+		require.False(t, findTitle(TestLocations.TimesSquare.Latitude, TestLocations.TimesSquare.Longitude),
+			"Should not find tight-radius post from outside its radius")
+	})
+}
+
+func TestPostHeatmap(t *testing.T) {
+	t.Run("should aggregate posts into grid cells", func(t *testing.T) {
+		centralParkPost := CreateTestPostAt(t, TestLocations.CentralPark.Latitude, TestLocations.CentralPark.Longitude, "Heatmap Central Park Post")
+		defer CleanupPost(t, centralParkPost.ID)
+
+		timesSquarePost := CreateTestPostAt(t, TestLocations.TimesSquare.Latitude, TestLocations.TimesSquare.Longitude, "Heatmap Times Square Post")
+		defer CleanupPost(t, timesSquarePost.ID)
+
+		empireStatePost := CreateTestPostAt(t, TestLocations.EmpireState.Latitude, TestLocations.EmpireState.Longitude, "Heatmap Empire State Post")
+		defer CleanupPost(t, empireStatePost.ID)
+
+		// A single coarse cell covering Manhattan
+		resp := makeRequest(t, "GET", "/posts/heatmap?bbox=-74.1,40.6,-73.8,40.9&grid=1", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var heatmap struct {
+			Cells []struct {
+				Latitude  float64 `json:"latitude"`
+				Longitude float64 `json:"longitude"`
+				Count     int64   `json:"count"`
+			} `json:"cells"`
+			Total int64 `json:"total"`
+		}
+		parseResponse(t, resp, &heatmap)
+
+		require.NotEmpty(t, heatmap.Cells)
+		require.GreaterOrEqual(t, heatmap.Total, int64(3))
+		require.GreaterOrEqual(t, heatmap.Cells[0].Count, int64(3), "All three posts should share the coarse cell")
+		for _, cell := range heatmap.Cells {
+			require.GreaterOrEqual(t, cell.Count, int64(domain.MinHeatmapCellPosts), "Sparse cells should be left out")
+		}
+	})
+
+	t.Run("should reject invalid bounding boxes", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			endpoint string
+		}{
+			{"missing bbox", "/posts/heatmap"},
+			{"malformed bbox", "/posts/heatmap?bbox=1,2,3"},
+			{"inverted bbox", "/posts/heatmap?bbox=-73.8,40.9,-74.1,40.6"},
+			{"grid too fine", "/posts/heatmap?bbox=-180,-90,180,90&grid=0.001"},
+			{"deleted posts", "/posts/heatmap?bbox=-74.1,40.6,-73.8,40.9&status=deleted"},
+			{"archived posts", "/posts/heatmap?bbox=-74.1,40.6,-73.8,40.9&status=archived"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				resp := makeRequest(t, "GET", tc.endpoint, nil)
+				require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+}
+
+func TestHeatmapFilters(t *testing.T) {
+	box, err := domain.NewGeoBoundingBox(-74.1, 40.6, -73.8, 40.9)
+	require.NoError(t, err)
+
+	t.Run("should never grid finer than the location obfuscation precision", func(t *testing.T) {
+		filters := domain.HeatmapFilters{BoundingBox: box, GridDegrees: domain.MinHeatmapGridDegrees}
+		filters.ClampGrid(0.01)
+		require.Equal(t, 0.01, filters.GridDegrees)
+
+		filters.GridDegrees = 0.05
+		filters.ClampGrid(0.01)
+		require.Equal(t, 0.05, filters.GridDegrees, "coarser grids are kept")
+	})
+
+	t.Run("should only count public posts", func(t *testing.T) {
+		for _, status := range []domain.PostStatus{domain.PostStatusActive, domain.PostStatusResolved, domain.PostStatusExpired} {
+			filters := domain.HeatmapFilters{BoundingBox: box, GridDegrees: 0.1, Status: &status}
+			require.NoError(t, filters.Validate(), status)
+		}
+		for _, status := range []domain.PostStatus{domain.PostStatusDraft, domain.PostStatusDeleted, domain.PostStatusArchived} {
+			filters := domain.HeatmapFilters{BoundingBox: box, GridDegrees: 0.1, Status: &status}
+			require.Error(t, filters.Validate(), status)
+		}
+	})
+}
+
+func TestListPhotoLimit(t *testing.T) {
+	t.Run("should cap photos in list views but not on the post itself", func(t *testing.T) {
+		post := CreateTestPostAt(t, TestLocations.EmpireState.Latitude, TestLocations.EmpireState.Longitude, "Post with many photos")
+		defer CleanupPost(t, post.ID)
+
+		for i := 0; i < 3; i++ {
+			UploadTestPhoto(t, post.ID)
+		}
+
+		resp := makeRequest(t, "GET", fmt.Sprintf("/posts/%s", post.ID), nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var full PostResponse
+		parseResponse(t, resp, &full)
+		require.GreaterOrEqual(t, len(full.Photos), 3)
+
+		endpoints := []string{
+			fmt.Sprintf("/users/%s/posts?max_photos=1", TestUserID),
+			fmt.Sprintf("/posts/nearby?lat=%f&lng=%f&radius=100&max_photos=1",
+				TestLocations.EmpireState.Latitude, TestLocations.EmpireState.Longitude),
+		}
+
+		for _, endpoint := range endpoints {
+			resp := makeRequest(t, "GET", endpoint, nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode, endpoint)
+
+			var listResp struct {
+				Posts []PostResponse `json:"posts"`
+			}
+			parseResponse(t, resp, &listResp)
+
+			found := false
+			for _, listed := range listResp.Posts {
+				if listed.ID == post.ID {
+					found = true
+					require.Len(t, listed.Photos, 1, endpoint)
+					// The cover photo is the first by display order
+					require.Equal(t, full.Photos[0].ID, listed.Photos[0].ID, endpoint)
+				}
+			}
+			require.True(t, found, endpoint)
+		}
+	})
+
+	t.Run("should reject an out-of-range max_photos", func(t *testing.T) {
+		resp := makeRequest(t, "GET", "/posts?max_photos=11", nil)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	})
+}
+
+func TestSearchPostsAlongRoute(t *testing.T) {
+	route := []map[string]float64{
+		{"latitude": TestLocations.CentralPark.Latitude, "longitude": TestLocations.CentralPark.Longitude},
+		{"latitude": TestLocations.TimesSquare.Latitude, "longitude": TestLocations.TimesSquare.Longitude},
+		{"latitude": TestLocations.EmpireState.Latitude, "longitude": TestLocations.EmpireState.Longitude},
+	}
+
+	t.Run("should find posts along the route in route order", func(t *testing.T) {
+		empireStatePost := CreateTestPostAt(t, TestLocations.EmpireState.Latitude, TestLocations.EmpireState.Longitude, "Empire State Route Post")
+		defer CleanupPost(t, empireStatePost.ID)
+
+		centralParkPost := CreateTestPostAt(t, TestLocations.CentralPark.Latitude, TestLocations.CentralPark.Longitude, "Central Park Route Post")
+		defer CleanupPost(t, centralParkPost.ID)
+
+		brooklynBridgePost := CreateTestPostAt(t, TestLocations.BrooklynBridge.Latitude, TestLocations.BrooklynBridge.Longitude, "Brooklyn Bridge Route Post")
+		defer CleanupPost(t, brooklynBridgePost.ID)
+
+		resp := makeRequest(t, "POST", "/posts/along-route", map[string]interface{}{
+			"points":          route,
+			"corridor_meters": 300,
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var searchResp struct {
+			Posts          []PostResponse `json:"posts"`
+			CorridorMeters int            `json:"corridor_meters"`
+		}
+		parseResponse(t, resp, &searchResp)
+		require.Equal(t, 300, searchResp.CorridorMeters)
+
+		var order []string
+		for _, post := range searchResp.Posts {
+			switch post.ID {
+			case centralParkPost.ID, empireStatePost.ID:
+				order = append(order, post.ID)
+			case brooklynBridgePost.ID:
+				t.Fatal("Brooklyn Bridge is well outside the corridor")
+			}
+		}
+		require.Equal(t, []string{centralParkPost.ID, empireStatePost.ID}, order)
+	})
+
+	t.Run("should reject a route with a single point", func(t *testing.T) {
+		resp := makeRequest(t, "POST", "/posts/along-route", map[string]interface{}{
+			"points": route[:1],
+		})
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	})
+
+	t.Run("should reject out-of-range route coordinates", func(t *testing.T) {
+		resp := makeRequest(t, "POST", "/posts/along-route", map[string]interface{}{
+			"points": []map[string]float64{route[0], {"latitude": 91, "longitude": 0}},
+		})
+		require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		resp.Body.Close()
+	})
 }
@@ -0,0 +1,33 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceIdentity(t *testing.T) {
+	t.Run("should stamp events with the configured service name", func(t *testing.T) {
+		require.NoError(t, domain.SetServiceIdentity("fn-posts-eu", "1.4.2"))
+		t.Cleanup(func() {
+			require.NoError(t, domain.SetServiceIdentity("", ""))
+		})
+
+		postEvent := domain.NewPostEvent(domain.EventTypePostCreated, domain.NewPostID(), domain.NewUserID(), nil, nil)
+		require.Equal(t, "fn-posts-eu", postEvent.SourceService)
+
+		exchangeEvent := domain.NewContactExchangeEvent(domain.EventTypeContactExchangeRequested, domain.NewContactExchangeRequestID(), domain.NewUserID(), nil, nil)
+		require.Equal(t, "fn-posts-eu", exchangeEvent.SourceService)
+		require.Equal(t, "1.4.2", domain.ServiceVersion())
+	})
+
+	t.Run("should fall back to defaults and reject invalid names", func(t *testing.T) {
+		require.NoError(t, domain.SetServiceIdentity("", ""))
+		require.Equal(t, domain.DefaultServiceName, domain.ServiceName())
+		require.Equal(t, domain.DefaultServiceVersion, domain.ServiceVersion())
+
+		require.Error(t, domain.SetServiceIdentity("fn posts", "1.0.0"))
+		require.Equal(t, domain.DefaultServiceName, domain.ServiceName())
+	})
+}
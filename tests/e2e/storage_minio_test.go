@@ -0,0 +1,120 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinIOStorage(t *testing.T) {
+	endpoint := os.Getenv("TEST_MINIO_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "localhost:9001"
+	}
+
+	storage, err := service.NewStorageService(config.StorageConfig{
+		Provider: "minio",
+		MinIO: config.MinIOConfig{
+			Endpoint:  endpoint,
+			AccessKey: "testadmin",
+			SecretKey: "testpass123",
+			Bucket:    "posts-test-bucket",
+		},
+		CircuitBreakerThreshold: 5,
+	})
+	if err != nil {
+		t.Skipf("MinIO not available: %v", err)
+	}
+	ctx := context.Background()
+
+	t.Run("should store, serve and delete uploaded photos", func(t *testing.T) {
+		file, header := pngUpload(t, "photo.png", 4, 3)
+
+		result, err := storage.UploadPhoto(ctx, file, header, uuid.New(), nil)
+		require.NoError(t, err)
+		require.Equal(t, 4, result.Width)
+		require.Equal(t, 3, result.Height)
+		require.Equal(t, result.URL, storage.GetPhotoURL(result.Filename))
+
+		resp, err := http.Get(result.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+
+		require.NoError(t, storage.DeletePhoto(ctx, result.Filename))
+
+		resp, err = http.Get(result.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	processor := service.NewPhotoProcessor(storage, nil, nil)
+
+	t.Run("should upload a thumbnail scaled to the max dimension", func(t *testing.T) {
+		file, header := pngUpload(t, "wide.png", 800, 600)
+
+		result, err := processor.ProcessUpload(ctx, file, header, uuid.New(), nil)
+		require.NoError(t, err)
+		require.Contains(t, result.ThumbnailURL, "/thumbnail/")
+
+		resp, err := http.Get(result.ThumbnailURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		thumbnail, err := png.DecodeConfig(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, 400, thumbnail.Width)
+		require.Equal(t, 300, thumbnail.Height)
+	})
+
+	t.Run("should keep the upload of an undecodable image without a thumbnail", func(t *testing.T) {
+		file, header := multipartUpload(t, "corrupt.jpg", []byte("not really a jpeg"))
+
+		result, err := processor.ProcessUpload(ctx, file, header, uuid.New(), nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, result.URL)
+		require.Empty(t, result.ThumbnailURL)
+	})
+}
+
+// pngUpload encodes a blank image and returns it as a parsed multipart file
+func pngUpload(t *testing.T, filename string, width, height int) (multipart.File, *multipart.FileHeader) {
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return multipartUpload(t, filename, img.Bytes())
+}
+
+// multipartUpload returns content as a parsed multipart file
+func multipartUpload(t *testing.T, filename string, content []byte) (multipart.File, *multipart.FileHeader) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("photo", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+
+	header := form.File["photo"][0]
+	file, err := header.Open()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	return file, header
+}
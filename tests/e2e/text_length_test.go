@@ -0,0 +1,48 @@
+package e2e
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextLength(t *testing.T) {
+	t.Run("should count characters rather than bytes", func(t *testing.T) {
+		require.Equal(t, 2, domain.TextLength("🔑🐕"))
+		require.Equal(t, 4, domain.TextLength("失物招領"))
+		require.Equal(t, 5, domain.TextLength("keys!"))
+	})
+
+	t.Run("should truncate on character boundaries", func(t *testing.T) {
+		truncated := domain.TruncateText("找到了🐕钥匙", 4)
+		require.Equal(t, "找到了🐕", truncated)
+		require.True(t, utf8.ValidString(truncated))
+
+		require.Equal(t, "short", domain.TruncateText("short", 10))
+		require.Equal(t, "", domain.TruncateText("anything", 0))
+
+		long := strings.Repeat("🐕", domain.MaxDescriptionLength+10)
+		truncated = domain.TruncateText(long, domain.MaxDescriptionLength)
+		require.Equal(t, domain.MaxDescriptionLength, domain.TextLength(truncated))
+		require.True(t, utf8.ValidString(truncated))
+	})
+
+	t.Run("should accept titles and descriptions up to the limit in characters", func(t *testing.T) {
+		// 200 CJK characters are 600 bytes but still a valid title
+		title := strings.Repeat("猫", domain.MaxTitleLength)
+		description := strings.Repeat("🐈", domain.MaxDescriptionLength)
+
+		post, err := domain.NewDraftPost(title, description, nil, TestLocations.CentralPark, 1000, domain.PostTypeLost, domain.NewUserID(), nil)
+		require.NoError(t, err)
+		require.NoError(t, post.Update(domain.PostUpdate{Title: &title, Description: &description}))
+
+		_, err = domain.NewDraftPost(title+"猫", description, nil, TestLocations.CentralPark, 1000, domain.PostTypeLost, domain.NewUserID(), nil)
+		require.Error(t, err)
+
+		_, err = domain.NewDraftPost(title, description+"🐈", nil, TestLocations.CentralPark, 1000, domain.PostTypeLost, domain.NewUserID(), nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/application/anti_corruption"
+	"github.com/jsarabia/fn-posts/internal/config"
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThinEvents(t *testing.T) {
+	ctx := context.Background()
+	post := domain.ReconstructPost(domain.NewPostID(), "Found wallet", "Brown leather",
+		TestLocations.CentralPark, 1000, domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil,
+		time.Now(), time.Now(), nil)
+	created := func() *domain.PostEvent {
+		return domain.NewPostEvent(domain.EventTypePostCreated, post.ID(), post.CreatedBy(), nil,
+			&domain.PostCreatedEventData{Post: post.ToPostData()})
+	}
+	updated := func() *domain.PostEvent {
+		return domain.NewPostEvent(domain.EventTypePostUpdated, post.ID(), post.CreatedBy(), nil,
+			&domain.PostUpdatedEventData{Post: post.ToPostData(), Changes: map[string]interface{}{"title": "Found wallet", "description": "Brown leather"}})
+	}
+
+	// relay records events in an outbox and relays them through the relay publisher, sending
+	// thin variants of the configured types
+	relay := func(t *testing.T, kafka *recordingKafkaPublisher, thinEventTypes []string, events ...*domain.PostEvent) (service.BulkResult, *memoryOutboxRepository) {
+		outbox := &memoryOutboxRepository{}
+		for _, event := range events {
+			require.NoError(t, outbox.Append(ctx, event))
+		}
+		publisher := anti_corruption.NewRelayEventPublisher(kafka, &flakyEventPublisher{failures: map[string]int{}}, thinEventTypes)
+		result, err := service.NewRelayWorker(outbox, publisher, nil, config.OutboxConfig{}).RelayPending(ctx)
+		require.NoError(t, err)
+		return result, outbox
+	}
+
+	t.Run("should publish thin variants of the configured event types only", func(t *testing.T) {
+		kafka := &recordingKafkaPublisher{}
+		event := updated()
+
+		result, _ := relay(t, kafka, []string{string(domain.EventTypePostUpdated)}, created(), event)
+		require.Equal(t, 2, result.Succeeded)
+		require.Equal(t, []string{"posts.events", "posts.events", anti_corruption.ThinEventTopic}, kafka.topics())
+
+		thin := kafka.messages[2]
+		require.Equal(t, post.ID().String(), thin.key)
+		var thinEvent struct {
+			EventID string                        `json:"event_id"`
+			Data    anti_corruption.ThinEventData `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(thin.value, &thinEvent))
+		require.Equal(t, event.ID.String(), thinEvent.EventID)
+		require.Equal(t, post.ID().String(), thinEvent.Data.PostID)
+		require.Equal(t, []string{"description", "title"}, thinEvent.Data.ChangedFields)
+	})
+
+	t.Run("should publish no thin variants unless configured", func(t *testing.T) {
+		kafka := &recordingKafkaPublisher{}
+
+		relay(t, kafka, nil, created(), updated())
+		require.Equal(t, []string{"posts.events", "posts.events"}, kafka.topics())
+	})
+
+	t.Run("should not fail or resend the event when only its thin variant fails", func(t *testing.T) {
+		kafka := &recordingKafkaPublisher{err: errors.New("topic unavailable"), failTopic: anti_corruption.ThinEventTopic}
+
+		result, outbox := relay(t, kafka, []string{string(domain.EventTypePostUpdated)}, updated())
+		require.Equal(t, 1, result.Succeeded)
+		require.Zero(t, result.Failed)
+		require.Equal(t, []string{"posts.events"}, kafka.topics())
+		require.True(t, outbox.done[1], "the event is not retried")
+	})
+}
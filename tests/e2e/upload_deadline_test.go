@@ -0,0 +1,64 @@
+package e2e
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jsarabia/fn-posts/internal/handler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadDeadlineMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	readBody := func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.Status(http.StatusRequestTimeout)
+			return
+		}
+		c.Status(http.StatusOK)
+	}
+
+	router := gin.New()
+	router.POST("/upload", handler.UploadDeadlineMiddleware(2*time.Second), readBody)
+	router.POST("/plain", readBody)
+
+	server := httptest.NewUnstartedServer(router)
+	server.Config.ReadTimeout = 100 * time.Millisecond
+	server.Start()
+	t.Cleanup(server.Close)
+
+	// slowPost sends the body well after the server-wide read timeout has passed
+	slowPost := func(path string) (int, error) {
+		body, writer := io.Pipe()
+		go func() {
+			time.Sleep(300 * time.Millisecond)
+			_, _ = writer.Write([]byte("photo bytes"))
+			_ = writer.Close()
+		}()
+
+		resp, err := server.Client().Post(server.URL+path, "application/octet-stream", body)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	t.Run("should let upload routes read past the server read timeout", func(t *testing.T) {
+		status, err := slowPost("/upload")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("should keep the server read timeout on other routes", func(t *testing.T) {
+		status, err := slowPost("/plain")
+		if err == nil {
+			require.NotEqual(t, http.StatusOK, status)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/jsarabia/fn-posts/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// countingUserContextRepository records how many users were fetched through each method
+type countingUserContextRepository struct {
+	single  int
+	batched []domain.UserID
+}
+
+func (r *countingUserContextRepository) GetPrivacySafeUser(ctx context.Context, userID domain.UserID) (*domain.PrivacySafeUser, error) {
+	r.single++
+	return &domain.PrivacySafeUser{UserID: userID, DisplayName: "Cached User"}, nil
+}
+
+func (r *countingUserContextRepository) GetPrivacySafeUsers(ctx context.Context, userIDs []domain.UserID) (map[domain.UserID]*domain.PrivacySafeUser, error) {
+	r.batched = append(r.batched, userIDs...)
+	users := make(map[domain.UserID]*domain.PrivacySafeUser, len(userIDs))
+	for _, userID := range userIDs {
+		users[userID] = &domain.PrivacySafeUser{UserID: userID, DisplayName: "Cached User"}
+	}
+	return users, nil
+}
+
+func TestCachingUserContextRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should reuse a fetched user until it expires", func(t *testing.T) {
+		source := &countingUserContextRepository{}
+		cache := repository.NewCachingUserContextRepository(source, 50*time.Millisecond)
+		userID := domain.NewUserID()
+
+		for i := 0; i < 3; i++ {
+			user, err := cache.GetPrivacySafeUser(ctx, userID)
+			require.NoError(t, err)
+			require.Equal(t, userID, user.UserID)
+		}
+		require.Equal(t, 1, source.single)
+
+		time.Sleep(60 * time.Millisecond)
+		_, err := cache.GetPrivacySafeUser(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, 2, source.single)
+	})
+
+	t.Run("should only batch fetch users that are not cached", func(t *testing.T) {
+		source := &countingUserContextRepository{}
+		cache := repository.NewCachingUserContextRepository(source, time.Minute)
+		cached, first, second := domain.NewUserID(), domain.NewUserID(), domain.NewUserID()
+
+		_, err := cache.GetPrivacySafeUser(ctx, cached)
+		require.NoError(t, err)
+
+		users, err := cache.GetPrivacySafeUsers(ctx, []domain.UserID{cached, first, second, first})
+		require.NoError(t, err)
+		require.Len(t, users, 3)
+		require.ElementsMatch(t, []domain.UserID{first, second}, source.batched)
+
+		// Everything is cached now
+		_, err = cache.GetPrivacySafeUsers(ctx, []domain.UserID{cached, first, second})
+		require.NoError(t, err)
+		require.Len(t, source.batched, 2)
+		require.Equal(t, 1, source.single)
+	})
+}
@@ -0,0 +1,70 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/jsarabia/fn-posts/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTCTimestamps(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	reconstructRequest := func(createdAt, expiresAt time.Time) *domain.ContactExchangeRequest {
+		return domain.ReconstructContactExchangeRequest(
+			domain.NewContactExchangeRequestID(), domain.NewPostID(), domain.NewUserID(), domain.NewUserID(),
+			domain.ContactExchangeStatusPending, nil, false, nil, nil, nil, nil, nil,
+			expiresAt, createdAt, createdAt,
+		)
+	}
+
+	t.Run("should create timestamps in UTC", func(t *testing.T) {
+		photo, err := domain.NewPhoto(domain.CreatePhotoRequest{
+			PostID: domain.NewPostID(), URL: "https://example.com/umbrella.jpg", DisplayOrder: 1, Format: "jpg",
+		})
+		require.NoError(t, err)
+		require.Equal(t, time.UTC, photo.CreatedAt().Location())
+
+		post, err := domain.NewPost("Lost umbrella", "Black, folding", []domain.Photo{*photo}, TestLocations.CentralPark, 1000,
+			domain.PostTypeLost, domain.NewUserID(), nil)
+		require.NoError(t, err)
+		require.Equal(t, time.UTC, post.CreatedAt().Location())
+		require.Equal(t, time.UTC, post.UpdatedAt().Location())
+
+		request, err := domain.NewContactExchangeRequest(post.ID(), domain.NewUserID(), post.CreatedBy(), nil, false, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, time.UTC, request.CreatedAt().Location())
+		require.Equal(t, time.UTC, request.ExpiresAt().Location())
+	})
+
+	t.Run("should normalize timestamps read back in another zone", func(t *testing.T) {
+		createdAt := time.Date(2025, 3, 8, 12, 0, 0, 0, newYork)
+		request := reconstructRequest(createdAt, createdAt.Add(24*time.Hour))
+
+		require.Equal(t, time.UTC, request.ExpiresAt().Location())
+		require.True(t, request.CreatedAt().Equal(createdAt))
+		// 24 hours across the spring forward lands at 13:00 local, 17:00 UTC
+		require.Equal(t, time.Date(2025, 3, 9, 17, 0, 0, 0, time.UTC), request.ExpiresAt())
+
+		post := domain.ReconstructPost(domain.NewPostID(), "Found scarf", "Red wool", TestLocations.CentralPark, 1000,
+			domain.PostStatusActive, domain.PostTypeFound, domain.NewUserID(), nil, createdAt, createdAt, nil)
+		require.Equal(t, time.UTC, post.CreatedAt().Location())
+		require.True(t, post.CreatedAt().Equal(createdAt))
+	})
+
+	t.Run("should compare expiry by instant across the fall back", func(t *testing.T) {
+		// 01:30 occurs twice in New York on 2 November 2025, first in EDT then in EST
+		edt := time.FixedZone("EDT", -4*60*60)
+		est := time.FixedZone("EST", -5*60*60)
+		expiresAt := time.Date(2025, 11, 2, 1, 30, 0, 0, edt)
+		request := reconstructRequest(expiresAt.Add(-24*time.Hour), expiresAt)
+
+		require.False(t, request.IsExpiredAt(time.Date(2025, 11, 2, 1, 15, 0, 0, edt)))
+		require.True(t, request.IsExpiredAt(expiresAt))
+		// Earlier on the wall clock, but an hour after the expiry
+		require.True(t, request.IsExpiredAt(time.Date(2025, 11, 2, 1, 15, 0, 0, est)))
+	})
+}